@@ -11,10 +11,12 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"golang.org/x/term"
@@ -38,6 +40,14 @@ import (
 )
 
 func main() {
+	// Subcommands are dispatched before the flag set is built
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		os.Exit(runServe(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "mcp" {
+		os.Exit(runMCP(os.Args[2:]))
+	}
+
 	help := flag.Bool("help", false, "Show help")
 	versionFlag := flag.Bool("version", false, "Show version")
 	// Update flags (bv-182)
@@ -46,6 +56,7 @@ func main() {
 	rollbackFlag := flag.Bool("rollback", false, "Rollback to the previous version (from backup)")
 	yesFlag := flag.Bool("yes", false, "Skip confirmation prompts (use with --update)")
 	exportFile := flag.String("export-md", "", "Export issues to a Markdown file (e.g., report.md)")
+	recipeExportFile := flag.String("export", "", "Export issues using the recipe's export config (format: markdown/json/csv/mermaid or custom template)")
 	robotHelp := flag.Bool("robot-help", false, "Show AI agent help")
 	robotInsights := flag.Bool("robot-insights", false, "Output graph analysis and insights as JSON for AI agents")
 	robotPlan := flag.Bool("robot-plan", false, "Output dependency-respecting execution plan as JSON for AI agents")
@@ -56,11 +67,13 @@ func main() {
 	robotNext := flag.Bool("robot-next", false, "Output only the top pick recommendation as JSON (minimal triage)")
 	robotDiff := flag.Bool("robot-diff", false, "Output diff as JSON (use with --diff-since)")
 	robotRecipes := flag.Bool("robot-recipes", false, "Output available recipes as JSON for AI agents")
+	robotSchema := flag.Bool("robot-schema", false, "Output JSON Schemas for robot outputs (optionally for one command: --robot-schema triage)")
 	robotLabelHealth := flag.Bool("robot-label-health", false, "Output label health metrics as JSON for AI agents")
 	robotLabelFlow := flag.Bool("robot-label-flow", false, "Output cross-label dependency flow as JSON for AI agents")
 	robotLabelAttention := flag.Bool("robot-label-attention", false, "Output attention-ranked labels as JSON for AI agents")
 	attentionLimit := flag.Int("attention-limit", 5, "Limit number of labels in --robot-label-attention output")
 	robotAlerts := flag.Bool("robot-alerts", false, "Output alerts (drift + proactive) as JSON for AI agents")
+	robotWatch := flag.Bool("robot-watch", false, "Stream NDJSON change records (diffs, newly actionable, drift alerts) whenever the beads file changes")
 	// Smart suggestions (bv-180)
 	robotSuggest := flag.Bool("robot-suggest", false, "Output smart suggestions (duplicates, dependencies, labels, cycles) as JSON")
 	suggestType := flag.String("suggest-type", "", "Filter suggestions by type: duplicate, dependency, label, cycle")
@@ -120,11 +133,16 @@ func main() {
 	// Orphan commit detection flags (bv-jdop)
 	robotOrphans := flag.Bool("robot-orphans", false, "Output orphan commit candidates (commits that should be linked but aren't) as JSON")
 	orphansMinScore := flag.Int("orphans-min-score", 30, "Minimum suspicion score for orphan candidates (0-100)")
+	correlationDryRun := flag.Bool("correlation-dry-run", false, "Show what each bead ID pattern (.bv/correlation.yaml) matches in recent commits")
 	// File-bead index flags (bv-hmib)
 	robotFileBeads := flag.String("robot-file-beads", "", "Output beads that touched a file path as JSON")
 	fileBeadsLimit := flag.Int("file-beads-limit", 20, "Max closed beads to show (use with --robot-file-beads)")
 	fileHotspots := flag.Bool("robot-file-hotspots", false, "Output files touched by most beads as JSON")
 	hotspotsLimit := flag.Int("hotspots-limit", 10, "Max hotspots to show (use with --robot-file-hotspots)")
+	// Code ownership flags
+	robotOwnership := flag.Bool("robot-ownership", false, "Output directory owners, assignee suggestions and unowned open beads as JSON")
+	ownershipDepth := flag.Int("ownership-depth", 2, "Directory depth to group files at (use with --robot-ownership)")
+	ownershipStaleDays := flag.Int("ownership-stale-days", 90, "Days without commits after which an area has no recent owner (use with --robot-ownership)")
 	// Impact analysis flag (bv-19pq)
 	robotImpact := flag.String("robot-impact", "", "Analyze impact of modifying files (comma-separated paths)")
 	// Co-change detection flag (bv-7a2f)
@@ -146,17 +164,40 @@ func main() {
 	// Sprint flags (bv-156)
 	robotSprintList := flag.Bool("robot-sprint-list", false, "Output sprints as JSON")
 	robotSprintShow := flag.String("robot-sprint-show", "", "Output specific sprint details as JSON")
+	robotSprintSuggest := flag.String("robot-sprint-suggest", "", "Output capacity-aware bead suggestions for sprint ID (or 'next') as JSON")
+	sprintApply := flag.Bool("sprint-apply", false, "Add the --robot-sprint-suggest beads to the sprint")
+	// Sprint management flags (write .beads/sprints.jsonl)
+	sprintCreate := flag.String("sprint-create", "", "Create a sprint with this ID")
+	sprintUpdate := flag.String("sprint-update", "", "Update name, dates or velocity target of a sprint")
+	sprintAdd := flag.String("sprint-add", "", "Add --sprint-beads to a sprint")
+	sprintRemove := flag.String("sprint-remove", "", "Remove --sprint-beads from a sprint")
+	sprintClose := flag.String("sprint-close", "", "Close a sprint (end it now)")
+	sprintName := flag.String("sprint-name", "", "Sprint name (create/update; default: the ID)")
+	sprintStart := flag.String("sprint-start", "", "Sprint start date, YYYY-MM-DD (create/update; default: today)")
+	sprintEnd := flag.String("sprint-end", "", "Sprint end date, YYYY-MM-DD (create/update; default: start + --sprint-days)")
+	sprintDays := flag.Int("sprint-days", 0, "Sprint length in days (create/update/suggest; default: 14)")
+	sprintVelocity := flag.Float64("sprint-velocity", 0, "Sprint velocity target in beads (create/update)")
+	sprintBeads := flag.String("sprint-beads", "", "Comma-separated bead IDs (create/add/remove)")
+	sprintCarryOver := flag.String("sprint-carry-over", "", "With --sprint-close: add unfinished beads to this sprint")
 	// Forecast flags (bv-158)
 	robotForecast := flag.String("robot-forecast", "", "Output ETA forecast for bead ID, or 'all' for all open issues")
 	forecastLabel := flag.String("forecast-label", "", "Filter forecast by label")
 	forecastSprint := flag.String("forecast-sprint", "", "Filter forecast by sprint ID")
 	forecastAgents := flag.Int("forecast-agents", 1, "Number of parallel agents for capacity calculation")
+	forecastMC := flag.Bool("forecast-mc", false, "Add a Monte Carlo P50/P85/P95 completion forecast (use with --robot-forecast)")
+	forecastTrials := flag.Int("forecast-trials", analysis.DefaultMonteCarloTrials, "Monte Carlo trials (use with --forecast-mc)")
+	forecastSeed := flag.Int64("forecast-seed", 0, "Monte Carlo random seed for reproducible forecasts (default: time-based)")
 	// Capacity simulation flags (bv-160)
 	robotCapacity := flag.Bool("robot-capacity", false, "Output capacity simulation and completion projection as JSON")
 	capacityAgents := flag.Int("agents", 1, "Number of parallel agents for capacity simulation")
 	capacityLabel := flag.String("capacity-label", "", "Filter capacity simulation by label")
+	// Epic rollup flags
+	robotEpics := flag.Bool("robot-epics", false, "Output epic progress rollups and projected finish dates as JSON (use --agents for parallelism)")
 	// Burndown flags (bv-159)
 	robotBurndown := flag.String("robot-burndown", "", "Output burndown data for sprint ID, or 'current' for active sprint")
+	// Sprint retrospective flags
+	sprintRetro := flag.String("sprint-retro", "", "Generate a retrospective for sprint ID, or 'last' for the most recently ended sprint")
+	retroFormat := flag.String("retro-format", "markdown", "Retrospective output format: markdown or json (use with --sprint-retro)")
 	// Action script emission flags (bv-89)
 	emitScript := flag.Bool("emit-script", false, "Emit shell script for top-N recommendations (agent workflows)")
 	scriptLimit := flag.Int("script-limit", 5, "Limit number of items in emitted script (use with --emit-script)")
@@ -197,6 +238,9 @@ func main() {
 	_ = forecastLabel
 	_ = forecastSprint
 	_ = forecastAgents
+	_ = forecastMC
+	_ = forecastTrials
+	_ = forecastSeed
 	_ = robotCapacity
 	_ = capacityAgents
 	_ = capacityLabel
@@ -217,10 +261,12 @@ func main() {
 		*robotNext ||
 		*robotDiff ||
 		*robotRecipes ||
+		*robotSchema ||
 		*robotLabelHealth ||
 		*robotLabelFlow ||
 		*robotLabelAttention ||
 		*robotAlerts ||
+		*robotWatch ||
 		*robotSuggest ||
 		*robotGraph ||
 		*robotSearch ||
@@ -228,6 +274,7 @@ func main() {
 		*robotHistory ||
 		*robotFileBeads != "" ||
 		*fileHotspots ||
+		*robotOwnership ||
 		*robotImpact != "" ||
 		*robotFileRelations != "" ||
 		*robotRelatedWork != "" ||
@@ -236,11 +283,14 @@ func main() {
 		*robotCausality != "" ||
 		*robotSprintList ||
 		*robotSprintShow != "" ||
+		*robotSprintSuggest != "" ||
 		*robotForecast != "" ||
 		*robotBurndown != "" ||
+		(*sprintRetro != "" && *retroFormat == "json") ||
 		*robotByLabel != "" ||
 		*robotByAssignee != "" ||
 		*robotCapacity ||
+		*robotEpics ||
 		// When stdout is non-TTY, --diff-since auto-enables JSON output. Mark this
 		// as robot mode early so parsers keep stdout JSON clean.
 		(*diffSince != "" && !stdoutIsTTY)
@@ -251,6 +301,24 @@ func main() {
 		envRobot = true
 	}
 
+	// Custom workflow statuses and issue types must be known before any
+	// issues are loaded, otherwise issues using them are rejected as invalid
+	if cwd, err := os.Getwd(); err == nil {
+		workflow, err := loader.LoadWorkflow(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading workflow: %v\n", err)
+			os.Exit(1)
+		}
+		model.SetWorkflow(workflow)
+
+		issueTypes, err := loader.LoadIssueTypes(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading issue types: %v\n", err)
+			os.Exit(1)
+		}
+		model.SetTypeRegistry(issueTypes)
+	}
+
 	// Handle -r shorthand
 	if *recipeShort != "" && *recipeName == "" {
 		*recipeName = *recipeShort
@@ -314,6 +382,17 @@ func main() {
 		fmt.Println("      Output includes: id, title, score, reasons, claim_command, show_command")
 		fmt.Println("      Use when you just need to know \"what should I work on next?\"")
 		fmt.Println("")
+		fmt.Println("  bv serve [--addr 127.0.0.1:7331 | --socket path]")
+		fmt.Println("      Long-running server with a warm analysis cache; reloads when beads change.")
+		fmt.Println("      GET /triage, /next, /plan, /insights, /graph, /search, /forecast, /history,")
+		fmt.Println("      /blocker-chain, /related, /suggest, /issue")
+		fmt.Println("      JSON-RPC 2.0 on POST /rpc. Use when calling bv many times per session.")
+		fmt.Println("")
+		fmt.Println("  bv mcp")
+		fmt.Println("      Model Context Protocol server on stdio. Tools: triage, next, plan,")
+		fmt.Println("      blocker-chain, related, forecast, search, suggest (typed JSON schemas).")
+		fmt.Println("      Resources: bv://issue/<id>. Register the command `bv mcp` with your MCP client.")
+		fmt.Println("")
 		fmt.Println("  --search \"query\" [--robot-search]")
 		fmt.Println("      Semantic vector search over issue titles/descriptions.")
 		fmt.Println("      Builds/updates a local on-disk vector index on first run.")
@@ -370,6 +449,20 @@ func main() {
 		fmt.Println("      - --hotspots-limit <n>: Max hotspots to show (default: 10)")
 		fmt.Println("      Example: bv --robot-file-hotspots")
 		fmt.Println("")
+		fmt.Println("  --robot-ownership")
+		fmt.Println("      Outputs code ownership derived from bead-to-file correlations as JSON.")
+		fmt.Println("      Answers: 'Who knows this area, and which open work has nobody to ask?'")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - areas: Directories with top owners (commits, share, last_touch) and labels")
+		fmt.Println("      - suggestions: Assignees for unassigned open beads that mention paths")
+		fmt.Println("      - unowned: Open beads in areas with no commits in the stale window")
+		fmt.Println("      - stats: total_areas, areas_without_recent_owner, unique_owners")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --ownership-depth <n>: Directory depth of an area (default: 2)")
+		fmt.Println("      - --ownership-stale-days <n>: Days before an owner is no longer recent (default: 90)")
+		fmt.Println("      Example: bv --robot-ownership")
+		fmt.Println("      Example: bv --robot-ownership --ownership-depth 1 --ownership-stale-days 30")
+		fmt.Println("")
 		fmt.Println("  --robot-impact <files>")
 		fmt.Println("      Analyzes impact of modifying files - what beads might be affected?")
 		fmt.Println("      Critical for agents: check before making changes to avoid conflicts.")
@@ -427,6 +520,26 @@ func main() {
 		fmt.Println("      Returns the full sprint object with all fields.")
 		fmt.Println("      Example: bv --robot-sprint-show sprint-1")
 		fmt.Println("")
+		fmt.Println("  --robot-sprint-suggest <id|next>")
+		fmt.Println("      Suggests beads for a sprint from the execution plan, filled to capacity.")
+		fmt.Println("      Capacity = recent velocity × --agents × sprint days; the sprint's")
+		fmt.Println("      velocity_target caps the bead count. Beads already in the sprint count")
+		fmt.Println("      first; beads in other open sprints are skipped.")
+		fmt.Println("      Key fields:")
+		fmt.Println("      - suggestion.items: Beads in plan order (track_id, estimated_minutes, reason)")
+		fmt.Println("      - suggestion.capacity_minutes / planned_minutes / deferred")
+		fmt.Println("      Add --sprint-apply to write the suggested beads into the sprint.")
+		fmt.Println("      Example: bv --robot-sprint-suggest sprint-4 --agents=3 --sprint-apply")
+		fmt.Println("")
+		fmt.Println("  Sprint management (writes .beads/sprints.jsonl, prints a summary line):")
+		fmt.Println("      --sprint-create <id> [--sprint-name --sprint-start --sprint-end|--sprint-days")
+		fmt.Println("                            --sprint-velocity --sprint-beads a,b]")
+		fmt.Println("      --sprint-update <id> [--sprint-name --sprint-start --sprint-end --sprint-velocity]")
+		fmt.Println("      --sprint-add <id> --sprint-beads a,b")
+		fmt.Println("      --sprint-remove <id> --sprint-beads a,b")
+		fmt.Println("      --sprint-close <id> [--sprint-carry-over <next-id>]")
+		fmt.Println("      Example: bv --sprint-create sprint-5 --sprint-start 2025-02-03 --sprint-velocity 12")
+		fmt.Println("")
 		fmt.Println("  --robot-burndown <id|current>")
 		fmt.Println("      Outputs burndown data for a sprint as JSON.")
 		fmt.Println("      Use 'current' to get the active sprint, or specify sprint ID.")
@@ -441,6 +554,17 @@ func main() {
 		fmt.Println("      Example: bv --robot-burndown current")
 		fmt.Println("      Example: bv --robot-burndown sprint-1")
 		fmt.Println("")
+		fmt.Println("  --sprint-retro <id|last> [--retro-format markdown|json]")
+		fmt.Println("      Sprint retrospective; 'last' picks the most recently ended sprint.")
+		fmt.Println("      Key fields (json):")
+		fmt.Println("      - planned, added, removed: Scope at start vs mid-sprint changes")
+		fmt.Println("      - completed, carry_over: Closed by sprint end vs unfinished (carried_to)")
+		fmt.Println("      - velocity: completed vs target, per_week, met_target")
+		fmt.Println("      - cycle_time: p50/p85 days and bucketed distribution")
+		fmt.Println("      - blockers: Beads whose blocked periods stalled sprint work")
+		fmt.Println("      Example: bv --sprint-retro last > retro.md")
+		fmt.Println("      Example: bv --sprint-retro sprint-4 --retro-format json")
+		fmt.Println("")
 		fmt.Println("  --robot-forecast <id|all>")
 		fmt.Println("      Outputs ETA forecast for a specific bead or all open issues.")
 		fmt.Println("      Returns estimated completion date, confidence, and factors.")
@@ -451,6 +575,13 @@ func main() {
 		fmt.Println("      Example: bv --robot-forecast bv-123")
 		fmt.Println("      Example: bv --robot-forecast all --forecast-label=backend")
 		fmt.Println("      Example: bv --robot-forecast all --forecast-agents=2")
+		fmt.Println("      Monte Carlo (P50/P85/P95 from historical cycle times):")
+		fmt.Println("        --forecast-mc         Add a monte_carlo section to the output")
+		fmt.Println("        --forecast-trials=N   Simulated futures (default: 1000)")
+		fmt.Println("        --forecast-seed=S     Seed for reproducible results (reported as seed)")
+		fmt.Println("      Key fields: monte_carlo.p50/p85/p95, p*_days, basis (sample pool per issue)")
+		fmt.Println("      Example: bv --robot-forecast epic-12 --forecast-mc --forecast-agents=3")
+		fmt.Println("      Example: bv --robot-forecast all --forecast-sprint=sprint-4 --forecast-mc --forecast-seed=7")
 		fmt.Println("")
 		fmt.Println("  --robot-capacity [--agents=N] [--capacity-label=X]")
 		fmt.Println("      Outputs capacity simulation and completion projection as JSON.")
@@ -467,6 +598,16 @@ func main() {
 		fmt.Println("      Example: bv --robot-capacity --agents=3")
 		fmt.Println("      Example: bv --robot-capacity --capacity-label=backend")
 		fmt.Println("")
+		fmt.Println("  --robot-epics [--agents=N]")
+		fmt.Println("      Outputs a progress rollup for every epic, recursing through nested epics.")
+		fmt.Println("      Key fields (per epic):")
+		fmt.Println("        - percent_by_count, percent_by_estimate: Closed share of descendants")
+		fmt.Println("        - critical_path, critical_path_minutes: Longest open blocking chain inside the epic")
+		fmt.Println("        - blocked: Open descendants waiting on an open blocker")
+		fmt.Println("        - projected_finish: Critical path serially, the rest split across agents")
+		fmt.Println("        - parent_id, depth, sub_epics: Epic nesting")
+		fmt.Println("      Example: bv --robot-epics --agents=3")
+		fmt.Println("")
 		fmt.Println("  --emit-script [--script-limit=N] [--script-format=bash|fish|zsh]")
 		fmt.Println("      Emits a shell script for top-N priority recommendations.")
 		fmt.Println("      Useful for agent workflows and automation.")
@@ -486,6 +627,14 @@ func main() {
 		fmt.Println("      Generates a readable status report with Mermaid.js visualizations.")
 		fmt.Println("      Runs pre-export and post-export hooks if configured in .bv/hooks.yaml")
 		fmt.Println("")
+		fmt.Println("  --recipe <name> --export <file>")
+		fmt.Println("      Applies the recipe's filters and sort, then writes its export config:")
+		fmt.Println("      export.format (markdown|json|csv|mermaid, else inferred from the file")
+		fmt.Println("      extension), export.include_graph (default true for markdown), and")
+		fmt.Println("      export.template (a Go text/template relative to the recipe file,")
+		fmt.Println("      receiving .Issues, .Metrics (by ID) and .Triage).")
+		fmt.Println("      Example: bv --recipe blocked --export blocked.csv")
+		fmt.Println("")
 		fmt.Println("  --no-hooks")
 		fmt.Println("      Skip running hooks during export. Useful for CI or quick exports.")
 		fmt.Println("")
@@ -524,6 +673,12 @@ func main() {
 		fmt.Println("      Output: {recipes: [{name, description, source}]}")
 		fmt.Println("      Sources: 'builtin', 'user' (~/.config/bv/recipes.yaml), 'project' (.bv/recipes.yaml)")
 		fmt.Println("")
+		fmt.Println("  --robot-schema [command]")
+		fmt.Println("      Outputs JSON Schema (draft 2020-12) for robot outputs, keyed by command name.")
+		fmt.Println("      Fields: schema_version (bumped when any output changes shape), draft, commands{}.")
+		fmt.Println("      Pass a command (e.g. triage, plan, suggest, history) to get just that schema.")
+		fmt.Println("      Example: bv --robot-schema triage | jq '.commands.triage.properties | keys'")
+		fmt.Println("")
 		fmt.Println("  --robot-label-health")
 		fmt.Println("      Outputs label health metrics as JSON (velocity, freshness, flow, criticality).")
 		fmt.Println("      Includes label summaries, detailed metrics, and cross-label dependencies.")
@@ -542,10 +697,19 @@ func main() {
 		fmt.Println("      Use to identify which labels need the most focus based on centrality and health factors.")
 		fmt.Println("")
 		fmt.Println("  --robot-alerts")
-		fmt.Println("      Outputs drift + proactive alerts as JSON (staleness, cascades, density, cycles).")
+		fmt.Println("      Outputs drift + proactive alerts as JSON (staleness, cascades, density, cycles,")
+		fmt.Println("      abandoned claims, high-impact unblocks, potential duplicates).")
 		fmt.Println("      Filters: --severity=<info|warning|critical>, --alert-type=<type>, --alert-label=<label>")
 		fmt.Println("      Fields: type, severity, message, issue_id, label, detected_at, details[].")
 		fmt.Println("")
+		fmt.Println("  --robot-watch")
+		fmt.Println("      Streams one NDJSON record per debounced change to the beads file until interrupted.")
+		fmt.Println("      First record: event=ready (data_hash, issue_count, actionable_count, drift_alerts).")
+		fmt.Println("      Then event=change records with diff (new/closed/modified/reopened issues, cycles,")
+		fmt.Println("      metric_deltas), newly_actionable[], and drift_alerts[] that started firing.")
+		fmt.Println("      Drift is measured against the saved baseline (--save-baseline), else the start state.")
+		fmt.Println("      Example: bv --robot-watch | jq -c 'select(.newly_actionable) | .newly_actionable'")
+		fmt.Println("")
 		fmt.Println("  --robot-graph [--graph-format=json|dot|mermaid] [--graph-root=ID] [--graph-depth=N]")
 		fmt.Println("      Outputs dependency graph in specified format (default: JSON adjacency).")
 		fmt.Println("      Formats:")
@@ -712,6 +876,22 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-schema (no beads needed)
+	if *robotSchema {
+		output, err := buildRobotSchema(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding schema: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --check-update (bv-182)
 	if *checkUpdateFlag {
 		available, newVersion, releaseURL, err := updater.CheckUpdateAvailable()
@@ -891,9 +1071,7 @@ func main() {
 			return summaries[i].Name < summaries[j].Name
 		})
 
-		output := struct {
-			Recipes []recipe.RecipeSummary `json:"recipes"`
-		}{
+		output := RecipesOutput{
 			Recipes: summaries,
 		}
 
@@ -946,7 +1124,9 @@ func main() {
 	var issues []model.Issue
 	var beadsPath string
 	var workspaceInfo *workspace.LoadSummary
-	var asOfResolved string // Resolved commit SHA when using --as-of (for robot output metadata)
+	var workspaceLoader *workspace.AggregateLoader
+	var workspaceIssues []model.Issue // All workspace issues, before --repo filtering
+	var asOfResolved string           // Resolved commit SHA when using --as-of (for robot output metadata)
 
 	if *asOf != "" {
 		// Time-travel mode: load historical issues from git
@@ -978,16 +1158,36 @@ func main() {
 		}
 	} else if *workspaceConfig != "" {
 		// Load from workspace configuration
-		loadedIssues, results, err := workspace.LoadAllFromConfig(context.Background(), *workspaceConfig)
+		wl, err := workspace.NewAggregateLoaderFromConfig(*workspaceConfig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading workspace: %v\n", err)
+			os.Exit(1)
+		}
+		loadedIssues, results, err := wl.LoadAll(context.Background())
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading workspace: %v\n", err)
 			os.Exit(1)
 		}
 		issues = loadedIssues
+		workspaceLoader = wl
+		workspaceIssues = loadedIssues
 		summary := workspace.Summarize(results)
 		workspaceInfo = &summary
 
 		// Print workspace loading summary
+		if len(summary.DiscoveredRepos) > 0 && !envRobot {
+			fmt.Fprintf(os.Stderr, "Discovered %d repos: %s\n", len(summary.DiscoveredRepos), strings.Join(summary.DiscoveredRepos, ", "))
+		}
+		if len(summary.DanglingRefs) > 0 && !envRobot {
+			fmt.Fprintf(os.Stderr, "Warning: %d dependencies point outside the workspace\n", len(summary.DanglingRefs))
+			for i, ref := range summary.DanglingRefs {
+				if i == 5 {
+					fmt.Fprintf(os.Stderr, "  ... and %d more\n", len(summary.DanglingRefs)-i)
+					break
+				}
+				fmt.Fprintf(os.Stderr, "  - %s -> %s (%s)\n", ref.IssueID, ref.DependsOnID, strings.ReplaceAll(ref.Reason, "_", " "))
+			}
+		}
 		if summary.FailedRepos > 0 {
 			if !envRobot {
 				fmt.Fprintf(os.Stderr, "Warning: %d repos failed to load\n", summary.FailedRepos)
@@ -996,7 +1196,7 @@ func main() {
 				}
 			}
 		}
-		// No live reload for workspace mode (multiple files)
+		// Workspace mode watches every repo's beads file instead (see below)
 		beadsPath = ""
 
 		// Automatically ensure .bv/ is in .gitignore at workspace root
@@ -1088,6 +1288,9 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		if closer, ok := embedder.(io.Closer); ok {
+			defer closer.Close()
+		}
 
 		projectDir, err := os.Getwd()
 		if err != nil {
@@ -1106,7 +1309,7 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Building semantic index (%d issues)...\n", len(docs))
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), search.SyncTimeout(embedder.Provider()))
 		defer cancel()
 
 		syncStats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
@@ -1286,7 +1489,7 @@ func main() {
 		if !*pagesIncludeClosed {
 			var openIssues []model.Issue
 			for _, issue := range issues {
-				if issue.Status != model.StatusClosed {
+				if !issue.Status.IsClosed() {
 					openIssues = append(openIssues, issue)
 				}
 			}
@@ -1425,13 +1628,7 @@ func main() {
 		cfg := analysis.DefaultLabelHealthConfig()
 		results := analysis.ComputeAllLabelHealth(issues, cfg, time.Now().UTC(), nil)
 
-		output := struct {
-			GeneratedAt    string                       `json:"generated_at"`
-			DataHash       string                       `json:"data_hash"`
-			AnalysisConfig analysis.LabelHealthConfig   `json:"analysis_config"`
-			Results        analysis.LabelAnalysisResult `json:"results"`
-			UsageHints     []string                     `json:"usage_hints"`
-		}{
+		output := LabelHealthOutput{
 			GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
 			DataHash:       dataHash,
 			AnalysisConfig: cfg,
@@ -1456,13 +1653,7 @@ func main() {
 	if *robotLabelFlow {
 		cfg := analysis.DefaultLabelHealthConfig()
 		flow := analysis.ComputeCrossLabelFlow(issues, cfg)
-		output := struct {
-			GeneratedAt string                     `json:"generated_at"`
-			DataHash    string                     `json:"data_hash"`
-			Flow        analysis.CrossLabelFlow    `json:"flow"`
-			Config      analysis.LabelHealthConfig `json:"analysis_config"`
-			UsageHints  []string                   `json:"usage_hints"`
-		}{
+		output := LabelFlowOutput{
 			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 			DataHash:    dataHash,
 			Flow:        flow,
@@ -1497,25 +1688,6 @@ func main() {
 		}
 
 		// Build limited output
-		type AttentionOutput struct {
-			GeneratedAt string `json:"generated_at"`
-			DataHash    string `json:"data_hash"`
-			Limit       int    `json:"limit"`
-			TotalLabels int    `json:"total_labels"`
-			Labels      []struct {
-				Rank            int     `json:"rank"`
-				Label           string  `json:"label"`
-				AttentionScore  float64 `json:"attention_score"`
-				NormalizedScore float64 `json:"normalized_score"`
-				Reason          string  `json:"reason"`
-				OpenCount       int     `json:"open_count"`
-				BlockedCount    int     `json:"blocked_count"`
-				StaleCount      int     `json:"stale_count"`
-				PageRankSum     float64 `json:"pagerank_sum"`
-				VelocityFactor  float64 `json:"velocity_factor"`
-			} `json:"labels"`
-			UsageHints []string `json:"usage_hints"`
-		}
 
 		output := AttentionOutput{
 			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
@@ -1688,6 +1860,38 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-watch (streaming change feed)
+	if *robotWatch {
+		if beadsPath == "" {
+			fmt.Fprintln(os.Stderr, "Error: --robot-watch needs a single beads file (not available with --as-of or --workspace)")
+			os.Exit(1)
+		}
+		projectDir, _ := os.Getwd()
+		driftConfig, err := drift.LoadConfig(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading drift config: %v\n", err)
+			os.Exit(1)
+		}
+		opts := robotWatchOptions{beadsPath: beadsPath, driftCfg: driftConfig}
+		if *repoFilter != "" {
+			opts.filter = func(loaded []model.Issue) []model.Issue { return filterByRepo(loaded, *repoFilter) }
+		}
+		if path := baseline.DefaultPath(projectDir); baseline.Exists(path) {
+			if opts.reference, err = baseline.Load(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := runRobotWatch(ctx, issues, opts, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-alerts (drift + proactive)
 	if *robotAlerts {
 		projectDir, _ := os.Getwd()
@@ -1702,7 +1906,7 @@ func main() {
 
 		openCount, closedCount, blockedCount := 0, 0, 0
 		for _, issue := range issues {
-			switch issue.Status {
+			switch issue.Status.Base() {
 			case model.StatusClosed:
 				closedCount++
 			case model.StatusBlocked:
@@ -1753,24 +1957,14 @@ func main() {
 		}
 		driftResult.Alerts = filtered
 
-		output := struct {
-			GeneratedAt string        `json:"generated_at"`
-			DataHash    string        `json:"data_hash"`
-			Alerts      []drift.Alert `json:"alerts"`
-			Summary     struct {
-				Total    int `json:"total"`
-				Critical int `json:"critical"`
-				Warning  int `json:"warning"`
-				Info     int `json:"info"`
-			} `json:"summary"`
-			UsageHints []string `json:"usage_hints"`
-		}{
+		output := AlertsOutput{
 			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 			DataHash:    dataHash,
 			Alerts:      driftResult.Alerts,
 			UsageHints: []string{
 				"--severity=warning --alert-type=stale_issue   # stale warnings only",
 				"--alert-type=blocking_cascade                 # high-unblock opportunities",
+				"--alert-type=abandoned_claim                  # claimed work with no recent updates",
 				"jq '.alerts | map(.issue_id)'                # list impacted issues",
 			},
 		}
@@ -1847,7 +2041,7 @@ func main() {
 		// Compute status counts from issues
 		openCount, closedCount, blockedCount := 0, 0, 0
 		for _, issue := range issues {
-			switch issue.Status {
+			switch issue.Status.Base() {
 			case model.StatusOpen, model.StatusInProgress:
 				openCount++
 			case model.StatusClosed:
@@ -1887,6 +2081,13 @@ func main() {
 
 		bl := baseline.New(graphStats, topMetrics, cycles, *saveBaseline)
 
+		// Record close rates so --check-drift can spot velocity drops
+		velocityWeeks := drift.DefaultConfig().VelocityWindowWeeks
+		if driftConfig, err := drift.LoadConfig(projectDir); err == nil {
+			velocityWeeks = driftConfig.VelocityWindowWeeks
+		}
+		bl.Velocity = drift.ComputeVelocityStats(issues, time.Now().UTC(), velocityWeeks)
+
 		if err := bl.Save(baselinePath); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving baseline: %v\n", err)
 			os.Exit(1)
@@ -1922,7 +2123,7 @@ func main() {
 		// Compute status counts from issues
 		openCount, closedCount, blockedCount := 0, 0, 0
 		for _, issue := range issues {
-			switch issue.Status {
+			switch issue.Status.Base() {
 			case model.StatusOpen, model.StatusInProgress:
 				openCount++
 			case model.StatusClosed:
@@ -1963,26 +2164,17 @@ func main() {
 			driftConfig = drift.DefaultConfig()
 		}
 
+		if bl.Velocity != nil {
+			// Same window as the baseline so the rates compare
+			current.Velocity = drift.ComputeVelocityStats(issues, time.Now().UTC(), bl.Velocity.WindowWeeks)
+		}
+
 		calc := drift.NewCalculator(bl, current, driftConfig)
 		result := calc.Calculate()
 
 		if *robotDriftCheck {
 			// JSON output
-			output := struct {
-				GeneratedAt string `json:"generated_at"`
-				HasDrift    bool   `json:"has_drift"`
-				ExitCode    int    `json:"exit_code"`
-				Summary     struct {
-					Critical int `json:"critical"`
-					Warning  int `json:"warning"`
-					Info     int `json:"info"`
-				} `json:"summary"`
-				Alerts   []drift.Alert `json:"alerts"`
-				Baseline struct {
-					CreatedAt string `json:"created_at"`
-					CommitSHA string `json:"commit_sha,omitempty"`
-				} `json:"baseline"`
-			}{
+			output := DriftCheckOutput{
 				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 				HasDrift:    result.HasDrift,
 				ExitCode:    result.ExitCode(),
@@ -2092,17 +2284,7 @@ func main() {
 			}
 		}
 
-		fullStats := struct {
-			PageRank          map[string]float64 `json:"pagerank"`
-			Betweenness       map[string]float64 `json:"betweenness"`
-			Eigenvector       map[string]float64 `json:"eigenvector"`
-			Hubs              map[string]float64 `json:"hubs"`
-			Authorities       map[string]float64 `json:"authorities"`
-			CriticalPathScore map[string]float64 `json:"critical_path_score"`
-			CoreNumber        map[string]int     `json:"core_number"`
-			Slack             map[string]float64 `json:"slack"`
-			Articulation      []string           `json:"articulation_points"`
-		}{
+		fullStats := InsightsFullStats{
 			PageRank:          limitMaps(stats.PageRank(), mapLimit),
 			Betweenness:       limitMaps(stats.Betweenness(), mapLimit),
 			Eigenvector:       limitMaps(stats.Eigenvector(), mapLimit),
@@ -2120,21 +2302,7 @@ func main() {
 		// Generate advanced insights with canonical structure (bv-181)
 		advancedInsights := analyzer.GenerateAdvancedInsights(analysis.DefaultAdvancedInsightsConfig())
 
-		output := struct {
-			GeneratedAt    string                  `json:"generated_at"`
-			DataHash       string                  `json:"data_hash"`
-			AsOf           string                  `json:"as_of,omitempty"`        // Historical snapshot ref
-			AsOfCommit     string                  `json:"as_of_commit,omitempty"` // Resolved commit SHA
-			AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
-			Status         analysis.MetricStatus   `json:"status"`
-			LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
-			LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
-			analysis.Insights
-			FullStats        interface{}                `json:"full_stats"`
-			TopWhatIfs       []analysis.WhatIfEntry     `json:"top_what_ifs,omitempty"`      // Issues with highest downstream impact (bv-83)
-			AdvancedInsights *analysis.AdvancedInsights `json:"advanced_insights,omitempty"` // bv-181: Canonical advanced features
-			UsageHints       []string                   `json:"usage_hints"`                 // bv-84: Agent-friendly hints
-		}{
+		output := InsightsOutput{
 			GeneratedAt:      time.Now().UTC().Format(time.RFC3339),
 			DataHash:         dataHash,
 			AsOf:             *asOf,
@@ -2201,18 +2369,7 @@ func main() {
 		status := stats.Status()
 
 		// Wrap with metadata
-		output := struct {
-			GeneratedAt    string                  `json:"generated_at"`
-			DataHash       string                  `json:"data_hash"`
-			AsOf           string                  `json:"as_of,omitempty"`        // Historical snapshot ref
-			AsOfCommit     string                  `json:"as_of_commit,omitempty"` // Resolved commit SHA
-			AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
-			Status         analysis.MetricStatus   `json:"status"`
-			LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
-			LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
-			Plan           analysis.ExecutionPlan  `json:"plan"`
-			UsageHints     []string                `json:"usage_hints"` // bv-84: Agent-friendly hints
-		}{
+		output := PlanOutput{
 			GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
 			DataHash:       dataHash,
 			AsOf:           *asOf,
@@ -2314,30 +2471,7 @@ func main() {
 		}
 
 		// Build output with summary
-		output := struct {
-			GeneratedAt       string                                    `json:"generated_at"`
-			DataHash          string                                    `json:"data_hash"`
-			AsOf              string                                    `json:"as_of,omitempty"`        // Historical snapshot ref
-			AsOfCommit        string                                    `json:"as_of_commit,omitempty"` // Resolved commit SHA
-			AnalysisConfig    analysis.AnalysisConfig                   `json:"analysis_config"`
-			Status            analysis.MetricStatus                     `json:"status"`
-			LabelScope        string                                    `json:"label_scope,omitempty"`   // bv-122: Label filter applied
-			LabelContext      *analysis.LabelHealth                     `json:"label_context,omitempty"` // bv-122: Health context for scoped label
-			Recommendations   []analysis.EnhancedPriorityRecommendation `json:"recommendations"`
-			FieldDescriptions map[string]string                         `json:"field_descriptions"`
-			Filters           struct {
-				MinConfidence float64 `json:"min_confidence,omitempty"`
-				MaxResults    int     `json:"max_results"`
-				ByLabel       string  `json:"by_label,omitempty"`
-				ByAssignee    string  `json:"by_assignee,omitempty"`
-			} `json:"filters"`
-			Summary struct {
-				TotalIssues     int `json:"total_issues"`
-				Recommendations int `json:"recommendations"`
-				HighConfidence  int `json:"high_confidence"`
-			} `json:"summary"`
-			Usage []string `json:"usage_hints"` // bv-84: Agent-friendly hints
-		}{
+		output := PriorityOutput{
 			GeneratedAt:       time.Now().UTC().Format(time.RFC3339),
 			DataHash:          dataHash,
 			AsOf:              *asOf,
@@ -2396,13 +2530,7 @@ func main() {
 		if *robotNext {
 			// Minimal output: just the top pick
 			if len(triage.QuickRef.TopPicks) == 0 {
-				output := struct {
-					GeneratedAt string `json:"generated_at"`
-					DataHash    string `json:"data_hash"`
-					AsOf        string `json:"as_of,omitempty"`
-					AsOfCommit  string `json:"as_of_commit,omitempty"`
-					Message     string `json:"message"`
-				}{
+				output := NextEmptyOutput{
 					GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 					DataHash:    dataHash,
 					AsOf:        *asOf,
@@ -2419,19 +2547,7 @@ func main() {
 			}
 
 			top := triage.QuickRef.TopPicks[0]
-			output := struct {
-				GeneratedAt string   `json:"generated_at"`
-				DataHash    string   `json:"data_hash"`
-				AsOf        string   `json:"as_of,omitempty"`
-				AsOfCommit  string   `json:"as_of_commit,omitempty"`
-				ID          string   `json:"id"`
-				Title       string   `json:"title"`
-				Score       float64  `json:"score"`
-				Reasons     []string `json:"reasons"`
-				Unblocks    int      `json:"unblocks"`
-				ClaimCmd    string   `json:"claim_command"`
-				ShowCmd     string   `json:"show_command"`
-			}{
+			output := NextOutput{
 				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 				DataHash:    dataHash,
 				AsOf:        *asOf,
@@ -2455,15 +2571,7 @@ func main() {
 		}
 
 		// Full triage output with usage hints
-		output := struct {
-			GeneratedAt string                 `json:"generated_at"`
-			DataHash    string                 `json:"data_hash"`
-			AsOf        string                 `json:"as_of,omitempty"`        // Historical snapshot ref (e.g., HEAD~30)
-			AsOfCommit  string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA
-			Triage      analysis.TriageResult  `json:"triage"`
-			Feedback    *analysis.FeedbackJSON `json:"feedback,omitempty"` // bv-90: Feedback loop state
-			UsageHints  []string               `json:"usage_hints"`        // bv-84: Agent-friendly hints
-		}{
+		output := TriageOutput{
 			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 			DataHash:    dataHash,
 			AsOf:        *asOf,
@@ -2739,7 +2847,7 @@ func main() {
 		}
 
 		// Generate report with explicit beads path
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
@@ -2832,7 +2940,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 				os.Exit(1)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -2912,7 +3020,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 				os.Exit(1)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -2942,13 +3050,13 @@ func main() {
 				os.Exit(1)
 			}
 
-			result := map[string]interface{}{
-				"status":    "confirmed",
-				"commit":    commitSHA,
-				"bead":      beadID,
-				"by":        feedbackBy,
-				"reason":    *correlationFeedbackReason,
-				"orig_conf": originalConf,
+			result := CorrelationFeedbackOutput{
+				Status:       "confirmed",
+				Commit:       commitSHA,
+				Bead:         beadID,
+				By:           feedbackBy,
+				Reason:       *correlationFeedbackReason,
+				OriginalConf: originalConf,
 			}
 			encoder := json.NewEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
@@ -2983,7 +3091,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 				os.Exit(1)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -3013,13 +3121,13 @@ func main() {
 				os.Exit(1)
 			}
 
-			result := map[string]interface{}{
-				"status":    "rejected",
-				"commit":    commitSHA,
-				"bead":      beadID,
-				"by":        feedbackBy,
-				"reason":    *correlationFeedbackReason,
-				"orig_conf": originalConf,
+			result := CorrelationFeedbackOutput{
+				Status:       "rejected",
+				Commit:       commitSHA,
+				Bead:         beadID,
+				By:           feedbackBy,
+				Reason:       *correlationFeedbackReason,
+				OriginalConf: originalConf,
 			}
 			encoder := json.NewEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
@@ -3031,6 +3139,54 @@ func main() {
 		}
 	}
 
+	// Handle --correlation-dry-run flag
+	if *correlationDryRun {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		patterns, err := correlation.LoadPatternSet(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading correlation patterns: %v\n", err)
+			os.Exit(1)
+		}
+		known := make(map[string]bool, len(issues))
+		for _, issue := range issues {
+			known[strings.ToLower(issue.ID)] = true
+		}
+		dryRun, err := patterns.DryRun(cwd, *historyLimit, known)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error running patterns: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Scanned %d commits with %d patterns\n", dryRun.CommitsScanned, len(dryRun.Patterns))
+		for _, p := range dryRun.Patterns {
+			fmt.Printf("\n%s  %s\n", p.Name, p.Regex)
+			if p.Confidence > 0 {
+				fmt.Printf("  confidence: %.2f\n", p.Confidence)
+			}
+			fmt.Printf("  commits: %d, ids: %d (%d not beads)\n", p.Commits, p.IDs, p.UnknownIDs)
+			if len(p.Denied) > 0 {
+				fmt.Printf("  denied: %s\n", strings.Join(p.Denied, ", "))
+			}
+			for _, sample := range p.Samples {
+				marker := "?"
+				if sample.Known {
+					marker = "✓"
+				}
+				fmt.Printf("    %s %s %-12s %s\n", marker, sample.ShortSHA, sample.ID, truncateTitle(sample.Message, 60))
+			}
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-orphans flag (bv-jdop)
 	if *robotOrphans {
 		cwd, err := os.Getwd()
@@ -3068,7 +3224,7 @@ func main() {
 		}
 
 		// Generate history report first (to get existing correlations)
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		correlatorOpts := correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		}
@@ -3081,6 +3237,15 @@ func main() {
 
 		// Detect orphans using OrphanDetector
 		detector := correlation.NewOrphanDetector(report, cwd)
+		patterns, err := correlation.LoadPatternSet(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading correlation patterns: %v\n", err)
+			os.Exit(1)
+		}
+		detector.SetPatterns(patterns)
+		if index := correlator.Index(); index != nil {
+			detector.SetFileSource(index)
+		}
 		extractOpts := correlation.ExtractOptions{
 			Limit: *historyLimit,
 		}
@@ -3155,7 +3320,7 @@ func main() {
 		}
 
 		// Generate history report first
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3172,12 +3337,6 @@ func main() {
 
 		if *fileHotspots {
 			// Output hotspots
-			type HotspotsOutput struct {
-				GeneratedAt time.Time                  `json:"generated_at"`
-				DataHash    string                     `json:"data_hash"`
-				Hotspots    []correlation.FileHotspot  `json:"hotspots"`
-				Stats       correlation.FileIndexStats `json:"stats"`
-			}
 
 			hotspots := fileLookup.GetHotspots(*hotspotsLimit)
 			output := HotspotsOutput{
@@ -3200,15 +3359,6 @@ func main() {
 				result.ClosedBeads = result.ClosedBeads[:*fileBeadsLimit]
 			}
 
-			type FileBeadsOutput struct {
-				GeneratedAt time.Time                   `json:"generated_at"`
-				DataHash    string                      `json:"data_hash"`
-				FilePath    string                      `json:"file_path"`
-				TotalBeads  int                         `json:"total_beads"`
-				OpenBeads   []correlation.BeadReference `json:"open_beads"`
-				ClosedBeads []correlation.BeadReference `json:"closed_beads"`
-			}
-
 			output := FileBeadsOutput{
 				GeneratedAt: time.Now(),
 				DataHash:    report.DataHash,
@@ -3226,6 +3376,75 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-ownership flag
+	if *robotOwnership {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		ownershipBeads := make([]correlation.OwnershipBead, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Status: string(issue.Status),
+			}
+			ownershipBeads[i] = correlation.OwnershipBead{
+				ID:          issue.ID,
+				Title:       issue.Title,
+				Description: issue.Description,
+				Assignee:    issue.Assignee,
+				Labels:      issue.Labels,
+				Closed:      issue.Status.IsClosed(),
+			}
+		}
+
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
+		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts := correlation.DefaultOwnershipOptions()
+		opts.Depth = *ownershipDepth
+		opts.StaleAfter = time.Duration(*ownershipStaleDays) * 24 * time.Hour
+
+		output := OwnershipOutput{
+			OwnershipReport: correlation.BuildOwnershipReport(report, ownershipBeads, opts),
+			DataHash:        report.DataHash,
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding ownership report: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-impact flag (bv-19pq)
 	if *robotImpact != "" {
 		cwd, err := os.Getwd()
@@ -3259,7 +3478,7 @@ func main() {
 			}
 		}
 
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3276,17 +3495,6 @@ func main() {
 
 		impactResult := fileLookup.ImpactAnalysis(files)
 
-		type ImpactOutput struct {
-			GeneratedAt   time.Time                  `json:"generated_at"`
-			DataHash      string                     `json:"data_hash"`
-			Files         []string                   `json:"files"`
-			RiskLevel     string                     `json:"risk_level"`
-			RiskScore     float64                    `json:"risk_score"`
-			Summary       string                     `json:"summary"`
-			Warnings      []string                   `json:"warnings"`
-			AffectedBeads []correlation.AffectedBead `json:"affected_beads"`
-		}
-
 		output := ImpactOutput{
 			GeneratedAt:   time.Now(),
 			DataHash:      report.DataHash,
@@ -3346,7 +3554,7 @@ func main() {
 			}
 		}
 
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3358,15 +3566,6 @@ func main() {
 		fileLookup := correlation.NewFileLookup(report)
 		result := fileLookup.GetRelatedFiles(*robotFileRelations, *relationsThreshold, *relationsLimit)
 
-		type RelationsOutput struct {
-			GeneratedAt  time.Time                   `json:"generated_at"`
-			DataHash     string                      `json:"data_hash"`
-			FilePath     string                      `json:"file_path"`
-			TotalCommits int                         `json:"total_commits"`
-			Threshold    float64                     `json:"threshold"`
-			RelatedFiles []correlation.CoChangeEntry `json:"related_files"`
-		}
-
 		output := RelationsOutput{
 			GeneratedAt:  time.Now(),
 			DataHash:     report.DataHash,
@@ -3424,7 +3623,7 @@ func main() {
 			}
 		}
 
-		correlatorObj := correlation.NewCorrelator(cwd, beadsPath)
+		correlatorObj := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlatorObj.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3457,10 +3656,6 @@ func main() {
 		}
 
 		// Add data hash to output
-		type RelatedWorkOutput struct {
-			*correlation.RelatedWorkResult
-			DataHash string `json:"data_hash"`
-		}
 
 		output := RelatedWorkOutput{
 			RelatedWorkResult: result,
@@ -3484,10 +3679,18 @@ func main() {
 			os.Exit(1)
 		}
 
-		issues, err := loader.LoadIssues(cwd)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+		var issues []model.Issue
+		var dangling []workspace.DanglingRef
+		if workspaceLoader != nil {
+			// Follow blockers across every repo, ignoring --repo
+			issues = workspaceIssues
+			dangling = workspaceInfo.DanglingRefs
+		} else {
+			issues, err = loader.LoadIssues(cwd)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+				os.Exit(1)
+			}
 		}
 
 		an := analysis.NewAnalyzer(issues)
@@ -3498,19 +3701,14 @@ func main() {
 			os.Exit(1)
 		}
 
-		type BlockerChainOutput struct {
-			GeneratedAt time.Time                    `json:"generated_at"`
-			DataHash    string                       `json:"data_hash"`
-			Result      *analysis.BlockerChainResult `json:"result"`
-		}
-
 		// Compute data hash for consistency
 		dataHash := analysis.ComputeDataHash(issues)
 
 		output := BlockerChainOutput{
-			GeneratedAt: time.Now(),
-			DataHash:    dataHash,
-			Result:      result,
+			GeneratedAt:  time.Now(),
+			DataHash:     dataHash,
+			Result:       result,
+			DanglingRefs: danglingRefsInChain(result, dangling),
 		}
 
 		encoder := json.NewEncoder(os.Stdout)
@@ -3561,7 +3759,7 @@ func main() {
 		}
 
 		// Generate history report
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3640,7 +3838,7 @@ func main() {
 			}
 		}
 
-		correlatorObj := correlation.NewCorrelator(cwd, beadsPath)
+		correlatorObj := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlatorObj.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3711,11 +3909,7 @@ func main() {
 			}
 		} else {
 			// Output all sprints as JSON
-			output := struct {
-				GeneratedAt time.Time      `json:"generated_at"`
-				SprintCount int            `json:"sprint_count"`
-				Sprints     []model.Sprint `json:"sprints"`
-			}{
+			output := SprintListOutput{
 				GeneratedAt: time.Now().UTC(),
 				SprintCount: len(sprints),
 				Sprints:     sprints,
@@ -3730,6 +3924,135 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle sprint management commands
+	if *sprintCreate != "" || *sprintUpdate != "" || *sprintAdd != "" || *sprintRemove != "" || *sprintClose != "" {
+		edit := sprintEdit{
+			Name:      *sprintName,
+			Days:      *sprintDays,
+			Velocity:  *sprintVelocity,
+			Beads:     splitBeadIDs(*sprintBeads),
+			CarryOver: *sprintCarryOver,
+		}
+		switch {
+		case *sprintCreate != "":
+			edit.Action, edit.SprintID = sprintActionCreate, *sprintCreate
+		case *sprintUpdate != "":
+			edit.Action, edit.SprintID = sprintActionUpdate, *sprintUpdate
+		case *sprintAdd != "":
+			edit.Action, edit.SprintID = sprintActionAdd, *sprintAdd
+		case *sprintRemove != "":
+			edit.Action, edit.SprintID = sprintActionRemove, *sprintRemove
+		default:
+			edit.Action, edit.SprintID = sprintActionClose, *sprintClose
+		}
+		if (edit.Action == sprintActionAdd || edit.Action == sprintActionRemove) && len(edit.Beads) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: --sprint-%s requires --sprint-beads\n", edit.Action)
+			os.Exit(1)
+		}
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "sprint-velocity" {
+				edit.VelocitySet = true
+			}
+		})
+		for _, date := range []struct {
+			value  string
+			target *time.Time
+		}{{*sprintStart, &edit.Start}, {*sprintEnd, &edit.End}} {
+			parsed, err := parseSprintDate(date.value)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			*date.target = parsed
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		sprints, err := loader.LoadSprints(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading sprints: %v\n", err)
+			os.Exit(1)
+		}
+		updated, summary, err := applySprintEdit(sprints, edit, issues, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := loader.SaveSprints(cwd, updated); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving sprints: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(summary)
+		os.Exit(0)
+	}
+
+	// Handle --robot-sprint-suggest flag
+	if *robotSprintSuggest != "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		sprints, err := loader.LoadSprints(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading sprints: %v\n", err)
+			os.Exit(1)
+		}
+
+		now := time.Now()
+		target := &model.Sprint{StartDate: startOfDay(now)}
+		target.EndDate = target.StartDate.AddDate(0, 0, analysis.DefaultSprintDays)
+		if *robotSprintSuggest != "next" {
+			target = nil
+			for i := range sprints {
+				if sprints[i].ID == *robotSprintSuggest {
+					target = &sprints[i]
+					break
+				}
+			}
+			if target == nil {
+				fmt.Fprintf(os.Stderr, "Sprint not found: %s\n", *robotSprintSuggest)
+				os.Exit(1)
+			}
+		} else if *sprintApply {
+			fmt.Fprintf(os.Stderr, "Error: --sprint-apply needs a sprint ID; create it first with --sprint-create\n")
+			os.Exit(1)
+		}
+
+		opts := analysis.SprintSuggestOptionsFor(target, sprints, *capacityAgents, now)
+		if *sprintDays > 0 {
+			opts.Days = *sprintDays
+		}
+		graphStats := analysis.NewAnalyzer(issues).Analyze()
+		suggestion := analysis.SuggestSprint(issues, &graphStats, opts, now)
+
+		output := SprintSuggestOutput{
+			GeneratedAt: now.UTC(),
+			SprintID:    target.ID,
+			Suggestion:  suggestion,
+		}
+		if *sprintApply && len(suggestion.Items) > 0 {
+			target.AddBeads(suggestion.BeadIDs()...)
+			target.UpdatedAt = now
+			if err := loader.SaveSprints(cwd, sprints); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving sprints: %v\n", err)
+				os.Exit(1)
+			}
+			output.Applied = true
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding sprint suggestion: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-burndown flag (bv-159)
 	if *robotBurndown != "" {
 		cwd, err := os.Getwd()
@@ -3792,6 +4115,87 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --sprint-retro flag
+	if *sprintRetro != "" {
+		if *retroFormat != "markdown" && *retroFormat != "json" {
+			fmt.Fprintf(os.Stderr, "Error: --retro-format must be markdown or json\n")
+			os.Exit(1)
+		}
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		sprints, err := loader.LoadSprints(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading sprints: %v\n", err)
+			os.Exit(1)
+		}
+
+		now := time.Now()
+		var targetSprint *model.Sprint
+		for i := range sprints {
+			if *sprintRetro == "last" {
+				if sprints[i].IsEnded(now) && (targetSprint == nil || sprints[i].EndDate.After(targetSprint.EndDate)) {
+					targetSprint = &sprints[i]
+				}
+			} else if sprints[i].ID == *sprintRetro {
+				targetSprint = &sprints[i]
+				break
+			}
+		}
+		if targetSprint == nil {
+			if *sprintRetro == "last" {
+				fmt.Fprintf(os.Stderr, "No ended sprint found\n")
+			} else {
+				fmt.Fprintf(os.Stderr, "Sprint not found: %s\n", *sprintRetro)
+			}
+			os.Exit(1)
+		}
+
+		input := analysis.SprintRetroInput{
+			Sprint:  *targetSprint,
+			Sprints: sprints,
+			Issues:  issues,
+		}
+		issueMap := make(map[string]model.Issue, len(issues))
+		for _, iss := range issues {
+			issueMap[iss.ID] = iss
+		}
+		if scopeChanges, err := computeSprintScopeChanges(cwd, targetSprint, issueMap, now); err == nil {
+			for _, change := range scopeChanges {
+				input.ScopeChanges = append(input.ScopeChanges, analysis.SprintScopeChange{
+					Date:    change.Date,
+					IssueID: change.IssueID,
+					Action:  change.Action,
+				})
+			}
+		}
+		// Git history is optional: without it planned vs added falls back to
+		// sprint history and cycle times to created → closed
+		if correlation.ValidateRepository(cwd) == nil {
+			if !targetSprint.StartDate.IsZero() {
+				if atStart, err := loader.NewGitLoader(cwd).LoadAtDate(targetSprint.StartDate); err == nil {
+					input.AtStart = atStart
+				}
+			}
+			input.BlockedPeriods, input.CycleTimes = sprintRetroHistory(issues, targetSprint, cwd)
+		}
+		retro := analysis.SprintRetrospective(input, now)
+
+		if *retroFormat == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(SprintRetroOutput{GeneratedAt: now.UTC(), Retro: retro}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding sprint retrospective: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			fmt.Print(export.GenerateSprintRetroMarkdown(retro))
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-forecast flag (bv-158)
 	if *robotForecast != "" {
 		cwd, err := os.Getwd()
@@ -3853,29 +4257,13 @@ func main() {
 			agents = 1
 		}
 
-		type ForecastSummary struct {
-			TotalMinutes  int       `json:"total_minutes"`
-			TotalDays     float64   `json:"total_days"`
-			AvgConfidence float64   `json:"avg_confidence"`
-			EarliestETA   time.Time `json:"earliest_eta"`
-			LatestETA     time.Time `json:"latest_eta"`
-		}
-		type ForecastOutput struct {
-			GeneratedAt   time.Time              `json:"generated_at"`
-			Agents        int                    `json:"agents"`
-			Filters       map[string]string      `json:"filters,omitempty"`
-			ForecastCount int                    `json:"forecast_count"`
-			Forecasts     []analysis.ETAEstimate `json:"forecasts"`
-			Summary       *ForecastSummary       `json:"summary,omitempty"`
-		}
-
 		var forecasts []analysis.ETAEstimate
 		var outputErr error
 
 		if *robotForecast == "all" {
 			// Forecast all open issues
 			for _, iss := range targetIssues {
-				if iss.Status == model.StatusClosed {
+				if iss.Status.IsClosed() {
 					continue
 				}
 				eta, err := analysis.EstimateETAForIssue(issues, &graphStats, iss.ID, agents, now)
@@ -3940,6 +4328,17 @@ func main() {
 			output.Filters = filters
 		}
 
+		if *forecastMC {
+			// An explicit --forecast-seed, even 0, reproduces a forecast
+			seed := now.UnixNano()
+			flag.Visit(func(f *flag.Flag) {
+				if f.Name == "forecast-seed" {
+					seed = *forecastSeed
+				}
+			})
+			output.MonteCarlo = monteCarloForecast(issues, targetIssues, *robotForecast, *forecastLabel, *forecastSprint, agents, *forecastTrials, seed, cwd, now)
+		}
+
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if outputErr = encoder.Encode(output); outputErr != nil {
@@ -3949,6 +4348,23 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-epics flag
+	if *robotEpics {
+		now := time.Now()
+		output := EpicsOutput{
+			GeneratedAt:      now.UTC(),
+			DataHash:         analysis.ComputeDataHash(issues),
+			EpicRollupResult: analysis.ComputeEpicRollups(issues, *capacityAgents, now),
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding epics: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-capacity flag (bv-160)
 	if *robotCapacity {
 		// Build graph stats for analysis
@@ -3975,7 +4391,7 @@ func main() {
 		issueMap := make(map[string]model.Issue)
 		for _, iss := range targetIssues {
 			issueMap[iss.ID] = iss
-			if iss.Status != model.StatusClosed {
+			if !iss.Status.IsClosed() {
 				openIssues = append(openIssues, iss)
 			}
 		}
@@ -4021,7 +4437,7 @@ func main() {
 		for _, iss := range openIssues {
 			hasOpenBlocker := false
 			for _, depID := range blockedBy[iss.ID] {
-				if dep, ok := issueMap[depID]; ok && dep.Status != model.StatusClosed {
+				if dep, ok := issueMap[depID]; ok && !dep.Status.IsClosed() {
 					hasOpenBlocker = true
 					break
 				}
@@ -4046,7 +4462,7 @@ func main() {
 				copy(longestChain, path)
 			}
 			for _, nextID := range blocks[id] {
-				if dep, ok := issueMap[nextID]; ok && dep.Status != model.StatusClosed {
+				if dep, ok := issueMap[nextID]; ok && !dep.Status.IsClosed() {
 					dfs(nextID, path)
 				}
 			}
@@ -4078,12 +4494,6 @@ func main() {
 		estimatedDays := float64(effectiveMinutes) / (60.0 * 8.0) // 8hr workday
 
 		// Find bottlenecks (issues blocking the most other issues)
-		type Bottleneck struct {
-			ID          string   `json:"id"`
-			Title       string   `json:"title"`
-			BlocksCount int      `json:"blocks_count"`
-			Blocks      []string `json:"blocks,omitempty"`
-		}
 		bottlenecks := make([]Bottleneck, 0)
 		for _, iss := range openIssues {
 			if len(blocks[iss.ID]) > 1 {
@@ -4105,23 +4515,6 @@ func main() {
 		}
 
 		// Build output
-		type CapacityOutput struct {
-			GeneratedAt       time.Time    `json:"generated_at"`
-			Agents            int          `json:"agents"`
-			Label             string       `json:"label,omitempty"`
-			OpenIssueCount    int          `json:"open_issue_count"`
-			TotalMinutes      int          `json:"total_minutes"`
-			TotalDays         float64      `json:"total_days"`
-			SerialMinutes     int          `json:"serial_minutes"`
-			ParallelMinutes   int          `json:"parallel_minutes"`
-			ParallelizablePct float64      `json:"parallelizable_pct"`
-			EstimatedDays     float64      `json:"estimated_days"`
-			CriticalPathLen   int          `json:"critical_path_length"`
-			CriticalPath      []string     `json:"critical_path,omitempty"`
-			ActionableCount   int          `json:"actionable_count"`
-			Actionable        []string     `json:"actionable,omitempty"`
-			Bottlenecks       []Bottleneck `json:"bottlenecks,omitempty"`
-		}
 
 		output := CapacityOutput{
 			GeneratedAt:       now.UTC(),
@@ -4192,15 +4585,7 @@ func main() {
 
 		if *robotDiff {
 			// JSON output
-			output := struct {
-				GeneratedAt      string                 `json:"generated_at"`
-				ResolvedRevision string                 `json:"resolved_revision"`
-				AsOf             string                 `json:"as_of,omitempty"`        // "to" snapshot ref (if --as-of used)
-				AsOfCommit       string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA for "to"
-				FromDataHash     string                 `json:"from_data_hash"`
-				ToDataHash       string                 `json:"to_data_hash"`
-				Diff             *analysis.SnapshotDiff `json:"diff"`
-			}{
+			output := DiffOutput{
 				GeneratedAt:      time.Now().UTC().Format(time.RFC3339),
 				ResolvedRevision: revision,
 				AsOf:             *asOf,
@@ -4254,6 +4639,62 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *recipeExportFile != "" {
+		opts := recipeExportOptions(activeRecipe, recipeLoader.Path(*recipeName), *recipeExportFile)
+		selected := issues
+		title := "Beads Export"
+		recipeLabel := ""
+		if activeRecipe != nil {
+			selected = applyRecipeSort(applyRecipeFilters(issues, activeRecipe), activeRecipe)
+			recipeLabel = activeRecipe.Name
+			title = "Beads Export: " + activeRecipe.Name
+		}
+		exportFormat := opts.Format
+		if opts.TemplatePath != "" {
+			exportFormat = "template"
+		}
+		fmt.Printf("Exporting %d issues to %s (%s)...\n", len(selected), *recipeExportFile, exportFormat)
+
+		cwd, _ := os.Getwd()
+		var executor *hooks.Executor
+		if !*noHooks {
+			hookLoader := hooks.NewLoader(hooks.WithProjectDir(cwd))
+			if err := hookLoader.Load(); err != nil {
+				fmt.Printf("Warning: failed to load hooks: %v\n", err)
+			} else if hookLoader.HasHooks() {
+				ctx := hooks.ExportContext{
+					ExportPath:   *recipeExportFile,
+					ExportFormat: exportFormat,
+					IssueCount:   len(selected),
+					Timestamp:    time.Now(),
+				}
+				executor = hooks.NewExecutor(hookLoader.Config(), ctx)
+				if err := executor.RunPreExport(); err != nil {
+					fmt.Printf("Error: pre-export hook failed: %v\n", err)
+					os.Exit(1)
+				}
+			}
+		}
+
+		data := export.BuildRecipeExportData(title, recipeLabel, selected, issues, opts.IncludeGraph)
+		if err := export.SaveRecipeExport(*recipeExportFile, data, opts); err != nil {
+			fmt.Printf("Error exporting: %v\n", err)
+			os.Exit(1)
+		}
+
+		if executor != nil {
+			if err := executor.RunPostExport(); err != nil {
+				fmt.Printf("Warning: post-export hook failed: %v\n", err)
+			}
+			if len(executor.Results()) > 0 {
+				fmt.Println(executor.Summary())
+			}
+		}
+
+		fmt.Println("Done!")
+		os.Exit(0)
+	}
+
 	if *exportFile != "" {
 		fmt.Printf("Exporting to %s...\n", *exportFile)
 
@@ -4327,7 +4768,17 @@ func main() {
 			FailedCount:  workspaceInfo.FailedRepos,
 			TotalIssues:  workspaceInfo.TotalIssues,
 			RepoPrefixes: workspaceInfo.RepoPrefixes,
+			DanglingRefs: workspaceInfo.DanglingRefs,
 		})
+
+		// Live reload: watch every repo and reload only the one that changed
+		var filter func([]model.Issue) []model.Issue
+		if *repoFilter != "" {
+			filter = func(loaded []model.Issue) []model.Issue { return filterByRepo(loaded, *repoFilter) }
+		}
+		if err := m.EnableWorkspaceWatch(workspaceLoader, filter); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: workspace live reload unavailable: %v\n", err)
+		}
 	}
 
 	// Debug render mode - output a view to file and exit
@@ -4555,7 +5006,7 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 	// Build a set of open blocker IDs for actionable filtering
 	openBlockers := make(map[string]bool)
 	for _, issue := range issues {
-		if issue.Status != model.StatusClosed {
+		if !issue.Status.IsClosed() {
 			openBlockers[issue.ID] = true
 		}
 	}
@@ -4566,7 +5017,7 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 		if len(f.Status) > 0 {
 			match := false
 			for _, s := range f.Status {
-				if strings.EqualFold(string(issue.Status), s) {
+				if issue.Status.MatchesFilter(s) {
 					match = true
 					break
 				}
@@ -4983,6 +5434,153 @@ func generateProfileRecommendations(profile *analysis.StartupProfile, loadDurati
 	return recs
 }
 
+// danglingRefsInChain returns the dangling references held by issues in a
+// blocker chain: blockers the chain cannot follow.
+func danglingRefsInChain(result *analysis.BlockerChainResult, refs []workspace.DanglingRef) []workspace.DanglingRef {
+	if len(refs) == 0 {
+		return nil
+	}
+	inChain := make(map[string]bool, len(result.Chain))
+	for _, entry := range result.Chain {
+		inChain[entry.ID] = true
+	}
+	var out []workspace.DanglingRef
+	for _, ref := range refs {
+		if inChain[ref.IssueID] && ref.Type.IsBlocking() {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
+
+// monteCarloForecast runs the --forecast-mc simulation for the --robot-forecast
+// target: a single issue (an epic includes its descendants), or every open
+// issue passing the label/sprint filters.
+func monteCarloForecast(issues, targetIssues []model.Issue, target, label, sprint string, agents, trials int, seed int64, repoPath string, now time.Time) *analysis.MonteCarloForecast {
+	var ids []string
+	kind := "all"
+	if target != "all" {
+		ids = []string{target}
+		kind = "issue"
+		for _, iss := range issues {
+			if iss.ID == target && iss.IssueType == model.TypeEpic {
+				ids = append(ids, analysis.EpicDescendants(issues, target)...)
+				kind = "epic"
+				break
+			}
+		}
+	} else {
+		for _, iss := range targetIssues {
+			if !iss.Status.IsClosed() {
+				ids = append(ids, iss.ID)
+			}
+		}
+		switch {
+		case sprint != "":
+			kind, target = "sprint", sprint
+		case label != "":
+			kind, target = "label", label
+		}
+	}
+
+	forecast := analysis.SimulateCompletion(issues, ids, analysis.MonteCarloOptions{
+		Trials:     trials,
+		Agents:     agents,
+		Seed:       seed,
+		CycleTimes: historyCycleTimes(issues, repoPath),
+	}, now)
+	forecast.Target = target
+	forecast.Kind = kind
+	return &forecast
+}
+
+// historyCycleTimes returns claim-to-close times observed in git history for
+// closed issues. Forecasts work without them, so failures yield nil.
+func historyCycleTimes(issues []model.Issue, repoPath string) map[string]time.Duration {
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return nil
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return nil
+	}
+
+	var beadInfos []correlation.BeadInfo
+	for _, issue := range issues {
+		if issue.Status.IsClosed() {
+			beadInfos = append(beadInfos, correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)})
+		}
+	}
+	if len(beadInfos) == 0 {
+		return nil
+	}
+
+	report, err := correlation.NewCorrelator(repoPath, beadsPath).GenerateReport(beadInfos, correlation.CorrelatorOptions{})
+	if err != nil {
+		return nil
+	}
+	cycleTimes := make(map[string]time.Duration)
+	for id, history := range report.Histories {
+		if history.CycleTime != nil && history.CycleTime.ClaimToClose != nil && *history.CycleTime.ClaimToClose > 0 {
+			cycleTimes[id] = *history.CycleTime.ClaimToClose
+		}
+	}
+	return cycleTimes
+}
+
+// sprintRetroHistory returns blocked periods and claim-to-close times from git
+// history for the sprint's beads. Both are optional inputs to the
+// retrospective, so failures yield nil.
+func sprintRetroHistory(issues []model.Issue, sprint *model.Sprint, repoPath string) ([]analysis.RetroBlockedPeriod, map[string]time.Duration) {
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return nil, nil
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return nil, nil
+	}
+
+	titles := make(map[string]string, len(issues))
+	var beadInfos []correlation.BeadInfo
+	for _, issue := range issues {
+		titles[issue.ID] = issue.Title
+		if sprint.HasBead(issue.ID) {
+			beadInfos = append(beadInfos, correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)})
+		}
+	}
+	if len(beadInfos) == 0 {
+		return nil, nil
+	}
+
+	report, err := correlation.NewCorrelator(repoPath, beadsPath).GenerateReport(beadInfos, correlation.CorrelatorOptions{})
+	if err != nil {
+		return nil, nil
+	}
+	var periods []analysis.RetroBlockedPeriod
+	cycleTimes := make(map[string]time.Duration)
+	for _, bead := range beadInfos {
+		if history, ok := report.Histories[bead.ID]; ok && history.CycleTime != nil &&
+			history.CycleTime.ClaimToClose != nil && *history.CycleTime.ClaimToClose > 0 {
+			cycleTimes[bead.ID] = *history.CycleTime.ClaimToClose
+		}
+		chain := report.BuildCausalityChain(bead.ID, correlation.CausalityOptions{BlockerTitles: titles})
+		if chain == nil || chain.Insights == nil {
+			continue
+		}
+		for _, p := range chain.Insights.BlockedPeriods {
+			periods = append(periods, analysis.RetroBlockedPeriod{
+				BeadID:    bead.ID,
+				BlockerID: p.BlockerID,
+				Start:     p.StartTime,
+				End:       p.EndTime,
+			})
+		}
+	}
+	return periods, cycleTimes
+}
+
 // filterByRepo filters issues to only include those from a specific repository.
 // The filter matches issue IDs that start with the given prefix.
 // If the prefix doesn't end with a separator character, it normalizes by checking
@@ -5632,7 +6230,7 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 	if !config.IncludeClosed {
 		var openIssues []model.Issue
 		for _, issue := range issues {
-			if issue.Status != model.StatusClosed {
+			if !issue.Status.IsClosed() {
 				openIssues = append(openIssues, issue)
 			}
 		}
@@ -6065,7 +6663,7 @@ func calculateBurndownAt(sprint *model.Sprint, issues []model.Issue, now time.Ti
 	totalIssues := len(sprintIssues)
 	completedIssues := 0
 	for _, iss := range sprintIssues {
-		if iss.Status == model.StatusClosed {
+		if iss.Status.IsClosed() {
 			completedIssues++
 		}
 	}
@@ -6160,7 +6758,7 @@ func generateDailyBurndown(sprint *model.Sprint, issues []model.Issue, now time.
 		completed := 0
 
 		for _, iss := range issues {
-			if iss.Status == model.StatusClosed && iss.ClosedAt != nil && !iss.ClosedAt.After(dayEnd) {
+			if iss.Status.IsClosed() && iss.ClosedAt != nil && !iss.ClosedAt.After(dayEnd) {
 				completed++
 			}
 		}
@@ -6367,7 +6965,7 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 	// Check for closed beads (status = closed)
 	issueStatusMap := make(map[string]bool)
 	for _, issue := range issues {
-		issueStatusMap[issue.ID] = issue.Status == model.StatusClosed
+		issueStatusMap[issue.ID] = issue.Status.IsClosed()
 	}
 
 	// Convert map to sorted slice
@@ -268,3 +268,26 @@ func repoRoot(t *testing.T) string {
 		dir = parent
 	}
 }
+
+func TestRecipeExportOptions(t *testing.T) {
+	if got := recipeExportOptions(nil, "", "out.csv"); got.Format != "csv" || got.IncludeGraph {
+		t.Errorf("from extension = %+v, want csv without graph", got)
+	}
+	if got := recipeExportOptions(nil, "", "out.txt"); got.Format != "markdown" || !got.IncludeGraph {
+		t.Errorf("fallback = %+v, want markdown with graph", got)
+	}
+
+	includeGraph := true
+	r := &recipe.Recipe{Export: recipe.ExportConfig{Format: "json", IncludeGraph: &includeGraph, Template: "status.tmpl"}}
+	got := recipeExportOptions(r, filepath.Join("proj", ".bv", "recipes.yaml"), "out.csv")
+	if got.Format != "json" || !got.IncludeGraph || got.TemplatePath != filepath.Join("proj", ".bv", "status.tmpl") {
+		t.Errorf("recipe export config not honored: %+v", got)
+	}
+
+	noGraph := false
+	r = &recipe.Recipe{Export: recipe.ExportConfig{IncludeGraph: &noGraph, Template: "/abs/status.tmpl"}}
+	got = recipeExportOptions(r, filepath.Join("proj", ".bv", "recipes.yaml"), "report.md")
+	if got.Format != "markdown" || got.IncludeGraph || got.TemplatePath != "/abs/status.tmpl" {
+		t.Errorf("include_graph: false or absolute template not honored: %+v", got)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/mcp"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/server"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// runMCP implements `bv mcp`: a Model Context Protocol server on stdin and
+// stdout, for agents that discover tools over MCP instead of shelling out.
+func runMCP(args []string) int {
+	fs := flag.NewFlagSet("bv mcp", flag.ContinueOnError)
+	debounce := fs.Duration("debounce", 0, "Delay before reloading after the beads file changes (default: watcher default)")
+	verbose := fs.Bool("verbose", false, "Log reloads and protocol errors to stderr")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bv mcp")
+		fmt.Fprintln(fs.Output(), "\nServe bv tools and issue resources over MCP (stdio transport).")
+		fmt.Fprintln(fs.Output(), "Register it with an MCP client as the command `bv mcp`, run from the project directory.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+		return 1
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 1
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+		return 1
+	}
+
+	// Stdout carries the protocol; keep loader warnings off it
+	_ = os.Setenv("BV_ROBOT", "1")
+
+	var logOut io.Writer = io.Discard
+	if *verbose {
+		logOut = os.Stderr
+	}
+	backend := server.New(beadsPath,
+		server.WithProjectDir(cwd),
+		server.WithLogger(logOut),
+		server.WithDebounce(*debounce),
+	)
+	defer backend.Close()
+
+	if err := backend.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		return 1
+	}
+	if err := backend.Watch(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: live reload disabled: %v\n", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	srv := mcp.New(backend, mcp.WithVersion(version.Version), mcp.WithLogger(logOut))
+	if err := srv.Serve(ctx, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+)
+
+// recipeExportOptions resolves export settings for --export. The recipe's
+// export.format wins, then the output file extension, then markdown.
+// export.template overrides the format entirely and is resolved relative to
+// recipePath, the file the recipe was loaded from. Markdown includes the
+// dependency graph unless the recipe sets include_graph: false, as
+// --export-md does.
+func recipeExportOptions(r *recipe.Recipe, recipePath, path string) export.RecipeExportOptions {
+	opts := export.RecipeExportOptions{Format: export.RecipeFormatForPath(path)}
+	var includeGraph *bool
+	if r != nil {
+		if r.Export.Format != "" {
+			opts.Format = r.Export.Format
+		}
+		includeGraph = r.Export.IncludeGraph
+		opts.TemplatePath = r.Export.Template
+		if opts.TemplatePath != "" && recipePath != "" && !filepath.IsAbs(opts.TemplatePath) {
+			opts.TemplatePath = filepath.Join(filepath.Dir(recipePath), opts.TemplatePath)
+		}
+	}
+	if opts.Format == "" {
+		opts.Format = export.RecipeFormatMarkdown
+	}
+	if includeGraph != nil {
+		opts.IncludeGraph = *includeGraph
+	} else {
+		opts.IncludeGraph = opts.Format == export.RecipeFormatMarkdown || opts.Format == "md"
+	}
+	return opts
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
+)
+
+// Named payloads for robot commands whose output is assembled in main.
+// Keeping them at package level lets --robot-schema reflect them.
+
+// RecipesOutput is the --robot-recipes payload.
+type RecipesOutput struct {
+	Recipes []recipe.RecipeSummary `json:"recipes"`
+}
+
+// LabelHealthOutput is the --robot-label-health payload.
+type LabelHealthOutput struct {
+	GeneratedAt    string                       `json:"generated_at"`
+	DataHash       string                       `json:"data_hash"`
+	AnalysisConfig analysis.LabelHealthConfig   `json:"analysis_config"`
+	Results        analysis.LabelAnalysisResult `json:"results"`
+	UsageHints     []string                     `json:"usage_hints"`
+}
+
+// LabelFlowOutput is the --robot-label-flow payload.
+type LabelFlowOutput struct {
+	GeneratedAt string                     `json:"generated_at"`
+	DataHash    string                     `json:"data_hash"`
+	Flow        analysis.CrossLabelFlow    `json:"flow"`
+	Config      analysis.LabelHealthConfig `json:"analysis_config"`
+	UsageHints  []string                   `json:"usage_hints"`
+}
+
+// AttentionOutput is the --robot-label-attention payload.
+type AttentionOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	Limit       int    `json:"limit"`
+	TotalLabels int    `json:"total_labels"`
+	Labels      []struct {
+		Rank            int     `json:"rank"`
+		Label           string  `json:"label"`
+		AttentionScore  float64 `json:"attention_score"`
+		NormalizedScore float64 `json:"normalized_score"`
+		Reason          string  `json:"reason"`
+		OpenCount       int     `json:"open_count"`
+		BlockedCount    int     `json:"blocked_count"`
+		StaleCount      int     `json:"stale_count"`
+		PageRankSum     float64 `json:"pagerank_sum"`
+		VelocityFactor  float64 `json:"velocity_factor"`
+	} `json:"labels"`
+	UsageHints []string `json:"usage_hints"`
+}
+
+// AlertsOutput is the --robot-alerts payload.
+type AlertsOutput struct {
+	GeneratedAt string        `json:"generated_at"`
+	DataHash    string        `json:"data_hash"`
+	Alerts      []drift.Alert `json:"alerts"`
+	Summary     struct {
+		Total    int `json:"total"`
+		Critical int `json:"critical"`
+		Warning  int `json:"warning"`
+		Info     int `json:"info"`
+	} `json:"summary"`
+	UsageHints []string `json:"usage_hints"`
+}
+
+// DriftCheckOutput is the --check-drift --robot-drift payload.
+type DriftCheckOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	HasDrift    bool   `json:"has_drift"`
+	ExitCode    int    `json:"exit_code"`
+	Summary     struct {
+		Critical int `json:"critical"`
+		Warning  int `json:"warning"`
+		Info     int `json:"info"`
+	} `json:"summary"`
+	Alerts   []drift.Alert `json:"alerts"`
+	Baseline struct {
+		CreatedAt string `json:"created_at"`
+		CommitSHA string `json:"commit_sha,omitempty"`
+	} `json:"baseline"`
+}
+
+// InsightsFullStats is the full_stats section of --robot-insights.
+type InsightsFullStats struct {
+	PageRank          map[string]float64 `json:"pagerank"`
+	Betweenness       map[string]float64 `json:"betweenness"`
+	Eigenvector       map[string]float64 `json:"eigenvector"`
+	Hubs              map[string]float64 `json:"hubs"`
+	Authorities       map[string]float64 `json:"authorities"`
+	CriticalPathScore map[string]float64 `json:"critical_path_score"`
+	CoreNumber        map[string]int     `json:"core_number"`
+	Slack             map[string]float64 `json:"slack"`
+	Articulation      []string           `json:"articulation_points"`
+}
+
+// InsightsOutput is the --robot-insights payload.
+type InsightsOutput struct {
+	GeneratedAt    string                  `json:"generated_at"`
+	DataHash       string                  `json:"data_hash"`
+	AsOf           string                  `json:"as_of,omitempty"`        // Historical snapshot ref
+	AsOfCommit     string                  `json:"as_of_commit,omitempty"` // Resolved commit SHA
+	AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
+	Status         analysis.MetricStatus   `json:"status"`
+	LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
+	LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
+	analysis.Insights
+	FullStats        InsightsFullStats          `json:"full_stats"`
+	TopWhatIfs       []analysis.WhatIfEntry     `json:"top_what_ifs,omitempty"`      // Issues with highest downstream impact (bv-83)
+	AdvancedInsights *analysis.AdvancedInsights `json:"advanced_insights,omitempty"` // bv-181: Canonical advanced features
+	UsageHints       []string                   `json:"usage_hints"`                 // bv-84: Agent-friendly hints
+}
+
+// PlanOutput is the --robot-plan payload.
+type PlanOutput struct {
+	GeneratedAt    string                  `json:"generated_at"`
+	DataHash       string                  `json:"data_hash"`
+	AsOf           string                  `json:"as_of,omitempty"`        // Historical snapshot ref
+	AsOfCommit     string                  `json:"as_of_commit,omitempty"` // Resolved commit SHA
+	AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
+	Status         analysis.MetricStatus   `json:"status"`
+	LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
+	LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
+	Plan           analysis.ExecutionPlan  `json:"plan"`
+	UsageHints     []string                `json:"usage_hints"` // bv-84: Agent-friendly hints
+}
+
+// PriorityOutput is the --robot-priority payload.
+type PriorityOutput struct {
+	GeneratedAt       string                                    `json:"generated_at"`
+	DataHash          string                                    `json:"data_hash"`
+	AsOf              string                                    `json:"as_of,omitempty"`        // Historical snapshot ref
+	AsOfCommit        string                                    `json:"as_of_commit,omitempty"` // Resolved commit SHA
+	AnalysisConfig    analysis.AnalysisConfig                   `json:"analysis_config"`
+	Status            analysis.MetricStatus                     `json:"status"`
+	LabelScope        string                                    `json:"label_scope,omitempty"`   // bv-122: Label filter applied
+	LabelContext      *analysis.LabelHealth                     `json:"label_context,omitempty"` // bv-122: Health context for scoped label
+	Recommendations   []analysis.EnhancedPriorityRecommendation `json:"recommendations"`
+	FieldDescriptions map[string]string                         `json:"field_descriptions"`
+	Filters           struct {
+		MinConfidence float64 `json:"min_confidence,omitempty"`
+		MaxResults    int     `json:"max_results"`
+		ByLabel       string  `json:"by_label,omitempty"`
+		ByAssignee    string  `json:"by_assignee,omitempty"`
+	} `json:"filters"`
+	Summary struct {
+		TotalIssues     int `json:"total_issues"`
+		Recommendations int `json:"recommendations"`
+		HighConfidence  int `json:"high_confidence"`
+	} `json:"summary"`
+	Usage []string `json:"usage_hints"` // bv-84: Agent-friendly hints
+}
+
+// NextEmptyOutput is the --robot-next payload when nothing is actionable.
+type NextEmptyOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	AsOf        string `json:"as_of,omitempty"`
+	AsOfCommit  string `json:"as_of_commit,omitempty"`
+	Message     string `json:"message"`
+}
+
+// NextOutput is the --robot-next payload.
+type NextOutput struct {
+	GeneratedAt string   `json:"generated_at"`
+	DataHash    string   `json:"data_hash"`
+	AsOf        string   `json:"as_of,omitempty"`
+	AsOfCommit  string   `json:"as_of_commit,omitempty"`
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Score       float64  `json:"score"`
+	Reasons     []string `json:"reasons"`
+	Unblocks    int      `json:"unblocks"`
+	ClaimCmd    string   `json:"claim_command"`
+	ShowCmd     string   `json:"show_command"`
+}
+
+// TriageOutput is the --robot-triage payload.
+type TriageOutput struct {
+	GeneratedAt string                 `json:"generated_at"`
+	DataHash    string                 `json:"data_hash"`
+	AsOf        string                 `json:"as_of,omitempty"`        // Historical snapshot ref (e.g., HEAD~30)
+	AsOfCommit  string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA
+	Triage      analysis.TriageResult  `json:"triage"`
+	Feedback    *analysis.FeedbackJSON `json:"feedback,omitempty"` // bv-90: Feedback loop state
+	UsageHints  []string               `json:"usage_hints"`        // bv-84: Agent-friendly hints
+}
+
+// HotspotsOutput is the --robot-file-hotspots payload.
+type HotspotsOutput struct {
+	GeneratedAt time.Time                  `json:"generated_at"`
+	DataHash    string                     `json:"data_hash"`
+	Hotspots    []correlation.FileHotspot  `json:"hotspots"`
+	Stats       correlation.FileIndexStats `json:"stats"`
+}
+
+// FileBeadsOutput is the --robot-file-beads payload.
+type FileBeadsOutput struct {
+	GeneratedAt time.Time                   `json:"generated_at"`
+	DataHash    string                      `json:"data_hash"`
+	FilePath    string                      `json:"file_path"`
+	TotalBeads  int                         `json:"total_beads"`
+	OpenBeads   []correlation.BeadReference `json:"open_beads"`
+	ClosedBeads []correlation.BeadReference `json:"closed_beads"`
+}
+
+// OwnershipOutput is the --robot-ownership payload.
+type OwnershipOutput struct {
+	*correlation.OwnershipReport
+	DataHash string `json:"data_hash"`
+}
+
+// ImpactOutput is the --robot-impact payload.
+type ImpactOutput struct {
+	GeneratedAt   time.Time                  `json:"generated_at"`
+	DataHash      string                     `json:"data_hash"`
+	Files         []string                   `json:"files"`
+	RiskLevel     string                     `json:"risk_level"`
+	RiskScore     float64                    `json:"risk_score"`
+	Summary       string                     `json:"summary"`
+	Warnings      []string                   `json:"warnings"`
+	AffectedBeads []correlation.AffectedBead `json:"affected_beads"`
+}
+
+// RelationsOutput is the --robot-file-relations payload.
+type RelationsOutput struct {
+	GeneratedAt  time.Time                   `json:"generated_at"`
+	DataHash     string                      `json:"data_hash"`
+	FilePath     string                      `json:"file_path"`
+	TotalCommits int                         `json:"total_commits"`
+	Threshold    float64                     `json:"threshold"`
+	RelatedFiles []correlation.CoChangeEntry `json:"related_files"`
+}
+
+// RelatedWorkOutput is the --robot-related payload.
+type RelatedWorkOutput struct {
+	*correlation.RelatedWorkResult
+	DataHash string `json:"data_hash"`
+}
+
+// BlockerChainOutput is the --robot-blocker-chain payload. In workspace mode
+// DanglingRefs lists blockers of chain members that are not in any loaded repo.
+type BlockerChainOutput struct {
+	GeneratedAt  time.Time                    `json:"generated_at"`
+	DataHash     string                       `json:"data_hash"`
+	Result       *analysis.BlockerChainResult `json:"result"`
+	DanglingRefs []workspace.DanglingRef      `json:"dangling_refs,omitempty"`
+}
+
+// SprintListOutput is the --robot-sprint-list payload.
+type SprintListOutput struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	SprintCount int            `json:"sprint_count"`
+	Sprints     []model.Sprint `json:"sprints"`
+}
+
+// SprintSuggestOutput is the --robot-sprint-suggest payload.
+type SprintSuggestOutput struct {
+	GeneratedAt time.Time                 `json:"generated_at"`
+	SprintID    string                    `json:"sprint_id,omitempty"` // Empty for "next"
+	Applied     bool                      `json:"applied"`             // Items were added to the sprint (--sprint-apply)
+	Suggestion  analysis.SprintSuggestion `json:"suggestion"`
+}
+
+// SprintRetroOutput is the --sprint-retro --retro-format json payload.
+type SprintRetroOutput struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Retro       analysis.SprintRetro `json:"retro"`
+}
+
+// ForecastSummary is the summary section of --robot-forecast.
+type ForecastSummary struct {
+	TotalMinutes  int       `json:"total_minutes"`
+	TotalDays     float64   `json:"total_days"`
+	AvgConfidence float64   `json:"avg_confidence"`
+	EarliestETA   time.Time `json:"earliest_eta"`
+	LatestETA     time.Time `json:"latest_eta"`
+}
+
+// ForecastOutput is the --robot-forecast payload.
+type ForecastOutput struct {
+	GeneratedAt   time.Time              `json:"generated_at"`
+	Agents        int                    `json:"agents"`
+	Filters       map[string]string      `json:"filters,omitempty"`
+	ForecastCount int                    `json:"forecast_count"`
+	Forecasts     []analysis.ETAEstimate `json:"forecasts"`
+	Summary       *ForecastSummary       `json:"summary,omitempty"`
+
+	// MonteCarlo is present with --forecast-mc
+	MonteCarlo *analysis.MonteCarloForecast `json:"monte_carlo,omitempty"`
+}
+
+// Bottleneck is one bottleneck in --robot-capacity.
+type Bottleneck struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	BlocksCount int      `json:"blocks_count"`
+	Blocks      []string `json:"blocks,omitempty"`
+}
+
+// CapacityOutput is the --robot-capacity payload.
+type CapacityOutput struct {
+	GeneratedAt       time.Time    `json:"generated_at"`
+	Agents            int          `json:"agents"`
+	Label             string       `json:"label,omitempty"`
+	OpenIssueCount    int          `json:"open_issue_count"`
+	TotalMinutes      int          `json:"total_minutes"`
+	TotalDays         float64      `json:"total_days"`
+	SerialMinutes     int          `json:"serial_minutes"`
+	ParallelMinutes   int          `json:"parallel_minutes"`
+	ParallelizablePct float64      `json:"parallelizable_pct"`
+	EstimatedDays     float64      `json:"estimated_days"`
+	CriticalPathLen   int          `json:"critical_path_length"`
+	CriticalPath      []string     `json:"critical_path,omitempty"`
+	ActionableCount   int          `json:"actionable_count"`
+	Actionable        []string     `json:"actionable,omitempty"`
+	Bottlenecks       []Bottleneck `json:"bottlenecks,omitempty"`
+}
+
+// EpicsOutput is the --robot-epics payload.
+type EpicsOutput struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	DataHash    string    `json:"data_hash"`
+	analysis.EpicRollupResult
+}
+
+// DiffOutput is the --diff-since --robot-diff payload.
+type DiffOutput struct {
+	GeneratedAt      string                 `json:"generated_at"`
+	ResolvedRevision string                 `json:"resolved_revision"`
+	AsOf             string                 `json:"as_of,omitempty"`        // "to" snapshot ref (if --as-of used)
+	AsOfCommit       string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA for "to"
+	FromDataHash     string                 `json:"from_data_hash"`
+	ToDataHash       string                 `json:"to_data_hash"`
+	Diff             *analysis.SnapshotDiff `json:"diff"`
+}
+
+// CorrelationFeedbackOutput is the --robot-confirm-correlation and
+// --robot-reject-correlation payload.
+type CorrelationFeedbackOutput struct {
+	Status       string  `json:"status" enum:"confirmed,rejected"`
+	Commit       string  `json:"commit"`
+	Bead         string  `json:"bead"`
+	By           string  `json:"by"`
+	Reason       string  `json:"reason"`
+	OriginalConf float64 `json:"orig_conf"`
+}
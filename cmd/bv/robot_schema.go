@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/jsonschema"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// robotSchemaVersion versions the published robot output schemas. Bump it
+// once per release in which a robot output changed shape, not with every
+// change: each schema's $id embeds it, so a bump rewrites every golden in
+// testdata/golden/robot_schema. Between releases, regenerate only the
+// goldens of the commands that changed.
+const robotSchemaVersion = "1"
+
+// robotSchemaCommand maps a robot command to the Go value(s) it encodes.
+// Commands with more than one output shape list every alternative.
+type robotSchemaCommand struct {
+	Name        string
+	Flag        string
+	Description string
+	Outputs     []any
+}
+
+var robotSchemaCommands = []robotSchemaCommand{
+	{"alerts", "--robot-alerts", "Drift and proactive alerts", []any{AlertsOutput{}}},
+	{"blocker-chain", "--robot-blocker-chain", "Full blocker chain for an issue", []any{BlockerChainOutput{}}},
+	{"burndown", "--robot-burndown", "Sprint burndown data", []any{BurndownOutput{}}},
+	{"capacity", "--robot-capacity", "Capacity simulation and completion projection", []any{CapacityOutput{}}},
+	{"causality", "--robot-causality", "Causal chain of events for a bead", []any{correlation.CausalityResult{}}},
+	{"confirm-correlation", "--robot-confirm-correlation", "Result of confirming a commit-bead correlation", []any{CorrelationFeedbackOutput{}}},
+	{"correlation-stats", "--robot-correlation-stats", "Correlation feedback statistics", []any{correlation.FeedbackStats{}}},
+	{"diff", "--robot-diff", "Changes since a historical point (--diff-since)", []any{DiffOutput{}}},
+	{"drift", "--robot-drift", "Drift from the saved baseline", []any{DriftCheckOutput{}}},
+	{"epics", "--robot-epics", "Epic progress rollups, critical paths and projected finish dates", []any{EpicsOutput{}}},
+	{"explain-correlation", "--robot-explain-correlation", "Why a commit is linked to a bead", []any{correlation.CorrelationExplanation{}}},
+	{"file-beads", "--robot-file-beads", "Beads that touched a file", []any{FileBeadsOutput{}}},
+	{"file-hotspots", "--robot-file-hotspots", "Files touched by the most beads", []any{HotspotsOutput{}}},
+	{"file-relations", "--robot-file-relations", "Files that frequently change together", []any{RelationsOutput{}}},
+	{"forecast", "--robot-forecast", "ETA forecasts for open issues", []any{ForecastOutput{}}},
+	{"graph", "--robot-graph", "Dependency graph export", []any{export.GraphExportResult{}}},
+	{"history", "--robot-history", "Bead-to-commit correlations", []any{correlation.HistoryReport{}}},
+	{"impact", "--robot-impact", "Impact of modifying files", []any{ImpactOutput{}}},
+	{"impact-network", "--robot-impact-network", "Bead impact network", []any{correlation.ImpactNetworkResult{}}},
+	{"insights", "--robot-insights", "Graph analysis and metrics", []any{InsightsOutput{}}},
+	{"label-attention", "--robot-label-attention", "Attention-ranked labels", []any{AttentionOutput{}}},
+	{"label-flow", "--robot-label-flow", "Cross-label dependency flow", []any{LabelFlowOutput{}}},
+	{"label-health", "--robot-label-health", "Label health metrics", []any{LabelHealthOutput{}}},
+	{"next", "--robot-next", "Single top pick, or a message when nothing is actionable", []any{NextOutput{}, NextEmptyOutput{}}},
+	{"orphans", "--robot-orphans", "Commits that look related to beads but are not linked", []any{correlation.OrphanReport{}}},
+	{"ownership", "--robot-ownership", "Directory owners, assignee suggestions and open beads in unowned areas", []any{OwnershipOutput{}}},
+	{"plan", "--robot-plan", "Dependency-respecting execution plan", []any{PlanOutput{}}},
+	{"priority", "--robot-priority", "Priority misalignment recommendations", []any{PriorityOutput{}}},
+	{"recipes", "--robot-recipes", "Available recipes", []any{RecipesOutput{}}},
+	{"reject-correlation", "--robot-reject-correlation", "Result of rejecting a commit-bead correlation", []any{CorrelationFeedbackOutput{}}},
+	{"related", "--robot-related", "Work related to a bead", []any{RelatedWorkOutput{}}},
+	{"search", "--robot-search", "Semantic search results", []any{robotSearchOutput{}}},
+	{"sprint-list", "--robot-sprint-list", "All sprints", []any{SprintListOutput{}}},
+	{"sprint-retro", "--sprint-retro --retro-format json", "Sprint retrospective: scope, carry-over, cycle time, blockers", []any{SprintRetroOutput{}}},
+	{"sprint-show", "--robot-sprint-show", "One sprint", []any{model.Sprint{}}},
+	{"sprint-suggest", "--robot-sprint-suggest", "Capacity-aware bead suggestions for a sprint", []any{SprintSuggestOutput{}}},
+	{"suggest", "--robot-suggest", "Smart suggestions (duplicates, dependencies, labels, cycles)", []any{analysis.RobotSuggestOutput{}}},
+	{"triage", "--robot-triage", "Unified triage (also --robot-triage-by-track and --robot-triage-by-label)", []any{TriageOutput{}}},
+	{"watch", "--robot-watch", "One NDJSON record of the change feed", []any{watchRecord{}}},
+}
+
+// RobotSchemaOutput is the --robot-schema payload.
+type RobotSchemaOutput struct {
+	SchemaVersion string                        `json:"schema_version"`
+	Draft         string                        `json:"draft"`
+	Commands      map[string]*jsonschema.Schema `json:"commands"`
+}
+
+// buildRobotSchema returns the schemas for command, or for every robot
+// command when command is empty. The "--robot-" prefix is optional.
+func buildRobotSchema(command string) (*RobotSchemaOutput, error) {
+	command = strings.TrimPrefix(strings.TrimPrefix(command, "--"), "robot-")
+	out := &RobotSchemaOutput{
+		SchemaVersion: robotSchemaVersion,
+		Draft:         jsonschema.Draft,
+		Commands:      make(map[string]*jsonschema.Schema),
+	}
+	for _, cmd := range robotSchemaCommands {
+		if command != "" && cmd.Name != command {
+			continue
+		}
+		out.Commands[cmd.Name] = robotCommandSchema(cmd)
+	}
+	if len(out.Commands) == 0 {
+		return nil, fmt.Errorf("unknown robot command %q (available: %s)", command, strings.Join(robotSchemaNames(), ", "))
+	}
+	return out, nil
+}
+
+func robotCommandSchema(cmd robotSchemaCommand) *jsonschema.Schema {
+	var s *jsonschema.Schema
+	if len(cmd.Outputs) == 1 {
+		s = jsonschema.Reflect(cmd.Outputs[0])
+	} else {
+		s = jsonschema.ReflectAnyOf(cmd.Outputs...)
+	}
+	s.ID = "urn:bv:robot-schema:v" + robotSchemaVersion + ":" + cmd.Name
+	s.Title = "bv " + cmd.Flag
+	s.Description = cmd.Description
+	return s
+}
+
+func robotSchemaNames() []string {
+	names := make([]string, 0, len(robotSchemaCommands))
+	for _, cmd := range robotSchemaCommands {
+		names = append(names, cmd.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
+)
+
+// TestRobotSchema_Golden pins every published schema. A failure here means a
+// robot output changed shape: if that was intended, regenerate with
+// GENERATE_GOLDEN=1 go test ./cmd/bv -run RobotSchema, and make sure
+// robotSchemaVersion is bumped once before the next release.
+func TestRobotSchema_Golden(t *testing.T) {
+	dir := filepath.Join("..", "..", "testdata", "golden", "robot_schema")
+	for _, cmd := range robotSchemaCommands {
+		t.Run(cmd.Name, func(t *testing.T) {
+			data, err := json.MarshalIndent(robotCommandSchema(cmd), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.NewGoldenFile(t, dir, cmd.Name+".schema.json").Assert(string(data) + "\n")
+		})
+	}
+}
+
+func TestRobotSchema_CoversEncodedFields(t *testing.T) {
+	for _, cmd := range robotSchemaCommands {
+		schema := robotCommandSchema(cmd)
+		alternatives := schema.AnyOf
+		if len(alternatives) == 0 {
+			alternatives = append(alternatives, schema)
+		}
+		for i, sample := range cmd.Outputs {
+			data, err := json.Marshal(sample)
+			if err != nil {
+				t.Fatalf("%s: %v", cmd.Name, err)
+			}
+			var fields map[string]any
+			if err := json.Unmarshal(data, &fields); err != nil {
+				t.Fatalf("%s: %v", cmd.Name, err)
+			}
+			for key := range fields {
+				if _, ok := alternatives[i].Properties[key]; !ok {
+					t.Errorf("%s: encoded field %q missing from schema", cmd.Name, key)
+				}
+			}
+			for _, key := range alternatives[i].Required {
+				if _, ok := fields[key]; !ok {
+					t.Errorf("%s: required field %q not encoded for a zero value", cmd.Name, key)
+				}
+			}
+		}
+	}
+}
+
+func TestBuildRobotSchema(t *testing.T) {
+	out, err := buildRobotSchema("--robot-triage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.SchemaVersion != robotSchemaVersion || len(out.Commands) != 1 || out.Commands["triage"] == nil {
+		t.Fatalf("schema output = %+v", out)
+	}
+	if _, ok := out.Commands["triage"].Properties["triage"]; !ok {
+		t.Error("triage schema should describe the triage field")
+	}
+
+	all, err := buildRobotSchema("")
+	if err != nil || len(all.Commands) != len(robotSchemaCommands) {
+		t.Fatalf("all commands: %d schemas, err=%v", len(all.Commands), err)
+	}
+	if _, err := buildRobotSchema("bogus"); err == nil {
+		t.Error("unknown command should error")
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+)
+
+// watchRecord is one NDJSON line written by --robot-watch. The first record
+// has event "ready" and describes the starting state; each later "change"
+// record describes one debounced reload relative to the previous one.
+type watchRecord struct {
+	Event           string                 `json:"event"` // ready | change | error
+	Seq             int                    `json:"seq"`
+	GeneratedAt     string                 `json:"generated_at"`
+	DataHash        string                 `json:"data_hash,omitempty"`
+	PrevDataHash    string                 `json:"prev_data_hash,omitempty"`
+	IssueCount      int                    `json:"issue_count"`
+	ActionableCount int                    `json:"actionable_count"`
+	Diff            *analysis.SnapshotDiff `json:"diff,omitempty"`
+	NewlyActionable []watchIssueRef        `json:"newly_actionable,omitempty"`
+	DriftAlerts     []drift.Alert          `json:"drift_alerts,omitempty"` // Alerts that started firing on this reload
+	Error           string                 `json:"error,omitempty"`
+}
+
+// watchIssueRef identifies an issue in a watch record.
+type watchIssueRef struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Priority int    `json:"priority"`
+}
+
+// watchState is everything --robot-watch remembers about one load.
+type watchState struct {
+	issues     []model.Issue
+	dataHash   string
+	snapshot   *analysis.Snapshot
+	actionable map[string]bool
+	alerts     []drift.Alert
+}
+
+// robotWatchOptions configures runRobotWatch.
+type robotWatchOptions struct {
+	beadsPath string
+	filter    func([]model.Issue) []model.Issue // Applied after each load (e.g. --repo)
+	reference *baseline.Baseline                // Drift reference; nil uses the starting state
+	driftCfg  *drift.Config
+	debounce  time.Duration
+	now       func() time.Time
+}
+
+// runRobotWatch writes a ready record for issues, then one change record per
+// debounced reload of the beads file until ctx is cancelled.
+func runRobotWatch(ctx context.Context, issues []model.Issue, opts robotWatchOptions, out io.Writer) error {
+	if opts.now == nil {
+		opts.now = time.Now
+	}
+	if opts.driftCfg == nil {
+		opts.driftCfg = drift.DefaultConfig()
+	}
+	enc := json.NewEncoder(out)
+
+	prev := newWatchState(issues, opts.now())
+	if opts.reference == nil {
+		opts.reference = prev.baseline()
+	}
+	prev.alerts = prev.driftAlerts(opts.reference, opts.driftCfg)
+	seq := 0
+	if err := enc.Encode(watchRecord{
+		Event:           "ready",
+		Seq:             seq,
+		GeneratedAt:     opts.now().UTC().Format(time.RFC3339),
+		DataHash:        prev.dataHash,
+		IssueCount:      len(prev.issues),
+		ActionableCount: len(prev.actionable),
+		DriftAlerts:     prev.alerts,
+	}); err != nil {
+		return err
+	}
+
+	changed := make(chan struct{}, 1)
+	watchOpts := []watcher.WatcherOption{
+		watcher.WithOnChange(func() {
+			select {
+			case changed <- struct{}{}:
+			default: // a reload is already pending
+			}
+		}),
+	}
+	if opts.debounce > 0 {
+		watchOpts = append(watchOpts, watcher.WithDebounceDuration(opts.debounce))
+	}
+	w, err := watcher.NewWatcher(opts.beadsPath, watchOpts...)
+	if err != nil {
+		return fmt.Errorf("watch %s: %w", opts.beadsPath, err)
+	}
+	if err := w.Start(); err != nil {
+		return fmt.Errorf("watch %s: %w", opts.beadsPath, err)
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+
+		loaded, err := loader.LoadIssuesFromFile(opts.beadsPath)
+		if err != nil {
+			seq++
+			// Keep the previous state so the next good load diffs against it
+			if err := enc.Encode(watchRecord{Event: "error", Seq: seq, GeneratedAt: opts.now().UTC().Format(time.RFC3339), Error: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if opts.filter != nil {
+			loaded = opts.filter(loaded)
+		}
+		if analysis.ComputeDataHash(loaded) == prev.dataHash {
+			continue // touched but unchanged
+		}
+
+		cur := newWatchState(loaded, opts.now())
+		cur.alerts = cur.driftAlerts(opts.reference, opts.driftCfg)
+		seq++
+		if err := enc.Encode(watchDelta(prev, cur, seq, opts.now())); err != nil {
+			return err
+		}
+		prev = cur
+	}
+}
+
+func newWatchState(issues []model.Issue, now time.Time) *watchState {
+	st := &watchState{
+		issues:     issues,
+		dataHash:   analysis.ComputeDataHash(issues),
+		snapshot:   analysis.NewSnapshotAt(issues, now, ""),
+		actionable: make(map[string]bool),
+	}
+	for _, issue := range analysis.NewAnalyzer(issues).GetActionableIssues() {
+		st.actionable[issue.ID] = true
+	}
+	return st
+}
+
+// baseline converts the state into the form drift.Calculator compares.
+func (st *watchState) baseline() *baseline.Baseline {
+	stats := st.snapshot.Stats
+	cycles := stats.Cycles()
+	return &baseline.Baseline{
+		Stats: baseline.GraphStats{
+			NodeCount:       stats.NodeCount,
+			EdgeCount:       stats.EdgeCount,
+			Density:         stats.Density,
+			OpenCount:       st.snapshot.OpenCount - st.snapshot.BlockedCount,
+			ClosedCount:     st.snapshot.ClosedCount,
+			BlockedCount:    st.snapshot.BlockedCount,
+			CycleCount:      len(cycles),
+			ActionableCount: len(st.actionable),
+		},
+		TopMetrics: baseline.TopMetrics{
+			PageRank:     buildMetricItems(stats.PageRank(), 10),
+			Betweenness:  buildMetricItems(stats.Betweenness(), 10),
+			CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
+			Hubs:         buildMetricItems(stats.Hubs(), 10),
+			Authorities:  buildMetricItems(stats.Authorities(), 10),
+		},
+		Cycles: cycles,
+	}
+}
+
+func (st *watchState) driftAlerts(reference *baseline.Baseline, cfg *drift.Config) []drift.Alert {
+	calc := drift.NewCalculator(reference, st.baseline(), cfg)
+	calc.SetIssues(st.issues)
+	return calc.Calculate().Alerts
+}
+
+// watchDelta describes the move from prev to cur.
+func watchDelta(prev, cur *watchState, seq int, now time.Time) watchRecord {
+	rec := watchRecord{
+		Event:           "change",
+		Seq:             seq,
+		GeneratedAt:     now.UTC().Format(time.RFC3339),
+		DataHash:        cur.dataHash,
+		PrevDataHash:    prev.dataHash,
+		IssueCount:      len(cur.issues),
+		ActionableCount: len(cur.actionable),
+		Diff:            analysis.CompareSnapshots(prev.snapshot, cur.snapshot),
+	}
+
+	for _, issue := range cur.issues {
+		if cur.actionable[issue.ID] && !prev.actionable[issue.ID] {
+			rec.NewlyActionable = append(rec.NewlyActionable, watchIssueRef{ID: issue.ID, Title: issue.Title, Priority: issue.Priority})
+		}
+	}
+	sort.Slice(rec.NewlyActionable, func(i, j int) bool {
+		a, b := rec.NewlyActionable[i], rec.NewlyActionable[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+
+	seen := make(map[string]bool, len(prev.alerts))
+	for _, a := range prev.alerts {
+		seen[watchAlertKey(a)] = true
+	}
+	for _, a := range cur.alerts {
+		if !seen[watchAlertKey(a)] {
+			rec.DriftAlerts = append(rec.DriftAlerts, a)
+		}
+	}
+	return rec
+}
+
+// watchAlertKey identifies an alert across reloads; messages carry numbers
+// that change from one reload to the next, so they are not part of it.
+func watchAlertKey(a drift.Alert) string {
+	return string(a.Type) + "|" + a.IssueID + "|" + a.Label
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestWatchDelta_NewlyActionableAndDiff(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	before := []model.Issue{
+		{ID: "A", Title: "Root", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask},
+		{ID: "B", Title: "Blocked", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	after := []model.Issue{
+		{ID: "A", Title: "Root", Status: model.StatusClosed, Priority: 1, IssueType: model.TypeTask},
+		before[1],
+		{ID: "C", Title: "New", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeBug},
+	}
+
+	prev := newWatchState(before, now)
+	cur := newWatchState(after, now.Add(time.Minute))
+	rec := watchDelta(prev, cur, 3, now)
+
+	if rec.Event != "change" || rec.Seq != 3 || rec.PrevDataHash != prev.dataHash || rec.DataHash != cur.dataHash {
+		t.Fatalf("record header = %+v", rec)
+	}
+	if rec.Diff.Summary.IssuesAdded != 1 || rec.Diff.Summary.IssuesClosed != 1 {
+		t.Errorf("diff summary = %+v", rec.Diff.Summary)
+	}
+	if len(rec.NewlyActionable) != 2 || rec.NewlyActionable[0].ID != "C" || rec.NewlyActionable[1].ID != "B" {
+		t.Errorf("newly actionable = %+v, want C (P0) then B (unblocked)", rec.NewlyActionable)
+	}
+}
+
+func TestRunRobotWatch_StreamsChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	write := func(lines string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`{"id":"A","title":"Root","status":"open","priority":1,"issue_type":"task"}` + "\n" +
+		`{"id":"B","title":"Next","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}` + "\n")
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- runRobotWatch(ctx, issues, robotWatchOptions{beadsPath: path, debounce: 20 * time.Millisecond}, pw)
+		pw.Close()
+	}()
+
+	records := make(chan watchRecord)
+	go func() {
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 1<<20), 1<<20)
+		for scanner.Scan() {
+			var rec watchRecord
+			if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+				t.Errorf("invalid NDJSON line: %v", err)
+				continue
+			}
+			records <- rec
+		}
+		close(records)
+	}()
+	next := func() watchRecord {
+		t.Helper()
+		select {
+		case rec := <-records:
+			return rec
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a watch record")
+			return watchRecord{}
+		}
+	}
+
+	ready := next()
+	if ready.Event != "ready" || ready.IssueCount != 2 || ready.ActionableCount != 1 {
+		t.Fatalf("ready record = %+v", ready)
+	}
+
+	// Give the watcher a moment to start before changing the file
+	time.Sleep(50 * time.Millisecond)
+	write(`{"id":"A","title":"Root","status":"closed","priority":1,"issue_type":"task"}` + "\n" +
+		`{"id":"B","title":"Next","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}` + "\n")
+
+	change := next()
+	if change.Event != "change" || change.Seq != 1 || change.PrevDataHash != ready.DataHash {
+		t.Fatalf("change record = %+v", change)
+	}
+	if change.Diff == nil || change.Diff.Summary.IssuesClosed != 1 {
+		t.Errorf("diff = %+v", change.Diff)
+	}
+	if len(change.NewlyActionable) != 1 || change.NewlyActionable[0].ID != "B" {
+		t.Errorf("newly actionable = %+v", change.NewlyActionable)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("runRobotWatch: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/server"
+)
+
+// runServe implements `bv serve`: load once, keep analysis warm, and answer
+// robot queries over HTTP until interrupted.
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("bv serve", flag.ContinueOnError)
+	addr := fs.String("addr", server.DefaultAddr, "Loopback address to listen on (host:port)")
+	socket := fs.String("socket", "", "Listen on a Unix socket instead of TCP (e.g. .bv/bv.sock)")
+	debounce := fs.Duration("debounce", 0, "Delay before reloading after the beads file changes (default: watcher default)")
+	quiet := fs.Bool("quiet", false, "Do not log requests and reloads to stderr")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: bv serve [--addr host:port | --socket path]")
+		fmt.Fprintln(fs.Output(), "\nServe robot queries from a warm analysis cache.")
+		fmt.Fprintf(fs.Output(), "Endpoints (GET): /%s; JSON-RPC 2.0: POST /rpc\n\n", strings.Join(server.MethodNames(), ", /"))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+		return 1
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 1
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+		return 1
+	}
+
+	// Keep loader warnings off stdout, as in robot mode
+	_ = os.Setenv("BV_ROBOT", "1")
+
+	var logOut io.Writer = os.Stderr
+	if *quiet {
+		logOut = io.Discard
+	}
+	srv := server.New(beadsPath,
+		server.WithProjectDir(cwd),
+		server.WithLogger(logOut),
+		server.WithDebounce(*debounce),
+	)
+	defer srv.Close()
+
+	if err := srv.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		return 1
+	}
+	if err := srv.Watch(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: live reload disabled: %v\n", err)
+	}
+
+	ln, err := server.Listen(*addr, *socket)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *socket != "" {
+		defer os.Remove(*socket)
+		fmt.Fprintf(os.Stderr, "bv serve: listening on unix:%s\n", *socket)
+	} else {
+		fmt.Fprintf(os.Stderr, "bv serve: listening on http://%s\n", ln.Addr())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	start := time.Now()
+	if err := srv.Serve(ctx, ln); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "bv serve: stopped after %s\n", time.Since(start).Round(time.Second))
+	return 0
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Sprint management actions (--sprint-create, --sprint-update, ...)
+const (
+	sprintActionCreate = "create"
+	sprintActionUpdate = "update"
+	sprintActionAdd    = "add"
+	sprintActionRemove = "remove"
+	sprintActionClose  = "close"
+)
+
+// sprintEdit is one sprint management command assembled from the CLI flags.
+// Zero values mean "not given" except for Velocity, which is only applied
+// when VelocitySet is true.
+type sprintEdit struct {
+	Action      string
+	SprintID    string
+	Name        string
+	Start       time.Time
+	End         time.Time
+	Days        int
+	Velocity    float64
+	VelocitySet bool
+	Beads       []string
+	CarryOver   string // --sprint-close: sprint receiving the unfinished beads
+}
+
+// applySprintEdit applies edit to sprints and returns the updated sprints and
+// a one-line summary for the user. Beads being added must exist in issues.
+func applySprintEdit(sprints []model.Sprint, edit sprintEdit, issues []model.Issue, now time.Time) ([]model.Sprint, string, error) {
+	idx := -1
+	for i := range sprints {
+		if sprints[i].ID == edit.SprintID {
+			idx = i
+			break
+		}
+	}
+	if edit.Action == sprintActionCreate {
+		if idx >= 0 {
+			return nil, "", fmt.Errorf("sprint %s already exists", edit.SprintID)
+		}
+	} else if idx < 0 {
+		return nil, "", fmt.Errorf("sprint not found: %s", edit.SprintID)
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+	if edit.Action == sprintActionCreate || edit.Action == sprintActionAdd {
+		var unknown []string
+		for _, id := range edit.Beads {
+			if _, ok := issueMap[id]; !ok {
+				unknown = append(unknown, id)
+			}
+		}
+		if len(unknown) > 0 {
+			return nil, "", fmt.Errorf("unknown bead(s): %s", strings.Join(unknown, ", "))
+		}
+	}
+
+	// Work on a copy so a failed validation leaves the caller's slice intact
+	updated := append([]model.Sprint(nil), sprints...)
+	var sprint *model.Sprint
+	var summary string
+
+	switch edit.Action {
+	case sprintActionCreate:
+		start := edit.Start
+		if start.IsZero() {
+			start = startOfDay(now)
+		}
+		end := edit.End
+		if end.IsZero() {
+			days := edit.Days
+			if days <= 0 {
+				days = analysis.DefaultSprintDays
+			}
+			end = start.AddDate(0, 0, days)
+		}
+		name := edit.Name
+		if name == "" {
+			name = edit.SprintID
+		}
+		updated = append(updated, model.Sprint{
+			ID:             edit.SprintID,
+			Name:           name,
+			StartDate:      start,
+			EndDate:        end,
+			VelocityTarget: edit.Velocity,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+		sprint = &updated[len(updated)-1]
+		sprint.AddBeads(edit.Beads...)
+		summary = fmt.Sprintf("Created sprint %s (%s → %s, %d beads)", sprint.ID,
+			sprint.StartDate.Format("Jan 2"), sprint.EndDate.Format("Jan 2"), len(sprint.BeadIDs))
+
+	case sprintActionUpdate:
+		sprint = &updated[idx]
+		sprint.BeadIDs = append([]string(nil), sprint.BeadIDs...)
+		if edit.Name != "" {
+			sprint.Name = edit.Name
+		}
+		if !edit.Start.IsZero() {
+			sprint.StartDate = edit.Start
+		}
+		if !edit.End.IsZero() {
+			sprint.EndDate = edit.End
+		} else if edit.Days > 0 && !sprint.StartDate.IsZero() {
+			sprint.EndDate = sprint.StartDate.AddDate(0, 0, edit.Days)
+		}
+		if edit.VelocitySet {
+			sprint.VelocityTarget = edit.Velocity
+		}
+		sprint.UpdatedAt = now
+		summary = fmt.Sprintf("Updated sprint %s", sprint.ID)
+
+	case sprintActionAdd:
+		sprint = &updated[idx]
+		sprint.BeadIDs = append([]string(nil), sprint.BeadIDs...)
+		added := sprint.AddBeads(edit.Beads...)
+		sprint.UpdatedAt = now
+		summary = fmt.Sprintf("Added %d bead(s) to sprint %s (%d total)", added, sprint.ID, len(sprint.BeadIDs))
+
+	case sprintActionRemove:
+		sprint = &updated[idx]
+		sprint.BeadIDs = append([]string(nil), sprint.BeadIDs...)
+		removed := sprint.RemoveBeads(edit.Beads...)
+		sprint.UpdatedAt = now
+		summary = fmt.Sprintf("Removed %d bead(s) from sprint %s (%d total)", removed, sprint.ID, len(sprint.BeadIDs))
+
+	case sprintActionClose:
+		sprint = &updated[idx]
+		sprint.Close(now)
+		summary = fmt.Sprintf("Closed sprint %s on %s", sprint.ID, sprint.EndDate.Format("Jan 2"))
+
+		if edit.CarryOver != "" {
+			next := -1
+			for i := range updated {
+				if updated[i].ID == edit.CarryOver {
+					next = i
+					break
+				}
+			}
+			if next < 0 || next == idx {
+				return nil, "", fmt.Errorf("carry-over sprint not found: %s", edit.CarryOver)
+			}
+			// The closed sprint keeps its beads so retrospectives see what slipped
+			var unfinished []string
+			for _, id := range sprint.BeadIDs {
+				if issue, ok := issueMap[id]; ok && !issue.Status.IsClosed() {
+					unfinished = append(unfinished, id)
+				}
+			}
+			target := &updated[next]
+			target.BeadIDs = append([]string(nil), target.BeadIDs...)
+			carried := target.AddBeads(unfinished...)
+			target.UpdatedAt = now
+			summary += fmt.Sprintf("; carried %d unfinished bead(s) to %s", carried, target.ID)
+		}
+
+	default:
+		return nil, "", fmt.Errorf("unknown sprint action %q", edit.Action)
+	}
+
+	if err := sprint.Validate(); err != nil {
+		return nil, "", fmt.Errorf("invalid sprint %s: %w", sprint.ID, err)
+	}
+	return updated, summary, nil
+}
+
+// parseSprintDate accepts YYYY-MM-DD (local midnight) or RFC3339.
+func parseSprintDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD or RFC3339)", value)
+	}
+	return t, nil
+}
+
+// splitBeadIDs parses a comma-separated bead list, dropping blanks.
+func splitBeadIDs(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
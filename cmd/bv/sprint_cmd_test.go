@@ -0,0 +1,118 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestApplySprintEdit(t *testing.T) {
+	now := time.Date(2025, 1, 10, 15, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusClosed},
+		{ID: "B", Status: model.StatusOpen},
+		{ID: "C", Status: model.StatusInProgress},
+	}
+	base := []model.Sprint{
+		{ID: "s1", Name: "Sprint 1", StartDate: now.AddDate(0, 0, -7), EndDate: now.AddDate(0, 0, 7), BeadIDs: []string{"A", "B", "C"}},
+		{ID: "s2", Name: "Sprint 2", StartDate: now.AddDate(0, 0, 7), EndDate: now.AddDate(0, 0, 21)},
+	}
+	clone := func() []model.Sprint {
+		out := append([]model.Sprint(nil), base...)
+		for i := range out {
+			out[i].BeadIDs = append([]string(nil), base[i].BeadIDs...)
+		}
+		return out
+	}
+
+	t.Run("create with defaults", func(t *testing.T) {
+		got, summary, err := applySprintEdit(clone(), sprintEdit{Action: sprintActionCreate, SprintID: "s3", Days: 10, Beads: []string{"B"}}, issues, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := got[2]
+		if s.Name != "s3" || !s.StartDate.Equal(startOfDay(now)) || !s.EndDate.Equal(startOfDay(now).AddDate(0, 0, 10)) || !s.HasBead("B") {
+			t.Errorf("created %+v", s)
+		}
+		if !strings.HasPrefix(summary, "Created sprint s3") {
+			t.Errorf("summary = %q", summary)
+		}
+	})
+
+	t.Run("update velocity only when set", func(t *testing.T) {
+		sprints := clone()
+		sprints[0].VelocityTarget = 5
+		got, _, err := applySprintEdit(sprints, sprintEdit{Action: sprintActionUpdate, SprintID: "s1", Name: "Renamed"}, issues, now)
+		if err != nil || got[0].Name != "Renamed" || got[0].VelocityTarget != 5 {
+			t.Fatalf("update = %+v, %v", got[0], err)
+		}
+		got, _, _ = applySprintEdit(got, sprintEdit{Action: sprintActionUpdate, SprintID: "s1", VelocitySet: true}, issues, now)
+		if got[0].VelocityTarget != 0 {
+			t.Errorf("velocity = %v, want 0", got[0].VelocityTarget)
+		}
+	})
+
+	t.Run("add and remove beads", func(t *testing.T) {
+		sprints := clone()
+		got, _, err := applySprintEdit(sprints, sprintEdit{Action: sprintActionAdd, SprintID: "s2", Beads: []string{"B", "C"}}, issues, now)
+		if err != nil || len(got[1].BeadIDs) != 2 {
+			t.Fatalf("add = %v, %v", got[1].BeadIDs, err)
+		}
+		got, _, err = applySprintEdit(got, sprintEdit{Action: sprintActionRemove, SprintID: "s1", Beads: []string{"A"}}, issues, now)
+		if err != nil || got[0].HasBead("A") {
+			t.Fatalf("remove = %v, %v", got[0].BeadIDs, err)
+		}
+		if !sprints[0].HasBead("A") {
+			t.Errorf("input sprints were modified")
+		}
+	})
+
+	t.Run("close carries unfinished beads over", func(t *testing.T) {
+		got, summary, err := applySprintEdit(clone(), sprintEdit{Action: sprintActionClose, SprintID: "s1", CarryOver: "s2"}, issues, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got[0].EndDate.Equal(now) || len(got[0].BeadIDs) != 3 {
+			t.Errorf("closed sprint = %+v", got[0])
+		}
+		if strings.Join(got[1].BeadIDs, ",") != "B,C" {
+			t.Errorf("carried = %v, want B,C", got[1].BeadIDs)
+		}
+		if !strings.Contains(summary, "carried 2 unfinished bead(s) to s2") {
+			t.Errorf("summary = %q", summary)
+		}
+	})
+
+	errorCases := []struct {
+		name string
+		edit sprintEdit
+		want string
+	}{
+		{"duplicate ID", sprintEdit{Action: sprintActionCreate, SprintID: "s1"}, "already exists"},
+		{"missing sprint", sprintEdit{Action: sprintActionAdd, SprintID: "nope", Beads: []string{"B"}}, "sprint not found"},
+		{"unknown bead", sprintEdit{Action: sprintActionAdd, SprintID: "s1", Beads: []string{"Z"}}, "unknown bead(s): Z"},
+		{"end before start", sprintEdit{Action: sprintActionUpdate, SprintID: "s2", End: now}, "end_date"},
+		{"missing carry-over", sprintEdit{Action: sprintActionClose, SprintID: "s1", CarryOver: "s1"}, "carry-over sprint not found"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := applySprintEdit(clone(), tc.edit, issues, now); err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("err = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseSprintDate(t *testing.T) {
+	if got, err := parseSprintDate("2025-02-03"); err != nil || got.Day() != 3 || got.Hour() != 0 {
+		t.Errorf("parseSprintDate(YYYY-MM-DD) = %v, %v", got, err)
+	}
+	if got, err := parseSprintDate("2025-02-03T10:00:00Z"); err != nil || got.Hour() != 10 {
+		t.Errorf("parseSprintDate(RFC3339) = %v, %v", got, err)
+	}
+	if _, err := parseSprintDate("next week"); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
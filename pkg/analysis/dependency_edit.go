@@ -0,0 +1,158 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DependencyEdit is a proposed change to the edge "From depends on To".
+type DependencyEdit struct {
+	From   string               `json:"from"`
+	To     string               `json:"to"`
+	Type   model.DependencyType `json:"type"`
+	Remove bool                 `json:"remove,omitempty"`
+}
+
+// DependencyEditPreview describes what a DependencyEdit would do to the graph,
+// so the edit can be reviewed before it is persisted.
+type DependencyEditPreview struct {
+	Edit    DependencyEdit `json:"edit"`
+	Allowed bool           `json:"allowed"`
+	Reason  string         `json:"reason,omitempty"` // Why the edit is refused
+
+	// CyclePath is the cycle the edit would close, e.g. [A B C A].
+	CyclePath []string `json:"cycle_path,omitempty"`
+
+	// Longest open blocking chain in the project (issues on the critical path).
+	CriticalPathBefore int `json:"critical_path_before"`
+	CriticalPathAfter  int `json:"critical_path_after"`
+
+	// Issues that closing To would make actionable.
+	UnblocksBefore []string `json:"unblocks_before,omitempty"`
+	UnblocksAfter  []string `json:"unblocks_after,omitempty"`
+
+	// Whether From has no open blockers.
+	FromActionableBefore bool `json:"from_actionable_before"`
+	FromActionableAfter  bool `json:"from_actionable_after"`
+}
+
+// PreviewDependencyEdit validates edit against issues and computes its effect
+// on the critical path and unblocks. Additions that would close a cycle are
+// refused via CheckDependencyAddition.
+func PreviewDependencyEdit(issues []model.Issue, edit DependencyEdit) DependencyEditPreview {
+	if edit.Type == "" {
+		edit.Type = model.DepBlocks
+	}
+	preview := DependencyEditPreview{Edit: edit}
+
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	from, fromOK := byID[edit.From]
+	if !fromOK {
+		preview.Reason = fmt.Sprintf("issue %s not found", edit.From)
+		return preview
+	}
+	if _, ok := byID[edit.To]; !ok {
+		preview.Reason = fmt.Sprintf("issue %s not found", edit.To)
+		return preview
+	}
+	if edit.From == edit.To {
+		preview.Reason = "an issue cannot depend on itself"
+		return preview
+	}
+
+	exists := hasDependency(*from, edit.To, edit.Type)
+	switch {
+	case edit.Remove && !exists:
+		preview.Reason = fmt.Sprintf("%s has no %s dependency on %s", edit.From, edit.Type, edit.To)
+		return preview
+	case !edit.Remove && exists:
+		preview.Reason = fmt.Sprintf("%s already has a %s dependency on %s", edit.From, edit.Type, edit.To)
+		return preview
+	}
+
+	if !edit.Remove {
+		canAdd, cyclePath, warning := CheckDependencyAddition(issues, edit.From, edit.To)
+		if !canAdd {
+			preview.CyclePath = cyclePath
+			preview.Reason = warning
+			return preview
+		}
+	}
+
+	after := ApplyDependencyEdit(issues, edit)
+	preview.Allowed = true
+	preview.CriticalPathBefore, preview.UnblocksBefore, preview.FromActionableBefore = dependencyEditMetrics(issues, edit)
+	preview.CriticalPathAfter, preview.UnblocksAfter, preview.FromActionableAfter = dependencyEditMetrics(after, edit)
+	return preview
+}
+
+// ApplyDependencyEdit returns a copy of issues with edit applied. The input is
+// not modified.
+func ApplyDependencyEdit(issues []model.Issue, edit DependencyEdit) []model.Issue {
+	out := make([]model.Issue, len(issues))
+	copy(out, issues)
+	for i := range out {
+		if out[i].ID != edit.From {
+			continue
+		}
+		issue := out[i].Clone()
+		if edit.Remove {
+			kept := issue.Dependencies[:0]
+			for _, dep := range issue.Dependencies {
+				if dep != nil && dep.DependsOnID == edit.To && sameDependencyType(dep.Type, edit.Type) {
+					continue
+				}
+				kept = append(kept, dep)
+			}
+			issue.Dependencies = kept
+		} else {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{
+				IssueID:     edit.From,
+				DependsOnID: edit.To,
+				Type:        edit.Type,
+			})
+		}
+		out[i] = issue
+		break
+	}
+	return out
+}
+
+// dependencyEditMetrics computes the preview metrics for one graph state.
+func dependencyEditMetrics(issues []model.Issue, edit DependencyEdit) (criticalPath int, unblocks []string, fromActionable bool) {
+	analyzer := NewAnalyzer(issues)
+	stats := analyzer.AnalyzeWithConfig(AnalysisConfig{ComputeCriticalPath: true})
+	for _, issue := range issues {
+		if issue.Status.IsClosed() {
+			continue
+		}
+		if depth := int(stats.GetCriticalPathScore(issue.ID)); depth > criticalPath {
+			criticalPath = depth
+		}
+	}
+	unblocks = analyzer.ComputeUnblocks(edit.To)
+
+	fromActionable = len(analyzer.GetOpenBlockers(edit.From)) == 0
+	return criticalPath, unblocks, fromActionable
+}
+
+func hasDependency(issue model.Issue, toID string, depType model.DependencyType) bool {
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.DependsOnID == toID && sameDependencyType(dep.Type, depType) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameDependencyType treats the legacy empty type as "blocks".
+func sameDependencyType(a, b model.DependencyType) bool {
+	if a.IsBlocking() && b.IsBlocking() {
+		return true
+	}
+	return a == b
+}
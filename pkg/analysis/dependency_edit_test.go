@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func depEditIssues() []model.Issue {
+	blocks := func(from, to string) *model.Dependency {
+		return &model.Dependency{IssueID: from, DependsOnID: to, Type: model.DepBlocks}
+	}
+	// C depends on B depends on A; D is independent.
+	return []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{blocks("B", "A")}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{blocks("C", "B")}},
+		{ID: "D", Status: model.StatusOpen},
+	}
+}
+
+func TestPreviewDependencyEdit_RefusesCycle(t *testing.T) {
+	p := PreviewDependencyEdit(depEditIssues(), DependencyEdit{From: "A", To: "C", Type: model.DepBlocks})
+	if p.Allowed {
+		t.Fatal("edge closing a cycle must be refused")
+	}
+	if strings.Join(p.CyclePath, ",") != "A,C,B,A" {
+		t.Fatalf("cycle path = %v", p.CyclePath)
+	}
+	if !strings.Contains(p.Reason, "would create cycle") {
+		t.Fatalf("reason = %q", p.Reason)
+	}
+}
+
+func TestPreviewDependencyEdit_AddExtendsCriticalPath(t *testing.T) {
+	issues := depEditIssues()
+	p := PreviewDependencyEdit(issues, DependencyEdit{From: "D", To: "C", Type: model.DepBlocks})
+	if !p.Allowed {
+		t.Fatalf("expected allowed, got %q", p.Reason)
+	}
+	if p.CriticalPathBefore != 3 || p.CriticalPathAfter != 4 {
+		t.Fatalf("critical path %d → %d, want 3 → 4", p.CriticalPathBefore, p.CriticalPathAfter)
+	}
+	if len(p.UnblocksBefore) != 0 || strings.Join(p.UnblocksAfter, ",") != "D" {
+		t.Fatalf("unblocks %v → %v", p.UnblocksBefore, p.UnblocksAfter)
+	}
+	if !p.FromActionableBefore || p.FromActionableAfter {
+		t.Fatal("D should stop being actionable")
+	}
+	// Input untouched.
+	if len(issues[3].Dependencies) != 0 {
+		t.Fatal("PreviewDependencyEdit mutated its input")
+	}
+}
+
+func TestPreviewDependencyEdit_Remove(t *testing.T) {
+	p := PreviewDependencyEdit(depEditIssues(), DependencyEdit{From: "C", To: "B", Remove: true})
+	if !p.Allowed {
+		t.Fatalf("expected allowed, got %q", p.Reason)
+	}
+	if p.CriticalPathAfter != 2 || !p.FromActionableAfter {
+		t.Fatalf("unexpected preview after removal: %+v", p)
+	}
+
+	p = PreviewDependencyEdit(depEditIssues(), DependencyEdit{From: "D", To: "A", Remove: true})
+	if p.Allowed || !strings.Contains(p.Reason, "no blocks dependency") {
+		t.Fatalf("removing a missing edge should be refused: %+v", p)
+	}
+}
+
+func TestPreviewDependencyEdit_RejectsDuplicatesAndSelf(t *testing.T) {
+	if p := PreviewDependencyEdit(depEditIssues(), DependencyEdit{From: "B", To: "A"}); p.Allowed {
+		t.Error("duplicate edge should be refused")
+	}
+	if p := PreviewDependencyEdit(depEditIssues(), DependencyEdit{From: "A", To: "A"}); p.Allowed {
+		t.Error("self edge should be refused")
+	}
+	if p := PreviewDependencyEdit(depEditIssues(), DependencyEdit{From: "A", To: "missing"}); p.Allowed {
+		t.Error("unknown target should be refused")
+	}
+}
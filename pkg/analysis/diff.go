@@ -55,7 +55,7 @@ func NewSnapshotAt(issues []model.Issue, timestamp time.Time, revision string) *
 func (s *Snapshot) computeCounts() {
 	s.TotalCount = len(s.Issues)
 	for _, issue := range s.Issues {
-		switch issue.Status {
+		switch issue.Status.Base() {
 		case model.StatusClosed:
 			s.ClosedCount++
 		case model.StatusBlocked:
@@ -169,10 +169,10 @@ func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
 
 		// Check for status changes
 		isStatusChange := false
-		if fromIssue.Status != model.StatusClosed && toIssue.Status == model.StatusClosed {
+		if !fromIssue.Status.IsClosed() && toIssue.Status.IsClosed() {
 			diff.ClosedIssues = append(diff.ClosedIssues, toIssue)
 			isStatusChange = true
-		} else if fromIssue.Status == model.StatusClosed && toIssue.Status != model.StatusClosed {
+		} else if fromIssue.Status.IsClosed() && !toIssue.Status.IsClosed() {
 			diff.ReopenedIssues = append(diff.ReopenedIssues, toIssue)
 			isStatusChange = true
 		}
@@ -0,0 +1,298 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// EpicRollup summarizes an epic as a container of work: progress, what is
+// left on its critical path, what is stuck, and when it should finish.
+// Counts cover every non-epic descendant, including those of nested epics.
+type EpicRollup struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Status   string   `json:"status"`
+	Priority int      `json:"priority"`
+	ParentID string   `json:"parent_id,omitempty"` // Enclosing epic when nested
+	Depth    int      `json:"depth"`               // 0 for top-level epics
+	SubEpics []string `json:"sub_epics,omitempty"` // Direct child epics
+
+	Total             int     `json:"total"`  // Non-epic descendants
+	Closed            int     `json:"closed"` // Closed non-epic descendants
+	PercentByCount    float64 `json:"percent_by_count"`
+	TotalMinutes      int     `json:"total_minutes"`
+	ClosedMinutes     int     `json:"closed_minutes"`
+	PercentByEstimate float64 `json:"percent_by_estimate"`
+	Unestimated       int     `json:"unestimated"` // Descendants sized with the median estimate
+
+	RemainingMinutes    int      `json:"remaining_minutes"`
+	CriticalPath        []string `json:"critical_path"` // Longest open blocking chain inside the epic, first to do first
+	CriticalPathMinutes int      `json:"critical_path_minutes"`
+	Blocked             []string `json:"blocked"` // Open descendants waiting on an open blocker
+
+	ProjectedDays   float64    `json:"projected_days"`
+	ProjectedFinish *time.Time `json:"projected_finish,omitempty"` // Nil once nothing remains
+}
+
+// EpicRollupResult is the rollup of every epic plus the velocity used to
+// project finish dates.
+type EpicRollupResult struct {
+	Agents                int          `json:"agents"`
+	VelocityMinutesPerDay float64      `json:"velocity_minutes_per_day"` // Per agent, from closures in the last 30 days
+	Epics                 []EpicRollup `json:"epics"`                    // Depth-first: each epic is followed by its sub-epics
+}
+
+// ByID indexes the rollups by epic ID.
+func (r EpicRollupResult) ByID() map[string]EpicRollup {
+	byID := make(map[string]EpicRollup, len(r.Epics))
+	for _, epic := range r.Epics {
+		byID[epic.ID] = epic
+	}
+	return byID
+}
+
+// ComputeEpicRollups rolls every epic up from its parent-child descendants.
+//
+// Sizes use EstimatedMinutes, or the median estimate for unestimated issues.
+// The projected finish follows the --robot-capacity model: the critical path
+// is worked serially while the rest is split across agents, at the recent
+// per-agent velocity.
+func ComputeEpicRollups(issues []model.Issue, agents int, now time.Time) EpicRollupResult {
+	if agents <= 0 {
+		agents = 1
+	}
+	medianMinutes := computeMedianEstimatedMinutes(issues)
+	velocity, _ := velocityMinutesPerDayForLabel(issues, "", now.Add(-30*24*time.Hour), medianMinutes)
+	if velocity <= 0 {
+		// Same conservative default as EstimateETAForIssue
+		velocity = float64(medianMinutes) / 5.0
+	}
+	result := EpicRollupResult{
+		Agents:                agents,
+		VelocityMinutesPerDay: velocity,
+		Epics:                 []EpicRollup{},
+	}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	children := make(map[string][]string)
+	parentOf := make(map[string]string)
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.Type != model.DepParentChild {
+				continue
+			}
+			if _, ok := issueMap[dep.DependsOnID]; ok {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+				if _, seen := parentOf[issue.ID]; !seen {
+					parentOf[issue.ID] = dep.DependsOnID
+				}
+			}
+		}
+	}
+
+	var epics []*model.Issue
+	for i := range issues {
+		if issues[i].IssueType == model.TypeEpic && !issues[i].Status.IsTombstone() {
+			epics = append(epics, &issues[i])
+		}
+	}
+	sort.Slice(epics, func(i, j int) bool {
+		if epics[i].Priority != epics[j].Priority {
+			return epics[i].Priority < epics[j].Priority
+		}
+		return epics[i].ID < epics[j].ID
+	})
+
+	// The nearest enclosing epic, skipping non-epic containers in between
+	enclosingEpic := func(id string) string {
+		seen := map[string]bool{id: true}
+		for parent, ok := parentOf[id]; ok && !seen[parent]; parent, ok = parentOf[parent] {
+			if issueMap[parent].IssueType == model.TypeEpic {
+				return parent
+			}
+			seen[parent] = true
+		}
+		return ""
+	}
+	subEpics := make(map[string][]string)
+	var roots []string
+	for _, epic := range epics {
+		if parent := enclosingEpic(epic.ID); parent != "" {
+			subEpics[parent] = append(subEpics[parent], epic.ID)
+		} else {
+			roots = append(roots, epic.ID)
+		}
+	}
+
+	sizeOf := func(issue *model.Issue) (int, bool) {
+		if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+			return *issue.EstimatedMinutes, true
+		}
+		return medianMinutes, false
+	}
+
+	listed := make(map[string]bool, len(epics))
+	var visit func(id, parentID string, depth int)
+	visit = func(id, parentID string, depth int) {
+		if listed[id] {
+			return
+		}
+		listed[id] = true
+		epic := issueMap[id]
+		rollup := EpicRollup{
+			ID:           epic.ID,
+			Title:        epic.Title,
+			Status:       string(epic.Status),
+			Priority:     epic.Priority,
+			ParentID:     parentID,
+			Depth:        depth,
+			SubEpics:     subEpics[id],
+			CriticalPath: []string{},
+			Blocked:      []string{},
+		}
+
+		open := make(map[string]bool)
+		var openIDs []string
+		for _, descID := range descendantsOf(children, id) {
+			issue := issueMap[descID]
+			if issue.IssueType == model.TypeEpic || issue.Status.IsTombstone() {
+				continue
+			}
+			minutes, explicit := sizeOf(issue)
+			rollup.Total++
+			rollup.TotalMinutes += minutes
+			if !explicit {
+				rollup.Unestimated++
+			}
+			if issue.Status.IsClosed() {
+				rollup.Closed++
+				rollup.ClosedMinutes += minutes
+				continue
+			}
+			open[descID] = true
+			openIDs = append(openIDs, descID)
+			for _, dep := range issue.Dependencies {
+				if dep == nil || !dep.Type.IsBlocking() {
+					continue
+				}
+				if blocker, ok := issueMap[dep.DependsOnID]; ok && !blocker.Status.IsClosed() && !blocker.Status.IsTombstone() {
+					rollup.Blocked = append(rollup.Blocked, descID)
+					break
+				}
+			}
+		}
+		sort.Strings(rollup.Blocked)
+
+		switch {
+		case rollup.Total > 0:
+			rollup.PercentByCount = roundTo(float64(rollup.Closed)/float64(rollup.Total)*100, 10)
+			rollup.PercentByEstimate = roundTo(float64(rollup.ClosedMinutes)/float64(rollup.TotalMinutes)*100, 10)
+		case epic.Status.IsClosed():
+			rollup.PercentByCount, rollup.PercentByEstimate = 100, 100
+		}
+
+		rollup.RemainingMinutes = rollup.TotalMinutes - rollup.ClosedMinutes
+		rollup.CriticalPath, rollup.CriticalPathMinutes = epicCriticalPath(openIDs, open, issueMap, sizeOf)
+		if rollup.RemainingMinutes > 0 {
+			parallel := float64(rollup.RemainingMinutes-rollup.CriticalPathMinutes) / float64(agents)
+			rollup.ProjectedDays = roundTo((float64(rollup.CriticalPathMinutes)+parallel)/velocity, 10)
+			finish := now.Add(time.Duration(rollup.ProjectedDays * 24 * float64(time.Hour)))
+			rollup.ProjectedFinish = &finish
+		}
+
+		result.Epics = append(result.Epics, rollup)
+		for _, sub := range subEpics[id] {
+			visit(sub, id, depth+1)
+		}
+	}
+	for _, id := range roots {
+		visit(id, "", 0)
+	}
+	// Epics caught in a parent-child cycle have no root to hang from
+	for _, epic := range epics {
+		visit(epic.ID, "", 0)
+	}
+	return result
+}
+
+// descendantsOf returns every issue below id in the parent-child tree.
+func descendantsOf(children map[string][]string, id string) []string {
+	var out []string
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range children[current] {
+			if !seen[child] {
+				seen[child] = true
+				out = append(out, child)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return out
+}
+
+// epicCriticalPath finds the most expensive chain of blocking dependencies
+// among the open issues, ordered from the first issue to work on.
+func epicCriticalPath(ids []string, open map[string]bool, issueMap map[string]*model.Issue, sizeOf func(*model.Issue) (int, bool)) ([]string, int) {
+	cost := make(map[string]int, len(ids))
+	next := make(map[string]string, len(ids)) // Blocker continuing the chain
+	visiting := make(map[string]bool)
+	var longest func(id string) int
+	longest = func(id string) int {
+		if c, ok := cost[id]; ok {
+			return c
+		}
+		if visiting[id] {
+			return 0 // Dependency cycle: cut it here
+		}
+		visiting[id] = true
+		best, bestID := 0, ""
+		for _, dep := range issueMap[id].Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || !open[dep.DependsOnID] {
+				continue
+			}
+			if c := longest(dep.DependsOnID); c > best || (c == best && c > 0 && dep.DependsOnID < bestID) {
+				best, bestID = c, dep.DependsOnID
+			}
+		}
+		visiting[id] = false
+		minutes, _ := sizeOf(issueMap[id])
+		cost[id] = minutes + best
+		if bestID != "" {
+			next[id] = bestID
+		}
+		return cost[id]
+	}
+
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	total, end := 0, ""
+	for _, id := range sorted {
+		if c := longest(id); c > total {
+			total, end = c, id
+		}
+	}
+	if end == "" {
+		return []string{}, 0
+	}
+
+	// Walk from the last issue back through its blockers, then reverse
+	var path []string
+	seen := make(map[string]bool)
+	for id := end; id != "" && !seen[id]; id = next[id] {
+		seen[id] = true
+		path = append(path, id)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, total
+}
@@ -0,0 +1,84 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeEpicRollups(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	longAgo := now.AddDate(0, -6, 0)
+	minutes := func(m int) *int { return &m }
+	child := func(parent string) *model.Dependency {
+		return &model.Dependency{DependsOnID: parent, Type: model.DepParentChild}
+	}
+	blockedBy := func(id string) *model.Dependency {
+		return &model.Dependency{DependsOnID: id, Type: model.DepBlocks}
+	}
+
+	issues := []model.Issue{
+		{ID: "E1", Title: "Auth", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 1},
+		{ID: "E2", Title: "SSO", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 1, Dependencies: []*model.Dependency{child("E1")}},
+		{ID: "E3", Title: "Done", IssueType: model.TypeEpic, Status: model.StatusClosed, Priority: 2},
+		{ID: "T1", IssueType: model.TypeTask, Status: model.StatusClosed, EstimatedMinutes: minutes(120), ClosedAt: &longAgo,
+			Dependencies: []*model.Dependency{child("E1")}},
+		{ID: "T2", IssueType: model.TypeTask, Status: model.StatusOpen, EstimatedMinutes: minutes(60),
+			Dependencies: []*model.Dependency{child("E1"), blockedBy("T3")}},
+		{ID: "T3", IssueType: model.TypeTask, Status: model.StatusOpen, Dependencies: []*model.Dependency{child("E1")}},
+		{ID: "T4", IssueType: model.TypeTask, Status: model.StatusOpen, EstimatedMinutes: minutes(240),
+			Dependencies: []*model.Dependency{child("E2"), blockedBy("T2")}},
+	}
+
+	result := ComputeEpicRollups(issues, 1, now)
+	if result.VelocityMinutesPerDay != 24 { // No recent closures: median 120 / 5
+		t.Fatalf("velocity = %v, want 24", result.VelocityMinutesPerDay)
+	}
+	var order []string
+	for _, epic := range result.Epics {
+		order = append(order, epic.ID)
+	}
+	if !stringSlicesEqual(order, []string{"E1", "E2", "E3"}) {
+		t.Fatalf("order = %v", order)
+	}
+
+	e1 := result.ByID()["E1"]
+	if e1.Total != 4 || e1.Closed != 1 || e1.PercentByCount != 25 || e1.TotalMinutes != 540 || e1.PercentByEstimate != 22.2 || e1.Unestimated != 1 {
+		t.Errorf("E1 progress = %+v", e1)
+	}
+	if !stringSlicesEqual(e1.SubEpics, []string{"E2"}) || !stringSlicesEqual(e1.Blocked, []string{"T2", "T4"}) {
+		t.Errorf("E1 sub-epics %v, blocked %v", e1.SubEpics, e1.Blocked)
+	}
+	if !stringSlicesEqual(e1.CriticalPath, []string{"T3", "T2", "T4"}) || e1.CriticalPathMinutes != 420 {
+		t.Errorf("E1 critical path = %v (%d min)", e1.CriticalPath, e1.CriticalPathMinutes)
+	}
+	if e1.ProjectedDays != 17.5 || e1.ProjectedFinish == nil || !e1.ProjectedFinish.Equal(now.Add(420*time.Hour)) {
+		t.Errorf("E1 projection = %v days, finish %v", e1.ProjectedDays, e1.ProjectedFinish)
+	}
+
+	e2 := result.ByID()["E2"]
+	if e2.ParentID != "E1" || e2.Depth != 1 || e2.Total != 1 || !stringSlicesEqual(e2.Blocked, []string{"T4"}) || e2.ProjectedDays != 10 {
+		t.Errorf("E2 = %+v", e2)
+	}
+
+	e3 := result.ByID()["E3"]
+	if e3.PercentByCount != 100 || e3.ProjectedFinish != nil || len(e3.CriticalPath) != 0 {
+		t.Errorf("E3 = %+v", e3)
+	}
+
+	// More agents only shorten work off the critical path
+	if parallel := ComputeEpicRollups(issues, 3, now).ByID()["E1"]; parallel.ProjectedDays != 17.5 {
+		t.Errorf("E1 with 3 agents = %v days, want 17.5 (all work is on the critical path)", parallel.ProjectedDays)
+	}
+}
+
+func TestComputeEpicRollups_ParentChildCycle(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", IssueType: model.TypeEpic, Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepParentChild}}},
+		{ID: "B", IssueType: model.TypeEpic, Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepParentChild}}},
+	}
+	if got := ComputeEpicRollups(issues, 1, time.Now()); len(got.Epics) != 2 {
+		t.Fatalf("cycle epics = %+v", got.Epics)
+	}
+}
@@ -187,7 +187,7 @@ func velocityMinutesPerDayForLabel(issues []model.Issue, label string, since tim
 	samples := 0
 
 	for _, iss := range issues {
-		if iss.Status != model.StatusClosed {
+		if !iss.Status.IsClosed() {
 			continue
 		}
 
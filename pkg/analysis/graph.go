@@ -1415,7 +1415,7 @@ func (a *Analyzer) GetActionableIssues() []model.Issue {
 
 	for _, id := range ids {
 		issue := a.issueMap[id]
-		if issue.Status == model.StatusClosed {
+		if issue.Status.IsClosed() {
 			continue
 		}
 
@@ -1430,7 +1430,7 @@ func (a *Analyzer) GetActionableIssues() []model.Issue {
 				continue
 			}
 
-			if blocker.Status != model.StatusClosed {
+			if !blocker.Status.IsClosed() {
 				isBlocked = true
 				break
 			}
@@ -1481,7 +1481,7 @@ func (a *Analyzer) GetOpenBlockers(issueID string) []string {
 	for _, dep := range issue.Dependencies {
 		if dep != nil && dep.Type.IsBlocking() {
 			if blocker, exists := a.issueMap[dep.DependsOnID]; exists {
-				if blocker.Status != model.StatusClosed {
+				if !blocker.Status.IsClosed() {
 					openBlockers = append(openBlockers, dep.DependsOnID)
 				}
 			}
@@ -1496,10 +1496,12 @@ type BlockerChainEntry struct {
 	Title       string `json:"title"`
 	Status      string `json:"status"`
 	Priority    int    `json:"priority"`
-	Depth       int    `json:"depth"`        // 0 = target, 1 = direct blocker, 2 = blocker's blocker, etc.
-	IsRoot      bool   `json:"is_root"`      // True if this is the root blocker (has no open blockers)
-	Actionable  bool   `json:"actionable"`   // True if this can be worked on (no open blockers)
-	BlocksCount int    `json:"blocks_count"` // Number of issues this blocks
+	Depth       int    `json:"depth"`                // 0 = target, 1 = direct blocker, 2 = blocker's blocker, etc.
+	IsRoot      bool   `json:"is_root"`              // True if this is the root blocker (has no open blockers)
+	Actionable  bool   `json:"actionable"`           // True if this can be worked on (no open blockers)
+	BlocksCount int    `json:"blocks_count"`         // Number of issues this blocks
+	Repo        string `json:"repo,omitempty"`       // Owning repo (workspace mode)
+	CrossRepo   bool   `json:"cross_repo,omitempty"` // True if owned by a different repo than the target
 }
 
 // BlockerChainResult contains the full blocker chain analysis.
@@ -1542,6 +1544,7 @@ func (a *Analyzer) GetBlockerChain(issueID string) *BlockerChainResult {
 		IsRoot:      false,
 		Actionable:  len(a.GetOpenBlockers(issueID)) == 0,
 		BlocksCount: a.countBlockedBy(issueID),
+		Repo:        IssueRepo(&issue),
 	}
 	result.Chain = append(result.Chain, targetEntry)
 
@@ -1599,7 +1602,9 @@ func (a *Analyzer) GetBlockerChain(issueID string) *BlockerChainResult {
 			IsRoot:      isRoot,
 			Actionable:  isRoot,
 			BlocksCount: a.countBlockedBy(item.id),
+			Repo:        IssueRepo(&blocker),
 		}
+		entry.CrossRepo = entry.Repo != "" && targetEntry.Repo != "" && entry.Repo != targetEntry.Repo
 		result.Chain = append(result.Chain, entry)
 
 		if isRoot {
@@ -1625,11 +1630,21 @@ func (a *Analyzer) GetBlockerChain(issueID string) *BlockerChainResult {
 	return result
 }
 
+// IssueRepo returns the repo that owns an issue, or "" when unknown. The
+// workspace loader sets SourceRepo to the repo name; bd uses "." for the
+// current repo, which is treated as unknown.
+func IssueRepo(issue *model.Issue) string {
+	if issue == nil || issue.SourceRepo == "." {
+		return ""
+	}
+	return issue.SourceRepo
+}
+
 // countBlockedBy returns the number of open issues that are blocked by the given issue.
 func (a *Analyzer) countBlockedBy(issueID string) int {
 	count := 0
 	for _, issue := range a.issueMap {
-		if issue.Status == model.StatusClosed {
+		if issue.Status.IsClosed() {
 			continue
 		}
 		for _, dep := range issue.Dependencies {
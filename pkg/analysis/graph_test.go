@@ -633,6 +633,22 @@ func TestGetBlockerChain(t *testing.T) {
 		}
 	})
 
+	t.Run("cross-repo blocker", func(t *testing.T) {
+		issues := []model.Issue{
+			{ID: "web-1", Status: model.StatusOpen, SourceRepo: "web", Dependencies: []*model.Dependency{
+				{DependsOnID: "api-1", Type: model.DepBlocks},
+			}},
+			{ID: "api-1", Status: model.StatusOpen, SourceRepo: "api"},
+		}
+		result := analysis.NewAnalyzer(issues).GetBlockerChain("web-1")
+		if result.Chain[0].Repo != "web" || result.Chain[0].CrossRepo {
+			t.Errorf("target entry = %+v", result.Chain[0])
+		}
+		if root := result.RootBlockers[0]; root.Repo != "api" || !root.CrossRepo {
+			t.Errorf("root blocker = %+v, want cross-repo blocker owned by api", root)
+		}
+	})
+
 	t.Run("closed blocker not in chain", func(t *testing.T) {
 		// A blocked by B, but B is closed - A should not be blocked
 		issues := []model.Issue{
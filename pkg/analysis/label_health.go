@@ -238,7 +238,7 @@ func ComputeCrossLabelFlow(issues []model.Issue, cfg LabelHealthConfig) CrossLab
 	totalDeps := 0
 
 	for _, blocked := range issues {
-		if !cfg.IncludeClosedInFlow && blocked.Status == model.StatusClosed {
+		if !cfg.IncludeClosedInFlow && blocked.Status.IsClosed() {
 			continue
 		}
 		for _, dep := range blocked.Dependencies {
@@ -249,7 +249,7 @@ func ComputeCrossLabelFlow(issues []model.Issue, cfg LabelHealthConfig) CrossLab
 			if !ok {
 				continue
 			}
-			if !cfg.IncludeClosedInFlow && blocker.Status == model.StatusClosed {
+			if !cfg.IncludeClosedInFlow && blocker.Status.IsClosed() {
 				continue
 			}
 			// Cross-product of labels
@@ -426,7 +426,7 @@ func ComputeFreshnessMetrics(issues []model.Issue, now time.Time, staleDays int)
 		if iss.UpdatedAt.After(mostRecent) {
 			mostRecent = iss.UpdatedAt
 		}
-		if iss.Status != model.StatusClosed {
+		if !iss.Status.IsClosed() {
 			if oldestOpen.IsZero() || iss.CreatedAt.Before(oldestOpen) {
 				oldestOpen = iss.CreatedAt
 			}
@@ -485,7 +485,7 @@ func ComputeLabelHealthForLabel(label string, issues []model.Issue, cfg LabelHea
 
 	// Status counts
 	for _, iss := range labeled {
-		switch iss.Status {
+		switch iss.Status.Base() {
 		case model.StatusClosed:
 			health.ClosedCount++
 		case model.StatusInProgress:
@@ -850,7 +850,7 @@ func ExtractLabels(issues []model.Issue) LabelExtractionResult {
 			stats.IssueIDs = append(stats.IssueIDs, issue.ID)
 
 			// Count by status
-			switch issue.Status {
+			switch issue.Status.Base() {
 			case model.StatusOpen:
 				stats.OpenCount++
 			case model.StatusClosed:
@@ -1000,7 +1000,7 @@ func ComputeBlockedByLabel(issues []model.Issue, analyzer *Analyzer) map[string]
 	blocked := make(map[string]int)
 
 	for _, issue := range issues {
-		if issue.Status == model.StatusClosed {
+		if issue.Status.IsClosed() {
 			continue
 		}
 
@@ -1208,7 +1208,7 @@ func computeSingleCascade(sourceLabel string, blockedIssues []model.Issue, flow
 				continue
 			}
 			blocker, exists := issueMap[dep.DependsOnID]
-			if !exists || blocker.Status == model.StatusClosed {
+			if !exists || blocker.Status.IsClosed() {
 				continue
 			}
 			// Count how many issues this blocker transitively affects
@@ -1996,7 +1996,7 @@ func computeLabelAttention(label string, issues []model.Issue, issueMap map[stri
 
 	// Count open and blocked issues
 	for _, iss := range labeledIssues {
-		if iss.Status != model.StatusClosed {
+		if !iss.Status.IsClosed() {
 			score.OpenCount++
 		}
 	}
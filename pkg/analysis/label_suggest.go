@@ -125,7 +125,7 @@ func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggest
 
 	for _, issue := range issues {
 		// Skip closed issues
-		if issue.Status == model.StatusClosed {
+		if issue.Status.IsClosed() {
 			continue
 		}
 
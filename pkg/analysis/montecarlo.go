@@ -0,0 +1,391 @@
+package analysis
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultMonteCarloTrials is the number of simulated futures per forecast.
+const DefaultMonteCarloTrials = 1000
+
+// minPoolSamples is the fewest historical cycle times a label/type pool needs
+// before it is preferred over a broader pool.
+const minPoolSamples = 3
+
+// MonteCarloOptions configures SimulateCompletion.
+type MonteCarloOptions struct {
+	Trials int   // Simulated futures (default DefaultMonteCarloTrials)
+	Agents int   // Issues worked in parallel (default 1)
+	Seed   int64 // Same seed and data give the same forecast
+
+	// CycleTimes are observed cycle times from git history (the correlation
+	// report's claim-to-close), keyed by issue ID. For those issues they are
+	// used instead of ClosedAt - CreatedAt.
+	CycleTimes map[string]time.Duration
+}
+
+// MonteCarloForecast is a probabilistic completion forecast for a set of
+// issues: the dates by which all of them are done in 50%, 85% and 95% of
+// the simulated futures.
+type MonteCarloForecast struct {
+	Target         string         `json:"target"`
+	Kind           string         `json:"kind"`            // issue, epic, label, sprint or all
+	OpenIssues     int            `json:"open_issues"`     // Simulated issues: open targets plus their open blockers
+	Trials         int            `json:"trials"`          // Simulated futures
+	Agents         int            `json:"agents"`          // Issues worked in parallel
+	Seed           int64          `json:"seed"`            // Re-run with this seed to reproduce
+	P50            time.Time      `json:"p50"`             // Done by this date in half of the trials
+	P85            time.Time      `json:"p85"`             // Done by this date in 85% of the trials
+	P95            time.Time      `json:"p95"`             // Done by this date in 95% of the trials
+	P50Days        float64        `json:"p50_days"`        // Days from now to P50
+	P85Days        float64        `json:"p85_days"`        // Days from now to P85
+	P95Days        float64        `json:"p95_days"`        // Days from now to P95
+	HistorySamples int            `json:"history_samples"` // Closed issues with a usable cycle time
+	Basis          map[string]int `json:"basis"`           // Simulated issues per sample pool (label_type, label, type, global, estimate)
+}
+
+// SimulateCompletion forecasts when every issue in targetIDs is closed.
+//
+// Each trial draws a duration for every open target and every open issue
+// blocking one (transitively) from historical cycle times of closed issues
+// sharing its label and type, falling back to label only, type only, all
+// closed issues and finally the issue's estimate. In-progress issues are
+// assumed half done. The dependency DAG is then scheduled across Agents
+// workers, highest priority first, and the finish time of the last target is
+// recorded. Blocker cycles are broken by starting the highest priority issue
+// in the cycle.
+func SimulateCompletion(issues []model.Issue, targetIDs []string, opts MonteCarloOptions, now time.Time) MonteCarloForecast {
+	if opts.Trials <= 0 {
+		opts.Trials = DefaultMonteCarloTrials
+	}
+	if opts.Agents <= 0 {
+		opts.Agents = 1
+	}
+
+	result := MonteCarloForecast{
+		Trials: opts.Trials,
+		Agents: opts.Agents,
+		Seed:   opts.Seed,
+		Basis:  make(map[string]int),
+		P50:    now,
+		P85:    now,
+		P95:    now,
+	}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	scope := simulationScope(issueMap, targetIDs)
+	result.OpenIssues = len(scope)
+	if len(scope) == 0 {
+		return result
+	}
+
+	pools := buildCycleTimePools(issues, opts.CycleTimes)
+	result.HistorySamples = len(pools.global)
+
+	// Per-issue sampling setup, in scheduling order (priority, then ID)
+	sort.Slice(scope, func(i, j int) bool {
+		if scope[i].Priority != scope[j].Priority {
+			return scope[i].Priority < scope[j].Priority
+		}
+		return scope[i].ID < scope[j].ID
+	})
+	index := make(map[string]int, len(scope))
+	for i, issue := range scope {
+		index[issue.ID] = i
+	}
+
+	medianMinutes := computeMedianEstimatedMinutes(issues)
+	samplers := make([]durationSampler, len(scope))
+	blockers := make([][]int, len(scope))
+	dependents := make([][]int, len(scope))
+	isTarget := make([]bool, len(scope))
+	for _, id := range targetIDs {
+		if i, ok := index[id]; ok {
+			isTarget[i] = true
+		}
+	}
+	for i, issue := range scope {
+		samplers[i] = pools.samplerFor(issue, medianMinutes)
+		result.Basis[samplers[i].basis]++
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if j, ok := index[dep.DependsOnID]; ok && j != i {
+				blockers[i] = append(blockers[i], j)
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	durations := make([]float64, len(scope))
+	finishes := make([]float64, opts.Trials)
+	for trial := range finishes {
+		for i := range scope {
+			durations[i] = samplers[i].sample(rng)
+		}
+		finishes[trial] = simulateSchedule(durations, blockers, dependents, isTarget, opts.Agents)
+	}
+	sort.Float64s(finishes)
+
+	result.P50Days = roundDays(percentile(finishes, 0.50))
+	result.P85Days = roundDays(percentile(finishes, 0.85))
+	result.P95Days = roundDays(percentile(finishes, 0.95))
+	result.P50 = now.Add(durationDays(result.P50Days))
+	result.P85 = now.Add(durationDays(result.P85Days))
+	result.P95 = now.Add(durationDays(result.P95Days))
+	return result
+}
+
+// EpicDescendants returns the IDs of all issues below epicID in the
+// parent-child hierarchy, in breadth-first order.
+func EpicDescendants(issues []model.Issue, epicID string) []string {
+	children := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+
+	var out []string
+	seen := map[string]bool{epicID: true}
+	queue := []string{epicID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if !seen[child] {
+				seen[child] = true
+				out = append(out, child)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return out
+}
+
+// simulationScope returns the open targets plus every open issue that
+// (transitively) blocks one of them.
+func simulationScope(issueMap map[string]*model.Issue, targetIDs []string) []*model.Issue {
+	var scope []*model.Issue
+	seen := make(map[string]bool)
+	queue := append([]string(nil), targetIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		issue, ok := issueMap[id]
+		if seen[id] || !ok || issue.Status.IsClosed() {
+			continue
+		}
+		seen[id] = true
+		scope = append(scope, issue)
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				queue = append(queue, dep.DependsOnID)
+			}
+		}
+	}
+	return scope
+}
+
+// cycleTimePools groups historical cycle times (in days) of closed issues
+type cycleTimePools struct {
+	byLabelType map[string][]float64
+	byLabel     map[string][]float64
+	byType      map[string][]float64
+	global      []float64
+}
+
+func buildCycleTimePools(issues []model.Issue, observed map[string]time.Duration) cycleTimePools {
+	pools := cycleTimePools{
+		byLabelType: make(map[string][]float64),
+		byLabel:     make(map[string][]float64),
+		byType:      make(map[string][]float64),
+	}
+	for _, issue := range issues {
+		if !issue.Status.IsClosed() {
+			continue
+		}
+		var d time.Duration
+		if ct, ok := observed[issue.ID]; ok && ct > 0 {
+			d = ct
+		} else if issue.ClosedAt != nil && !issue.CreatedAt.IsZero() {
+			d = issue.ClosedAt.Sub(issue.CreatedAt)
+		}
+		if d <= 0 {
+			continue
+		}
+		days := d.Hours() / 24
+		itype := string(issue.IssueType)
+		pools.global = append(pools.global, days)
+		pools.byType[itype] = append(pools.byType[itype], days)
+		for _, label := range issue.Labels {
+			pools.byLabel[label] = append(pools.byLabel[label], days)
+			pools.byLabelType[label+"\x00"+itype] = append(pools.byLabelType[label+"\x00"+itype], days)
+		}
+	}
+	return pools
+}
+
+// samplerFor picks the most specific pool with enough samples for issue
+func (p cycleTimePools) samplerFor(issue *model.Issue, medianMinutes int) durationSampler {
+	scale := 1.0
+	if issue.Status.IsActive() {
+		scale = 0.5
+	}
+
+	itype := string(issue.IssueType)
+	var labelType, label []float64
+	for _, l := range issue.Labels {
+		labelType = append(labelType, p.byLabelType[l+"\x00"+itype]...)
+		label = append(label, p.byLabel[l]...)
+	}
+	switch {
+	case len(labelType) >= minPoolSamples:
+		return durationSampler{pool: labelType, scale: scale, basis: "label_type"}
+	case len(label) >= minPoolSamples:
+		return durationSampler{pool: label, scale: scale, basis: "label"}
+	case len(p.byType[itype]) >= minPoolSamples:
+		return durationSampler{pool: p.byType[itype], scale: scale, basis: "type"}
+	case len(p.global) > 0:
+		return durationSampler{pool: p.global, scale: scale, basis: "global"}
+	}
+
+	// No history: spread the estimate between half and double
+	minutes := medianMinutes
+	if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+		minutes = *issue.EstimatedMinutes
+	}
+	if minutes <= 0 {
+		minutes = DefaultEstimatedMinutes
+	}
+	return durationSampler{estimateDays: float64(minutes) / (60 * 8), scale: scale, basis: "estimate"}
+}
+
+// durationSampler draws one issue's duration in days
+type durationSampler struct {
+	pool         []float64
+	estimateDays float64
+	scale        float64
+	basis        string
+}
+
+func (s durationSampler) sample(rng *rand.Rand) float64 {
+	if len(s.pool) > 0 {
+		return s.pool[rng.Intn(len(s.pool))] * s.scale
+	}
+	return s.estimateDays * (0.5 + 1.5*rng.Float64()) * s.scale
+}
+
+// simulateSchedule list-schedules issues (indexed in priority order) on
+// agents workers and returns when the last target finishes, in days.
+func simulateSchedule(durations []float64, blockers, dependents [][]int, isTarget []bool, agents int) float64 {
+	n := len(durations)
+	waiting := make([]int, n)
+	started := make([]bool, n)
+	ready := &intHeap{}
+	for i := range durations {
+		waiting[i] = len(blockers[i])
+		if waiting[i] == 0 {
+			heap.Push(ready, i)
+		}
+	}
+
+	running := &finishHeap{}
+	now, last := 0.0, 0.0
+	done := 0
+	next := 0 // Lowest index that might not have started, for breaking cycles
+	for done < n {
+		for running.Len() < agents && ready.Len() > 0 {
+			i := heap.Pop(ready).(int)
+			started[i] = true
+			heap.Push(running, finishEvent{at: now + durations[i], issue: i})
+		}
+		if running.Len() == 0 {
+			// Everything left waits on a cycle; start the highest priority issue
+			for started[next] {
+				next++
+			}
+			heap.Push(ready, next)
+			continue
+		}
+
+		ev := heap.Pop(running).(finishEvent)
+		now = ev.at
+		done++
+		if isTarget[ev.issue] {
+			last = now
+		}
+		for _, d := range dependents[ev.issue] {
+			waiting[d]--
+			if waiting[d] == 0 && !started[d] {
+				heap.Push(ready, d)
+			}
+		}
+	}
+	return last
+}
+
+// percentile returns the q-quantile of sorted values (nearest rank)
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+func roundDays(days float64) float64 {
+	return math.Round(days*10) / 10
+}
+
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+type finishEvent struct {
+	at    float64
+	issue int
+}
+
+type finishHeap []finishEvent
+
+func (h finishHeap) Len() int { return len(h) }
+func (h finishHeap) Less(i, j int) bool {
+	if h[i].at != h[j].at {
+		return h[i].at < h[j].at
+	}
+	return h[i].issue < h[j].issue
+}
+func (h finishHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *finishHeap) Push(x any)   { *h = append(*h, x.(finishEvent)) }
+func (h *finishHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
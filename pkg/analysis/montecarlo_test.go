@@ -0,0 +1,126 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// closedWithCycle returns closed issues that each took days to close
+func closedWithCycle(n int, days float64, labels []string, now time.Time) []model.Issue {
+	var out []model.Issue
+	for i := 0; i < n; i++ {
+		created := now.Add(-90 * 24 * time.Hour)
+		closed := created.Add(time.Duration(days * float64(24*time.Hour)))
+		out = append(out, model.Issue{
+			ID:        "done-" + string(rune('a'+i)) + labels[0],
+			Status:    model.StatusClosed,
+			IssueType: model.TypeTask,
+			Labels:    labels,
+			CreatedAt: created,
+			ClosedAt:  &closed,
+		})
+	}
+	return out
+}
+
+func TestSimulateCompletion_Schedule(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	history := closedWithCycle(5, 2, []string{"api"}, now)
+
+	open := func(id string, blockers ...string) model.Issue {
+		issue := model.Issue{ID: id, Status: model.StatusOpen, IssueType: model.TypeTask, Labels: []string{"api"}}
+		for _, b := range blockers {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks})
+		}
+		return issue
+	}
+
+	tests := []struct {
+		name    string
+		open    []model.Issue
+		targets []string
+		agents  int
+		want    float64
+	}{
+		{"chain runs serially", []model.Issue{open("A", "B"), open("B", "C"), open("C")}, []string{"A"}, 3, 6},
+		{"independent issues in parallel", []model.Issue{open("A"), open("B")}, []string{"A", "B"}, 2, 2},
+		{"one agent", []model.Issue{open("A"), open("B")}, []string{"A", "B"}, 1, 4},
+		{"cycle is broken", []model.Issue{open("A", "B"), open("B", "A")}, []string{"A", "B"}, 1, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := append(append([]model.Issue{}, history...), tt.open...)
+			f := SimulateCompletion(issues, tt.targets, MonteCarloOptions{Trials: 50, Agents: tt.agents, Seed: 1}, now)
+			if f.P50Days != tt.want || f.P95Days != tt.want {
+				t.Errorf("P50/P95 = %.1f/%.1f days, want %.1f", f.P50Days, f.P95Days, tt.want)
+			}
+			if f.Basis["label_type"] != len(tt.open) {
+				t.Errorf("basis = %v, want all from label_type", f.Basis)
+			}
+			if !f.P50.Equal(now.Add(durationDays(tt.want))) {
+				t.Errorf("P50 date = %v", f.P50)
+			}
+		})
+	}
+}
+
+func TestSimulateCompletion_Reproducible(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	issues := closedWithCycle(4, 1, []string{"x"}, now)
+	issues = append(issues, closedWithCycle(4, 9, []string{"y"}, now)...)
+	issues = append(issues,
+		model.Issue{ID: "A", Status: model.StatusOpen, IssueType: model.TypeBug},
+		model.Issue{ID: "B", Status: model.StatusInProgress, IssueType: model.TypeBug, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+	)
+
+	opts := MonteCarloOptions{Trials: 500, Agents: 1, Seed: 42}
+	first := SimulateCompletion(issues, []string{"B"}, opts, now)
+	second := SimulateCompletion(issues, []string{"B"}, opts, now)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("same seed gave different forecasts:\n%+v\n%+v", first, second)
+	}
+	if !(first.P50Days <= first.P85Days && first.P85Days <= first.P95Days) {
+		t.Errorf("percentiles out of order: %+v", first)
+	}
+	if first.OpenIssues != 2 || first.HistorySamples != 8 || first.Basis["global"] != 2 {
+		t.Errorf("scope/basis = %d issues, %d samples, %v", first.OpenIssues, first.HistorySamples, first.Basis)
+	}
+}
+
+func TestSimulateCompletion_NoHistoryAndObservedCycleTimes(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	est := 8 * 60
+	issues := []model.Issue{{ID: "A", Status: model.StatusOpen, EstimatedMinutes: &est}}
+
+	f := SimulateCompletion(issues, []string{"A"}, MonteCarloOptions{Seed: 7}, now)
+	if f.Basis["estimate"] != 1 || f.P50Days < 0.5 || f.P95Days > 2 {
+		t.Errorf("estimate fallback = %+v", f)
+	}
+
+	// Observed git cycle times replace CreatedAt/ClosedAt
+	closed := now
+	issues = append(issues, model.Issue{ID: "D", Status: model.StatusClosed, CreatedAt: now.Add(-30 * 24 * time.Hour), ClosedAt: &closed})
+	f = SimulateCompletion(issues, []string{"A"}, MonteCarloOptions{Seed: 7, CycleTimes: map[string]time.Duration{"D": 72 * time.Hour}}, now)
+	if f.P50Days != 3 {
+		t.Errorf("P50 = %.1f days, want 3 from the observed cycle time", f.P50Days)
+	}
+
+	if f := SimulateCompletion(issues, []string{"D"}, MonteCarloOptions{}, now); f.OpenIssues != 0 || !f.P95.Equal(now) {
+		t.Errorf("closed target should be done now, got %+v", f)
+	}
+}
+
+func TestEpicDescendants(t *testing.T) {
+	child := func(id, parent string) model.Issue {
+		return model.Issue{ID: id, Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}}
+	}
+	issues := []model.Issue{{ID: "E"}, child("F", "E"), child("T1", "F"), child("T2", "E"), {ID: "X"}}
+	if got := EpicDescendants(issues, "E"); !reflect.DeepEqual(got, []string{"F", "T2", "T1"}) {
+		t.Errorf("EpicDescendants = %v", got)
+	}
+}
@@ -64,7 +64,7 @@ func (a *Analyzer) GetExecutionPlan() ExecutionPlan {
 	// Calculate totals
 	totalOpen := 0
 	for _, issue := range a.issueMap {
-		if issue.Status != model.StatusClosed {
+		if !issue.Status.IsClosed() {
 			totalOpen++
 		}
 	}
@@ -102,7 +102,7 @@ func (a *Analyzer) computeUnblocks(issueID string) []string {
 		dependentIssue := a.issueMap[dependentID]
 
 		// Skip closed issues (they don't need unblocking)
-		if dependentIssue.Status == model.StatusClosed {
+		if dependentIssue.Status.IsClosed() {
 			continue
 		}
 
@@ -122,7 +122,7 @@ func (a *Analyzer) computeUnblocks(issueID string) []string {
 
 			// Check status of other blocker
 			if otherBlocker, exists := a.issueMap[otherBlockerID]; exists {
-				if otherBlocker.Status != model.StatusClosed {
+				if !otherBlocker.Status.IsClosed() {
 					stillBlocked = true
 					break
 				}
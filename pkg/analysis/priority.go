@@ -123,7 +123,7 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 
 	for id, issue := range a.issueMap {
 		// Skip closed issues
-		if issue.Status == model.StatusClosed {
+		if issue.Status.IsClosed() {
 			continue
 		}
 
@@ -132,7 +132,8 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 		bwNorm := normalize(betweenness[id], maxBW)
 		blockerNorm := normalizeInt(blockerCounts[id], maxBlockers)
 		stalenessNorm := computeStaleness(issue.UpdatedAt, now)
-		priorityNorm := computePriorityBoost(issue.Priority)
+		// The type's priority weight scales the explicit priority (e.g. incidents above spikes)
+		priorityNorm := math.Min(computePriorityBoost(issue.Priority)*issue.IssueType.PriorityWeight(), 1.0)
 
 		// Compute time-to-impact signal
 		timeToImpactNorm, timeToImpactExplanation := computeTimeToImpact(
@@ -853,7 +854,7 @@ func (a *Analyzer) countTransitiveUnblocks(issueID string) int {
 			if simulatedClosed[depID] {
 				continue
 			}
-			if issue, exists := a.issueMap[depID]; exists && issue.Status == model.StatusClosed {
+			if issue, exists := a.issueMap[depID]; exists && issue.Status.IsClosed() {
 				continue
 			}
 
@@ -869,7 +870,7 @@ func (a *Analyzer) countTransitiveUnblocks(issueID string) int {
 				isClosed := false
 				if simulatedClosed[blockerID] {
 					isClosed = true
-				} else if bIssue, ok := a.issueMap[blockerID]; ok && bIssue.Status == model.StatusClosed {
+				} else if bIssue, ok := a.issueMap[blockerID]; ok && bIssue.Status.IsClosed() {
 					isClosed = true
 				}
 
@@ -71,6 +71,36 @@ func TestComputeImpactScoresPriorityBoost(t *testing.T) {
 	}
 }
 
+func TestComputeImpactScoresTypePriorityWeight(t *testing.T) {
+	registry := &model.TypeRegistry{Types: []model.TypeDefinition{
+		{Name: "incident", PriorityWeight: 1.5},
+		{Name: "spike", PriorityWeight: 0.5},
+	}}
+	if err := registry.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	model.SetTypeRegistry(registry)
+	t.Cleanup(func() { model.SetTypeRegistry(nil) })
+
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "I", Title: "Incident", Status: model.StatusOpen, Priority: 2, IssueType: "incident", UpdatedAt: now},
+		{ID: "S", Title: "Spike", Status: model.StatusOpen, Priority: 2, IssueType: "spike", UpdatedAt: now},
+		{ID: "T", Title: "Task", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, UpdatedAt: now},
+		{ID: "X", Title: "P0 Incident", Status: model.StatusOpen, Priority: 0, IssueType: "incident", UpdatedAt: now},
+	}
+	scoreMap := make(map[string]analysis.ImpactScore)
+	for _, s := range analysis.NewAnalyzer(issues).ComputeImpactScoresAt(now) {
+		scoreMap[s.IssueID] = s
+	}
+
+	for id, want := range map[string]float64{"I": 0.75, "S": 0.25, "T": 0.5, "X": 1.0} {
+		if got := scoreMap[id].Breakdown.PriorityBoostNorm; got != want {
+			t.Errorf("%s should have boost %.2f, got %f", id, want, got)
+		}
+	}
+}
+
 func TestComputeImpactScoresStaleness(t *testing.T) {
 	now := time.Now()
 
@@ -216,7 +216,7 @@ func computeCrossRepoRisk(issue *model.Issue, issues map[string]model.Issue) flo
 func computeStatusRisk(issue *model.Issue, now time.Time) float64 {
 	var risk float64
 
-	switch issue.Status {
+	switch issue.Status.Base() {
 	case model.StatusBlocked:
 		// Blocked items have inherent risk
 		risk = 0.7
@@ -342,7 +342,7 @@ func ComputeAllRiskSignals(
 	weights := DefaultRiskWeights()
 
 	for id, issue := range issues {
-		if issue.Status == model.StatusClosed {
+		if issue.Status.IsClosed() {
 			continue // Skip closed issues
 		}
 		result[id] = ComputeRiskSignalsWithWeights(&issue, stats, issues, now, weights)
@@ -0,0 +1,222 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultSprintDays is the sprint length used when none is given.
+const DefaultSprintDays = 14
+
+// SprintSuggestOptions configures SuggestSprint.
+type SprintSuggestOptions struct {
+	Agents         int      // Issues worked in parallel (default 1)
+	Days           int      // Sprint length in days (default DefaultSprintDays)
+	VelocityTarget float64  // Maximum beads to plan; 0 plans by capacity only
+	Exclude        []string // Beads already planned elsewhere, e.g. in other open sprints
+	Committed      []string // Beads already in this sprint; open ones use up capacity first
+}
+
+// SprintSuggestionItem is one bead proposed for the sprint.
+type SprintSuggestionItem struct {
+	ID               string `json:"id"`
+	Title            string `json:"title"`
+	Priority         int    `json:"priority"`
+	TrackID          string `json:"track_id"`
+	EstimatedMinutes int    `json:"estimated_minutes"`
+	Reason           string `json:"reason"` // "actionable" or "unblocked by <id>"
+}
+
+// SprintSuggestion is a capacity-aware selection of beads for a sprint.
+type SprintSuggestion struct {
+	Agents                int                    `json:"agents"`
+	Days                  int                    `json:"days"`
+	VelocityMinutesPerDay float64                `json:"velocity_minutes_per_day"` // Per agent, from closures in the last 30 days
+	CapacityMinutes       int                    `json:"capacity_minutes"`         // Velocity × agents × days
+	PlannedMinutes        int                    `json:"planned_minutes"`          // Committed plus suggested work
+	Committed             int                    `json:"committed"`                // Open beads already in the sprint
+	Items                 []SprintSuggestionItem `json:"items"`
+	Deferred              []string               `json:"deferred,omitempty"` // Ready beads that did not fit
+}
+
+// BeadIDs returns the IDs of the suggested beads in plan order.
+func (s SprintSuggestion) BeadIDs() []string {
+	ids := make([]string, len(s.Items))
+	for i, item := range s.Items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+// SuggestSprint fills a sprint from the execution plan until the team's
+// capacity is used up. Actionable items are taken round-robin across the
+// plan's tracks so parallel agents each have a work stream; once an item is
+// planned, the issues it unblocks become candidates too. Items that would
+// overflow capacity are deferred while smaller ones may still fit. Epics are
+// skipped since their children carry the work.
+//
+// stats is optional and only refines the per-issue estimates.
+func SuggestSprint(issues []model.Issue, stats *GraphStats, opts SprintSuggestOptions, now time.Time) SprintSuggestion {
+	if opts.Agents <= 0 {
+		opts.Agents = 1
+	}
+	if opts.Days <= 0 {
+		opts.Days = DefaultSprintDays
+	}
+
+	medianMinutes := computeMedianEstimatedMinutes(issues)
+	velocity, _ := velocityMinutesPerDayForLabel(issues, "", now.Add(-30*24*time.Hour), medianMinutes)
+	if velocity <= 0 {
+		// Same conservative default as EstimateETAForIssue
+		velocity = float64(medianMinutes) / 5.0
+	}
+
+	result := SprintSuggestion{
+		Agents:                opts.Agents,
+		Days:                  opts.Days,
+		VelocityMinutesPerDay: velocity,
+		CapacityMinutes:       int(velocity * float64(opts.Agents) * float64(opts.Days)),
+		Items:                 []SprintSuggestionItem{},
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	dependents := make(map[string][]string)
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+	for _, issue := range issues {
+		if issue.Status.IsClosed() {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				dependents[dep.DependsOnID] = append(dependents[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+	for id := range dependents {
+		sort.Strings(dependents[id])
+	}
+
+	type candidate struct {
+		id, track, reason string
+	}
+	var queue []candidate
+	plan := NewAnalyzer(issues).GetExecutionPlan()
+	for i := 0; ; i++ {
+		added := false
+		for _, track := range plan.Tracks {
+			if i < len(track.Items) {
+				queue = append(queue, candidate{track.Items[i].ID, track.TrackID, "actionable"})
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	seen := make(map[string]bool, len(opts.Exclude))
+	for _, id := range opts.Exclude {
+		seen[id] = true
+	}
+	planned := make(map[string]bool)
+	ready := func(issue model.Issue) bool {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if blocker, ok := issueMap[dep.DependsOnID]; ok && !blocker.Status.IsClosed() && !planned[blocker.ID] {
+				return false
+			}
+		}
+		return true
+	}
+
+	var committedIDs []string
+	for _, id := range opts.Committed {
+		issue, ok := issueMap[id]
+		if !ok || seen[id] || issue.Status.IsClosed() || issue.IssueType == model.TypeEpic {
+			continue
+		}
+		seen[id] = true
+		planned[id] = true
+		minutes, _ := estimateComplexityMinutes(issue, stats, medianMinutes)
+		result.PlannedMinutes += minutes
+		result.Committed++
+		committedIDs = append(committedIDs, id)
+	}
+	for _, id := range committedIDs {
+		for _, depID := range dependents[id] {
+			if !seen[depID] && ready(issueMap[depID]) {
+				queue = append(queue, candidate{depID, "", fmt.Sprintf("unblocked by %s", id)})
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if seen[c.id] {
+			continue
+		}
+		seen[c.id] = true
+
+		issue := issueMap[c.id]
+		if issue.IssueType == model.TypeEpic {
+			continue
+		}
+		if opts.VelocityTarget > 0 && float64(result.Committed+len(result.Items)) >= opts.VelocityTarget {
+			result.Deferred = append(result.Deferred, c.id)
+			continue
+		}
+		minutes, _ := estimateComplexityMinutes(issue, stats, medianMinutes)
+		if result.PlannedMinutes+minutes > result.CapacityMinutes {
+			result.Deferred = append(result.Deferred, c.id)
+			continue
+		}
+
+		planned[c.id] = true
+		result.PlannedMinutes += minutes
+		result.Items = append(result.Items, SprintSuggestionItem{
+			ID:               issue.ID,
+			Title:            issue.Title,
+			Priority:         issue.Priority,
+			TrackID:          c.track,
+			EstimatedMinutes: minutes,
+			Reason:           c.reason,
+		})
+
+		for _, depID := range dependents[c.id] {
+			if !seen[depID] && ready(issueMap[depID]) {
+				queue = append(queue, candidate{depID, c.track, fmt.Sprintf("unblocked by %s", c.id)})
+			}
+		}
+	}
+
+	return result
+}
+
+// SprintSuggestOptionsFor derives suggestion options for sprint: its length,
+// velocity target and beads, excluding beads planned in other sprints that
+// have not ended.
+func SprintSuggestOptionsFor(sprint *model.Sprint, sprints []model.Sprint, agents int, now time.Time) SprintSuggestOptions {
+	opts := SprintSuggestOptions{
+		Agents:         agents,
+		VelocityTarget: sprint.VelocityTarget,
+		Committed:      sprint.BeadIDs,
+	}
+	if !sprint.StartDate.IsZero() && !sprint.EndDate.IsZero() {
+		opts.Days = int(math.Ceil(sprint.EndDate.Sub(sprint.StartDate).Hours() / 24))
+	}
+	for i := range sprints {
+		if sprints[i].ID != sprint.ID && !sprints[i].IsEnded(now) {
+			opts.Exclude = append(opts.Exclude, sprints[i].BeadIDs...)
+		}
+	}
+	return opts
+}
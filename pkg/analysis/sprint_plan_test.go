@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSuggestSprint(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	hour, big := 60, 600
+	issue := func(id string, minutes *int, blockers ...string) model.Issue {
+		iss := model.Issue{ID: id, Title: id, Status: model.StatusOpen, IssueType: model.TypeTask, EstimatedMinutes: minutes}
+		for _, b := range blockers {
+			iss.Dependencies = append(iss.Dependencies, &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks})
+		}
+		return iss
+	}
+	// No closures: velocity falls back to median/5 = 12 min/day
+	issues := []model.Issue{
+		issue("A", &hour),
+		issue("B", &hour),
+		issue("C", &hour, "A"),
+		issue("D", &hour, "A", "E"),
+		issue("E", &big),
+		{ID: "EPIC", Status: model.StatusOpen, IssueType: model.TypeEpic},
+	}
+
+	tests := []struct {
+		name     string
+		opts     SprintSuggestOptions
+		want     []string
+		deferred []string
+	}{
+		{"fills capacity and follows unblocks", SprintSuggestOptions{Days: 15}, []string{"A", "B", "C"}, []string{"E"}},
+		{"agents multiply capacity", SprintSuggestOptions{Days: 15, Agents: 5}, []string{"A", "B", "C", "D", "E"}, nil},
+		{"excluded beads stay out", SprintSuggestOptions{Days: 15, Exclude: []string{"B"}}, []string{"A", "C"}, []string{"E"}},
+		{"committed beads use capacity first", SprintSuggestOptions{Days: 15, Committed: []string{"A", "B"}}, []string{"C"}, []string{"E"}},
+		{"velocity target caps beads", SprintSuggestOptions{Days: 15, VelocityTarget: 1}, []string{"A"}, []string{"B", "C", "E"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SuggestSprint(issues, nil, tt.opts, now)
+			got := s.BeadIDs()
+			sort.Strings(got)
+			sort.Strings(s.Deferred)
+			if !stringSlicesEqual(got, tt.want) || !stringSlicesEqual(s.Deferred, tt.deferred) {
+				t.Errorf("planned %v deferred %v, want %v deferred %v", got, s.Deferred, tt.want, tt.deferred)
+			}
+			if s.PlannedMinutes > s.CapacityMinutes {
+				t.Errorf("planned %d minutes over capacity %d", s.PlannedMinutes, s.CapacityMinutes)
+			}
+		})
+	}
+
+	s := SuggestSprint(issues, nil, SprintSuggestOptions{Days: 15}, now)
+	if s.CapacityMinutes != 180 || s.VelocityMinutesPerDay != 12 {
+		t.Errorf("capacity = %d at %.1f min/day, want 180 at 12", s.CapacityMinutes, s.VelocityMinutesPerDay)
+	}
+	for _, item := range s.Items {
+		if item.ID == "C" && item.Reason != "unblocked by A" {
+			t.Errorf("C reason = %q", item.Reason)
+		}
+	}
+}
+
+func TestSprintSuggestOptionsFor(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	sprints := []model.Sprint{
+		{ID: "old", Name: "Old", EndDate: now.AddDate(0, 0, -1), BeadIDs: []string{"X"}},
+		{ID: "cur", Name: "Cur", StartDate: now, EndDate: now.AddDate(0, 0, 10), BeadIDs: []string{"A"}, VelocityTarget: 8},
+		{ID: "next", Name: "Next", BeadIDs: []string{"B"}},
+	}
+	opts := SprintSuggestOptionsFor(&sprints[1], sprints, 2, now)
+	if opts.Days != 10 || opts.Agents != 2 || opts.VelocityTarget != 8 {
+		t.Errorf("opts = %+v", opts)
+	}
+	if !stringSlicesEqual(opts.Committed, []string{"A"}) || !stringSlicesEqual(opts.Exclude, []string{"B"}) {
+		t.Errorf("committed %v exclude %v, want [A] [B]", opts.Committed, opts.Exclude)
+	}
+}
@@ -0,0 +1,383 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SprintScopeChange is a bead added to or removed from a sprint, as recorded
+// in the history of sprints.jsonl.
+type SprintScopeChange struct {
+	Date    time.Time
+	IssueID string
+	Action  string // "added" or "removed"
+}
+
+// RetroBlockedPeriod is a span a bead spent blocked, from its causal chain.
+type RetroBlockedPeriod struct {
+	BeadID    string
+	BlockerID string
+	Start     time.Time
+	End       time.Time
+}
+
+// SprintRetroInput is everything SprintRetrospective puts together. Only
+// Sprint and Issues are required; the git-derived fields refine the report.
+type SprintRetroInput struct {
+	Sprint  model.Sprint
+	Sprints []model.Sprint // All sprints, to find where carry-over went
+	Issues  []model.Issue  // Current issues
+
+	// AtStart holds the issues as of the sprint start (GitLoader.LoadAtDate).
+	// Nil when unknown; beads missing from it were created mid-sprint.
+	AtStart []model.Issue
+
+	ScopeChanges   []SprintScopeChange
+	BlockedPeriods []RetroBlockedPeriod
+	CycleTimes     map[string]time.Duration // Observed claim-to-close times by issue ID
+}
+
+// RetroBead is one bead in a retrospective section.
+type RetroBead struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Status    string     `json:"status"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	AddedAt   *time.Time `json:"added_at,omitempty"`   // Scope added mid-sprint
+	RemovedAt *time.Time `json:"removed_at,omitempty"` // Scope dropped mid-sprint
+	CarriedTo string     `json:"carried_to,omitempty"` // Later sprint the bead also appears in
+}
+
+// RetroVelocity compares completed work with the sprint's VelocityTarget.
+type RetroVelocity struct {
+	Target         float64 `json:"target"`                // Beads; 0 when the sprint has no target
+	Completed      int     `json:"completed"`             // Beads closed by the sprint end
+	PercentOfGoal  float64 `json:"percent_of_goal"`       // Completed / target × 100 (0 without a target)
+	CompletionRate float64 `json:"completion_rate"`       // Completed / all sprint beads
+	PerWeek        float64 `json:"per_week"`              // Completed beads per 7 days of sprint
+	PlannedDone    int     `json:"planned_done"`          // Completed beads that were planned at start
+	AddedDone      int     `json:"added_done"`            // Completed beads added mid-sprint
+	MetTarget      *bool   `json:"met_target,omitempty"`  // Nil without a target
+	SprintDays     float64 `json:"sprint_days,omitempty"` // Elapsed sprint length used for PerWeek
+	Note           string  `json:"note,omitempty"`        // Caveats, e.g. sprint still running
+}
+
+// CycleTimeBucket counts completed beads within a cycle-time range.
+type CycleTimeBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// CycleTimeDistribution summarizes cycle times of beads completed in the
+// sprint, in days.
+type CycleTimeDistribution struct {
+	Samples int               `json:"samples"`
+	MinDays float64           `json:"min_days"`
+	P50Days float64           `json:"p50_days"`
+	P85Days float64           `json:"p85_days"`
+	MaxDays float64           `json:"max_days"`
+	AvgDays float64           `json:"avg_days"`
+	Buckets []CycleTimeBucket `json:"buckets"`
+}
+
+// RetroBlocker is an issue that stalled sprint beads during the sprint.
+type RetroBlocker struct {
+	BlockerID    string   `json:"blocker_id"`
+	Title        string   `json:"title,omitempty"`
+	StalledBeads []string `json:"stalled_beads"`
+	BlockedHours float64  `json:"blocked_hours"` // Summed over stalled beads, clipped to the sprint
+}
+
+// SprintRetro is a sprint retrospective report.
+type SprintRetro struct {
+	SprintID  string    `json:"sprint_id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Ended     bool      `json:"ended"`
+
+	Planned   []RetroBead `json:"planned"`   // In the sprint at its start
+	Added     []RetroBead `json:"added"`     // Scope added mid-sprint
+	Removed   []RetroBead `json:"removed"`   // Dropped from the sprint mid-sprint
+	Completed []RetroBead `json:"completed"` // Closed by the sprint end
+	CarryOver []RetroBead `json:"carry_over"`
+
+	Velocity  RetroVelocity         `json:"velocity"`
+	CycleTime CycleTimeDistribution `json:"cycle_time"`
+	Blockers  []RetroBlocker        `json:"blockers"`
+
+	// ScopeSource says how mid-sprint scope was detected: "git" when a
+	// snapshot at the sprint start was available, otherwise "sprint_history"
+	// (sprints.jsonl changes only) or "none".
+	ScopeSource string `json:"scope_source"`
+}
+
+// cycleTimeBuckets are the distribution ranges, in days (upper bound exclusive).
+var cycleTimeBuckets = []struct {
+	label string
+	max   float64
+}{
+	{"< 1d", 1},
+	{"1-3d", 3},
+	{"3-7d", 7},
+	{"1-2w", 14},
+	{"> 2w", math.Inf(1)},
+}
+
+// SprintRetrospective builds the retrospective for in.Sprint as of now.
+//
+// A bead counts as planned when it was in the sprint at its start: it
+// existed in the AtStart snapshot and was not added to sprints.jsonl after
+// the start. Everything else in the sprint is added scope. Completed beads
+// were closed by the sprint end (or now, for a running sprint); the rest are
+// carry-over.
+func SprintRetrospective(in SprintRetroInput, now time.Time) SprintRetro {
+	sprint := in.Sprint
+	end := sprint.EndDate
+	if end.IsZero() || end.After(now) {
+		end = now
+	}
+
+	retro := SprintRetro{
+		SprintID:    sprint.ID,
+		Name:        sprint.Name,
+		StartDate:   sprint.StartDate,
+		EndDate:     sprint.EndDate,
+		Ended:       sprint.IsEnded(now),
+		Planned:     []RetroBead{},
+		Added:       []RetroBead{},
+		Removed:     []RetroBead{},
+		Completed:   []RetroBead{},
+		CarryOver:   []RetroBead{},
+		Blockers:    []RetroBlocker{},
+		ScopeSource: "none",
+	}
+
+	issueMap := make(map[string]model.Issue, len(in.Issues))
+	for _, issue := range in.Issues {
+		issueMap[issue.ID] = issue
+	}
+	bead := func(id string) RetroBead {
+		issue, ok := issueMap[id]
+		if !ok {
+			return RetroBead{ID: id, Status: "missing"}
+		}
+		return RetroBead{ID: id, Title: issue.Title, Status: string(issue.Status), ClosedAt: issue.ClosedAt}
+	}
+
+	// Mid-sprint scope: sprint membership changes after the start, and beads
+	// that did not exist yet when the sprint started
+	addedAt := make(map[string]time.Time)
+	removed := make(map[string]time.Time)
+	if len(in.ScopeChanges) > 0 {
+		retro.ScopeSource = "sprint_history"
+	}
+	for _, change := range in.ScopeChanges {
+		if !change.Date.After(sprint.StartDate) {
+			continue
+		}
+		switch change.Action {
+		case "added":
+			if _, ok := addedAt[change.IssueID]; !ok {
+				addedAt[change.IssueID] = change.Date
+			}
+			delete(removed, change.IssueID)
+		case "removed":
+			removed[change.IssueID] = change.Date
+		}
+	}
+	var existedAtStart map[string]bool
+	if in.AtStart != nil {
+		retro.ScopeSource = "git"
+		existedAtStart = make(map[string]bool, len(in.AtStart))
+		for _, issue := range in.AtStart {
+			existedAtStart[issue.ID] = true
+		}
+	}
+
+	// Later sprints the unfinished beads moved into
+	carriedTo := make(map[string]string)
+	for _, other := range in.Sprints {
+		if other.ID == sprint.ID || other.StartDate.Before(sprint.StartDate) {
+			continue
+		}
+		for _, id := range other.BeadIDs {
+			if _, ok := carriedTo[id]; !ok {
+				carriedTo[id] = other.ID
+			}
+		}
+	}
+
+	var cycleDays []float64
+	for _, id := range sprint.BeadIDs {
+		b := bead(id)
+		isAdded := false
+		if at, ok := addedAt[id]; ok {
+			isAdded = true
+			b.AddedAt = &at
+		} else if existedAtStart != nil && !existedAtStart[id] {
+			isAdded = true
+			if issue, ok := issueMap[id]; ok && !issue.CreatedAt.IsZero() {
+				created := issue.CreatedAt
+				b.AddedAt = &created
+			}
+		}
+		if isAdded {
+			retro.Added = append(retro.Added, b)
+		} else {
+			retro.Planned = append(retro.Planned, b)
+		}
+
+		issue, ok := issueMap[id]
+		done := ok && issue.Status.IsClosed() && (issue.ClosedAt == nil || !issue.ClosedAt.After(end))
+		if !done {
+			b.CarriedTo = carriedTo[id]
+			retro.CarryOver = append(retro.CarryOver, b)
+			continue
+		}
+		retro.Completed = append(retro.Completed, b)
+		if isAdded {
+			retro.Velocity.AddedDone++
+		} else {
+			retro.Velocity.PlannedDone++
+		}
+
+		cycle, observed := in.CycleTimes[id]
+		if !observed && issue.ClosedAt != nil && !issue.CreatedAt.IsZero() {
+			cycle = issue.ClosedAt.Sub(issue.CreatedAt)
+		}
+		if cycle > 0 {
+			cycleDays = append(cycleDays, cycle.Hours()/24)
+		}
+	}
+	for id, at := range removed {
+		if !containsString(sprint.BeadIDs, id) {
+			b := bead(id)
+			b.RemovedAt = &at
+			retro.Removed = append(retro.Removed, b)
+		}
+	}
+	sort.Slice(retro.Removed, func(i, j int) bool { return retro.Removed[i].ID < retro.Removed[j].ID })
+
+	retro.Velocity = retroVelocity(retro.Velocity, sprint, len(sprint.BeadIDs), len(retro.Completed), end, retro.Ended)
+	retro.CycleTime = cycleTimeDistribution(cycleDays)
+	retro.Blockers = retroBlockers(in.BlockedPeriods, sprint, end, issueMap)
+	return retro
+}
+
+func retroVelocity(v RetroVelocity, sprint model.Sprint, total, completed int, end time.Time, ended bool) RetroVelocity {
+	v.Target = sprint.VelocityTarget
+	v.Completed = completed
+	if total > 0 {
+		v.CompletionRate = roundTo(float64(completed)/float64(total), 100)
+	}
+	if v.Target > 0 {
+		v.PercentOfGoal = roundTo(float64(completed)/v.Target*100, 10)
+		met := float64(completed) >= v.Target
+		v.MetTarget = &met
+	}
+	if !sprint.StartDate.IsZero() && end.After(sprint.StartDate) {
+		v.SprintDays = roundTo(end.Sub(sprint.StartDate).Hours()/24, 10)
+		v.PerWeek = roundTo(float64(completed)/v.SprintDays*7, 10)
+	}
+	if !ended {
+		v.Note = "sprint still running; figures are as of now"
+	}
+	return v
+}
+
+func cycleTimeDistribution(days []float64) CycleTimeDistribution {
+	dist := CycleTimeDistribution{Samples: len(days), Buckets: make([]CycleTimeBucket, len(cycleTimeBuckets))}
+	for i, bucket := range cycleTimeBuckets {
+		dist.Buckets[i].Label = bucket.label
+	}
+	if len(days) == 0 {
+		return dist
+	}
+
+	sort.Float64s(days)
+	total := 0.0
+	for _, d := range days {
+		total += d
+		for i, bucket := range cycleTimeBuckets {
+			if d < bucket.max {
+				dist.Buckets[i].Count++
+				break
+			}
+		}
+	}
+	dist.MinDays = roundTo(days[0], 10)
+	dist.P50Days = roundTo(percentile(days, 0.50), 10)
+	dist.P85Days = roundTo(percentile(days, 0.85), 10)
+	dist.MaxDays = roundTo(days[len(days)-1], 10)
+	dist.AvgDays = roundTo(total/float64(len(days)), 10)
+	return dist
+}
+
+// retroBlockers aggregates blocked periods of sprint beads that overlap the
+// sprint, by blocker, longest total first.
+func retroBlockers(periods []RetroBlockedPeriod, sprint model.Sprint, end time.Time, issueMap map[string]model.Issue) []RetroBlocker {
+	byBlocker := make(map[string]*RetroBlocker)
+	stalled := make(map[string]map[string]bool)
+	for _, p := range periods {
+		if !containsString(sprint.BeadIDs, p.BeadID) {
+			continue
+		}
+		start, stop := p.Start, p.End
+		if start.Before(sprint.StartDate) {
+			start = sprint.StartDate
+		}
+		if stop.IsZero() || stop.After(end) {
+			stop = end
+		}
+		if !stop.After(start) {
+			continue
+		}
+
+		blockerID := p.BlockerID
+		if blockerID == "" {
+			blockerID = "(unknown)"
+		}
+		b, ok := byBlocker[blockerID]
+		if !ok {
+			b = &RetroBlocker{BlockerID: blockerID, Title: issueMap[blockerID].Title}
+			byBlocker[blockerID] = b
+			stalled[blockerID] = make(map[string]bool)
+		}
+		b.BlockedHours += stop.Sub(start).Hours()
+		if !stalled[blockerID][p.BeadID] {
+			stalled[blockerID][p.BeadID] = true
+			b.StalledBeads = append(b.StalledBeads, p.BeadID)
+		}
+	}
+
+	blockers := make([]RetroBlocker, 0, len(byBlocker))
+	for _, b := range byBlocker {
+		b.BlockedHours = roundTo(b.BlockedHours, 10)
+		sort.Strings(b.StalledBeads)
+		blockers = append(blockers, *b)
+	}
+	sort.Slice(blockers, func(i, j int) bool {
+		if blockers[i].BlockedHours != blockers[j].BlockedHours {
+			return blockers[i].BlockedHours > blockers[j].BlockedHours
+		}
+		return blockers[i].BlockerID < blockers[j].BlockerID
+	})
+	return blockers
+}
+
+func roundTo(v float64, scale float64) float64 {
+	return math.Round(v*scale) / scale
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
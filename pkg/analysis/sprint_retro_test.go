@@ -0,0 +1,112 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSprintRetrospective(t *testing.T) {
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 14)
+	now := end.AddDate(0, 0, 3)
+	at := func(days float64) *time.Time {
+		ts := start.Add(time.Duration(days * 24 * float64(time.Hour)))
+		return &ts
+	}
+	issue := func(id string, status model.Status, createdDays float64, closedDays *time.Time) model.Issue {
+		return model.Issue{ID: id, Title: "Issue " + id, Status: status, CreatedAt: *at(createdDays), ClosedAt: closedDays}
+	}
+
+	issues := []model.Issue{
+		issue("A", model.StatusClosed, -2, at(0.5)), // planned, done in 2.5d
+		issue("B", model.StatusClosed, -10, at(13)), // planned, done in 23d
+		issue("C", model.StatusOpen, -5, nil),       // planned, carried over
+		issue("D", model.StatusClosed, 3, at(5)),    // created mid-sprint, done in 2d
+		issue("E", model.StatusClosed, -1, at(16)),  // added via sprints.jsonl, closed after the end
+		issue("F", model.StatusOpen, -1, nil),       // removed mid-sprint
+		issue("X", model.StatusClosed, -20, at(10)), // blocker, not in the sprint
+	}
+	sprint := model.Sprint{ID: "s1", Name: "Sprint 1", StartDate: start, EndDate: end, VelocityTarget: 4, BeadIDs: []string{"A", "B", "C", "D", "E"}}
+	in := SprintRetroInput{
+		Sprint:  sprint,
+		Sprints: []model.Sprint{sprint, {ID: "s2", Name: "Sprint 2", StartDate: end, BeadIDs: []string{"C"}}},
+		Issues:  issues,
+		AtStart: []model.Issue{issues[0], issues[1], issues[2], issues[4], issues[5], issues[6]},
+		ScopeChanges: []SprintScopeChange{
+			{Date: start.Add(-time.Hour), IssueID: "A", Action: "added"},
+			{Date: *at(2), IssueID: "E", Action: "added"},
+			{Date: *at(4), IssueID: "F", Action: "removed"},
+		},
+		BlockedPeriods: []RetroBlockedPeriod{
+			{BeadID: "B", BlockerID: "X", Start: start.AddDate(0, 0, -1), End: *at(2)},
+			{BeadID: "C", BlockerID: "X", Start: *at(1), End: *at(2)},
+			{BeadID: "Q", BlockerID: "X", Start: *at(1), End: *at(9)}, // not in sprint
+		},
+		CycleTimes: map[string]time.Duration{"A": 6 * time.Hour},
+	}
+
+	retro := SprintRetrospective(in, now)
+
+	ids := func(beads []RetroBead) []string {
+		out := make([]string, len(beads))
+		for i, b := range beads {
+			out[i] = b.ID
+		}
+		return out
+	}
+	checks := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"planned", ids(retro.Planned), []string{"A", "B", "C"}},
+		{"added", ids(retro.Added), []string{"D", "E"}},
+		{"removed", ids(retro.Removed), []string{"F"}},
+		{"completed", ids(retro.Completed), []string{"A", "B", "D"}},
+		{"carry-over", ids(retro.CarryOver), []string{"C", "E"}},
+	}
+	for _, c := range checks {
+		if !stringSlicesEqual(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if retro.CarryOver[0].CarriedTo != "s2" || retro.Added[0].AddedAt == nil || retro.Removed[0].RemovedAt == nil || !retro.Ended || retro.ScopeSource != "git" {
+		t.Errorf("retro details = %+v", retro)
+	}
+
+	v := retro.Velocity
+	if v.Completed != 3 || v.PlannedDone != 2 || v.AddedDone != 1 || v.PercentOfGoal != 75 || v.MetTarget == nil || *v.MetTarget || v.PerWeek != 1.5 || v.CompletionRate != 0.6 {
+		t.Errorf("velocity = %+v", v)
+	}
+
+	ct := retro.CycleTime
+	if ct.Samples != 3 || ct.MinDays != 0.3 || ct.P50Days != 2 || ct.MaxDays != 23 {
+		t.Errorf("cycle time = %+v", ct)
+	}
+	if ct.Buckets[0].Count != 1 || ct.Buckets[1].Count != 1 || ct.Buckets[4].Count != 1 {
+		t.Errorf("buckets = %+v", ct.Buckets)
+	}
+
+	if len(retro.Blockers) != 1 || retro.Blockers[0].BlockerID != "X" || retro.Blockers[0].BlockedHours != 72 ||
+		!stringSlicesEqual(retro.Blockers[0].StalledBeads, []string{"B", "C"}) || retro.Blockers[0].Title != "Issue X" {
+		t.Errorf("blockers = %+v", retro.Blockers)
+	}
+}
+
+func TestSprintRetrospective_RunningSprintWithoutHistory(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	sprint := model.Sprint{ID: "s1", Name: "S", StartDate: now.AddDate(0, 0, -3), EndDate: now.AddDate(0, 0, 4), BeadIDs: []string{"A", "gone"}}
+	retro := SprintRetrospective(SprintRetroInput{Sprint: sprint, Issues: []model.Issue{{ID: "A", Status: model.StatusOpen}}}, now)
+
+	if retro.Ended || retro.Velocity.Note == "" || retro.ScopeSource != "none" || retro.Velocity.MetTarget != nil {
+		t.Errorf("running sprint retro = %+v", retro)
+	}
+	if len(retro.Planned) != 2 || len(retro.CarryOver) != 2 || retro.CarryOver[1].Status != "missing" {
+		t.Errorf("beads = planned %v carry-over %v", retro.Planned, retro.CarryOver)
+	}
+	if retro.CycleTime.Samples != 0 || len(retro.CycleTime.Buckets) != 5 {
+		t.Errorf("cycle time = %+v", retro.CycleTime)
+	}
+}
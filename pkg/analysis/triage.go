@@ -88,6 +88,8 @@ type BlockerItem struct {
 	UnblocksIDs   []string `json:"unblocks_ids"`
 	Actionable    bool     `json:"actionable"` // Can we work on this now?
 	BlockedBy     []string `json:"blocked_by,omitempty"`
+	Repo          string   `json:"repo,omitempty"`       // Owning repo (workspace mode)
+	CrossRepo     bool     `json:"cross_repo,omitempty"` // Unblocks work in other repos
 }
 
 // ProjectHealth provides overall project status
@@ -163,7 +165,7 @@ func ComputeProjectVelocity(issues []model.Issue, now time.Time, weeks int) *Vel
 	monthAgo := now.Add(-30 * 24 * time.Hour)
 
 	for _, iss := range issues {
-		if iss.Status != model.StatusClosed {
+		if !iss.Status.IsClosed() {
 			continue
 		}
 
@@ -457,7 +459,7 @@ func buildUnblocksMap(analyzer *Analyzer, issues []model.Issue) map[string][]str
 	openBlockerCount := make(map[string]int, len(issues))
 
 	for _, dependent := range issues {
-		if dependent.Status == model.StatusClosed {
+		if dependent.Status.IsClosed() {
 			continue
 		}
 		if len(dependent.Dependencies) == 0 {
@@ -483,7 +485,7 @@ func buildUnblocksMap(analyzer *Analyzer, issues []model.Issue) map[string][]str
 			seen[blockerID] = struct{}{}
 
 			dependentsByBlocker[blockerID] = append(dependentsByBlocker[blockerID], dependent.ID)
-			if blocker.Status != model.StatusClosed {
+			if !blocker.Status.IsClosed() {
 				openBlockerCount[dependent.ID]++
 			}
 		}
@@ -491,14 +493,14 @@ func buildUnblocksMap(analyzer *Analyzer, issues []model.Issue) map[string][]str
 
 	unblocksMap := make(map[string][]string, len(issues))
 	for _, blocker := range issues {
-		if blocker.Status == model.StatusClosed {
+		if blocker.Status.IsClosed() {
 			continue
 		}
 
 		var unblocks []string
 		for _, dependentID := range dependentsByBlocker[blocker.ID] {
 			depIssue, ok := issueByID[dependentID]
-			if !ok || depIssue.Status == model.StatusClosed {
+			if !ok || depIssue.Status.IsClosed() {
 				continue
 			}
 			if openBlockerCount[dependentID] == 1 {
@@ -533,7 +535,7 @@ func computeCounts(issues []model.Issue, analyzer *Analyzer) HealthCounts {
 		counts.ByType[string(issue.IssueType)]++
 		counts.ByPriority[issue.Priority]++
 
-		if issue.Status == model.StatusClosed {
+		if issue.Status.IsClosed() {
 			counts.Closed++
 		} else {
 			counts.Open++
@@ -667,6 +669,7 @@ func buildBlockersToClear(analyzer *Analyzer, unblocksMap map[string][]string, l
 	type blocker struct {
 		id       string
 		title    string
+		repo     string
 		unblocks []string
 	}
 
@@ -682,12 +685,13 @@ func buildBlockersToClear(analyzer *Analyzer, unblocksMap map[string][]string, l
 			continue
 		}
 		issue := analyzer.GetIssue(id)
-		if issue == nil || issue.Status == model.StatusClosed {
+		if issue == nil || issue.Status.IsClosed() {
 			continue
 		}
 		blockers = append(blockers, blocker{
 			id:       id,
 			title:    issue.Title,
+			repo:     IssueRepo(issue),
 			unblocks: unblocks,
 		})
 	}
@@ -706,6 +710,15 @@ func buildBlockersToClear(analyzer *Analyzer, unblocksMap map[string][]string, l
 			UnblocksCount: len(b.unblocks),
 			UnblocksIDs:   b.unblocks,
 			Actionable:    actionableSet[b.id],
+			Repo:          b.repo,
+		}
+		if b.repo != "" {
+			for _, id := range b.unblocks {
+				if repo := IssueRepo(analyzer.GetIssue(id)); repo != "" && repo != b.repo {
+					item.CrossRepo = true
+					break
+				}
+			}
 		}
 		if !item.Actionable {
 			item.BlockedBy = analyzer.GetOpenBlockers(b.id)
@@ -904,7 +917,7 @@ func computeSingleTriageScore(base ImpactScore, unblocksMap map[string][]string,
 	// Calculate quick-win boost
 	// Quick wins are items with low blocker depth but high impact
 	blockerDepth := analyzer.GetBlockerDepth(base.IssueID)
-	if issue := analyzer.GetIssue(base.IssueID); issue == nil || issue.Status != model.StatusInProgress {
+	if issue := analyzer.GetIssue(base.IssueID); issue == nil || !issue.Status.IsActive() {
 		if blockerDepth <= opts.QuickWinMaxDepth && blockerDepth >= 0 {
 			// Lower depth = higher quick win potential
 			depthFactor := 1.0 - float64(blockerDepth)/float64(opts.QuickWinMaxDepth+1)
@@ -1042,7 +1055,7 @@ func GenerateTriageReasons(ctx TriageReasonContext) TriageReasons {
 	var reasons []string
 	primary := ""
 	actionHint := "Start work on this issue"
-	if ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress {
+	if ctx.Issue != nil && ctx.Issue.Status.IsActive() {
 		actionHint = "Continue work on this issue"
 	}
 
@@ -1091,13 +1104,13 @@ func GenerateTriageReasons(ctx TriageReasonContext) TriageReasons {
 	if ctx.DaysSinceUpdate > 14 {
 		reason := fmt.Sprintf("🕐 No activity in %d days - may need review", ctx.DaysSinceUpdate)
 		reasons = append(reasons, reason)
-		if ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress {
+		if ctx.Issue != nil && ctx.Issue.Status.IsActive() {
 			actionHint = "Check if this is stuck and needs help"
 		}
 	} else if ctx.DaysSinceUpdate > 7 {
 		reason := fmt.Sprintf("📅 Last updated %d days ago", ctx.DaysSinceUpdate)
 		reasons = append(reasons, reason)
-		if ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress {
+		if ctx.Issue != nil && ctx.Issue.Status.IsActive() {
 			actionHint = "Continue work on this issue"
 		}
 	}
@@ -1111,7 +1124,7 @@ func GenerateTriageReasons(ctx TriageReasonContext) TriageReasons {
 		}
 
 		// Update action hint unless in-progress (keep work/review guidance) or critically stale
-		isInProgress := ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress
+		isInProgress := ctx.Issue != nil && ctx.Issue.Status.IsActive()
 		isCriticalStale := isInProgress && ctx.DaysSinceUpdate > 14
 		if !isInProgress && !isCriticalStale {
 			actionHint = "Quick win - start here for fast progress"
@@ -1119,7 +1132,7 @@ func GenerateTriageReasons(ctx TriageReasonContext) TriageReasons {
 	}
 
 	// 6. Agent claim status
-	isInProgress := ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress
+	isInProgress := ctx.Issue != nil && ctx.Issue.Status.IsActive()
 	if isInProgress {
 		if ctx.ClaimedByAgent != "" {
 			reason := fmt.Sprintf("👤 Claimed by %s", ctx.ClaimedByAgent)
@@ -214,6 +214,22 @@ func TestTriageHealthCounts(t *testing.T) {
 	}
 }
 
+func TestTriageBlockersShowOwningRepo(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "api-1", Status: model.StatusOpen, SourceRepo: "api"},
+		{ID: "web-1", Status: model.StatusOpen, SourceRepo: "web", Dependencies: []*model.Dependency{{DependsOnID: "api-1", Type: model.DepBlocks}}},
+	}
+
+	triage := ComputeTriage(issues)
+	if len(triage.BlockersToClear) != 1 {
+		t.Fatalf("expected 1 blocker, got %d", len(triage.BlockersToClear))
+	}
+	b := triage.BlockersToClear[0]
+	if b.ID != "api-1" || b.Repo != "api" || !b.CrossRepo {
+		t.Errorf("blocker = %+v, want api-1 owned by api, blocking another repo", b)
+	}
+}
+
 func TestTriageGraphHealth(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "a", Status: model.StatusOpen},
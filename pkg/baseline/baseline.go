@@ -41,6 +41,21 @@ type Baseline struct {
 
 	// Cycles stores detected cycles
 	Cycles [][]string `json:"cycles,omitempty"`
+
+	// Velocity stores close rates for velocity drift (absent in older baselines)
+	Velocity *VelocityStats `json:"velocity,omitempty"`
+}
+
+// VelocityStats records how fast issues were being closed
+type VelocityStats struct {
+	// WindowWeeks is the number of full weeks averaged
+	WindowWeeks int `json:"window_weeks"`
+
+	// WeeklyCloseRate is the average number of issues closed per week
+	WeeklyCloseRate float64 `json:"weekly_close_rate"`
+
+	// LabelCloseRates is the weekly close rate per label (labels with closures only)
+	LabelCloseRates map[string]float64 `json:"label_close_rates,omitempty"`
 }
 
 // GraphStats contains basic graph statistics
@@ -200,6 +215,11 @@ func (b *Baseline) Summary() string {
 	sb.WriteString(fmt.Sprintf("Actionable: %d | Cycles: %d\n",
 		b.Stats.ActionableCount, b.Stats.CycleCount))
 
+	if b.Velocity != nil {
+		sb.WriteString(fmt.Sprintf("Velocity: %.1f closed/week (last %d weeks)\n",
+			b.Velocity.WeeklyCloseRate, b.Velocity.WindowWeeks))
+	}
+
 	if len(b.TopMetrics.PageRank) > 0 {
 		sb.WriteString("\nTop PageRank:\n")
 		for i, item := range b.TopMetrics.PageRank {
@@ -73,39 +73,42 @@ var excludedPaths = []string{
 
 // ExtractCoCommittedFiles extracts code files changed in the same commit as a bead event
 func (c *CoCommitExtractor) ExtractCoCommittedFiles(event BeadEvent) ([]FileChange, error) {
+	files, err := c.filesWithStats(event.CommitSHA)
+	if err != nil {
+		return nil, err
+	}
+	return filterCodeFiles(files), nil
+}
+
+// ChangedFiles returns every file changed in a commit, without line stats
+func (c *CoCommitExtractor) ChangedFiles(sha string) ([]FileChange, error) {
+	return c.getFilesChanged(sha)
+}
+
+// filesWithStats returns every file changed in a commit with its line stats
+func (c *CoCommitExtractor) filesWithStats(sha string) ([]FileChange, error) {
 	// Get file list with status
-	files, err := c.getFilesChanged(event.CommitSHA)
+	files, err := c.getFilesChanged(sha)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get line stats
-	stats, err := c.getLineStats(event.CommitSHA)
+	stats, err := c.getLineStats(sha)
 	if err != nil {
 		// Non-fatal: continue without stats
 		stats = make(map[string]lineStats)
 	}
 
-	// Filter to code files only
-	var codeFiles []FileChange
-	for _, f := range files {
-		if !isCodeFile(f.Path) {
-			continue
-		}
-		if isExcludedPath(f.Path) {
-			continue
-		}
-
-		// Add line stats if available
+	// Add line stats if available
+	for i, f := range files {
 		if s, ok := stats[f.Path]; ok {
-			f.Insertions = s.insertions
-			f.Deletions = s.deletions
+			files[i].Insertions = s.insertions
+			files[i].Deletions = s.deletions
 		}
-
-		codeFiles = append(codeFiles, f)
 	}
 
-	return codeFiles, nil
+	return files, nil
 }
 
 // CreateCorrelatedCommit creates a CorrelatedCommit with confidence scoring
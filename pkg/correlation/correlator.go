@@ -15,6 +15,8 @@ type Correlator struct {
 	repoPath    string
 	extractor   *Extractor
 	coCommitter *CoCommitExtractor
+	refs        *RefExtractor
+	groups      *GroupExtractor
 }
 
 // NewCorrelator creates a new correlator for the given repository.
@@ -26,6 +28,8 @@ func NewCorrelator(repoPath string, beadsFilePath ...string) *Correlator {
 		repoPath:    repoPath,
 		extractor:   NewExtractor(repoPath, beadsFilePath...),
 		coCommitter: NewCoCommitExtractor(repoPath),
+		refs:        NewRefExtractor(repoPath),
+		groups:      NewGroupExtractor(repoPath),
 	}
 }
 
@@ -59,6 +63,20 @@ func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*
 		return nil, fmt.Errorf("extracting co-commits: %w", err)
 	}
 
+	// Add commits linked by trailers and branch names; these are optional
+	// signals, so a failure leaves the co-commit report intact
+	if refCommits, err := c.refs.Extract(extractOpts); err == nil {
+		canonicalBeadIDs(beads, refCommits)
+		commits = mergeRefCommits(commits, refCommits)
+	}
+
+	report := c.buildReport(beads, opts, events, commits)
+	addGroups(report, c.groups, opts)
+	return report, nil
+}
+
+// buildReport assembles a report from extracted events and co-commits
+func (c *Correlator) buildReport(beads []BeadInfo, opts CorrelatorOptions, events []BeadEvent, commits []CorrelatedCommit) *HistoryReport {
 	// Build bead histories
 	histories := c.buildHistories(beads, events, commits)
 
@@ -94,7 +112,7 @@ func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*
 		Stats:           stats,
 		Histories:       histories,
 		CommitIndex:     commitIndex,
-	}, nil
+	}
 }
 
 // findLatestCommitSHA finds the most recent commit SHA from events and commits
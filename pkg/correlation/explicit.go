@@ -14,50 +14,68 @@ import (
 // ExplicitMatcher finds commits that explicitly reference bead IDs in messages.
 type ExplicitMatcher struct {
 	repoPath string
-	patterns []*regexp.Regexp
+	patterns []BeadPattern
+	deny     DenyList
 }
 
 // DefaultPatterns returns the default set of bead ID patterns.
 func DefaultPatterns() []*regexp.Regexp {
-	return []*regexp.Regexp{
+	var patterns []*regexp.Regexp
+	for _, p := range defaultBeadPatterns() {
+		patterns = append(patterns, p.Regex)
+	}
+	return patterns
+}
+
+// defaultBeadPatterns returns the default patterns with their names.
+func defaultBeadPatterns() []BeadPattern {
+	return []BeadPattern{
 		// [ID] format - very explicit
-		regexp.MustCompile(`\[([A-Za-z]+-\d+)\]`),
+		{Name: "bracket", Regex: regexp.MustCompile(`\[([A-Za-z]+-\d+)\]`)},
 
 		// Closes/Fixes/Refs keywords with optional # prefix
 		// Note: Allow optional colon and whitespace after keyword
-		regexp.MustCompile(`(?i)closes?:?\s*#?([A-Za-z]+-\d+)`),
-		regexp.MustCompile(`(?i)fix(?:es|ed)?:?\s*#?([A-Za-z]+-\d+)`),
-		regexp.MustCompile(`(?i)refs?:?\s*#?([A-Za-z]+-\d+)`),
-		regexp.MustCompile(`(?i)resolves?:?\s*#?([A-Za-z]+-\d+)`),
+		{Name: "closes", Regex: regexp.MustCompile(`(?i)closes?:?\s*#?([A-Za-z]+-\d+)`)},
+		{Name: "fixes", Regex: regexp.MustCompile(`(?i)fix(?:es|ed)?:?\s*#?([A-Za-z]+-\d+)`)},
+		{Name: "refs", Regex: regexp.MustCompile(`(?i)refs?:?\s*#?([A-Za-z]+-\d+)`)},
+		{Name: "resolves", Regex: regexp.MustCompile(`(?i)resolves?:?\s*#?([A-Za-z]+-\d+)`)},
 
 		// beads-123 or bead-123 format (common for this project)
-		regexp.MustCompile(`(?i)beads?[-_](\d+)`),
-		regexp.MustCompile(`(?i)bv[-_](\d+)`),
+		{Name: "beads", Regex: regexp.MustCompile(`(?i)beads?[-_](\d+)`)},
+		{Name: "bv", Regex: regexp.MustCompile(`(?i)bv[-_](\d+)`)},
 
 		// Generic ID at word boundary (PROJECT-123 style)
-		regexp.MustCompile(`\b([A-Z]{2,10}-\d+)\b`),
+		{Name: "generic", Regex: regexp.MustCompile(`\b([A-Z]{2,10}-\d+)\b`)},
 	}
 }
 
 // NewExplicitMatcher creates a new explicit matcher with default patterns.
 func NewExplicitMatcher(repoPath string) *ExplicitMatcher {
-	return &ExplicitMatcher{
-		repoPath: repoPath,
-		patterns: DefaultPatterns(),
-	}
+	return NewExplicitMatcherWithPatternSet(repoPath, DefaultPatternSet())
 }
 
 // NewExplicitMatcherWithPatterns creates a matcher with custom patterns.
 func NewExplicitMatcherWithPatterns(repoPath string, patterns []*regexp.Regexp) *ExplicitMatcher {
+	m := &ExplicitMatcher{repoPath: repoPath}
+	for _, pattern := range patterns {
+		m.AddPattern(pattern)
+	}
+	return m
+}
+
+// NewExplicitMatcherWithPatternSet creates a matcher from a pattern set,
+// such as one loaded from .bv/correlation.yaml.
+func NewExplicitMatcherWithPatternSet(repoPath string, set *PatternSet) *ExplicitMatcher {
 	return &ExplicitMatcher{
 		repoPath: repoPath,
-		patterns: patterns,
+		patterns: append([]BeadPattern(nil), set.Patterns...),
+		deny:     set.Deny,
 	}
 }
 
 // AddPattern adds a custom pattern to the matcher.
 func (m *ExplicitMatcher) AddPattern(pattern *regexp.Regexp) {
-	m.patterns = append(m.patterns, pattern)
+	m.patterns = append(m.patterns, BeadPattern{Name: pattern.String(), Regex: pattern})
 }
 
 // ExplicitMatch represents a bead ID found in a commit message.
@@ -75,35 +93,17 @@ type ExplicitMatch struct {
 // ExtractIDsFromMessage extracts all bead IDs from a commit message.
 // Ordering: matches are returned in the order patterns are evaluated; we also keep stable ID ordering for predictability.
 func (m *ExplicitMatcher) ExtractIDsFromMessage(message string) []IDMatch {
-	var matches []IDMatch
-	seen := make(map[string]bool)
-
-	for _, pattern := range m.patterns {
-		found := pattern.FindAllStringSubmatch(message, -1)
-		for _, match := range found {
-			if len(match) >= 2 {
-				id := normalizeBeadID(match[1])
-				if !seen[id] {
-					seen[id] = true
-					matchType := classifyMatch(match[0])
-					matches = append(matches, IDMatch{
-						ID:        id,
-						MatchType: matchType,
-						RawMatch:  match[0],
-					})
-				}
-			}
-		}
-	}
-
-	return matches
+	set := PatternSet{Patterns: m.patterns, Deny: m.deny}
+	return set.Extract(message)
 }
 
 // IDMatch represents a single ID match from a message.
 type IDMatch struct {
-	ID        string
-	MatchType string
-	RawMatch  string
+	ID         string
+	MatchType  string
+	RawMatch   string
+	Pattern    string  // Name of the pattern that matched
+	Confidence float64 // Pattern's configured confidence (0 = derive from MatchType)
 }
 
 // normalizeBeadID normalizes a bead ID to a consistent format.
@@ -170,6 +170,22 @@ func CalculateConfidence(matchType string, totalMatches int) float64 {
 	return base
 }
 
+// matchConfidence is the confidence of an ID match: the pattern's configured
+// confidence when set, less the multiple-ID penalty, else CalculateConfidence.
+func matchConfidence(match IDMatch, totalMatches int) float64 {
+	if match.Confidence <= 0 {
+		return CalculateConfidence(match.MatchType, totalMatches)
+	}
+	confidence := match.Confidence
+	if totalMatches > 1 {
+		confidence -= 0.02 * float64(totalMatches-1)
+	}
+	if confidence < 0.10 {
+		confidence = 0.10
+	}
+	return confidence
+}
+
 // FindCommitsForBead finds all commits that explicitly reference a bead ID.
 func (m *ExplicitMatcher) FindCommitsForBead(beadID string, opts ExtractOptions) ([]ExplicitMatch, error) {
 	// Use git log --grep to efficiently find commits mentioning this ID
@@ -293,7 +309,7 @@ func (m *ExplicitMatcher) parseGrepOutput(data []byte, searchPattern string) ([]
 			if strings.EqualFold(idMatch.ID, searchPattern) ||
 				strings.Contains(strings.ToLower(idMatch.RawMatch), strings.ToLower(searchPattern)) {
 				matchType = idMatch.MatchType
-				confidence = CalculateConfidence(idMatch.MatchType, len(idMatches))
+				confidence = matchConfidence(idMatch, len(idMatches))
 				break
 			}
 		}
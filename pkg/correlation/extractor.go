@@ -82,9 +82,19 @@ type beadSnapshot struct {
 
 // Extract extracts bead lifecycle events from git history
 func (e *Extractor) Extract(opts ExtractOptions) ([]BeadEvent, error) {
-	// Build git log command
-	logArgs := e.buildGitLogArgs(opts)
+	return e.runGitLog(e.buildGitLogArgs(opts), opts.BeadID)
+}
+
+// extractRange extracts events from the commits in a revision range such as
+// "abc123..HEAD", oldest first.
+func (e *Extractor) extractRange(revRange string) ([]BeadEvent, error) {
+	args := insertBefore(e.buildGitLogArgs(ExtractOptions{}), "--", revRange)
+	return e.runGitLog(args, "")
+}
 
+// runGitLog runs git log with the given arguments and parses its events,
+// oldest first.
+func (e *Extractor) runGitLog(logArgs []string, filterBeadID string) ([]BeadEvent, error) {
 	// Inject config to disable colors (essential for parsing)
 	args := append([]string{"-c", "color.ui=false"}, logArgs...)
 
@@ -101,7 +111,7 @@ func (e *Extractor) Extract(opts ExtractOptions) ([]BeadEvent, error) {
 	}
 
 	// Parse output stream
-	events, parseErr := e.parseGitLogOutput(stdout, opts.BeadID)
+	events, parseErr := e.parseGitLogOutput(stdout, filterBeadID)
 
 	// If parsing failed, ensure we drain the pipe or kill the process to avoid deadlock
 	// where git log is blocked writing to full pipe while we wait for it to exit.
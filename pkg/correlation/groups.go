@@ -0,0 +1,249 @@
+// Package correlation provides pull-request-aware grouping of commits into
+// units of work.
+package correlation
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GroupKind describes how a group of commits reached the mainline
+type GroupKind string
+
+const (
+	// GroupMerge is a merge commit and the branch commits it brought in
+	GroupMerge GroupKind = "merge"
+	// GroupSquash is a single commit squash-merged from a pull request
+	GroupSquash GroupKind = "squash"
+)
+
+// CommitGroup is a unit of work: a merged branch or a squash-merged pull
+// request, correlated to beads as a whole.
+type CommitGroup struct {
+	ID            string        `json:"id"`                  // "#123", or the short merge SHA without a PR number
+	Kind          GroupKind     `json:"kind"`                // merge or squash
+	PRNumber      int           `json:"pr_number,omitempty"` // Pull request number, if known
+	Title         string        `json:"title"`               // Pull request title or merge subject
+	Branch        string        `json:"branch,omitempty"`    // Merged branch, if named in the merge subject
+	MergeSHA      string        `json:"merge_sha"`
+	Author        string        `json:"author"`          // Author of the merge or squash commit
+	FirstCommitAt time.Time     `json:"first_commit_at"` // Earliest author date in the group
+	MergedAt      time.Time     `json:"merged_at"`       // Commit date of the merge or squash commit
+	CycleTime     time.Duration `json:"cycle_time"`      // First commit to merge
+	Commits       []string      `json:"commits"`         // Every commit SHA, oldest first, the merge last
+	BeadIDs       []string      `json:"bead_ids"`        // Beads correlated with any commit in the group
+}
+
+// groupLogFormat is the commit header plus parents, commit date and body; a
+// record separator ends each commit since bodies span lines.
+const groupLogFormat = gitLogHeaderFormat + "%x00%P%x00%cI%x00%b%x1e"
+
+var (
+	// prSuffixPattern matches the "(#123)" suffix hosts add to squash and
+	// merge subjects
+	prSuffixPattern = regexp.MustCompile(`\(#(\d+)\)\s*$`)
+	// prMergePattern matches "Merge pull request #123 from owner/branch"
+	prMergePattern = regexp.MustCompile(`^Merge pull request #(\d+) `)
+)
+
+// GroupExtractor detects commit groups along the mainline (first-parent
+// history) of a repository.
+type GroupExtractor struct {
+	repoPath string
+}
+
+// NewGroupExtractor creates a group extractor for the given repository
+func NewGroupExtractor(repoPath string) *GroupExtractor {
+	return &GroupExtractor{repoPath: repoPath}
+}
+
+// Extract returns the groups merged by the mainline commits selected by opts,
+// newest first. Groups are not yet linked to beads; see linkGroups.
+func (g *GroupExtractor) Extract(opts ExtractOptions) ([]CommitGroup, error) {
+	var selection []string
+	if opts.Since != nil {
+		selection = append(selection, fmt.Sprintf("--since=%s", opts.Since.Format(time.RFC3339)))
+	}
+	if opts.Until != nil {
+		selection = append(selection, fmt.Sprintf("--until=%s", opts.Until.Format(time.RFC3339)))
+	}
+	if opts.Limit > 0 {
+		selection = append(selection, fmt.Sprintf("-n%d", opts.Limit))
+	}
+	mainline, err := g.scan(selection)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []CommitGroup
+	for _, commit := range mainline {
+		if commit.Group != nil {
+			groups = append(groups, *commit.Group)
+		}
+	}
+	return groups, nil
+}
+
+// mainlineCommit is a first-parent commit with the group it merged, if any
+type mainlineCommit struct {
+	SHA         string
+	CommittedAt time.Time
+	Group       *CommitGroup
+}
+
+// scan lists the mainline commits selected by git log arguments, newest
+// first, detecting the group each one merged.
+func (g *GroupExtractor) scan(selection []string) ([]mainlineCommit, error) {
+	args := append([]string{"-c", "color.ui=false", "log", "--first-parent", "--format=" + groupLogFormat}, selection...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	var mainline []mainlineCommit
+	for _, record := range strings.Split(string(out), "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		parts := strings.SplitN(record, "\x00", 8)
+		if len(parts) != 8 {
+			continue
+		}
+		info, err := parseCommitInfo(strings.Join(parts[:5], "\x00"))
+		if err != nil {
+			continue
+		}
+		committedAt, err := time.Parse(time.RFC3339, parts[6])
+		if err != nil {
+			committedAt = info.Timestamp
+		}
+		commit := mainlineCommit{SHA: info.SHA, CommittedAt: committedAt}
+		if group, ok := g.detectGroup(info, strings.Fields(parts[5]), committedAt, parts[7]); ok {
+			commit.Group = &group
+		}
+		mainline = append(mainline, commit)
+	}
+	return mainline, nil
+}
+
+// detectGroup builds the group a mainline commit merged, if any
+func (g *GroupExtractor) detectGroup(info commitInfo, parents []string, mergedAt time.Time, body string) (CommitGroup, bool) {
+	group := CommitGroup{
+		Kind:          GroupSquash,
+		Title:         info.Message,
+		MergeSHA:      info.SHA,
+		Author:        info.Author,
+		FirstCommitAt: info.Timestamp,
+		MergedAt:      mergedAt,
+	}
+	if m := prSuffixPattern.FindStringSubmatch(info.Message); m != nil {
+		group.PRNumber, _ = strconv.Atoi(m[1])
+		group.Title = strings.TrimSpace(prSuffixPattern.ReplaceAllString(info.Message, ""))
+	}
+
+	if len(parents) < 2 {
+		if group.PRNumber == 0 {
+			return CommitGroup{}, false
+		}
+	} else {
+		group.Kind = GroupMerge
+		group.Branch = branchFromMergeSubject(info.Message)
+		if m := prMergePattern.FindStringSubmatch(info.Message); m != nil {
+			group.PRNumber, _ = strconv.Atoi(m[1])
+			// Hosts put the pull request title in the merge body
+			if title := firstLine(body); title != "" {
+				group.Title = title
+			}
+		}
+		commits, err := branchCommits(g.repoPath, parents)
+		if err != nil {
+			// Non-fatal: a group of the merge alone
+			commits = nil
+		}
+		for i := len(commits) - 1; i >= 0; i-- {
+			group.Commits = append(group.Commits, commits[i].SHA)
+			if commits[i].Timestamp.Before(group.FirstCommitAt) {
+				group.FirstCommitAt = commits[i].Timestamp
+			}
+		}
+	}
+	group.Commits = append(group.Commits, info.SHA)
+
+	group.ID = shortSHA(info.SHA)
+	if group.PRNumber > 0 {
+		group.ID = fmt.Sprintf("#%d", group.PRNumber)
+	}
+	if group.CycleTime = group.MergedAt.Sub(group.FirstCommitAt); group.CycleTime < 0 {
+		group.CycleTime = 0
+	}
+	return group, true
+}
+
+// firstLine returns the first non-blank line of text
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// linkGroups keeps the groups with at least one correlated commit and sets
+// their beads from the commit index.
+func linkGroups(groups []CommitGroup, index CommitIndex) []CommitGroup {
+	var linked []CommitGroup
+	for _, group := range groups {
+		seen := make(map[string]bool)
+		group.BeadIDs = []string{}
+		for _, sha := range group.Commits {
+			for _, beadID := range index[sha] {
+				if !seen[beadID] {
+					seen[beadID] = true
+					group.BeadIDs = append(group.BeadIDs, beadID)
+				}
+			}
+		}
+		if len(group.BeadIDs) == 0 {
+			continue
+		}
+		sort.Strings(group.BeadIDs)
+		linked = append(linked, group)
+	}
+	return linked
+}
+
+// addGroups attaches the commit groups of the commits selected by opts to a
+// report. Grouping is optional, so a failure leaves the report without
+// groups.
+func addGroups(report *HistoryReport, extractor *GroupExtractor, opts CorrelatorOptions) {
+	groups, err := extractor.Extract(ExtractOptions{Since: opts.Since, Until: opts.Until, Limit: opts.Limit})
+	if err != nil {
+		return
+	}
+	setGroups(report, groups)
+}
+
+// setGroups links groups to the report's beads and sets their average cycle
+// time.
+func setGroups(report *HistoryReport, groups []CommitGroup) {
+	report.Groups = linkGroups(groups, report.CommitIndex)
+	if len(report.Groups) == 0 {
+		return
+	}
+
+	var total time.Duration
+	for _, group := range report.Groups {
+		total += group.CycleTime
+	}
+	avgDays := total.Hours() / 24 / float64(len(report.Groups))
+	report.Stats.AvgGroupCycleTimeDays = &avgDays
+}
@@ -0,0 +1,79 @@
+package correlation
+
+import (
+	"testing"
+)
+
+func TestCorrelator_Groups(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create beads", "", [2]string{"bv-1", "open"}, [2]string{"bv-2", "open"})
+
+	// A pull request merged with a merge commit
+	r.git("checkout", "-q", "-b", "feature-login")
+	r.write(".beads/issues.jsonl", `{"id":"bv-1","title":"Bead bv-1","status":"in_progress"}`+"\n"+`{"id":"bv-2","title":"Bead bv-2","status":"open"}`+"\n")
+	r.write("pkg/login.go", "login\n")
+	r.git("add", "-A")
+	r.git("commit", "-q", "--date", "2020-01-01T00:00:00Z", "-m", "Start login")
+	r.write("pkg/form.go", "form\n")
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", "Add form")
+	r.git("checkout", "-q", "-")
+	r.git("merge", "-q", "--no-ff", "-m", "Merge pull request #12 from acme/feature-login", "-m", "Add login", "feature-login")
+
+	// A squash-merged pull request and a direct commit
+	r.commit("Add export (#13)", "pkg/export.go", [2]string{"bv-1", "in_progress"}, [2]string{"bv-2", "in_progress"})
+	r.commit("Tweak export", "pkg/export2.go", [2]string{"bv-1", "closed"}, [2]string{"bv-2", "in_progress"})
+
+	report, err := NewCorrelator(r.dir).GenerateReport(indexTestBeads, CorrelatorOptions{})
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	if len(report.Groups) != 2 {
+		t.Fatalf("Groups = %+v, want 2", report.Groups)
+	}
+
+	squash, merge := report.Groups[0], report.Groups[1]
+	if squash.ID != "#13" || squash.Kind != GroupSquash || squash.Title != "Add export" || len(squash.Commits) != 1 {
+		t.Errorf("squash group = %+v, want #13 with 1 commit", squash)
+	}
+	if len(squash.BeadIDs) != 1 || squash.BeadIDs[0] != "bv-2" {
+		t.Errorf("squash beads = %v, want [bv-2]", squash.BeadIDs)
+	}
+
+	if merge.ID != "#12" || merge.Kind != GroupMerge || merge.Title != "Add login" || merge.Branch != "acme/feature-login" {
+		t.Errorf("merge group = %+v, want #12 \"Add login\" from acme/feature-login", merge)
+	}
+	if len(merge.Commits) != 3 || merge.Commits[2] != merge.MergeSHA {
+		t.Errorf("merge commits = %v, want 2 branch commits then the merge", merge.Commits)
+	}
+	if len(merge.BeadIDs) != 1 || merge.BeadIDs[0] != "bv-1" {
+		t.Errorf("merge beads = %v, want [bv-1]", merge.BeadIDs)
+	}
+	if merge.FirstCommitAt.Year() != 2020 || merge.CycleTime != merge.MergedAt.Sub(merge.FirstCommitAt) {
+		t.Errorf("merge cycle = %v from %v, want first commit to merge", merge.CycleTime, merge.FirstCommitAt)
+	}
+	if report.Stats.AvgGroupCycleTimeDays == nil {
+		t.Error("AvgGroupCycleTimeDays = nil, want the group average")
+	}
+
+	ic := NewIndexedCorrelator(r.dir)
+	defer ic.Close()
+	assertSameReport(t, r, ic, CorrelatorOptions{})
+	assertSameReport(t, r, ic, CorrelatorOptions{BeadID: "bv-1"})
+}
+
+func TestLinkGroups(t *testing.T) {
+	groups := []CommitGroup{
+		{ID: "#1", Commits: []string{"a", "b"}},
+		{ID: "#2", Commits: []string{"c"}},
+	}
+	index := CommitIndex{"a": {"bv-2"}, "b": {"bv-1", "bv-2"}}
+
+	linked := linkGroups(groups, index)
+	if len(linked) != 1 || linked[0].ID != "#1" {
+		t.Fatalf("linkGroups() = %+v, want only #1", linked)
+	}
+	if got := linked[0].BeadIDs; len(got) != 2 || got[0] != "bv-1" || got[1] != "bv-2" {
+		t.Errorf("BeadIDs = %v, want [bv-1 bv-2]", got)
+	}
+}
@@ -0,0 +1,891 @@
+// Package correlation provides a persistent history index so reports do not
+// replay git log on every run.
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// HistoryIndexFileName is the history index database under .bv/.
+const HistoryIndexFileName = "history.db"
+
+// historyIndexVersion is stored in the index. Bump it whenever the schema or
+// the way events and correlations are derived changes, so that existing
+// indexes are rebuilt instead of serving stale data.
+const historyIndexVersion = "5"
+
+const historyIndexSchema = `
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+
+-- Every indexed commit; seq orders them oldest first and touches_beads marks
+-- commits that changed the beads file
+CREATE TABLE IF NOT EXISTS commits (
+	sha           TEXT PRIMARY KEY,
+	seq           INTEGER NOT NULL,
+	timestamp     TEXT NOT NULL,
+	unix          INTEGER NOT NULL,
+	author        TEXT NOT NULL,
+	author_email  TEXT NOT NULL,
+	message       TEXT NOT NULL,
+	touches_beads INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_commits_seq ON commits(seq);
+
+CREATE TABLE IF NOT EXISTS events (
+	commit_sha TEXT NOT NULL,
+	ord        INTEGER NOT NULL,
+	bead_id    TEXT NOT NULL,
+	event_type TEXT NOT NULL,
+	PRIMARY KEY (commit_sha, ord)
+);
+CREATE INDEX IF NOT EXISTS idx_events_bead ON events(bead_id);
+
+-- source_sha is the trailer or merge commit a ref link came from; empty for
+-- co-commits
+CREATE TABLE IF NOT EXISTS correlations (
+	id         INTEGER PRIMARY KEY,
+	commit_sha TEXT NOT NULL,
+	source_sha TEXT NOT NULL DEFAULT '',
+	bead_id    TEXT NOT NULL,
+	method     TEXT NOT NULL,
+	confidence REAL NOT NULL,
+	reason     TEXT NOT NULL,
+	UNIQUE (commit_sha, bead_id, method, source_sha)
+);
+CREATE INDEX IF NOT EXISTS idx_correlations_commit ON correlations(commit_sha);
+CREATE INDEX IF NOT EXISTS idx_correlations_source ON correlations(source_sha);
+CREATE INDEX IF NOT EXISTS idx_correlations_bead ON correlations(bead_id);
+
+-- Files changed per commit; file_scans marks commits whose files are stored,
+-- including commits that changed none
+CREATE TABLE IF NOT EXISTS commit_files (
+	commit_sha TEXT NOT NULL,
+	ord        INTEGER NOT NULL,
+	path       TEXT NOT NULL,
+	action     TEXT NOT NULL,
+	insertions INTEGER NOT NULL,
+	deletions  INTEGER NOT NULL,
+	PRIMARY KEY (commit_sha, ord)
+);
+CREATE TABLE IF NOT EXISTS file_scans (
+	commit_sha TEXT PRIMARY KEY
+);
+
+-- First-parent commits of HEAD; seq orders them oldest first and committed
+-- is the commit date, which git log --since and --until select on
+CREATE TABLE IF NOT EXISTS mainline (
+	sha       TEXT PRIMARY KEY,
+	seq       INTEGER NOT NULL,
+	committed INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_mainline_seq ON mainline(seq);
+
+-- Groups merged by mainline commits; members are the group's commit SHAs,
+-- space separated, oldest first and the merge last
+CREATE TABLE IF NOT EXISTS commit_groups (
+	merge_sha       TEXT PRIMARY KEY,
+	kind            TEXT NOT NULL,
+	pr_number       INTEGER NOT NULL,
+	title           TEXT NOT NULL,
+	branch          TEXT NOT NULL,
+	author          TEXT NOT NULL,
+	first_commit_at TEXT NOT NULL,
+	merged_at       TEXT NOT NULL,
+	members         TEXT NOT NULL
+);
+`
+
+// historyIndexTables lists the tables dropped on rebuild, keyed by the
+// commit SHA columns whose rows go when a commit leaves history.
+var historyIndexTables = map[string][]string{
+	"commits":       {"sha"},
+	"events":        {"commit_sha"},
+	"correlations":  {"commit_sha", "source_sha"},
+	"commit_files":  {"commit_sha"},
+	"file_scans":    {"commit_sha"},
+	"mainline":      {"sha"},
+	"commit_groups": {"merge_sha"},
+}
+
+// IndexUpdateMode describes how an update brought the index up to date
+type IndexUpdateMode string
+
+const (
+	// IndexCurrent means the index was already at HEAD
+	IndexCurrent IndexUpdateMode = "current"
+	// IndexIncremental means only commits after the last indexed HEAD were indexed
+	IndexIncremental IndexUpdateMode = "incremental"
+	// IndexRecovered means history was rewritten (e.g. rebased): commits no
+	// longer in history were dropped and the replacements indexed
+	IndexRecovered IndexUpdateMode = "recovered"
+	// IndexRebuilt means the index was built from scratch
+	IndexRebuilt IndexUpdateMode = "rebuilt"
+)
+
+// IndexUpdate reports what HistoryIndex.Update did
+type IndexUpdate struct {
+	Mode           IndexUpdateMode `json:"mode"`
+	Head           string          `json:"head"`
+	NewCommits     int             `json:"new_commits"`      // Beads-file commits indexed
+	DroppedCommits int             `json:"dropped_commits"`  // Commits removed after a history rewrite
+	Reason         string          `json:"reason,omitempty"` // Why the index was rebuilt
+}
+
+// HistoryIndex is a persistent SQLite index under .bv/ of the commits in
+// history, keyed by commit SHA, with the bead events and co-committed files
+// of those that touched the beads file and the correlations of all of them.
+type HistoryIndex struct {
+	db          *sql.DB
+	repoPath    string
+	beadsFile   string
+	extractor   *Extractor
+	coCommitter *CoCommitExtractor
+	refs        *RefExtractor
+	groups      *GroupExtractor
+}
+
+// HistoryIndexPath returns the location of the history index for a repository
+func HistoryIndexPath(repoPath string) string {
+	return filepath.Join(repoPath, ".bv", HistoryIndexFileName)
+}
+
+// OpenHistoryIndex opens the history index of repoPath, creating it if
+// needed. beadsFilePath is optional, as for NewCorrelator.
+func OpenHistoryIndex(repoPath string, beadsFilePath ...string) (*HistoryIndex, error) {
+	path := HistoryIndexPath(repoPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("creating index directory: %w", err)
+	}
+
+	// Updates take the write lock when they begin, so a concurrent bv waits
+	// and then sees the HEAD the other one indexed
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_txlock=immediate")
+	if err != nil {
+		return nil, fmt.Errorf("opening history index: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	if err := dropStaleTables(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec(historyIndexSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating history index schema: %w", err)
+	}
+
+	extractor := NewExtractor(repoPath, beadsFilePath...)
+	return &HistoryIndex{
+		db:          db,
+		repoPath:    repoPath,
+		beadsFile:   extractor.primaryBeadsFile(),
+		extractor:   extractor,
+		coCommitter: NewCoCommitExtractor(repoPath),
+		refs:        NewRefExtractor(repoPath),
+		groups:      NewGroupExtractor(repoPath),
+	}, nil
+}
+
+// Close closes the index database
+func (x *HistoryIndex) Close() error {
+	return x.db.Close()
+}
+
+// Update indexes the commits made since the last update. If the previously
+// indexed HEAD is no longer an ancestor of HEAD (after a rebase, reset or
+// amend), the commits that left history are dropped and indexing resumes
+// from the merge base. The index is rebuilt when it is new, was written by
+// another version, tracks a different beads file or bead ID patterns, or the
+// old HEAD is gone.
+func (x *HistoryIndex) Update() (*IndexUpdate, error) {
+	head, err := getGitHead(x.repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving HEAD: %w", err)
+	}
+
+	meta, err := x.readMeta()
+	if err != nil {
+		return nil, err
+	}
+
+	update := &IndexUpdate{Head: head}
+	revRange := head
+	var dropped []string
+
+	lastHead := meta["head"]
+	switch {
+	case meta["version"] == "":
+		update.Mode, update.Reason = IndexRebuilt, "new index"
+	case meta["version"] != historyIndexVersion:
+		update.Mode, update.Reason = IndexRebuilt, "index format changed"
+	case meta["beads_file"] != x.beadsFile:
+		update.Mode, update.Reason = IndexRebuilt, "beads file changed"
+	case meta["patterns"] != x.refs.patterns.fingerprint():
+		update.Mode, update.Reason = IndexRebuilt, "bead ID patterns changed"
+	case lastHead == head:
+		update.Mode = IndexCurrent
+		return update, nil
+	case isAncestor(x.repoPath, lastHead, head):
+		update.Mode = IndexIncremental
+		revRange = lastHead + ".." + head
+	default:
+		base, err := mergeBase(x.repoPath, lastHead, head)
+		if err == nil {
+			dropped, err = revList(x.repoPath, base+".."+lastHead)
+		}
+		if err != nil {
+			update.Mode, update.Reason = IndexRebuilt, "previous HEAD is no longer in the repository"
+		} else {
+			update.Mode = IndexRecovered
+			update.DroppedCommits = len(dropped)
+			revRange = base + ".." + head
+		}
+	}
+
+	beadsCommits, err := x.listCommits(revRange)
+	if err != nil {
+		return nil, err
+	}
+	scan, err := x.refs.scan([]string{revRange})
+	if err != nil {
+		return nil, fmt.Errorf("extracting trailers and branches: %w", err)
+	}
+	events, err := x.extractor.extractRange(revRange)
+	if err != nil {
+		return nil, fmt.Errorf("extracting events: %w", err)
+	}
+	mainline, err := x.groups.scan([]string{revRange})
+	if err != nil {
+		return nil, fmt.Errorf("extracting merged groups: %w", err)
+	}
+
+	// Fetch co-committed files once per status-change commit, as
+	// CoCommitExtractor.ExtractAllCoCommits does
+	files := make(map[string][]FileChange)
+	var correlations []CorrelatedCommit
+	for _, event := range events {
+		if event.EventType != EventClaimed && event.EventType != EventClosed {
+			continue
+		}
+		changed, ok := files[event.CommitSHA]
+		if !ok {
+			changed, err = x.coCommitter.filesWithStats(event.CommitSHA)
+			if err != nil {
+				// Non-fatal: skip this commit
+				continue
+			}
+			files[event.CommitSHA] = changed
+		}
+		codeFiles := filterCodeFiles(changed)
+		if len(codeFiles) == 0 {
+			continue
+		}
+		correlations = append(correlations, x.coCommitter.CreateCorrelatedCommit(event, codeFiles))
+	}
+
+	tx, err := x.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("starting index update: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Another bv may have updated the index while the range was scanned
+	var currentHead string
+	if err := tx.QueryRow(`SELECT value FROM meta WHERE key = 'head'`).Scan(&currentHead); err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("reading index metadata: %w", err)
+	}
+	if currentHead != lastHead {
+		if currentHead == head {
+			return &IndexUpdate{Mode: IndexCurrent, Head: head}, nil
+		}
+		tx.Rollback()
+		return x.Update()
+	}
+
+	for table, columns := range historyIndexTables {
+		if update.Mode == IndexRebuilt {
+			if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+				return nil, fmt.Errorf("clearing %s: %w", table, err)
+			}
+			continue
+		}
+		for _, sha := range dropped {
+			for _, column := range columns {
+				if _, err := tx.Exec("DELETE FROM "+table+" WHERE "+column+" = ?", sha); err != nil {
+					return nil, fmt.Errorf("dropping %s from %s: %w", shortSHA(sha), table, err)
+				}
+			}
+		}
+	}
+	touchesBeads := make(map[string]bool, len(beadsCommits))
+	for _, commit := range beadsCommits {
+		touchesBeads[commit.SHA] = true
+	}
+
+	var seq int64
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(seq), 0) FROM commits`).Scan(&seq); err != nil {
+		return nil, fmt.Errorf("reading index position: %w", err)
+	}
+	for _, commit := range scan.commits {
+		seq++
+		if _, err := tx.Exec(
+			`INSERT OR REPLACE INTO commits (sha, seq, timestamp, unix, author, author_email, message, touches_beads) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			commit.SHA, seq, commit.Timestamp.Format(time.RFC3339), commit.Timestamp.Unix(), commit.Author, commit.AuthorEmail, commit.Message, touchesBeads[commit.SHA],
+		); err != nil {
+			return nil, fmt.Errorf("indexing commit %s: %w", shortSHA(commit.SHA), err)
+		}
+	}
+
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(seq), 0) FROM mainline`).Scan(&seq); err != nil {
+		return nil, fmt.Errorf("reading index position: %w", err)
+	}
+	for i := len(mainline) - 1; i >= 0; i-- {
+		seq++
+		if err := storeMainline(tx, mainline[i], seq); err != nil {
+			return nil, err
+		}
+	}
+
+	ord := make(map[string]int)
+	for _, event := range events {
+		if _, err := tx.Exec(
+			`INSERT OR REPLACE INTO events (commit_sha, ord, bead_id, event_type) VALUES (?, ?, ?, ?)`,
+			event.CommitSHA, ord[event.CommitSHA], event.BeadID, string(event.EventType),
+		); err != nil {
+			return nil, fmt.Errorf("indexing events of %s: %w", shortSHA(event.CommitSHA), err)
+		}
+		ord[event.CommitSHA]++
+	}
+
+	for sha, changed := range scan.files {
+		files[sha] = changed
+	}
+	for sha, changed := range files {
+		if err := storeFiles(tx, sha, changed); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, commit := range correlations {
+		if err := storeCorrelation(tx, commit, ""); err != nil {
+			return nil, err
+		}
+	}
+	for _, link := range scan.links {
+		if err := storeCorrelation(tx, link.CorrelatedCommit, link.Source); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, value := range map[string]string{
+		"version":    historyIndexVersion,
+		"beads_file": x.beadsFile,
+		"patterns":   x.refs.patterns.fingerprint(),
+		"head":       head,
+		"updated_at": time.Now().UTC().Format(time.RFC3339),
+	} {
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO meta (key, value) VALUES (?, ?)`, key, value); err != nil {
+			return nil, fmt.Errorf("writing index metadata: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing index update: %w", err)
+	}
+
+	update.NewCommits = len(beadsCommits)
+	return update, nil
+}
+
+// ChangedFiles returns every file changed in a commit. Files are read from
+// the index when stored and otherwise fetched from git and stored.
+func (x *HistoryIndex) ChangedFiles(sha string) ([]FileChange, error) {
+	var scanned int
+	if err := x.db.QueryRow(`SELECT COUNT(*) FROM file_scans WHERE commit_sha = ?`, sha).Scan(&scanned); err == nil && scanned > 0 {
+		return x.readFiles(sha)
+	}
+
+	files, err := x.coCommitter.ChangedFiles(sha)
+	if err != nil {
+		return nil, err
+	}
+	// Non-fatal: the files are fetched from git again next time
+	_ = storeFiles(x.db, sha, files)
+	return files, nil
+}
+
+// Load returns the indexed events and co-commit correlations selected by
+// opts, oldest first. Limit counts commits that touched the beads file, as
+// in Extractor.Extract.
+func (x *HistoryIndex) Load(opts CorrelatorOptions) ([]BeadEvent, []CorrelatedCommit, error) {
+	where := []string{"touches_beads = 1"}
+	var selectArgs []interface{}
+	if opts.Since != nil {
+		where = append(where, "unix >= ?")
+		selectArgs = append(selectArgs, opts.Since.Unix())
+	}
+	if opts.Until != nil {
+		where = append(where, "unix <= ?")
+		selectArgs = append(selectArgs, opts.Until.Unix())
+	}
+	if opts.BeadID != "" {
+		where = append(where, "sha IN (SELECT commit_sha FROM events WHERE bead_id = ?)")
+		selectArgs = append(selectArgs, opts.BeadID)
+	}
+	selected := "SELECT sha FROM commits WHERE " + strings.Join(where, " AND ") + " ORDER BY seq DESC"
+	if opts.Limit > 0 {
+		selected += " LIMIT ?"
+		selectArgs = append(selectArgs, opts.Limit)
+	}
+
+	eventFilter, correlationFilter := "", ""
+	args := selectArgs
+	if opts.BeadID != "" {
+		eventFilter, correlationFilter = " AND e.bead_id = ?", " AND r.bead_id = ?"
+		args = append(append([]interface{}{}, selectArgs...), opts.BeadID)
+	}
+
+	rows, err := x.db.Query(`
+		SELECT e.bead_id, e.event_type, c.sha, c.timestamp, c.author, c.author_email, c.message
+		FROM events e JOIN commits c ON c.sha = e.commit_sha
+		WHERE c.sha IN (`+selected+`)`+eventFilter+`
+		ORDER BY c.seq, e.ord`, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying indexed events: %w", err)
+	}
+	var events []BeadEvent
+	for rows.Next() {
+		var event BeadEvent
+		var eventType, timestamp string
+		if err := rows.Scan(&event.BeadID, &eventType, &event.CommitSHA, &timestamp, &event.Author, &event.AuthorEmail, &event.CommitMsg); err != nil {
+			rows.Close()
+			return nil, nil, fmt.Errorf("reading indexed events: %w", err)
+		}
+		event.EventType = EventType(eventType)
+		event.Timestamp, _ = time.Parse(time.RFC3339, timestamp)
+		events = append(events, event)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("reading indexed events: %w", err)
+	}
+
+	commits, err := x.queryCorrelations(`
+		WHERE r.source_sha = '' AND c.sha IN (`+selected+`)`+correlationFilter+`
+		ORDER BY c.seq, r.id`, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return events, commits, nil
+}
+
+// LoadRefs returns the indexed trailer and branch-name correlations selected
+// by opts, in the order RefExtractor.Extract returns them. Limit, Since and
+// Until select the trailer and merge commits the links came from.
+func (x *HistoryIndex) LoadRefs(opts CorrelatorOptions) ([]CorrelatedCommit, error) {
+	where := []string{"1 = 1"}
+	var args []interface{}
+	if opts.Since != nil {
+		where = append(where, "unix >= ?")
+		args = append(args, opts.Since.Unix())
+	}
+	if opts.Until != nil {
+		where = append(where, "unix <= ?")
+		args = append(args, opts.Until.Unix())
+	}
+	selected := "SELECT sha FROM commits WHERE " + strings.Join(where, " AND ") + " ORDER BY seq DESC"
+	if opts.Limit > 0 {
+		selected += " LIMIT ?"
+		args = append(args, opts.Limit)
+	}
+	beadFilter := ""
+	if opts.BeadID != "" {
+		beadFilter = " AND lower(r.bead_id) = lower(?)"
+		args = append(args, opts.BeadID)
+	}
+
+	// A commit can be on several merged branches; as in a git scan, the
+	// link from the newest merge wins
+	links, err := x.queryCorrelations(`
+		JOIN commits s ON s.sha = r.source_sha
+		WHERE r.source_sha IN (`+selected+`)`+beadFilter+`
+		ORDER BY c.unix, c.sha, r.bead_id, r.method, s.seq DESC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	var commits []CorrelatedCommit
+	for i, link := range links {
+		if i > 0 {
+			prev := links[i-1]
+			if prev.SHA == link.SHA && prev.BeadID == link.BeadID && prev.Method == link.Method {
+				continue
+			}
+		}
+		commits = append(commits, link)
+	}
+	return commits, nil
+}
+
+// queryCorrelations reads the correlations matched by a query tail over
+// correlations r joined to their commits c, with code files attached
+func (x *HistoryIndex) queryCorrelations(tail string, args ...interface{}) ([]CorrelatedCommit, error) {
+	rows, err := x.db.Query(`
+		SELECT r.bead_id, r.method, r.confidence, r.reason, c.sha, c.timestamp, c.author, c.author_email, c.message
+		FROM correlations r JOIN commits c ON c.sha = r.commit_sha`+tail, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying indexed correlations: %w", err)
+	}
+	var commits []CorrelatedCommit
+	for rows.Next() {
+		var commit CorrelatedCommit
+		var method, timestamp string
+		if err := rows.Scan(&commit.BeadID, &method, &commit.Confidence, &commit.Reason, &commit.SHA, &timestamp, &commit.Author, &commit.AuthorEmail, &commit.Message); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("reading indexed correlations: %w", err)
+		}
+		commit.ShortSHA = shortSHA(commit.SHA)
+		commit.Method = CorrelationMethod(method)
+		commit.Timestamp, _ = time.Parse(time.RFC3339, timestamp)
+		commits = append(commits, commit)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading indexed correlations: %w", err)
+	}
+
+	// Attach code files after the rows are closed; the index uses a single
+	// connection
+	files := make(map[string][]FileChange)
+	for i := range commits {
+		sha := commits[i].SHA
+		if _, ok := files[sha]; !ok {
+			changed, err := x.readFiles(sha)
+			if err != nil {
+				return nil, err
+			}
+			files[sha] = filterCodeFiles(changed)
+		}
+		commits[i].Files = files[sha]
+	}
+	return commits, nil
+}
+
+// LoadGroups returns the groups merged by the mainline commits selected by
+// opts, newest first, as GroupExtractor.Extract does. BeadID is ignored:
+// groups are linked to beads through the report's commit index.
+func (x *HistoryIndex) LoadGroups(opts CorrelatorOptions) ([]CommitGroup, error) {
+	where := []string{"1 = 1"}
+	var args []interface{}
+	if opts.Since != nil {
+		where = append(where, "committed >= ?")
+		args = append(args, opts.Since.Unix())
+	}
+	if opts.Until != nil {
+		where = append(where, "committed <= ?")
+		args = append(args, opts.Until.Unix())
+	}
+	selected := "SELECT sha, seq FROM mainline WHERE " + strings.Join(where, " AND ") + " ORDER BY seq DESC"
+	if opts.Limit > 0 {
+		selected += " LIMIT ?"
+		args = append(args, opts.Limit)
+	}
+
+	rows, err := x.db.Query(`
+		SELECT g.merge_sha, g.kind, g.pr_number, g.title, g.branch, g.author, g.first_commit_at, g.merged_at, g.members
+		FROM commit_groups g
+		JOIN (`+selected+`) m ON m.sha = g.merge_sha
+		ORDER BY m.seq DESC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("reading indexed groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []CommitGroup
+	for rows.Next() {
+		var g CommitGroup
+		var kind, firstCommitAt, mergedAt, members string
+		if err := rows.Scan(&g.MergeSHA, &kind, &g.PRNumber, &g.Title, &g.Branch, &g.Author, &firstCommitAt, &mergedAt, &members); err != nil {
+			return nil, fmt.Errorf("reading indexed groups: %w", err)
+		}
+		g.Kind = GroupKind(kind)
+		g.FirstCommitAt, _ = time.Parse(time.RFC3339, firstCommitAt)
+		g.MergedAt, _ = time.Parse(time.RFC3339, mergedAt)
+		g.Commits = strings.Fields(members)
+		g.ID = shortSHA(g.MergeSHA)
+		if g.PRNumber > 0 {
+			g.ID = fmt.Sprintf("#%d", g.PRNumber)
+		}
+		if g.CycleTime = g.MergedAt.Sub(g.FirstCommitAt); g.CycleTime < 0 {
+			g.CycleTime = 0
+		}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// dropStaleTables drops the tables of an index written by another version,
+// whose schema may differ. The metadata is kept so Update rebuilds it.
+func dropStaleTables(db *sql.DB) error {
+	var version string
+	err := db.QueryRow(`SELECT value FROM meta WHERE key = 'version'`).Scan(&version)
+	if err != nil || version == historyIndexVersion {
+		// A new index has no meta table yet
+		return nil
+	}
+	for table := range historyIndexTables {
+		if _, err := db.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+			return fmt.Errorf("dropping stale %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// readMeta returns the index metadata
+func (x *HistoryIndex) readMeta() (map[string]string, error) {
+	rows, err := x.db.Query(`SELECT key, value FROM meta`)
+	if err != nil {
+		return nil, fmt.Errorf("reading index metadata: %w", err)
+	}
+	defer rows.Close()
+
+	meta := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("reading index metadata: %w", err)
+		}
+		meta[key] = value
+	}
+	return meta, rows.Err()
+}
+
+// readFiles returns the stored files of a commit
+func (x *HistoryIndex) readFiles(sha string) ([]FileChange, error) {
+	rows, err := x.db.Query(`SELECT path, action, insertions, deletions FROM commit_files WHERE commit_sha = ? ORDER BY ord`, sha)
+	if err != nil {
+		return nil, fmt.Errorf("reading files of %s: %w", shortSHA(sha), err)
+	}
+	defer rows.Close()
+
+	var files []FileChange
+	for rows.Next() {
+		var f FileChange
+		if err := rows.Scan(&f.Path, &f.Action, &f.Insertions, &f.Deletions); err != nil {
+			return nil, fmt.Errorf("reading files of %s: %w", shortSHA(sha), err)
+		}
+		files = append(files, f)
+	}
+	return files, rows.Err()
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// storeCorrelation adds a correlation unless it is already stored; source is
+// the commit a ref link came from, or empty for co-commits
+func storeCorrelation(db execer, commit CorrelatedCommit, source string) error {
+	if _, err := db.Exec(
+		`INSERT OR IGNORE INTO correlations (commit_sha, source_sha, bead_id, method, confidence, reason) VALUES (?, ?, ?, ?, ?, ?)`,
+		commit.SHA, source, commit.BeadID, string(commit.Method), commit.Confidence, commit.Reason,
+	); err != nil {
+		return fmt.Errorf("indexing correlations of %s: %w", shortSHA(commit.SHA), err)
+	}
+	return nil
+}
+
+// storeMainline adds a mainline commit and the group it merged, if any
+func storeMainline(db execer, commit mainlineCommit, seq int64) error {
+	if _, err := db.Exec(
+		`INSERT OR REPLACE INTO mainline (sha, seq, committed) VALUES (?, ?, ?)`,
+		commit.SHA, seq, commit.CommittedAt.Unix(),
+	); err != nil {
+		return fmt.Errorf("indexing mainline commit %s: %w", shortSHA(commit.SHA), err)
+	}
+	if commit.Group == nil {
+		return nil
+	}
+	g := commit.Group
+	if _, err := db.Exec(
+		`INSERT OR REPLACE INTO commit_groups (merge_sha, kind, pr_number, title, branch, author, first_commit_at, merged_at, members) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		g.MergeSHA, string(g.Kind), g.PRNumber, g.Title, g.Branch, g.Author,
+		g.FirstCommitAt.Format(time.RFC3339), g.MergedAt.Format(time.RFC3339), strings.Join(g.Commits, " "),
+	); err != nil {
+		return fmt.Errorf("indexing group of %s: %w", shortSHA(g.MergeSHA), err)
+	}
+	return nil
+}
+
+// storeFiles replaces the stored files of a commit
+func storeFiles(db execer, sha string, files []FileChange) error {
+	if _, err := db.Exec(`DELETE FROM commit_files WHERE commit_sha = ?`, sha); err != nil {
+		return fmt.Errorf("storing files of %s: %w", shortSHA(sha), err)
+	}
+	for i, f := range files {
+		if _, err := db.Exec(
+			`INSERT INTO commit_files (commit_sha, ord, path, action, insertions, deletions) VALUES (?, ?, ?, ?, ?, ?)`,
+			sha, i, f.Path, f.Action, f.Insertions, f.Deletions,
+		); err != nil {
+			return fmt.Errorf("storing files of %s: %w", shortSHA(sha), err)
+		}
+	}
+	if _, err := db.Exec(`INSERT OR IGNORE INTO file_scans (commit_sha) VALUES (?)`, sha); err != nil {
+		return fmt.Errorf("storing files of %s: %w", shortSHA(sha), err)
+	}
+	return nil
+}
+
+// listCommits returns the commits in revRange that touched the beads file,
+// oldest first
+func (x *HistoryIndex) listCommits(revRange string) ([]commitInfo, error) {
+	cmd := exec.Command("git", "log", "--follow", "--format="+gitLogHeaderFormat, revRange, "--", x.beadsFile)
+	cmd.Dir = x.repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing commits: %w", err)
+	}
+
+	var commits []commitInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), gitLogMaxScanTokenSize)
+	for scanner.Scan() {
+		if info, err := parseCommitInfo(scanner.Text()); err == nil {
+			commits = append(commits, info)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading commit list: %w", err)
+	}
+
+	// git log lists newest first
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// isAncestor reports whether ancestor is reachable from commit
+func isAncestor(repoPath, ancestor, commit string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", ancestor, commit)
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
+
+// mergeBase returns the best common ancestor of two commits
+func mergeBase(repoPath, a, b string) (string, error) {
+	cmd := exec.Command("git", "merge-base", a, b)
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git merge-base failed: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// revList returns every commit in revRange
+func revList(repoPath, revRange string) ([]string, error) {
+	cmd := exec.Command("git", "rev-list", revRange)
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git rev-list failed: %w", err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// IndexedCorrelator generates history reports from the persistent history
+// index, bringing it up to date first. When the index cannot be opened or
+// updated it falls back to a full scan with Correlator.
+type IndexedCorrelator struct {
+	correlator    *Correlator
+	repoPath      string
+	beadsFilePath []string
+	index         *HistoryIndex
+	lastUpdate    *IndexUpdate
+}
+
+// NewIndexedCorrelator creates an indexed correlator for the given
+// repository. beadsFilePath is optional, as for NewCorrelator.
+func NewIndexedCorrelator(repoPath string, beadsFilePath ...string) *IndexedCorrelator {
+	return &IndexedCorrelator{
+		correlator:    NewCorrelator(repoPath, beadsFilePath...),
+		repoPath:      repoPath,
+		beadsFilePath: beadsFilePath,
+	}
+}
+
+// GenerateReport generates a history report from the index
+func (ic *IndexedCorrelator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*HistoryReport, error) {
+	if ic.index == nil {
+		index, err := OpenHistoryIndex(ic.repoPath, ic.beadsFilePath...)
+		if err != nil {
+			return ic.correlator.GenerateReport(beads, opts)
+		}
+		ic.index = index
+	}
+
+	update, err := ic.index.Update()
+	if err != nil {
+		ic.Close()
+		return ic.correlator.GenerateReport(beads, opts)
+	}
+	ic.lastUpdate = update
+
+	events, commits, err := ic.index.Load(opts)
+	if err != nil {
+		ic.Close()
+		return ic.correlator.GenerateReport(beads, opts)
+	}
+	refCommits, err := ic.index.LoadRefs(opts)
+	if err != nil {
+		ic.Close()
+		return ic.correlator.GenerateReport(beads, opts)
+	}
+	canonicalBeadIDs(beads, refCommits)
+	commits = mergeRefCommits(commits, refCommits)
+	groups, err := ic.index.LoadGroups(opts)
+	if err != nil {
+		ic.Close()
+		return ic.correlator.GenerateReport(beads, opts)
+	}
+	report := ic.correlator.buildReport(beads, opts, events, commits)
+	setGroups(report, groups)
+	return report, nil
+}
+
+// Index returns the open history index, or nil if reports fell back to git
+func (ic *IndexedCorrelator) Index() *HistoryIndex {
+	return ic.index
+}
+
+// LastUpdate returns what the last report's index update did, or nil if
+// it fell back to git
+func (ic *IndexedCorrelator) LastUpdate() *IndexUpdate {
+	if ic.index == nil {
+		return nil
+	}
+	return ic.lastUpdate
+}
+
+// Close closes the index, if open
+func (ic *IndexedCorrelator) Close() error {
+	if ic.index == nil {
+		return nil
+	}
+	err := ic.index.Close()
+	ic.index = nil
+	return err
+}
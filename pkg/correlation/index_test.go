@@ -0,0 +1,329 @@
+package correlation
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// indexTestRepo is a git repository with a beads file for index tests
+type indexTestRepo struct {
+	t   *testing.T
+	dir string
+}
+
+func newIndexTestRepo(t *testing.T) *indexTestRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	r := &indexTestRepo{t: t, dir: t.TempDir()}
+	r.git("init", "-q")
+	r.git("config", "user.email", "test@test.com")
+	r.git("config", "user.name", "Test User")
+	// Keep the index out of commits, as the .gitignore entry does
+	r.write(".git/info/exclude", ".bv/\n")
+	if err := os.MkdirAll(filepath.Join(r.dir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func (r *indexTestRepo) git(args ...string) string {
+	r.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes the beads (id -> status) and a code file, then commits them
+func (r *indexTestRepo) commit(message, codeFile string, beads ...[2]string) {
+	r.t.Helper()
+	var lines []string
+	for _, b := range beads {
+		lines = append(lines, `{"id":"`+b[0]+`","title":"Bead `+b[0]+`","status":"`+b[1]+`"}`)
+	}
+	r.write(".beads/issues.jsonl", strings.Join(lines, "\n")+"\n")
+	if codeFile != "" {
+		r.write(codeFile, message+"\n")
+	}
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", message)
+}
+
+func (r *indexTestRepo) write(name, content string) {
+	r.t.Helper()
+	path := filepath.Join(r.dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		r.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+var indexTestBeads = []BeadInfo{
+	{ID: "bv-1", Title: "Bead bv-1", Status: "closed"},
+	{ID: "bv-2", Title: "Bead bv-2", Status: "in_progress"},
+}
+
+// assertSameReport checks that the indexed report matches a full git scan
+func assertSameReport(t *testing.T, r *indexTestRepo, ic *IndexedCorrelator, opts CorrelatorOptions) *HistoryReport {
+	t.Helper()
+	want, err := NewCorrelator(r.dir).GenerateReport(indexTestBeads, opts)
+	if err != nil {
+		t.Fatalf("Correlator.GenerateReport() error = %v", err)
+	}
+	got, err := ic.GenerateReport(indexTestBeads, opts)
+	if err != nil {
+		t.Fatalf("IndexedCorrelator.GenerateReport() error = %v", err)
+	}
+	if ic.Index() == nil {
+		t.Fatal("IndexedCorrelator fell back to a git scan")
+	}
+
+	got.GeneratedAt = want.GeneratedAt
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("indexed report differs from git scan\n got: %s\nwant: %s", gotJSON, wantJSON)
+	}
+	return got
+}
+
+func TestIndexedCorrelator_MatchesGitScan(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create beads", "", [2]string{"bv-1", "open"}, [2]string{"bv-2", "open"})
+	r.commit("Start bv-1", "pkg/auth/login.go", [2]string{"bv-1", "in_progress"}, [2]string{"bv-2", "open"})
+	r.commit("Finish bv-1", "pkg/auth/session.go", [2]string{"bv-1", "closed"}, [2]string{"bv-2", "open"})
+
+	ic := NewIndexedCorrelator(r.dir)
+	defer ic.Close()
+
+	report := assertSameReport(t, r, ic, CorrelatorOptions{})
+	if update := ic.LastUpdate(); update.Mode != IndexRebuilt || update.NewCommits != 3 {
+		t.Errorf("first update = %+v, want a rebuild of 3 commits", update)
+	}
+	if got := len(report.Histories["bv-1"].Commits); got != 2 {
+		t.Errorf("bv-1 has %d correlated commits, want 2", got)
+	}
+
+	assertSameReport(t, r, ic, CorrelatorOptions{})
+	if update := ic.LastUpdate(); update.Mode != IndexCurrent {
+		t.Errorf("second update mode = %s, want %s", update.Mode, IndexCurrent)
+	}
+
+	assertSameReport(t, r, ic, CorrelatorOptions{Limit: 2})
+	assertSameReport(t, r, ic, CorrelatorOptions{BeadID: "bv-1"})
+}
+
+func TestIndexedCorrelator_Incremental(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create beads", "", [2]string{"bv-1", "open"}, [2]string{"bv-2", "open"})
+
+	ic := NewIndexedCorrelator(r.dir)
+	defer ic.Close()
+	assertSameReport(t, r, ic, CorrelatorOptions{})
+
+	r.commit("Start bv-2", "cmd/main.go", [2]string{"bv-1", "open"}, [2]string{"bv-2", "in_progress"})
+	r.write("README.md", "unrelated\n")
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", "Docs only")
+
+	assertSameReport(t, r, ic, CorrelatorOptions{})
+	update := ic.LastUpdate()
+	if update.Mode != IndexIncremental || update.NewCommits != 1 {
+		t.Errorf("update = %+v, want 1 incremental commit", update)
+	}
+
+	// A fresh process reuses the index on disk
+	reopened := NewIndexedCorrelator(r.dir)
+	defer reopened.Close()
+	assertSameReport(t, r, reopened, CorrelatorOptions{})
+	if mode := reopened.LastUpdate().Mode; mode != IndexCurrent {
+		t.Errorf("reopened update mode = %s, want %s", mode, IndexCurrent)
+	}
+}
+
+func TestIndexedCorrelator_RecoversAfterRebase(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create beads", "", [2]string{"bv-1", "open"}, [2]string{"bv-2", "open"})
+	r.commit("Start bv-1", "pkg/a.go", [2]string{"bv-1", "in_progress"}, [2]string{"bv-2", "open"})
+	r.commit("Close bv-1", "pkg/b.go", [2]string{"bv-1", "closed"}, [2]string{"bv-2", "open"})
+
+	ic := NewIndexedCorrelator(r.dir)
+	defer ic.Close()
+	assertSameReport(t, r, ic, CorrelatorOptions{})
+
+	// Rewrite the last two commits
+	r.git("reset", "-q", "--hard", "HEAD~2")
+	r.commit("Start bv-2 instead", "pkg/c.go", [2]string{"bv-1", "open"}, [2]string{"bv-2", "in_progress"})
+
+	report := assertSameReport(t, r, ic, CorrelatorOptions{})
+	update := ic.LastUpdate()
+	if update.Mode != IndexRecovered || update.DroppedCommits != 2 || update.NewCommits != 1 {
+		t.Errorf("update = %+v, want recovery dropping 2 commits and indexing 1", update)
+	}
+	if got := len(report.Histories["bv-1"].Commits); got != 0 {
+		t.Errorf("bv-1 still has %d commits from rewritten history", got)
+	}
+}
+
+func TestHistoryIndex_ChangedFiles(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create beads", "src/app.go", [2]string{"bv-1", "open"})
+
+	index, err := OpenHistoryIndex(r.dir)
+	if err != nil {
+		t.Fatalf("OpenHistoryIndex() error = %v", err)
+	}
+	defer index.Close()
+
+	head := r.git("rev-parse", "HEAD")
+	for i := 0; i < 2; i++ {
+		files, err := index.ChangedFiles(head)
+		if err != nil {
+			t.Fatalf("ChangedFiles() error = %v", err)
+		}
+		if len(files) != 2 {
+			t.Errorf("ChangedFiles() = %+v, want 2 files", files)
+		}
+	}
+
+	var stored int
+	if err := index.db.QueryRow(`SELECT COUNT(*) FROM commit_files WHERE commit_sha = ?`, head).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored != 2 {
+		t.Errorf("stored %d files, want 2", stored)
+	}
+}
+
+func TestIndexedCorrelator_RefLinks(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create beads", "", [2]string{"bv-1", "open"}, [2]string{"bv-2", "open"})
+	r.commit("Start bv-1\n\nBead: bv-1", "pkg/a.go", [2]string{"bv-1", "in_progress"}, [2]string{"bv-2", "open"})
+
+	ic := NewIndexedCorrelator(r.dir)
+	defer ic.Close()
+	assertSameReport(t, r, ic, CorrelatorOptions{})
+
+	// Branch commits indexed before their merge are linked by it
+	r.git("checkout", "-q", "-b", "feature/bv-2-export")
+	r.write("pkg/export.go", "export\n")
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", "Add export")
+	assertSameReport(t, r, ic, CorrelatorOptions{})
+	r.git("checkout", "-q", "-")
+	r.write("README.md", "docs\n")
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", "Docs\n\nBeads: bv-2")
+	r.git("merge", "-q", "--no-ff", "--no-edit", "feature/bv-2-export")
+
+	report := assertSameReport(t, r, ic, CorrelatorOptions{})
+	if mode := ic.LastUpdate().Mode; mode != IndexIncremental {
+		t.Errorf("update mode = %s, want %s", mode, IndexIncremental)
+	}
+	methods := make(map[CorrelationMethod]int)
+	for _, c := range report.Histories["bv-2"].Commits {
+		methods[c.Method]++
+	}
+	if methods[MethodTrailer] != 1 || methods[MethodBranchName] != 1 {
+		t.Errorf("bv-2 methods = %v, want 1 trailer and 1 branch_name commit", methods)
+	}
+	// The merge's group is stored with the commits it brought in
+	if len(report.Groups) != 1 || report.Groups[0].Branch != "feature/bv-2-export" {
+		t.Fatalf("groups = %+v, want the feature/bv-2-export merge", report.Groups)
+	}
+	var stored int
+	if err := ic.Index().db.QueryRow(`SELECT COUNT(*) FROM commit_groups`).Scan(&stored); err != nil {
+		t.Fatalf("counting stored groups: %v", err)
+	}
+	if stored != 1 {
+		t.Errorf("stored %d groups, want 1", stored)
+	}
+
+	assertSameReport(t, r, ic, CorrelatorOptions{Limit: 1})
+	assertSameReport(t, r, ic, CorrelatorOptions{BeadID: "bv-2"})
+
+	// Resetting past the merge drops its branch links
+	r.git("reset", "-q", "--hard", "HEAD~1")
+	report = assertSameReport(t, r, ic, CorrelatorOptions{})
+	for _, c := range report.Histories["bv-2"].Commits {
+		if c.Method == MethodBranchName {
+			t.Errorf("branch link %s survived the reset", c.ShortSHA)
+		}
+	}
+	if len(report.Groups) != 0 {
+		t.Errorf("groups = %+v, want none after the reset", report.Groups)
+	}
+}
+
+func TestHistoryIndex_ConcurrentUpdates(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create beads", "", [2]string{"bv-1", "open"}, [2]string{"bv-2", "open"})
+	r.commit("Start bv-1\n\nBead: bv-1", "pkg/auth/login.go", [2]string{"bv-1", "in_progress"}, [2]string{"bv-2", "open"})
+	r.commit("Finish bv-1", "pkg/auth/session.go", [2]string{"bv-1", "closed"}, [2]string{"bv-2", "open"})
+
+	// Two processes, such as the TUI and a robot command, update at once
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			index, err := OpenHistoryIndex(r.dir)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer index.Close()
+			_, err = index.Update()
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	index, err := OpenHistoryIndex(r.dir)
+	if err != nil {
+		t.Fatalf("OpenHistoryIndex() error = %v", err)
+	}
+	defer index.Close()
+	var total, distinct int
+	if err := index.db.QueryRow(`SELECT COUNT(*), COUNT(DISTINCT commit_sha || bead_id || method || source_sha) FROM correlations`).Scan(&total, &distinct); err != nil {
+		t.Fatal(err)
+	}
+	if total == 0 || total != distinct {
+		t.Errorf("stored %d correlations, %d distinct; want each once", total, distinct)
+	}
+
+	// Storing a correlation again is a no-op
+	head := r.git("rev-parse", "HEAD")
+	link := CorrelatedCommit{SHA: head, BeadID: "bv-1", Method: MethodTrailer, Confidence: 1}
+	for i := 0; i < 2; i++ {
+		if err := storeCorrelation(index.db, link, head); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var stored int
+	if err := index.db.QueryRow(`SELECT COUNT(*) FROM correlations WHERE commit_sha = ? AND source_sha = ?`, head, head).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored != 1 {
+		t.Errorf("stored the same correlation %d times, want 1", stored)
+	}
+}
@@ -40,9 +40,6 @@ var (
 		{regexp.MustCompile(`\bbv-[a-z0-9]+\b`), 25},   // bv-xxx pattern
 		{regexp.MustCompile(`\bbeads?[-_]?\d+\b`), 25}, // bead-123 pattern
 	}
-
-	// Pattern for extracting specific bead IDs from messages
-	orphanBeadIDPattern = regexp.MustCompile(`(?i)\bbv-([a-z0-9]{4,8})\b`) // Case-insensitive
 )
 
 // OrphanCandidate represents a commit that might be missing a bead linkage.
@@ -105,6 +102,14 @@ type OrphanDetector struct {
 	fileLookup  *FileLookup
 	beadWindows map[string]TemporalWindow // BeadID -> active time window
 	authorBeads map[string][]string       // Author email -> BeadIDs they worked on
+	patterns    *PatternSet               // Bead ID patterns for message matching
+	files       CommitFileSource          // Where changed files of orphans come from
+}
+
+// CommitFileSource lists the files a commit changed. CoCommitExtractor asks
+// git each time; HistoryIndex keeps the answers.
+type CommitFileSource interface {
+	ChangedFiles(sha string) ([]FileChange, error)
 }
 
 // NewOrphanDetector creates a detector from a history report.
@@ -125,6 +130,8 @@ func newOrphanDetector(report *HistoryReport, repoPath string) *OrphanDetector {
 		fileLookup:  NewFileLookup(report),
 		beadWindows: make(map[string]TemporalWindow),
 		authorBeads: make(map[string][]string),
+		patterns:    DefaultPatternSet(),
+		files:       NewCoCommitExtractor(repoPath),
 	}
 
 	// Build temporal windows for each bead
@@ -343,19 +350,28 @@ func (od *OrphanDetector) checkFiles(candidate *OrphanCandidate, beadScores map[
 	}
 }
 
+// SetPatterns replaces the bead ID patterns used to spot IDs in commit
+// messages, e.g. with those from .bv/correlation.yaml.
+func (od *OrphanDetector) SetPatterns(set *PatternSet) {
+	od.patterns = set
+}
+
 // checkMessage checks if commit message contains bead-like patterns.
 func (od *OrphanDetector) checkMessage(candidate *OrphanCandidate, beadScores map[string]*probableBeadBuilder) {
 	msg := strings.ToLower(candidate.Message)
 
-	// Look for bead-like patterns (using pre-compiled regexes)
+	// Look for bead-like patterns (using pre-compiled regexes), ignoring
+	// deny-listed tokens such as utf-8
 	totalWeight := 0
 	var matchDetails []string
 
 	for _, p := range orphanMessagePatterns {
-		if p.re.MatchString(msg) {
-			totalWeight += p.weight
-			match := p.re.FindString(msg)
-			matchDetails = append(matchDetails, match)
+		for _, match := range p.re.FindAllString(msg, -1) {
+			if !od.patterns.Deny.Denies(match) {
+				totalWeight += p.weight
+				matchDetails = append(matchDetails, match)
+				break
+			}
 		}
 	}
 
@@ -367,22 +383,19 @@ func (od *OrphanDetector) checkMessage(candidate *OrphanCandidate, beadScores ma
 		})
 	}
 
-	// Try to match specific bead IDs mentioned in message (case-insensitive)
-	matches := orphanBeadIDPattern.FindAllStringSubmatch(msg, -1)
-	for _, match := range matches {
-		if len(match) >= 2 {
-			beadID := "bv-" + strings.ToLower(match[1]) // Normalize to lowercase
-			if history, ok := od.lookup.beads[beadID]; ok {
-				if _, exists := beadScores[beadID]; !exists {
-					beadScores[beadID] = &probableBeadBuilder{
-						title:  history.Title,
-						status: history.Status,
-					}
+	// Try to match specific bead IDs mentioned in message (normalized to lowercase)
+	for _, match := range od.patterns.Extract(candidate.Message) {
+		beadID := match.ID
+		if history, ok := od.lookup.beads[beadID]; ok {
+			if _, exists := beadScores[beadID]; !exists {
+				beadScores[beadID] = &probableBeadBuilder{
+					title:  history.Title,
+					status: history.Status,
 				}
-				beadScores[beadID].score += 35
-				beadScores[beadID].reasons = append(beadScores[beadID].reasons,
-					"bead ID mentioned in commit message")
 			}
+			beadScores[beadID].score += 35
+			beadScores[beadID].reasons = append(beadScores[beadID].reasons,
+				"bead ID mentioned in commit message")
 		}
 	}
 }
@@ -436,10 +449,15 @@ func (od *OrphanDetector) checkAuthor(candidate *OrphanCandidate, beadScores map
 	}
 }
 
+// SetFileSource replaces where the detector looks up the files a commit
+// changed, e.g. with a HistoryIndex.
+func (od *OrphanDetector) SetFileSource(src CommitFileSource) {
+	od.files = src
+}
+
 // getCommitFiles returns files changed in a commit.
 func (od *OrphanDetector) getCommitFiles(sha string) []string {
-	cocommit := &CoCommitExtractor{repoPath: od.repoPath}
-	fileChanges, err := cocommit.getFilesChanged(sha)
+	fileChanges, err := od.files.ChangedFiles(sha)
 	if err != nil {
 		return nil
 	}
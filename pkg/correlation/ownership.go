@@ -0,0 +1,469 @@
+// Package correlation provides code ownership derived from bead-to-file
+// correlations.
+package correlation
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OwnershipBead carries the bead fields ownership analysis needs beyond the
+// history report.
+type OwnershipBead struct {
+	ID          string
+	Title       string
+	Description string
+	Assignee    string
+	Labels      []string
+	Closed      bool
+}
+
+// OwnershipOptions configures ownership analysis
+type OwnershipOptions struct {
+	Depth      int           // Directory depth files are grouped at (default 2)
+	StaleAfter time.Duration // Owners who touched an area within this window are recent (default 90 days)
+	MaxOwners  int           // Owners and labels listed per area (default 3)
+	Now        time.Time     // Reference time for recency (default time.Now())
+}
+
+// DefaultOwnershipOptions returns sensible defaults
+func DefaultOwnershipOptions() OwnershipOptions {
+	return OwnershipOptions{
+		Depth:      2,
+		StaleAfter: 90 * 24 * time.Hour,
+		MaxOwners:  3,
+	}
+}
+
+// AreaOwner is a person who committed to an area
+type AreaOwner struct {
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	Commits   int       `json:"commits"`
+	Changes   int       `json:"changes"` // Lines inserted + deleted in the area
+	Share     float64   `json:"share"`   // Fraction of the area's commits (0.0 - 1.0)
+	LastTouch time.Time `json:"last_touch"`
+	Recent    bool      `json:"recent"` // Touched the area within the stale window
+}
+
+// AreaLabel is a label carried by beads whose commits touched an area
+type AreaLabel struct {
+	Label string `json:"label"`
+	Beads int    `json:"beads"`
+}
+
+// OwnershipArea is a directory with the people and labels that touched it most
+type OwnershipArea struct {
+	Path           string      `json:"path"` // "." for files at the repository root
+	Files          int         `json:"files"`
+	Commits        int         `json:"commits"`
+	Beads          int         `json:"beads"`
+	Owners         []AreaOwner `json:"owners"`
+	Labels         []AreaLabel `json:"labels"`
+	LastTouch      time.Time   `json:"last_touch"`
+	HasRecentOwner bool        `json:"has_recent_owner"`
+}
+
+// AssigneeSuggestion proposes an owner for an unassigned open bead
+type AssigneeSuggestion struct {
+	BeadID     string   `json:"bead_id"`
+	Title      string   `json:"title"`
+	Assignee   string   `json:"assignee"`
+	Email      string   `json:"email,omitempty"`
+	Paths      []string `json:"paths"` // Paths mentioned in the bead
+	Areas      []string `json:"areas"` // Areas those paths fall in
+	Confidence float64  `json:"confidence"`
+	Reason     string   `json:"reason"`
+}
+
+// UnownedBead is an open bead in areas nobody has touched recently
+type UnownedBead struct {
+	BeadID   string   `json:"bead_id"`
+	Title    string   `json:"title"`
+	Assignee string   `json:"assignee,omitempty"`
+	Areas    []string `json:"areas"` // The bead's areas without a recent owner
+	Reason   string   `json:"reason"`
+}
+
+// OwnershipStats summarizes an ownership report
+type OwnershipStats struct {
+	TotalAreas          int `json:"total_areas"`
+	AreasWithoutOwner   int `json:"areas_without_recent_owner"`
+	UniqueOwners        int `json:"unique_owners"`
+	SuggestedAssignees  int `json:"suggested_assignees"`
+	BeadsInUnownedAreas int `json:"beads_in_unowned_areas"`
+}
+
+// OwnershipReport maps directories to their owners and flags open work in
+// areas without one.
+type OwnershipReport struct {
+	GeneratedAt    time.Time            `json:"generated_at"`
+	Depth          int                  `json:"depth"`
+	StaleAfterDays int                  `json:"stale_after_days"`
+	Areas          []OwnershipArea      `json:"areas"` // Most commits first
+	Suggestions    []AssigneeSuggestion `json:"suggestions"`
+	Unowned        []UnownedBead        `json:"unowned"`
+	Stats          OwnershipStats       `json:"stats"`
+}
+
+// mentionedPathPattern matches slash-separated paths such as pkg/ui or
+// cmd/bv/main.go in bead text
+var mentionedPathPattern = regexp.MustCompile(`[A-Za-z0-9_.\-]+(?:/[A-Za-z0-9_.\-]+)+/?`)
+
+// areaStats accumulates one area while the report is built
+type areaStats struct {
+	files   map[string]bool
+	commits map[string]bool
+	owners  map[string]*AreaOwner
+	beads   map[string]bool
+	last    time.Time
+}
+
+// BuildOwnershipReport groups the files in a history report into areas of
+// opts.Depth directories and ranks who committed to each. Open beads that
+// mention paths in their title or description get an assignee suggestion
+// when unassigned, and are flagged when their areas have no recent owner.
+func BuildOwnershipReport(report *HistoryReport, beads []OwnershipBead, opts OwnershipOptions) *OwnershipReport {
+	defaults := DefaultOwnershipOptions()
+	if opts.Depth <= 0 {
+		opts.Depth = defaults.Depth
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = defaults.StaleAfter
+	}
+	if opts.MaxOwners <= 0 {
+		opts.MaxOwners = defaults.MaxOwners
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	result := &OwnershipReport{
+		GeneratedAt:    opts.Now,
+		Depth:          opts.Depth,
+		StaleAfterDays: int(opts.StaleAfter.Hours() / 24),
+		Areas:          []OwnershipArea{},
+		Suggestions:    []AssigneeSuggestion{},
+		Unowned:        []UnownedBead{},
+	}
+	if report == nil {
+		return result
+	}
+
+	// Authors per area, counting each change once even when its commit is
+	// linked to several beads
+	areas := make(map[string]*areaStats)
+	seen := make(map[string]bool)
+	for _, history := range report.Histories {
+		for _, commit := range history.Commits {
+			for _, fc := range commit.Files {
+				file := normalizePath(fc.Path)
+				if file == "" || seen[commit.SHA+"\x00"+file] {
+					continue
+				}
+				seen[commit.SHA+"\x00"+file] = true
+				area := areas[areaOf(file, opts.Depth)]
+				if area == nil {
+					area = &areaStats{
+						files:   make(map[string]bool),
+						commits: make(map[string]bool),
+						owners:  make(map[string]*AreaOwner),
+						beads:   make(map[string]bool),
+					}
+					areas[areaOf(file, opts.Depth)] = area
+				}
+				area.addCommit(file, commit, fc)
+			}
+		}
+	}
+
+	// Labels per area come from the beads that touched its files
+	byID := make(map[string]OwnershipBead, len(beads))
+	for _, bead := range beads {
+		byID[bead.ID] = bead
+	}
+	beadAreas := make(map[string][]string)
+	for file, refs := range BuildFileIndex(report).FileToBeads {
+		areaPath := areaOf(file, opts.Depth)
+		area := areas[areaPath]
+		if area == nil {
+			continue
+		}
+		for _, ref := range refs {
+			area.beads[ref.BeadID] = true
+			beadAreas[ref.BeadID] = appendUnique(beadAreas[ref.BeadID], areaPath)
+		}
+	}
+
+	owners := make(map[string]bool)
+	for areaPath, stats := range areas {
+		for key := range stats.owners {
+			owners[key] = true
+		}
+		result.Areas = append(result.Areas, stats.build(areaPath, byID, opts))
+	}
+	sort.Slice(result.Areas, func(i, j int) bool {
+		if result.Areas[i].Commits != result.Areas[j].Commits {
+			return result.Areas[i].Commits > result.Areas[j].Commits
+		}
+		return result.Areas[i].Path < result.Areas[j].Path
+	})
+
+	// Open beads: suggest assignees and flag unowned areas
+	for _, bead := range beads {
+		if bead.Closed {
+			continue
+		}
+		paths, mentioned := mentionedAreas(bead.Title+"\n"+bead.Description, areas, opts.Depth)
+		if bead.Assignee == "" && len(mentioned) > 0 {
+			if suggestion, ok := suggestAssignee(bead, paths, mentioned, areas, opts); ok {
+				result.Suggestions = append(result.Suggestions, suggestion)
+			}
+		}
+
+		// The bead's areas are those it mentions and those its commits touched
+		all := append([]string(nil), mentioned...)
+		for _, areaPath := range beadAreas[bead.ID] {
+			all = appendUnique(all, areaPath)
+		}
+		var unowned []string
+		for _, areaPath := range all {
+			if !areas[areaPath].hasRecentOwner(opts) {
+				unowned = append(unowned, areaPath)
+			}
+		}
+		if len(unowned) > 0 {
+			sort.Strings(unowned)
+			result.Unowned = append(result.Unowned, UnownedBead{
+				BeadID:   bead.ID,
+				Title:    bead.Title,
+				Assignee: bead.Assignee,
+				Areas:    unowned,
+				Reason:   fmt.Sprintf("No commits to %s in the last %d days", strings.Join(unowned, ", "), result.StaleAfterDays),
+			})
+		}
+	}
+	sort.Slice(result.Suggestions, func(i, j int) bool {
+		if result.Suggestions[i].Confidence != result.Suggestions[j].Confidence {
+			return result.Suggestions[i].Confidence > result.Suggestions[j].Confidence
+		}
+		return result.Suggestions[i].BeadID < result.Suggestions[j].BeadID
+	})
+	sort.Slice(result.Unowned, func(i, j int) bool {
+		return result.Unowned[i].BeadID < result.Unowned[j].BeadID
+	})
+
+	result.Stats = OwnershipStats{
+		TotalAreas:          len(result.Areas),
+		UniqueOwners:        len(owners),
+		SuggestedAssignees:  len(result.Suggestions),
+		BeadsInUnownedAreas: len(result.Unowned),
+	}
+	for _, area := range result.Areas {
+		if !area.HasRecentOwner {
+			result.Stats.AreasWithoutOwner++
+		}
+	}
+	return result
+}
+
+// addCommit records a commit's change to one file of the area
+func (a *areaStats) addCommit(file string, commit CorrelatedCommit, fc FileChange) {
+	a.files[file] = true
+	if commit.Timestamp.After(a.last) {
+		a.last = commit.Timestamp
+	}
+
+	key := ownerKey(commit.Author, commit.AuthorEmail)
+	owner := a.owners[key]
+	if owner == nil {
+		owner = &AreaOwner{Name: commit.Author, Email: commit.AuthorEmail}
+		a.owners[key] = owner
+	}
+	owner.Changes += fc.Insertions + fc.Deletions
+	if commit.Timestamp.After(owner.LastTouch) {
+		owner.LastTouch = commit.Timestamp
+	}
+	if !a.commits[commit.SHA] {
+		a.commits[commit.SHA] = true
+		owner.Commits++
+	}
+}
+
+// hasRecentOwner reports whether anyone touched the area within the stale
+// window; a nil area has never been touched
+func (a *areaStats) hasRecentOwner(opts OwnershipOptions) bool {
+	return a != nil && opts.Now.Sub(a.last) <= opts.StaleAfter
+}
+
+// rankedOwners returns the area's owners, most commits first
+func (a *areaStats) rankedOwners(opts OwnershipOptions) []AreaOwner {
+	owners := make([]AreaOwner, 0, len(a.owners))
+	for _, owner := range a.owners {
+		o := *owner
+		o.Share = float64(o.Commits) / float64(len(a.commits))
+		o.Recent = opts.Now.Sub(o.LastTouch) <= opts.StaleAfter
+		owners = append(owners, o)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i].Commits != owners[j].Commits {
+			return owners[i].Commits > owners[j].Commits
+		}
+		if !owners[i].LastTouch.Equal(owners[j].LastTouch) {
+			return owners[i].LastTouch.After(owners[j].LastTouch)
+		}
+		return owners[i].Name < owners[j].Name
+	})
+	return owners
+}
+
+// build converts the accumulated stats into an OwnershipArea
+func (a *areaStats) build(areaPath string, beads map[string]OwnershipBead, opts OwnershipOptions) OwnershipArea {
+	area := OwnershipArea{
+		Path:           areaPath,
+		Files:          len(a.files),
+		Commits:        len(a.commits),
+		Beads:          len(a.beads),
+		Owners:         a.rankedOwners(opts),
+		Labels:         []AreaLabel{},
+		LastTouch:      a.last,
+		HasRecentOwner: a.hasRecentOwner(opts),
+	}
+	if len(area.Owners) > opts.MaxOwners {
+		area.Owners = area.Owners[:opts.MaxOwners]
+	}
+
+	labelCounts := make(map[string]int)
+	for beadID := range a.beads {
+		for _, label := range beads[beadID].Labels {
+			labelCounts[label]++
+		}
+	}
+	for label, count := range labelCounts {
+		area.Labels = append(area.Labels, AreaLabel{Label: label, Beads: count})
+	}
+	sort.Slice(area.Labels, func(i, j int) bool {
+		if area.Labels[i].Beads != area.Labels[j].Beads {
+			return area.Labels[i].Beads > area.Labels[j].Beads
+		}
+		return area.Labels[i].Label < area.Labels[j].Label
+	})
+	if len(area.Labels) > opts.MaxOwners {
+		area.Labels = area.Labels[:opts.MaxOwners]
+	}
+	return area
+}
+
+// suggestAssignee picks the recent owner with the most commits across the
+// areas a bead mentions
+func suggestAssignee(bead OwnershipBead, paths, mentioned []string, areas map[string]*areaStats, opts OwnershipOptions) (AssigneeSuggestion, bool) {
+	type candidate struct {
+		owner   AreaOwner
+		commits int
+	}
+	candidates := make(map[string]*candidate)
+	totalCommits := 0
+	for _, areaPath := range mentioned {
+		area := areas[areaPath]
+		totalCommits += len(area.commits)
+		for _, owner := range area.rankedOwners(opts) {
+			if !owner.Recent {
+				continue
+			}
+			key := ownerKey(owner.Name, owner.Email)
+			c := candidates[key]
+			if c == nil {
+				c = &candidate{owner: owner}
+				candidates[key] = c
+			}
+			c.commits += owner.Commits
+		}
+	}
+
+	var best *candidate
+	for _, c := range candidates {
+		if best == nil || c.commits > best.commits ||
+			(c.commits == best.commits && c.owner.Name < best.owner.Name) {
+			best = c
+		}
+	}
+	if best == nil || totalCommits == 0 {
+		return AssigneeSuggestion{}, false
+	}
+
+	return AssigneeSuggestion{
+		BeadID:     bead.ID,
+		Title:      bead.Title,
+		Assignee:   best.owner.Name,
+		Email:      best.owner.Email,
+		Paths:      paths,
+		Areas:      mentioned,
+		Confidence: float64(best.commits) / float64(totalCommits),
+		Reason: fmt.Sprintf("Top recent committer to %s (%d of %d %s)",
+			strings.Join(mentioned, ", "), best.commits, totalCommits, pluralize(totalCommits, "commit")),
+	}, true
+}
+
+// mentionedAreas finds the paths mentioned in text and the known areas they
+// fall in. Paths with an extension are files; a mentioned directory covers
+// every area beneath it.
+func mentionedAreas(text string, areas map[string]*areaStats, depth int) (paths, matched []string) {
+	for _, mention := range mentionedPathPattern.FindAllString(text, -1) {
+		mention = normalizePath(strings.TrimRight(mention, ".,;:"))
+		if mention == "" {
+			continue
+		}
+
+		var hits []string
+		if path.Ext(mention) != "" {
+			if areas[areaOf(mention, depth)] != nil {
+				hits = append(hits, areaOf(mention, depth))
+			}
+		} else {
+			for areaPath := range areas {
+				if areaPath == truncatePath(mention, depth) || strings.HasPrefix(areaPath, mention+"/") {
+					hits = append(hits, areaPath)
+				}
+			}
+		}
+		if len(hits) == 0 {
+			continue
+		}
+		paths = appendUnique(paths, mention)
+		for _, hit := range hits {
+			matched = appendUnique(matched, hit)
+		}
+	}
+	sort.Strings(matched)
+	return paths, matched
+}
+
+// areaOf returns the directory of file truncated to depth components
+func areaOf(file string, depth int) string {
+	dir := path.Dir(file)
+	if dir == "/" {
+		return "."
+	}
+	return truncatePath(dir, depth)
+}
+
+// truncatePath keeps the first depth components of a slash-separated path
+func truncatePath(p string, depth int) string {
+	parts := strings.Split(p, "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+// ownerKey identifies a person by email, falling back to their name
+func ownerKey(name, email string) string {
+	if email != "" {
+		return strings.ToLower(email)
+	}
+	return name
+}
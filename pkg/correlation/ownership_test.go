@@ -0,0 +1,156 @@
+package correlation
+
+import (
+	"testing"
+	"time"
+)
+
+func createOwnershipTestReport(now time.Time) *HistoryReport {
+	return &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-1": {
+				BeadID: "bv-1",
+				Status: "closed",
+				Commits: []CorrelatedCommit{
+					{
+						SHA:         "a1",
+						Author:      "Alice",
+						AuthorEmail: "alice@example.com",
+						Timestamp:   now.Add(-2 * 24 * time.Hour),
+						Files: []FileChange{
+							{Path: "pkg/auth/token.go", Insertions: 10, Deletions: 2},
+							{Path: "README.md", Insertions: 1},
+						},
+					},
+					{
+						SHA:         "a2",
+						Author:      "Alice",
+						AuthorEmail: "ALICE@example.com",
+						Timestamp:   now.Add(-24 * time.Hour),
+						Files:       []FileChange{{Path: "pkg/auth/session.go", Insertions: 5}},
+					},
+				},
+			},
+			"bv-2": {
+				BeadID: "bv-2",
+				Status: "closed",
+				Commits: []CorrelatedCommit{
+					{
+						SHA:         "b1",
+						Author:      "Bob",
+						AuthorEmail: "bob@example.com",
+						Timestamp:   now.Add(-3 * 24 * time.Hour),
+						Files:       []FileChange{{Path: "pkg/auth/token.go", Insertions: 1}},
+					},
+					{
+						SHA:         "b2",
+						Author:      "Bob",
+						AuthorEmail: "bob@example.com",
+						Timestamp:   now.Add(-200 * 24 * time.Hour),
+						Files:       []FileChange{{Path: "pkg/legacy/old.go", Insertions: 40}},
+					},
+				},
+			},
+			"bv-3": {
+				BeadID: "bv-3",
+				Status: "open",
+				Commits: []CorrelatedCommit{
+					{
+						// Shared with bv-1; counted once
+						SHA:         "a1",
+						Author:      "Alice",
+						AuthorEmail: "alice@example.com",
+						Timestamp:   now.Add(-2 * 24 * time.Hour),
+						Files:       []FileChange{{Path: "pkg/auth/token.go", Insertions: 10, Deletions: 2}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildOwnershipReport_Areas(t *testing.T) {
+	now := time.Now()
+	beads := []OwnershipBead{
+		{ID: "bv-1", Labels: []string{"auth", "security"}, Closed: true},
+		{ID: "bv-2", Labels: []string{"auth"}, Closed: true},
+		{ID: "bv-3", Labels: []string{"security"}},
+	}
+	report := BuildOwnershipReport(createOwnershipTestReport(now), beads, OwnershipOptions{Now: now})
+
+	if len(report.Areas) != 3 {
+		t.Fatalf("Areas = %+v, want pkg/auth, pkg/legacy and .", report.Areas)
+	}
+	auth := report.Areas[0]
+	if auth.Path != "pkg/auth" || auth.Commits != 3 || auth.Files != 2 || auth.Beads != 3 {
+		t.Errorf("first area = %+v, want pkg/auth with 3 commits, 2 files, 3 beads", auth)
+	}
+	if len(auth.Owners) != 2 || auth.Owners[0].Name != "Alice" || auth.Owners[0].Commits != 2 {
+		t.Fatalf("pkg/auth owners = %+v, want Alice (2 commits) first", auth.Owners)
+	}
+	if auth.Owners[0].Changes != 17 || auth.Owners[0].Share < 0.66 || !auth.Owners[0].Recent {
+		t.Errorf("Alice = %+v, want 17 changes, 2/3 share, recent", auth.Owners[0])
+	}
+	if len(auth.Labels) != 2 || auth.Labels[0].Label != "auth" || auth.Labels[0].Beads != 2 {
+		t.Errorf("pkg/auth labels = %+v, want auth (2) first", auth.Labels)
+	}
+	if !auth.HasRecentOwner {
+		t.Error("pkg/auth should have a recent owner")
+	}
+
+	for _, area := range report.Areas {
+		if area.Path == "pkg/legacy" && area.HasRecentOwner {
+			t.Error("pkg/legacy was last touched 200 days ago, want no recent owner")
+		}
+	}
+	if report.Stats.AreasWithoutOwner != 1 || report.Stats.UniqueOwners != 2 {
+		t.Errorf("Stats = %+v, want 1 area without owner and 2 owners", report.Stats)
+	}
+}
+
+func TestBuildOwnershipReport_SuggestionsAndUnowned(t *testing.T) {
+	now := time.Now()
+	beads := []OwnershipBead{
+		{ID: "bv-3", Title: "Rotate tokens", Assignee: "carol"},
+		{ID: "bv-10", Title: "Expire sessions", Description: "See pkg/auth/session.go."},
+		{ID: "bv-11", Title: "Delete pkg/legacy", Description: "Nobody uses it"},
+		{ID: "bv-12", Title: "Speed up", Description: "Touches pkg/auth and https://example.com/a/b"},
+		{ID: "bv-13", Title: "Docs", Description: "Unknown path lib/util", Assignee: "dave"},
+		{ID: "bv-14", Title: "Done", Description: "pkg/auth", Closed: true},
+	}
+	report := BuildOwnershipReport(createOwnershipTestReport(now), beads, OwnershipOptions{Now: now})
+
+	suggested := make(map[string]AssigneeSuggestion)
+	for _, s := range report.Suggestions {
+		suggested[s.BeadID] = s
+	}
+	if len(suggested) != 2 {
+		t.Fatalf("Suggestions = %+v, want bv-10 and bv-12", report.Suggestions)
+	}
+	if s := suggested["bv-10"]; s.Assignee != "Alice" || len(s.Areas) != 1 || s.Areas[0] != "pkg/auth" {
+		t.Errorf("bv-10 suggestion = %+v, want Alice for pkg/auth", s)
+	}
+	if s := suggested["bv-12"]; s.Assignee != "Alice" || s.Confidence < 0.66 || s.Confidence > 0.67 {
+		t.Errorf("bv-12 suggestion = %+v, want Alice with 2/3 confidence", s)
+	}
+
+	// Assigned beads and areas without recent owners get no suggestion, but
+	// open beads in those areas are flagged
+	if len(report.Unowned) != 1 || report.Unowned[0].BeadID != "bv-11" || report.Unowned[0].Areas[0] != "pkg/legacy" {
+		t.Errorf("Unowned = %+v, want bv-11 in pkg/legacy", report.Unowned)
+	}
+}
+
+func TestAreaOf(t *testing.T) {
+	tests := map[string]string{
+		"README.md":            ".",
+		"pkg/x.go":             "pkg",
+		"pkg/ui/history.go":    "pkg/ui",
+		"pkg/ui/sub/deep/a.go": "pkg/ui",
+	}
+	for file, want := range tests {
+		if got := areaOf(file, 2); got != want {
+			t.Errorf("areaOf(%q, 2) = %q, want %q", file, got, want)
+		}
+	}
+}
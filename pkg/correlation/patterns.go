@@ -0,0 +1,319 @@
+// Package correlation provides configurable bead ID patterns for matching commit messages.
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PatternConfigFileName is the project correlation config under .bv/.
+const PatternConfigFileName = "correlation.yaml"
+
+// BeadPattern is a named regex that finds bead IDs in text. The first
+// capture group is the ID; without one the whole match is used.
+type BeadPattern struct {
+	Name       string
+	Regex      *regexp.Regexp
+	Confidence float64 // Base confidence for matches; 0 derives it from the match type
+}
+
+// PatternSet is the bead ID patterns and deny-list used to link commits to
+// beads.
+type PatternSet struct {
+	Patterns []BeadPattern
+	Deny     DenyList
+}
+
+// DenyList rejects tokens that look like bead IDs but are not, such as
+// UTF-8 or ISO-8601.
+type DenyList struct {
+	ids      map[string]bool
+	patterns []*regexp.Regexp
+}
+
+// Denies reports whether id (in any case) is on the deny-list.
+func (d DenyList) Denies(id string) bool {
+	lower := strings.ToLower(id)
+	if d.ids[lower] {
+		return true
+	}
+	for _, re := range d.patterns {
+		if re.MatchString(lower) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultDenyPattern matches standards and encodings that the generic
+// PROJECT-123 pattern would otherwise pick up.
+var defaultDenyPattern = regexp.MustCompile(`^(utf|ucs|iso|sha|md|rfc|ecma|ieee|cve)-\d+$`)
+
+// DefaultPatternSet returns the built-in patterns: DefaultPatterns, plus
+// lowercase hash IDs like bv-a1b2c3, and the built-in deny-list.
+func DefaultPatternSet() *PatternSet {
+	patterns := append(defaultBeadPatterns(), BeadPattern{
+		Name:  "bv-hash",
+		Regex: regexp.MustCompile(`(?i)\b(bv-[a-z0-9]{4,8})\b`),
+	})
+	return &PatternSet{
+		Patterns: patterns,
+		Deny:     DenyList{patterns: []*regexp.Regexp{defaultDenyPattern}},
+	}
+}
+
+// Extract finds every bead ID in text, in pattern order, skipping
+// duplicates and denied IDs.
+func (s *PatternSet) Extract(text string) []IDMatch {
+	var matches []IDMatch
+	seen := make(map[string]bool)
+
+	for _, pattern := range s.Patterns {
+		for _, match := range pattern.Regex.FindAllStringSubmatch(text, -1) {
+			raw := match[0]
+			if len(match) >= 2 {
+				raw = match[1]
+			}
+			if raw == "" || s.Deny.Denies(raw) {
+				continue
+			}
+			id := normalizeBeadID(raw)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			matches = append(matches, IDMatch{
+				ID:         id,
+				MatchType:  classifyMatch(match[0]),
+				RawMatch:   match[0],
+				Pattern:    pattern.Name,
+				Confidence: pattern.Confidence,
+			})
+		}
+	}
+
+	return matches
+}
+
+// fingerprint identifies the patterns and deny-list, so stored matches can
+// be invalidated when the configuration changes.
+func (s *PatternSet) fingerprint() string {
+	h := sha256.New()
+	for _, p := range s.Patterns {
+		fmt.Fprintf(h, "pattern\x00%s\x00%s\x00%g\n", p.Name, p.Regex, p.Confidence)
+	}
+	var ids []string
+	for id := range s.Deny.ids {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		fmt.Fprintf(h, "deny\x00%s\n", id)
+	}
+	for _, re := range s.Deny.patterns {
+		fmt.Fprintf(h, "deny_pattern\x00%s\n", re)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// PatternConfig is the .bv/correlation.yaml file.
+type PatternConfig struct {
+	// IncludeDefaults keeps the built-in patterns and deny-list (default true)
+	IncludeDefaults *bool               `yaml:"include_defaults,omitempty"`
+	Patterns        []PatternDefinition `yaml:"patterns"`
+	Deny            []string            `yaml:"deny"`          // Exact IDs to ignore, any case
+	DenyPatterns    []string            `yaml:"deny_patterns"` // Regexes matched against lowercased IDs
+}
+
+// PatternDefinition declares one bead ID pattern.
+type PatternDefinition struct {
+	Name       string  `yaml:"name"`
+	Regex      string  `yaml:"regex"`
+	Confidence float64 `yaml:"confidence,omitempty"` // 0.0-1.0; omit to derive it from the match type
+}
+
+// Compile validates the config and builds its pattern set. Custom patterns
+// are tried before the built-in ones.
+func (c PatternConfig) Compile() (*PatternSet, error) {
+	set := &PatternSet{Deny: DenyList{ids: make(map[string]bool)}}
+	for i, def := range c.Patterns {
+		name := def.Name
+		if name == "" {
+			name = fmt.Sprintf("pattern-%d", i+1)
+		}
+		if def.Regex == "" {
+			return nil, fmt.Errorf("pattern %s: regex cannot be empty", name)
+		}
+		re, err := regexp.Compile(def.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %s: %w", name, err)
+		}
+		if def.Confidence < 0 || def.Confidence > 1 {
+			return nil, fmt.Errorf("pattern %s: confidence must be between 0 and 1", name)
+		}
+		set.Patterns = append(set.Patterns, BeadPattern{Name: name, Regex: re, Confidence: def.Confidence})
+	}
+	for _, id := range c.Deny {
+		set.Deny.ids[strings.ToLower(strings.TrimSpace(id))] = true
+	}
+	for _, expr := range c.DenyPatterns {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("deny pattern %q: %w", expr, err)
+		}
+		set.Deny.patterns = append(set.Deny.patterns, re)
+	}
+
+	if c.IncludeDefaults == nil || *c.IncludeDefaults {
+		defaults := DefaultPatternSet()
+		set.Patterns = append(set.Patterns, defaults.Patterns...)
+		set.Deny.patterns = append(set.Deny.patterns, defaults.Deny.patterns...)
+	}
+	if len(set.Patterns) == 0 {
+		return nil, fmt.Errorf("no patterns configured")
+	}
+	return set, nil
+}
+
+// LoadPatternSet reads .bv/correlation.yaml under projectDir. A missing file
+// yields DefaultPatternSet.
+func LoadPatternSet(projectDir string) (*PatternSet, error) {
+	path := filepath.Join(projectDir, ".bv", PatternConfigFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultPatternSet(), nil
+		}
+		return nil, fmt.Errorf("reading correlation config: %w", err)
+	}
+
+	var cfg PatternConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	set, err := cfg.Compile()
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return set, nil
+}
+
+// PatternMatchSample is one commit a pattern matched during a dry run.
+type PatternMatchSample struct {
+	ShortSHA string `json:"short_sha"`
+	ID       string `json:"id"`
+	Known    bool   `json:"known"` // ID is an existing bead
+	Message  string `json:"message"`
+}
+
+// PatternDryRunResult summarizes what one pattern matched.
+type PatternDryRunResult struct {
+	Name       string               `json:"name"`
+	Regex      string               `json:"regex"`
+	Confidence float64              `json:"confidence,omitempty"`
+	Commits    int                  `json:"commits"`     // Commits with at least one match
+	IDs        int                  `json:"ids"`         // Distinct IDs matched
+	UnknownIDs int                  `json:"unknown_ids"` // Distinct IDs that are not beads
+	Denied     []string             `json:"denied"`      // Distinct matches rejected by the deny-list
+	Samples    []PatternMatchSample `json:"samples"`     // First matches, newest first
+}
+
+// PatternDryRun is what every pattern matches across recent history.
+type PatternDryRun struct {
+	CommitsScanned int                   `json:"commits_scanned"`
+	Patterns       []PatternDryRunResult `json:"patterns"`
+}
+
+// maxDryRunSamples caps the samples listed per pattern.
+const maxDryRunSamples = 5
+
+// DryRun applies each pattern on its own to the last limit commit messages
+// in repoPath (0 = all) without linking anything. knownIDs flags which
+// matches are real beads, exposing patterns that over-match.
+func (s *PatternSet) DryRun(repoPath string, limit int, knownIDs map[string]bool) (*PatternDryRun, error) {
+	args := []string{"log", "--format=" + gitLogHeaderFormat}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("-n%d", limit))
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	var commits []commitInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), gitLogMaxScanTokenSize)
+	for scanner.Scan() {
+		if info, err := parseCommitInfo(scanner.Text()); err == nil {
+			commits = append(commits, info)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading git log: %w", err)
+	}
+
+	result := &PatternDryRun{CommitsScanned: len(commits), Patterns: []PatternDryRunResult{}}
+	for _, pattern := range s.Patterns {
+		single := &PatternSet{Patterns: []BeadPattern{pattern}}
+		r := PatternDryRunResult{
+			Name:       pattern.Name,
+			Regex:      pattern.Regex.String(),
+			Confidence: pattern.Confidence,
+			Denied:     []string{},
+			Samples:    []PatternMatchSample{},
+		}
+		ids := make(map[string]bool)
+		denied := make(map[string]bool)
+		for _, commit := range commits {
+			matched := false
+			for _, m := range single.Extract(commit.Message) {
+				raw := m.RawMatch
+				if sub := pattern.Regex.FindStringSubmatch(raw); len(sub) >= 2 {
+					raw = sub[1]
+				}
+				if s.Deny.Denies(raw) {
+					denied[strings.ToLower(raw)] = true
+					continue
+				}
+				matched = true
+				if !ids[m.ID] {
+					ids[m.ID] = true
+					if !knownIDs[m.ID] {
+						r.UnknownIDs++
+					}
+				}
+				if len(r.Samples) < maxDryRunSamples {
+					r.Samples = append(r.Samples, PatternMatchSample{
+						ShortSHA: shortSHA(commit.SHA),
+						ID:       m.ID,
+						Known:    knownIDs[m.ID],
+						Message:  commit.Message,
+					})
+				}
+			}
+			if matched {
+				r.Commits++
+			}
+		}
+		r.IDs = len(ids)
+		for id := range denied {
+			r.Denied = append(r.Denied, id)
+		}
+		sort.Strings(r.Denied)
+		result.Patterns = append(result.Patterns, r)
+	}
+	return result, nil
+}
@@ -0,0 +1,232 @@
+package correlation
+
+import (
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultPatternSet_DeniesStandards(t *testing.T) {
+	set := DefaultPatternSet()
+
+	matches := set.Extract("Handle UTF-8 and ISO-8601 input, see RFC-3339")
+	if len(matches) != 0 {
+		t.Errorf("Extract() = %v, want no matches", matches)
+	}
+
+	matches = set.Extract("Fix AUTH-123 with UTF-8 names")
+	if len(matches) != 1 || matches[0].ID != "auth-123" {
+		t.Errorf("Extract() = %v, want [auth-123]", matches)
+	}
+}
+
+func TestDefaultPatternSet_HashIDs(t *testing.T) {
+	set := DefaultPatternSet()
+
+	matches := set.Extract("Implement login for bv-a1b2c3")
+	if len(matches) != 1 {
+		t.Fatalf("Extract() = %v, want 1 match", matches)
+	}
+	if matches[0].ID != "bv-a1b2c3" || matches[0].Pattern != "bv-hash" {
+		t.Errorf("match = %+v, want bv-a1b2c3 from bv-hash", matches[0])
+	}
+}
+
+func TestDefaultPatternSet_Names(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, p := range DefaultPatternSet().Patterns {
+		if p.Name == "" || seen[p.Name] {
+			t.Errorf("pattern %s has an empty or duplicate name %q", p.Regex, p.Name)
+		}
+		seen[p.Name] = true
+	}
+
+	matches := DefaultPatternSet().Extract("Closes AUTH-7")
+	if len(matches) != 1 || matches[0].Pattern != "closes" {
+		t.Errorf("Extract() = %+v, want auth-7 from closes", matches)
+	}
+}
+
+func TestPatternConfigCompile(t *testing.T) {
+	cfg := PatternConfig{
+		Patterns: []PatternDefinition{
+			{Name: "task", Regex: `(?i)task:\s*(\w+-\w+)`, Confidence: 0.9},
+		},
+		Deny:         []string{"PROJ-1"},
+		DenyPatterns: []string{`^tmp-\d+$`},
+	}
+	set, err := cfg.Compile()
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if set.Patterns[0].Name != "task" {
+		t.Errorf("first pattern = %q, want custom pattern first", set.Patterns[0].Name)
+	}
+	if len(set.Patterns) != len(DefaultPatternSet().Patterns)+1 {
+		t.Errorf("got %d patterns, want defaults plus 1", len(set.Patterns))
+	}
+
+	matches := set.Extract("Task: web-42")
+	if len(matches) != 1 || matches[0].Pattern != "task" || matches[0].Confidence != 0.9 {
+		t.Errorf("Extract() = %+v, want web-42 from task at 0.9", matches)
+	}
+
+	for _, id := range []string{"proj-1", "TMP-7", "utf-8"} {
+		if !set.Deny.Denies(id) {
+			t.Errorf("Denies(%q) = false, want true", id)
+		}
+	}
+	if set.Deny.Denies("proj-2") {
+		t.Error("Denies(proj-2) = true, want false")
+	}
+}
+
+func TestPatternConfigCompile_WithoutDefaults(t *testing.T) {
+	off := false
+	cfg := PatternConfig{
+		IncludeDefaults: &off,
+		Patterns:        []PatternDefinition{{Regex: `\b(web-\d+)\b`}},
+	}
+	set, err := cfg.Compile()
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if len(set.Patterns) != 1 || set.Patterns[0].Name != "pattern-1" {
+		t.Errorf("patterns = %+v, want only pattern-1", set.Patterns)
+	}
+	if set.Deny.Denies("utf-8") {
+		t.Error("built-in deny-list should be dropped with include_defaults: false")
+	}
+	if matches := set.Extract("Fixes bv-12"); len(matches) != 0 {
+		t.Errorf("Extract() = %v, want no matches", matches)
+	}
+}
+
+func TestPatternConfigCompile_Errors(t *testing.T) {
+	off := false
+	tests := []struct {
+		name string
+		cfg  PatternConfig
+	}{
+		{"empty regex", PatternConfig{Patterns: []PatternDefinition{{Name: "x"}}}},
+		{"invalid regex", PatternConfig{Patterns: []PatternDefinition{{Regex: `(`}}}},
+		{"confidence too high", PatternConfig{Patterns: []PatternDefinition{{Regex: `x`, Confidence: 1.5}}}},
+		{"invalid deny pattern", PatternConfig{DenyPatterns: []string{`[`}}},
+		{"no patterns", PatternConfig{IncludeDefaults: &off}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.cfg.Compile(); err == nil {
+				t.Error("Compile() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestLoadPatternSet(t *testing.T) {
+	dir := t.TempDir()
+
+	set, err := LoadPatternSet(dir)
+	if err != nil {
+		t.Fatalf("LoadPatternSet() without config error = %v", err)
+	}
+	if len(set.Patterns) != len(DefaultPatternSet().Patterns) {
+		t.Errorf("got %d patterns, want the defaults", len(set.Patterns))
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ".bv", PatternConfigFileName)
+	config := `
+include_defaults: false
+patterns:
+  - name: jira
+    regex: '\b([A-Z]+-\d+)\b'
+    confidence: 0.8
+deny: [SEC-1]
+`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	set, err = LoadPatternSet(dir)
+	if err != nil {
+		t.Fatalf("LoadPatternSet() error = %v", err)
+	}
+	matches := set.Extract("WEB-7 and SEC-1")
+	if len(matches) != 1 || matches[0].ID != "web-7" {
+		t.Errorf("Extract() = %v, want [web-7]", matches)
+	}
+
+	if err := os.WriteFile(path, []byte("patterns:\n  - regex: '('\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPatternSet(dir); err == nil {
+		t.Error("LoadPatternSet() with invalid regex error = nil, want error")
+	}
+}
+
+func TestMatchConfidence(t *testing.T) {
+	configured := IDMatch{ID: "web-1", MatchType: "generic", Confidence: 0.8}
+	if got := matchConfidence(configured, 1); got != 0.8 {
+		t.Errorf("matchConfidence(single) = %v, want 0.8", got)
+	}
+	if got := matchConfidence(configured, 3); math.Abs(got-0.76) > 1e-9 {
+		t.Errorf("matchConfidence(3 IDs) = %v, want 0.76", got)
+	}
+
+	derived := IDMatch{ID: "bv-1", MatchType: "closes"}
+	if got, want := matchConfidence(derived, 1), CalculateConfidence("closes", 1); got != want {
+		t.Errorf("matchConfidence(derived) = %v, want %v", got, want)
+	}
+}
+
+func TestPatternSetDryRun(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test User")
+	git("commit", "-q", "--allow-empty", "-m", "Fix UTF-8 output for bv-a1b2")
+	git("commit", "-q", "--allow-empty", "-m", "Refs AUTH-9")
+
+	set := DefaultPatternSet()
+	result, err := set.DryRun(dir, 10, map[string]bool{"bv-a1b2": true})
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+	if result.CommitsScanned != 2 {
+		t.Errorf("CommitsScanned = %d, want 2", result.CommitsScanned)
+	}
+
+	byName := make(map[string]PatternDryRunResult)
+	for _, r := range result.Patterns {
+		byName[r.Name] = r
+	}
+	hash := byName["bv-hash"]
+	if hash.Commits != 1 || hash.IDs != 1 || hash.UnknownIDs != 0 {
+		t.Errorf("bv-hash = %+v, want 1 commit with 1 known ID", hash)
+	}
+	if len(hash.Samples) != 1 || !hash.Samples[0].Known {
+		t.Errorf("bv-hash samples = %+v, want 1 known sample", hash.Samples)
+	}
+	generic := byName["generic"]
+	if generic.IDs != 1 || generic.UnknownIDs != 1 {
+		t.Errorf("generic = %+v, want auth-9 as an unknown ID", generic)
+	}
+	if len(generic.Denied) != 1 || generic.Denied[0] != "utf-8" {
+		t.Errorf("generic denied = %v, want [utf-8]", generic.Denied)
+	}
+}
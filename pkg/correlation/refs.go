@@ -0,0 +1,337 @@
+// Package correlation provides bead links recovered from commit trailers and
+// the names of merged branches.
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTrailerKeys are the commit trailer keys that name beads, matched
+// case-insensitively (e.g. "Bead: bv-123").
+var DefaultTrailerKeys = []string{"Bead", "Beads", "Bead-Id"}
+
+const (
+	// TrailerConfidence is the confidence of a bead named in a commit trailer
+	TrailerConfidence = 0.95
+	// BranchNameConfidence is the confidence of a commit merged from a branch
+	// named after a single bead; each extra bead in the name lowers it
+	BranchNameConfidence = 0.80
+)
+
+// refLogFormat adds parents and unfolded trailers to the commit header; a
+// record separator ends each commit since trailers span lines.
+const refLogFormat = gitLogHeaderFormat + "%x00%P%x00%(trailers:only,unfold)%x1e"
+
+// mergeSubjectPattern recovers the branch name from default merge messages:
+// "Merge branch 'x'", "Merge remote-tracking branch 'origin/x'" and
+// "Merge pull request #12 from owner/x".
+var mergeSubjectPattern = regexp.MustCompile(`^Merge (?:remote-tracking )?branch '([^']+)'|^Merge pull request #\d+ from (\S+)`)
+
+// RefExtractor links commits to beads named in their trailers, and commits
+// on merged branches to beads named in the branch.
+type RefExtractor struct {
+	repoPath    string
+	trailerKeys map[string]bool
+	patterns    *PatternSet
+	coCommitter *CoCommitExtractor
+}
+
+// NewRefExtractor creates a trailer and branch-name extractor for the given
+// repository. Branch names are matched with the patterns of the repository's
+// .bv/correlation.yaml, or DefaultPatternSet if it is missing or invalid.
+func NewRefExtractor(repoPath string) *RefExtractor {
+	patterns, err := LoadPatternSet(repoPath)
+	if err != nil {
+		patterns = DefaultPatternSet()
+	}
+	r := &RefExtractor{
+		repoPath:    repoPath,
+		trailerKeys: make(map[string]bool),
+		patterns:    patterns,
+		coCommitter: NewCoCommitExtractor(repoPath),
+	}
+	for _, key := range DefaultTrailerKeys {
+		r.trailerKeys[strings.ToLower(key)] = true
+	}
+	return r
+}
+
+// SetPatterns replaces the bead ID patterns used on branch names.
+func (r *RefExtractor) SetPatterns(set *PatternSet) {
+	r.patterns = set
+}
+
+// refLink is a trailer or branch-name correlation and the commit that
+// produced it: the commit itself for trailers, the merge for branches.
+type refLink struct {
+	CorrelatedCommit
+	Source string
+}
+
+// refScan is the result of scanning commits for trailers and merges
+type refScan struct {
+	commits []commitInfo            // Scanned commits, oldest first
+	links   []refLink               // Ordered by correlatedCommitLess
+	files   map[string][]FileChange // All files changed by linked commits
+}
+
+// Extract returns trailer and branch-name correlations for the commits
+// selected by opts. Limit, Since and Until select trailer and merge commits;
+// commits on a merged branch are linked even if older.
+func (r *RefExtractor) Extract(opts ExtractOptions) ([]CorrelatedCommit, error) {
+	var args []string
+	if opts.Since != nil {
+		args = append(args, fmt.Sprintf("--since=%s", opts.Since.Format(time.RFC3339)))
+	}
+	if opts.Until != nil {
+		args = append(args, fmt.Sprintf("--until=%s", opts.Until.Format(time.RFC3339)))
+	}
+	if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("-n%d", opts.Limit))
+	}
+
+	scan, err := r.scan(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []CorrelatedCommit
+	for _, link := range scan.links {
+		if opts.BeadID != "" && !strings.EqualFold(link.BeadID, opts.BeadID) {
+			continue
+		}
+		commits = append(commits, link.CorrelatedCommit)
+	}
+	return commits, nil
+}
+
+// scan runs git log with the given selection arguments and collects the
+// links of every commit it lists.
+func (r *RefExtractor) scan(selection []string) (*refScan, error) {
+	args := append([]string{"-c", "color.ui=false", "log", "--format=" + refLogFormat}, selection...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	result := &refScan{files: make(map[string][]FileChange)}
+	seen := make(map[string]bool)
+	addLink := func(info commitInfo, beadID string, method CorrelationMethod, confidence float64, reason, source string) {
+		key := info.SHA + "\x00" + beadID + "\x00" + string(method)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		result.links = append(result.links, refLink{
+			CorrelatedCommit: CorrelatedCommit{
+				BeadID:      beadID,
+				SHA:         info.SHA,
+				ShortSHA:    shortSHA(info.SHA),
+				Message:     info.Message,
+				Author:      info.Author,
+				AuthorEmail: info.AuthorEmail,
+				Timestamp:   info.Timestamp,
+				Method:      method,
+				Confidence:  confidence,
+				Reason:      reason,
+			},
+			Source: source,
+		})
+	}
+
+	for _, record := range strings.Split(string(out), "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		parts := strings.SplitN(record, "\x00", 7)
+		if len(parts) != 7 {
+			continue
+		}
+		info, err := parseCommitInfo(strings.Join(parts[:5], "\x00"))
+		if err != nil {
+			continue
+		}
+		result.commits = append(result.commits, info)
+
+		for _, trailer := range strings.Split(parts[6], "\n") {
+			for _, id := range r.trailerBeadIDs(trailer) {
+				addLink(info, id, MethodTrailer, TrailerConfidence, fmt.Sprintf("Commit trailer %q", strings.TrimSpace(trailer)), info.SHA)
+			}
+		}
+
+		parents := strings.Fields(parts[5])
+		if len(parents) < 2 {
+			continue
+		}
+		branch := branchFromMergeSubject(info.Message)
+		if branch == "" {
+			continue
+		}
+		var ids []string
+		for _, match := range r.patterns.Extract(branch) {
+			ids = append(ids, match.ID)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		confidence := BranchNameConfidence - 0.05*float64(len(ids)-1)
+		if confidence < MethodRanges[MethodBranchName].Min {
+			confidence = MethodRanges[MethodBranchName].Min
+		}
+		merged, err := branchCommits(r.repoPath, parents)
+		if err != nil {
+			// Non-fatal: skip this merge
+			continue
+		}
+		reason := fmt.Sprintf("Merged from branch %s in %s", branch, shortSHA(info.SHA))
+		for _, commit := range merged {
+			for _, id := range ids {
+				addLink(commit, id, MethodBranchName, confidence, reason, info.SHA)
+			}
+		}
+	}
+
+	// git log lists newest first
+	for i, j := 0, len(result.commits)-1; i < j; i, j = i+1, j-1 {
+		result.commits[i], result.commits[j] = result.commits[j], result.commits[i]
+	}
+
+	for i := range result.links {
+		sha := result.links[i].SHA
+		files, ok := result.files[sha]
+		if !ok {
+			files, err = r.coCommitter.filesWithStats(sha)
+			if err != nil {
+				// Non-fatal: link without files
+				continue
+			}
+			result.files[sha] = files
+		}
+		result.links[i].Files = filterCodeFiles(files)
+	}
+	sort.SliceStable(result.links, func(i, j int) bool {
+		return correlatedCommitLess(result.links[i].CorrelatedCommit, result.links[j].CorrelatedCommit)
+	})
+
+	return result, nil
+}
+
+// trailerBeadIDs returns the bead IDs named by a "Key: value" trailer line
+// when the key is a bead trailer. The value is matched with the pattern set,
+// as branch names are, so notes and denied IDs are not taken for beads.
+func (r *RefExtractor) trailerBeadIDs(trailer string) []string {
+	key, value, ok := strings.Cut(trailer, ":")
+	if !ok || !r.trailerKeys[strings.ToLower(strings.TrimSpace(key))] {
+		return nil
+	}
+	var ids []string
+	for _, match := range r.patterns.Extract(value) {
+		ids = append(ids, match.ID)
+	}
+	return ids
+}
+
+// branchCommits returns the commits a merge brought in, newest first: those
+// reachable from its other parents but not its first parent.
+func branchCommits(repoPath string, parents []string) ([]commitInfo, error) {
+	args := []string{"log", "--format=" + gitLogHeaderFormat}
+	for _, parent := range parents[1:] {
+		args = append(args, parents[0]+".."+parent)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	var commits []commitInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), gitLogMaxScanTokenSize)
+	for scanner.Scan() {
+		if info, err := parseCommitInfo(scanner.Text()); err == nil {
+			commits = append(commits, info)
+		}
+	}
+	return commits, scanner.Err()
+}
+
+// branchFromMergeSubject returns the merged branch named in a merge commit
+// subject, or "" if the subject is not a default merge message.
+func branchFromMergeSubject(subject string) string {
+	m := mergeSubjectPattern.FindStringSubmatch(subject)
+	if m == nil {
+		return ""
+	}
+	if m[1] != "" {
+		return m[1]
+	}
+	return m[2]
+}
+
+// correlatedCommitLess orders correlations chronologically, then by SHA,
+// bead and method, so reports do not depend on extraction order.
+func correlatedCommitLess(a, b CorrelatedCommit) bool {
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return a.Timestamp.Before(b.Timestamp)
+	}
+	if a.SHA != b.SHA {
+		return a.SHA < b.SHA
+	}
+	if a.BeadID != b.BeadID {
+		return a.BeadID < b.BeadID
+	}
+	return a.Method < b.Method
+}
+
+// mergeRefCommits folds trailer and branch-name correlations into the
+// co-commit correlations. Commits found by several methods are combined by
+// the Scorer; the result stays chronological.
+func mergeRefCommits(commits, refCommits []CorrelatedCommit) []CorrelatedCommit {
+	if len(refCommits) == 0 {
+		return commits
+	}
+
+	// A commit that claims and closes a bead yields two co-commits; keep the
+	// first, as buildHistories would, so only distinct methods are combined
+	type key struct{ sha, beadID string }
+	seen := make(map[key]bool)
+	var unique []CorrelatedCommit
+	for _, c := range commits {
+		k := key{c.SHA, c.BeadID}
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, c)
+		}
+	}
+
+	merged := NewScorer().MergeCommits(unique, refCommits)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return correlatedCommitLess(merged[i], merged[j])
+	})
+	return merged
+}
+
+// canonicalBeadIDs maps bead IDs that differ only in case (trailers and
+// branch names are lowercased) to the IDs of the known beads.
+func canonicalBeadIDs(beads []BeadInfo, commits []CorrelatedCommit) {
+	byLower := make(map[string]string, len(beads))
+	for _, b := range beads {
+		byLower[strings.ToLower(b.ID)] = b.ID
+	}
+	for i := range commits {
+		if id, ok := byLower[strings.ToLower(commits[i].BeadID)]; ok {
+			commits[i].BeadID = id
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package correlation
+
+import (
+	"testing"
+)
+
+func TestRefExtractor_Trailers(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.write("src/a.go", "a\n")
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", "Add login form\n\nBead: bv-1, BV-2\nSigned-off-by: Test User <test@test.com>")
+	r.write("src/b.go", "b\n")
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", "Unrelated\n\nReviewed-by: Someone <x@y.z>")
+	r.write("src/c.go", "c\n")
+	r.git("add", "-A")
+	// Notes and denied IDs in a trailer are not beads; dated last so it sorts last
+	r.git("commit", "-q", "--date", "2099-01-01T00:00:00Z", "-m", "Note\n\nBead: bv-1 (login form), UTF-8")
+
+	commits, err := NewRefExtractor(r.dir).Extract(ExtractOptions{})
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(commits) != 3 {
+		t.Fatalf("Extract() = %+v, want 3 links", commits)
+	}
+	if c := commits[2]; c.BeadID != "bv-1" || len(c.Files) != 1 || c.Files[0].Path != "src/c.go" {
+		t.Errorf("note trailer = %s %+v, want only bv-1 from src/c.go", c.BeadID, c.Files)
+	}
+	for i, want := range []string{"bv-1", "bv-2"} {
+		c := commits[i]
+		if c.BeadID != want || c.Method != MethodTrailer || c.Confidence != TrailerConfidence {
+			t.Errorf("commit %d = %s/%s/%v, want %s/trailer/%v", i, c.BeadID, c.Method, c.Confidence, want, TrailerConfidence)
+		}
+		if len(c.Files) != 1 || c.Files[0].Path != "src/a.go" {
+			t.Errorf("commit %d files = %+v, want src/a.go", i, c.Files)
+		}
+	}
+
+	filtered, err := NewRefExtractor(r.dir).Extract(ExtractOptions{BeadID: "BV-2"})
+	if err != nil {
+		t.Fatalf("Extract(BeadID) error = %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].BeadID != "bv-2" {
+		t.Errorf("Extract(BeadID) = %+v, want only bv-2", filtered)
+	}
+}
+
+func TestRefExtractor_MergedBranch(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create beads", "", [2]string{"bv-1", "open"})
+	r.git("checkout", "-q", "-b", "feature/bv-7-login")
+	r.commit("Add form", "web/form.go", [2]string{"bv-1", "open"})
+	r.write("web/submit.go", "submit\n")
+	r.git("add", "-A")
+	// Date it after "Add form": commits in the same second are ordered by SHA
+	r.git("commit", "-q", "--date", "2099-01-01T00:00:00Z", "-m", "Wire submit")
+	r.git("checkout", "-q", "-")
+	r.git("merge", "-q", "--no-ff", "--no-edit", "feature/bv-7-login")
+	merge := r.git("rev-parse", "HEAD")
+
+	commits, err := NewRefExtractor(r.dir).Extract(ExtractOptions{})
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("Extract() = %+v, want the 2 branch commits", commits)
+	}
+	for _, c := range commits {
+		if c.SHA == merge {
+			t.Error("merge commit itself should not be linked")
+		}
+		if c.BeadID != "bv-7" || c.Method != MethodBranchName || c.Confidence != BranchNameConfidence {
+			t.Errorf("commit %s = %s/%s/%v, want bv-7/branch_name/%v", c.ShortSHA, c.BeadID, c.Method, c.Confidence, BranchNameConfidence)
+		}
+	}
+	if commits[0].Message != "Add form" || commits[1].Message != "Wire submit" {
+		t.Errorf("commits = %q, %q, want oldest first", commits[0].Message, commits[1].Message)
+	}
+
+	// Limit selects the merge, not the older branch commits
+	limited, err := NewRefExtractor(r.dir).Extract(ExtractOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("Extract(Limit) error = %v", err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("Extract(Limit: 1) = %d links, want 2", len(limited))
+	}
+}
+
+func TestBranchFromMergeSubject(t *testing.T) {
+	tests := map[string]string{
+		"Merge branch 'feature/bv-1-login'":              "feature/bv-1-login",
+		"Merge branch 'bv-2' into main":                  "bv-2",
+		"Merge remote-tracking branch 'origin/bv-3-fix'": "origin/bv-3-fix",
+		"Merge pull request #12 from acme/bv-4-docs":     "acme/bv-4-docs",
+		"Fix bv-5 crash":                                 "",
+		"Revert \"Merge branch 'bv-6'\"":                 "",
+	}
+	for subject, want := range tests {
+		if got := branchFromMergeSubject(subject); got != want {
+			t.Errorf("branchFromMergeSubject(%q) = %q, want %q", subject, got, want)
+		}
+	}
+}
+
+func TestCorrelator_CombinesRefSignals(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create beads", "", [2]string{"BV-1", "open"})
+	r.commit("Start work\n\nBead: bv-1", "pkg/auth.go", [2]string{"BV-1", "in_progress"})
+
+	beads := []BeadInfo{{ID: "BV-1", Title: "Bead BV-1", Status: "in_progress"}}
+	report, err := NewCorrelator(r.dir).GenerateReport(beads, CorrelatorOptions{})
+	if err != nil {
+		t.Fatalf("GenerateReport() error = %v", err)
+	}
+	commits := report.Histories["BV-1"].Commits
+	if len(commits) != 1 {
+		t.Fatalf("BV-1 commits = %+v, want 1", commits)
+	}
+	c := commits[0]
+	if len(c.Methods) != 2 || c.Methods[0] != c.Method {
+		t.Errorf("Methods = %v, want the primary method and one more", c.Methods)
+	}
+
+	signals := NewScorer().ExtractSignals(c)
+	found := make(map[SignalType]bool)
+	for _, sig := range signals {
+		found[sig.Type] = true
+	}
+	if !found[SignalCoCommit] || !found[SignalTrailer] {
+		t.Errorf("signals = %+v, want co_commit and trailer", signals)
+	}
+}
@@ -35,6 +35,18 @@ var MethodRanges = map[CorrelationMethod]ConfidenceRange{
 		Max:    0.85,
 		Desc:   "By same author during bead's active window (temporal correlation)",
 	},
+	MethodTrailer: {
+		Method: MethodTrailer,
+		Min:    0.90,
+		Max:    0.99,
+		Desc:   "Commit trailer names the bead (structured developer intent)",
+	},
+	MethodBranchName: {
+		Method: MethodBranchName,
+		Min:    0.60,
+		Max:    0.90,
+		Desc:   "Merged from a branch named after the bead (branch convention)",
+	},
 }
 
 // Scorer provides methods for calculating and combining confidence scores.
@@ -246,6 +258,19 @@ func (s *Scorer) MergeCommits(sources ...[]CorrelatedCommit) []CorrelatedCommit
 		}
 		result.Method = commits[highestIdx].Method
 
+		// Record every distinct method, primary first
+		result.Methods = []CorrelationMethod{result.Method}
+		seenMethods := map[CorrelationMethod]bool{result.Method: true}
+		for _, c := range commits {
+			if !seenMethods[c.Method] {
+				seenMethods[c.Method] = true
+				result.Methods = append(result.Methods, c.Method)
+			}
+		}
+		if len(result.Methods) == 1 {
+			result.Methods = nil
+		}
+
 		// Merge file changes (dedupe by path)
 		seenFiles := make(map[string]bool)
 		var allFiles []FileChange
@@ -372,31 +397,13 @@ func (s *Scorer) BuildExplanation(commit CorrelatedCommit, beadID string) Correl
 func (s *Scorer) ExtractSignals(commit CorrelatedCommit) []CorrelationSignal {
 	var signals []CorrelationSignal
 
-	// Primary signal based on correlation method
-	switch commit.Method {
-	case MethodCoCommitted:
-		signals = append(signals, CorrelationSignal{
-			Type:   SignalCoCommit,
-			Weight: 50,
-			Detail: "Commit modified both code and beads file together (direct causation)",
-		})
-	case MethodExplicitID:
-		signals = append(signals, CorrelationSignal{
-			Type:   SignalMessageMatch,
-			Weight: 40,
-			Detail: "Commit message contains bead ID reference",
-		})
-	case MethodTemporalAuthor:
-		signals = append(signals, CorrelationSignal{
-			Type:   SignalTiming,
-			Weight: 25,
-			Detail: "Commit within bead's active time window",
-		})
-		signals = append(signals, CorrelationSignal{
-			Type:   SignalAuthorMatch,
-			Weight: 15,
-			Detail: fmt.Sprintf("By assignee: %s", commit.Author),
-		})
+	// Primary signal based on correlation method, then any other method
+	// that found the same commit
+	signals = append(signals, methodSignals(commit.Method, commit)...)
+	for _, method := range commit.Methods {
+		if method != commit.Method {
+			signals = append(signals, methodSignals(method, commit)...)
+		}
 	}
 
 	// File-based signals
@@ -423,6 +430,50 @@ func (s *Scorer) ExtractSignals(commit CorrelatedCommit) []CorrelationSignal {
 	return signals
 }
 
+// methodSignals returns the signals implied by one correlation method
+func methodSignals(method CorrelationMethod, commit CorrelatedCommit) []CorrelationSignal {
+	switch method {
+	case MethodCoCommitted:
+		return []CorrelationSignal{{
+			Type:   SignalCoCommit,
+			Weight: 50,
+			Detail: "Commit modified both code and beads file together (direct causation)",
+		}}
+	case MethodExplicitID:
+		return []CorrelationSignal{{
+			Type:   SignalMessageMatch,
+			Weight: 40,
+			Detail: "Commit message contains bead ID reference",
+		}}
+	case MethodTemporalAuthor:
+		return []CorrelationSignal{
+			{
+				Type:   SignalTiming,
+				Weight: 25,
+				Detail: "Commit within bead's active time window",
+			},
+			{
+				Type:   SignalAuthorMatch,
+				Weight: 15,
+				Detail: fmt.Sprintf("By assignee: %s", commit.Author),
+			},
+		}
+	case MethodTrailer:
+		return []CorrelationSignal{{
+			Type:   SignalTrailer,
+			Weight: 45,
+			Detail: "Commit trailer names the bead",
+		}}
+	case MethodBranchName:
+		return []CorrelationSignal{{
+			Type:   SignalBranchName,
+			Weight: 30,
+			Detail: "Commit merged from a branch named after the bead",
+		}}
+	}
+	return nil
+}
+
 // buildSummary creates a one-line summary of the correlation
 func (s *Scorer) buildSummary(commit CorrelatedCommit, signals []CorrelationSignal) string {
 	methodDesc := ""
@@ -433,6 +484,10 @@ func (s *Scorer) buildSummary(commit CorrelatedCommit, signals []CorrelationSign
 		methodDesc = "Explicitly references bead ID"
 	case MethodTemporalAuthor:
 		methodDesc = "Temporal+author correlation"
+	case MethodTrailer:
+		methodDesc = "Named in commit trailer"
+	case MethodBranchName:
+		methodDesc = "Merged from bead branch"
 	}
 
 	return fmt.Sprintf("%s (%.0f%% confidence, %d signals)",
@@ -57,6 +57,10 @@ const (
 	MethodExplicitID CorrelationMethod = "explicit_id"
 	// MethodTemporalAuthor means the commit is temporally close and by the assignee
 	MethodTemporalAuthor CorrelationMethod = "temporal_author"
+	// MethodTrailer means a commit trailer (e.g. "Bead: bv-123") names the bead
+	MethodTrailer CorrelationMethod = "trailer"
+	// MethodBranchName means the commit was merged from a branch named after the bead
+	MethodBranchName CorrelationMethod = "branch_name"
 )
 
 // String returns the string representation of CorrelationMethod
@@ -67,7 +71,7 @@ func (c CorrelationMethod) String() string {
 // IsValid returns true if the correlation method is a recognized value
 func (c CorrelationMethod) IsValid() bool {
 	switch c {
-	case MethodCoCommitted, MethodExplicitID, MethodTemporalAuthor:
+	case MethodCoCommitted, MethodExplicitID, MethodTemporalAuthor, MethodTrailer, MethodBranchName:
 		return true
 	}
 	return false
@@ -83,17 +87,18 @@ type FileChange struct {
 
 // CorrelatedCommit represents a code commit linked to a bead with confidence metadata
 type CorrelatedCommit struct {
-	BeadID      string            `json:"-"` // Internal use for linking
-	SHA         string            `json:"sha"`
-	ShortSHA    string            `json:"short_sha"`
-	Message     string            `json:"message"`
-	Author      string            `json:"author"`
-	AuthorEmail string            `json:"author_email"`
-	Timestamp   time.Time         `json:"timestamp"`
-	Files       []FileChange      `json:"files"`
-	Method      CorrelationMethod `json:"method"`
-	Confidence  float64           `json:"confidence"` // 0.0 to 1.0
-	Reason      string            `json:"reason"`     // Human-readable explanation
+	BeadID      string              `json:"-"` // Internal use for linking
+	SHA         string              `json:"sha"`
+	ShortSHA    string              `json:"short_sha"`
+	Message     string              `json:"message"`
+	Author      string              `json:"author"`
+	AuthorEmail string              `json:"author_email"`
+	Timestamp   time.Time           `json:"timestamp"`
+	Files       []FileChange        `json:"files"`
+	Method      CorrelationMethod   `json:"method"`
+	Methods     []CorrelationMethod `json:"methods,omitempty"` // Every method that found the commit, when more than one
+	Confidence  float64             `json:"confidence"`        // 0.0 to 1.0
+	Reason      string              `json:"reason"`            // Human-readable explanation
 }
 
 // BeadMilestones contains key lifecycle timestamps for quick access
@@ -128,13 +133,14 @@ type CommitIndex map[string][]string
 
 // HistoryStats provides aggregate statistics for the history report
 type HistoryStats struct {
-	TotalBeads         int            `json:"total_beads"`
-	BeadsWithCommits   int            `json:"beads_with_commits"`
-	TotalCommits       int            `json:"total_commits"`
-	UniqueAuthors      int            `json:"unique_authors"`
-	AvgCommitsPerBead  float64        `json:"avg_commits_per_bead"`
-	AvgCycleTimeDays   *float64       `json:"avg_cycle_time_days,omitempty"` // nil if no closed beads
-	MethodDistribution map[string]int `json:"method_distribution"`          // Count per correlation method
+	TotalBeads            int            `json:"total_beads"`
+	BeadsWithCommits      int            `json:"beads_with_commits"`
+	TotalCommits          int            `json:"total_commits"`
+	UniqueAuthors         int            `json:"unique_authors"`
+	AvgCommitsPerBead     float64        `json:"avg_commits_per_bead"`
+	AvgCycleTimeDays      *float64       `json:"avg_cycle_time_days,omitempty"`       // nil if no closed beads
+	AvgGroupCycleTimeDays *float64       `json:"avg_group_cycle_time_days,omitempty"` // First commit to merge; nil if no groups
+	MethodDistribution    map[string]int `json:"method_distribution"`                 // Count per correlation method
 }
 
 // HistoryReport is the top-level output structure for --robot-history
@@ -146,6 +152,7 @@ type HistoryReport struct {
 	Stats           HistoryStats           `json:"stats"`                       // Aggregate statistics
 	Histories       map[string]BeadHistory `json:"histories"`                   // BeadID -> BeadHistory
 	CommitIndex     CommitIndex            `json:"commit_index"`                // SHA -> []BeadID for reverse lookup
+	Groups          []CommitGroup          `json:"groups,omitempty"`            // Merged branches and pull requests, newest first
 }
 
 // FilterOptions controls which beads to include in the history report
@@ -174,6 +181,10 @@ const (
 	SignalProximity SignalType = "proximity"
 	// SignalCoCommit indicates the commit modified beads file and code together
 	SignalCoCommit SignalType = "co_commit"
+	// SignalTrailer indicates a commit trailer names the bead
+	SignalTrailer SignalType = "trailer"
+	// SignalBranchName indicates the commit came from a branch named after the bead
+	SignalBranchName SignalType = "branch_name"
 )
 
 // CorrelationSignal represents a single factor contributing to correlation confidence
@@ -45,6 +45,26 @@ type Config struct {
 	BlockingCascadeInfo    int `yaml:"blocking_cascade_info_threshold" json:"blocking_cascade_info_threshold"`
 	BlockingCascadeWarning int `yaml:"blocking_cascade_warning_threshold" json:"blocking_cascade_warning_threshold"`
 
+	// Velocity drop: weekly close rate over the last VelocityWindowWeeks full
+	// weeks, compared with the rate saved in the baseline. Baseline rates
+	// below VelocityMinWeeklyRate are too noisy to compare.
+	VelocityWindowWeeks    int     `yaml:"velocity_window_weeks" json:"velocity_window_weeks"`
+	VelocityDropWarningPct float64 `yaml:"velocity_drop_warning_pct" json:"velocity_drop_warning_pct"`
+	VelocityMinWeeklyRate  float64 `yaml:"velocity_min_weekly_rate" json:"velocity_min_weekly_rate"`
+
+	// AbandonedClaimDays flags assigned in_progress issues with no update for this many days
+	AbandonedClaimDays int `yaml:"abandoned_claim_days" json:"abandoned_claim_days"`
+
+	// High-impact unblock: an actionable issue whose completion unblocks at least
+	// HighImpactMinUnblocks issues of priority HighImpactMaxPriority or better
+	HighImpactMaxPriority int `yaml:"high_impact_max_priority" json:"high_impact_max_priority"`
+	HighImpactMinUnblocks int `yaml:"high_impact_min_unblocks" json:"high_impact_min_unblocks"`
+
+	// DuplicateSimilarity is the keyword similarity (0-1) at which two open issues
+	// are flagged as potential duplicates; DuplicateMaxAlerts caps how many are reported
+	DuplicateSimilarity float64 `yaml:"duplicate_similarity" json:"duplicate_similarity"`
+	DuplicateMaxAlerts  int     `yaml:"duplicate_max_alerts" json:"duplicate_max_alerts"`
+
 	// Alert type enable/disable flags (bv-167)
 	// Disabled alert types will not generate alerts
 	DisabledAlerts []string `yaml:"disabled_alerts,omitempty" json:"disabled_alerts,omitempty"`
@@ -54,7 +74,8 @@ type Config struct {
 	LabelOverrides map[string]*LabelConfig `yaml:"label_overrides,omitempty" json:"label_overrides,omitempty"`
 }
 
-// LabelConfig allows per-label threshold customization (bv-167)
+// LabelConfig allows per-label threshold customization (bv-167).
+// Zero values inherit the global setting.
 type LabelConfig struct {
 	// StaleWarningDays overrides the default for issues with this label
 	StaleWarningDays int `yaml:"stale_warning_days,omitempty" json:"stale_warning_days,omitempty"`
@@ -62,6 +83,14 @@ type LabelConfig struct {
 	StaleCriticalDays int `yaml:"stale_critical_days,omitempty" json:"stale_critical_days,omitempty"`
 	// InProgressStaleMultiplier overrides the default for this label
 	InProgressStaleMultiplier float64 `yaml:"in_progress_stale_multiplier,omitempty" json:"in_progress_stale_multiplier,omitempty"`
+	// VelocityDropWarningPct overrides the default for this label's close rate
+	VelocityDropWarningPct float64 `yaml:"velocity_drop_warning_pct,omitempty" json:"velocity_drop_warning_pct,omitempty"`
+	// AbandonedClaimDays overrides the default for issues with this label
+	AbandonedClaimDays int `yaml:"abandoned_claim_days,omitempty" json:"abandoned_claim_days,omitempty"`
+	// HighImpactMinUnblocks overrides the default for issues with this label
+	HighImpactMinUnblocks int `yaml:"high_impact_min_unblocks,omitempty" json:"high_impact_min_unblocks,omitempty"`
+	// DuplicateSimilarity overrides the default when either issue has this label
+	DuplicateSimilarity float64 `yaml:"duplicate_similarity,omitempty" json:"duplicate_similarity,omitempty"`
 }
 
 // DefaultConfig returns sensible default thresholds
@@ -80,6 +109,14 @@ func DefaultConfig() *Config {
 		InProgressStaleMultiplier:    0.5, // In-progress thresholds are half as long
 		BlockingCascadeInfo:          3,   // Info alert when unblocks >=3
 		BlockingCascadeWarning:       5,   // Warning when unblocks >=5
+		VelocityWindowWeeks:          4,   // Compare the last 4 full weeks
+		VelocityDropWarningPct:       40,  // Warn if weekly close rate drops 40%+
+		VelocityMinWeeklyRate:        1,   // Ignore baselines closing <1 issue/week
+		AbandonedClaimDays:           7,   // Assigned in_progress untouched for a week
+		HighImpactMaxPriority:        1,   // P0/P1 downstream work counts as high impact
+		HighImpactMinUnblocks:        1,   // One unblocked P0/P1 is enough
+		DuplicateSimilarity:          0.8, // 80% keyword overlap
+		DuplicateMaxAlerts:           10,  // Report at most 10 pairs
 	}
 }
 
@@ -201,6 +238,27 @@ func (c *Config) Validate() error {
 	if c.BlockingCascadeWarning < c.BlockingCascadeInfo {
 		return fmt.Errorf("blocking_cascade_warning_threshold must be >= blocking_cascade_info_threshold")
 	}
+	if c.VelocityWindowWeeks < 0 || c.VelocityWindowWeeks > 52 {
+		return fmt.Errorf("velocity_window_weeks must be between 0 and 52")
+	}
+	if c.VelocityDropWarningPct < 0 || c.VelocityDropWarningPct > 100 {
+		return fmt.Errorf("velocity_drop_warning_pct must be between 0 and 100")
+	}
+	if c.VelocityMinWeeklyRate < 0 {
+		return fmt.Errorf("velocity_min_weekly_rate must be non-negative")
+	}
+	if c.AbandonedClaimDays < 0 {
+		return fmt.Errorf("abandoned_claim_days must be non-negative")
+	}
+	if c.HighImpactMaxPriority < 0 || c.HighImpactMinUnblocks < 0 {
+		return fmt.Errorf("high impact unblock thresholds must be non-negative")
+	}
+	if c.DuplicateSimilarity < 0 || c.DuplicateSimilarity > 1 {
+		return fmt.Errorf("duplicate_similarity must be between 0 and 1")
+	}
+	if c.DuplicateMaxAlerts < 0 {
+		return fmt.Errorf("duplicate_max_alerts must be non-negative")
+	}
 	// Validate label overrides (bv-167)
 	for label, lc := range c.LabelOverrides {
 		if lc == nil {
@@ -215,6 +273,15 @@ func (c *Config) Validate() error {
 		if lc.InProgressStaleMultiplier < 0 || lc.InProgressStaleMultiplier > 5 {
 			return fmt.Errorf("label %q: in_progress_stale_multiplier must be between 0 and 5", label)
 		}
+		if lc.VelocityDropWarningPct < 0 || lc.VelocityDropWarningPct > 100 {
+			return fmt.Errorf("label %q: velocity_drop_warning_pct must be between 0 and 100", label)
+		}
+		if lc.AbandonedClaimDays < 0 || lc.HighImpactMinUnblocks < 0 {
+			return fmt.Errorf("label %q: abandoned_claim_days and high_impact_min_unblocks must be non-negative", label)
+		}
+		if lc.DuplicateSimilarity < 0 || lc.DuplicateSimilarity > 1 {
+			return fmt.Errorf("label %q: duplicate_similarity must be between 0 and 1", label)
+		}
 	}
 	return nil
 }
@@ -285,6 +352,70 @@ func (c *Config) GetStalenessThresholds(labels []string) (warnDays, critDays int
 	return
 }
 
+// labelOverrides returns the overrides that apply to an issue with the given labels
+func (c *Config) labelOverrides(labels []string) []*LabelConfig {
+	var applicable []*LabelConfig
+	for _, label := range labels {
+		if lc, ok := c.LabelOverrides[label]; ok && lc != nil {
+			applicable = append(applicable, lc)
+		}
+	}
+	return applicable
+}
+
+// GetVelocityDropPct returns the velocity drop threshold for a label
+func (c *Config) GetVelocityDropPct(label string) float64 {
+	if lc, ok := c.LabelOverrides[label]; ok && lc != nil && lc.VelocityDropWarningPct > 0 {
+		return lc.VelocityDropWarningPct
+	}
+	return c.VelocityDropWarningPct
+}
+
+// GetAbandonedClaimDays returns the abandoned-claim threshold for an issue's labels.
+// Like staleness, the tightest (smallest) override among the labels wins.
+func (c *Config) GetAbandonedClaimDays(labels []string) int {
+	days := 0
+	for _, lc := range c.labelOverrides(labels) {
+		if lc.AbandonedClaimDays > 0 && (days == 0 || lc.AbandonedClaimDays < days) {
+			days = lc.AbandonedClaimDays
+		}
+	}
+	if days == 0 {
+		return c.AbandonedClaimDays
+	}
+	return days
+}
+
+// GetHighImpactMinUnblocks returns the high-impact unblock threshold for an
+// issue's labels. The tightest (smallest) override among the labels wins.
+func (c *Config) GetHighImpactMinUnblocks(labels []string) int {
+	n := 0
+	for _, lc := range c.labelOverrides(labels) {
+		if lc.HighImpactMinUnblocks > 0 && (n == 0 || lc.HighImpactMinUnblocks < n) {
+			n = lc.HighImpactMinUnblocks
+		}
+	}
+	if n == 0 {
+		return c.HighImpactMinUnblocks
+	}
+	return n
+}
+
+// GetDuplicateSimilarity returns the duplicate threshold for a pair of issues
+// given both issues' labels. The most sensitive (smallest) override wins.
+func (c *Config) GetDuplicateSimilarity(labels []string) float64 {
+	sim := 0.0
+	for _, lc := range c.labelOverrides(labels) {
+		if lc.DuplicateSimilarity > 0 && (sim == 0 || lc.DuplicateSimilarity < sim) {
+			sim = lc.DuplicateSimilarity
+		}
+	}
+	if sim == 0 {
+		return c.DuplicateSimilarity
+	}
+	return sim
+}
+
 // ExampleConfig returns an example configuration with comments
 func ExampleConfig() string {
 	return `# Drift detection thresholds configuration
@@ -315,6 +446,22 @@ in_progress_stale_multiplier: 0.5  # In-progress items age twice as fast
 blocking_cascade_info_threshold: 3   # Info alert if completing an issue unblocks 3+ items
 blocking_cascade_warning_threshold: 5 # Warning if unblocks 5+ items
 
+# Velocity drop: weekly close rate vs. the rate saved with the baseline
+velocity_window_weeks: 4         # Average over the last 4 full weeks
+velocity_drop_warning_pct: 40    # Warn if the close rate drops 40%+
+velocity_min_weekly_rate: 1      # Skip when the baseline closed <1 issue/week
+
+# Abandoned claims: assigned in_progress issues with no recent updates
+abandoned_claim_days: 7
+
+# High-impact unblocks: actionable work that unblocks high-priority issues
+high_impact_max_priority: 1      # P0 and P1 count as high priority
+high_impact_min_unblocks: 1      # Alert when at least one would be unblocked
+
+# Potential duplicates among open issues (keyword similarity 0-1)
+duplicate_similarity: 0.8
+duplicate_max_alerts: 10
+
 # Disable specific alert types (bv-167)
 # Uncomment to disable:
 # disabled_alerts:
@@ -322,12 +469,14 @@ blocking_cascade_warning_threshold: 5 # Warning if unblocks 5+ items
 #   - new_cycle
 #   - blocking_cascade
 
-# Per-label staleness overrides (bv-167)
+# Per-label overrides (bv-167)
 # Use tighter thresholds for urgent/priority labels
 # label_overrides:
 #   urgent:
 #     stale_warning_days: 3
 #     stale_critical_days: 7
+#     abandoned_claim_days: 2
+#     velocity_drop_warning_pct: 25
 #   low-priority:
 #     stale_warning_days: 30
 #     stale_critical_days: 60
@@ -4,6 +4,8 @@ package drift
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -78,6 +80,10 @@ type Calculator struct {
 	baseline *baseline.Baseline
 	current  *baseline.Baseline
 	issues   []model.Issue
+
+	// Lazily computed from issues, shared by the unblock checks
+	actionable []model.Issue
+	unblocks   map[string][]string
 }
 
 // NewCalculator creates a drift calculator with the given baseline and current snapshot
@@ -96,6 +102,7 @@ func NewCalculator(bl *baseline.Baseline, current *baseline.Baseline, cfg *Confi
 // Optional: drift detection still works without issues attached.
 func (c *Calculator) SetIssues(issues []model.Issue) {
 	c.issues = issues
+	c.actionable, c.unblocks = nil, nil
 }
 
 // Calculate performs drift detection and returns results
@@ -128,6 +135,18 @@ func (c *Calculator) Calculate() *Result {
 	// Check blocking cascades (uses current issues if provided)
 	c.checkBlockingCascade(result)
 
+	// Check close-rate drop against the baseline (warning)
+	c.checkVelocity(result)
+
+	// Check claimed work that has gone quiet (uses current issues if provided)
+	c.checkAbandonedClaims(result)
+
+	// Check actionable work that unblocks high-priority issues (uses current issues if provided)
+	c.checkHighImpactUnblocks(result)
+
+	// Check for likely duplicate open issues (uses current issues if provided)
+	c.checkPotentialDuplicates(result)
+
 	// Compute summary
 	for _, alert := range result.Alerts {
 		switch alert.Severity {
@@ -399,7 +418,7 @@ func (c *Calculator) checkStaleness(result *Result) {
 	}
 	now := time.Now().UTC()
 	for _, issue := range c.issues {
-		if issue.Status == model.StatusClosed {
+		if issue.Status.IsClosed() {
 			continue
 		}
 
@@ -417,7 +436,7 @@ func (c *Calculator) checkStaleness(result *Result) {
 		crit := float64(critDays)
 
 		// Tighten thresholds for in-progress items
-		if issue.Status == model.StatusInProgress && inProgressMult > 0 {
+		if issue.Status.IsActive() && inProgressMult > 0 {
 			warn *= inProgressMult
 			crit *= inProgressMult
 		}
@@ -471,14 +490,9 @@ func (c *Calculator) checkBlockingCascade(result *Result) {
 		issueMap[iss.ID] = iss
 	}
 
-	analyzer := analysis.NewAnalyzer(c.issues)
-	actionable := analyzer.GetActionableIssues()
-	if len(actionable) == 0 {
-		return
-	}
-
+	actionable, unblocksMap := c.unblocksMap()
 	for _, iss := range actionable {
-		unblocks := analyzer.ComputeUnblocks(iss.ID)
+		unblocks := unblocksMap[iss.ID]
 		count := len(unblocks)
 		if count == 0 {
 			continue
@@ -512,6 +526,272 @@ func (c *Calculator) checkBlockingCascade(result *Result) {
 	}
 }
 
+// unblocksMap returns the actionable issues and, for each, the issues its
+// completion would unblock. Computed once per set of attached issues.
+func (c *Calculator) unblocksMap() ([]model.Issue, map[string][]string) {
+	if c.unblocks == nil {
+		analyzer := analysis.NewAnalyzer(c.issues)
+		c.actionable = analyzer.GetActionableIssues()
+		c.unblocks = make(map[string][]string, len(c.actionable))
+		for _, iss := range c.actionable {
+			c.unblocks[iss.ID] = analyzer.ComputeUnblocks(iss.ID)
+		}
+	}
+	return c.actionable, c.unblocks
+}
+
+// ComputeVelocityStats measures the weekly close rate, overall and per label,
+// averaged over the last `weeks` full weeks before now. The current partial
+// week is left out so early-week snapshots don't read as a slowdown.
+func ComputeVelocityStats(issues []model.Issue, now time.Time, weeks int) *baseline.VelocityStats {
+	if weeks <= 0 {
+		weeks = DefaultConfig().VelocityWindowWeeks
+	}
+	weeklyRate := func(issues []model.Issue) float64 {
+		v := analysis.ComputeProjectVelocity(issues, now, weeks+1)
+		total := 0
+		for _, w := range v.Weekly[1:] {
+			total += w.Closed
+		}
+		return float64(total) / float64(weeks)
+	}
+
+	byLabel := make(map[string][]model.Issue)
+	for _, iss := range issues {
+		if !iss.Status.IsClosed() {
+			continue
+		}
+		for _, label := range iss.Labels {
+			byLabel[label] = append(byLabel[label], iss)
+		}
+	}
+
+	stats := &baseline.VelocityStats{
+		WindowWeeks:     weeks,
+		WeeklyCloseRate: weeklyRate(issues),
+	}
+	for label, closed := range byLabel {
+		if rate := weeklyRate(closed); rate > 0 {
+			if stats.LabelCloseRates == nil {
+				stats.LabelCloseRates = make(map[string]float64)
+			}
+			stats.LabelCloseRates[label] = rate
+		}
+	}
+	return stats
+}
+
+// checkVelocity warns when the weekly close rate has dropped relative to the
+// rate saved in the baseline, for the project as a whole and per label.
+// No-op for baselines saved without velocity.
+func (c *Calculator) checkVelocity(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertVelocityDrop)) {
+		return
+	}
+
+	bl := c.baseline.Velocity
+	if bl == nil {
+		return
+	}
+	cur := c.current.Velocity
+	if cur == nil {
+		if len(c.issues) == 0 {
+			return
+		}
+		// Measure over the same window as the baseline so the rates compare
+		cur = ComputeVelocityStats(c.issues, time.Now().UTC(), bl.WindowWeeks)
+	}
+
+	c.checkVelocityRate(result, "", bl.WeeklyCloseRate, cur.WeeklyCloseRate, c.config.VelocityDropWarningPct)
+
+	labels := make([]string, 0, len(bl.LabelCloseRates))
+	for label := range bl.LabelCloseRates {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		c.checkVelocityRate(result, label, bl.LabelCloseRates[label], cur.LabelCloseRates[label], c.config.GetVelocityDropPct(label))
+	}
+}
+
+func (c *Calculator) checkVelocityRate(result *Result, label string, blRate, curRate, dropPct float64) {
+	if dropPct <= 0 || blRate <= 0 || blRate < c.config.VelocityMinWeeklyRate {
+		return
+	}
+	drop := (blRate - curRate) / blRate * 100
+	if drop < dropPct {
+		return
+	}
+
+	scope := "Project"
+	if label != "" {
+		scope = fmt.Sprintf("Label %q", label)
+	}
+	result.Alerts = append(result.Alerts, Alert{
+		Type:        AlertVelocityDrop,
+		Severity:    SeverityWarning,
+		Message:     fmt.Sprintf("%s close rate dropped %.0f%% (%.1f → %.1f per week)", scope, drop, blRate, curRate),
+		BaselineVal: blRate,
+		CurrentVal:  curRate,
+		Delta:       curRate - blRate,
+		Label:       label,
+		DetectedAt:  time.Now().UTC(),
+	})
+}
+
+// checkAbandonedClaims flags in_progress issues that someone claimed but has
+// not updated within the (per-label) abandoned claim threshold.
+func (c *Calculator) checkAbandonedClaims(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertAbandonedClaim)) {
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, issue := range c.issues {
+		if !issue.Status.IsActive() || issue.Assignee == "" {
+			continue
+		}
+		limit := c.config.GetAbandonedClaimDays(issue.Labels)
+		if limit <= 0 {
+			continue
+		}
+
+		lastActive := issue.UpdatedAt
+		if lastActive.IsZero() {
+			lastActive = issue.CreatedAt
+		}
+		if lastActive.IsZero() {
+			continue
+		}
+		days := now.Sub(lastActive).Hours() / 24.0
+		if days < float64(limit) {
+			continue
+		}
+
+		result.Alerts = append(result.Alerts, Alert{
+			Type:        AlertAbandonedClaim,
+			Severity:    SeverityWarning,
+			Message:     fmt.Sprintf("Issue %s claimed by %s has had no updates for %.0f days", issue.ID, issue.Assignee, days),
+			CurrentVal:  days,
+			BaselineVal: float64(limit),
+			IssueID:     issue.ID,
+			DetectedAt:  now,
+			Details: []string{
+				fmt.Sprintf("assignee=%s", issue.Assignee),
+				fmt.Sprintf("last_update=%s", lastActive.Format(time.RFC3339)),
+			},
+		})
+	}
+}
+
+// checkHighImpactUnblocks flags actionable issues whose completion would
+// unblock high-priority work. Unlike blocking cascades, which count every
+// dependent, this only counts dependents at HighImpactMaxPriority or better.
+func (c *Calculator) checkHighImpactUnblocks(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertHighImpactUnblock)) {
+		return
+	}
+	if len(c.issues) == 0 {
+		return
+	}
+
+	priority := make(map[string]int, len(c.issues))
+	for _, iss := range c.issues {
+		priority[iss.ID] = iss.Priority
+	}
+
+	actionable, unblocksMap := c.unblocksMap()
+	for _, iss := range actionable {
+		minCount := c.config.GetHighImpactMinUnblocks(iss.Labels)
+		if minCount <= 0 {
+			continue
+		}
+		var high []string
+		prioritySum := 0
+		for _, id := range unblocksMap[iss.ID] {
+			if p, ok := priority[id]; ok && p <= c.config.HighImpactMaxPriority {
+				high = append(high, id)
+				prioritySum += p
+			}
+		}
+		if len(high) < minCount {
+			continue
+		}
+
+		result.Alerts = append(result.Alerts, Alert{
+			Type:                  AlertHighImpactUnblock,
+			Severity:              SeverityWarning,
+			Message:               fmt.Sprintf("Completing %s unblocks %d high-priority item(s)", iss.ID, len(high)),
+			IssueID:               iss.ID,
+			DetectedAt:            time.Now().UTC(),
+			Details:               high,
+			UnblocksCount:         len(high),
+			DownstreamPrioritySum: prioritySum,
+		})
+	}
+}
+
+// checkPotentialDuplicates flags pairs of open issues with very similar
+// titles and descriptions. The threshold for a pair is the most sensitive
+// one among the global setting and both issues' label overrides.
+func (c *Calculator) checkPotentialDuplicates(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertPotentialDuplicate)) {
+		return
+	}
+
+	var open []model.Issue
+	labels := make(map[string][]string)
+	for _, iss := range c.issues {
+		if !iss.Status.IsClosed() {
+			open = append(open, iss)
+			labels[iss.ID] = iss.Labels
+		}
+	}
+	if len(open) < 2 {
+		return
+	}
+
+	// Detect at the lowest threshold in play, then apply each pair's own
+	minSim := c.config.DuplicateSimilarity
+	for _, lc := range c.config.LabelOverrides {
+		if lc != nil && lc.DuplicateSimilarity > 0 && (minSim <= 0 || lc.DuplicateSimilarity < minSim) {
+			minSim = lc.DuplicateSimilarity
+		}
+	}
+	if minSim <= 0 {
+		return
+	}
+	dupCfg := analysis.DefaultDuplicateConfig()
+	dupCfg.JaccardThreshold = minSim
+	dupCfg.MaxSuggestions = math.MaxInt32
+
+	count := 0
+	for _, sug := range analysis.DetectDuplicates(open, dupCfg) {
+		pairLabels := append(append([]string{}, labels[sug.TargetBead]...), labels[sug.RelatedBead]...)
+		threshold := c.config.GetDuplicateSimilarity(pairLabels)
+		if threshold <= 0 || sug.Confidence < threshold {
+			continue
+		}
+		if c.config.DuplicateMaxAlerts > 0 && count >= c.config.DuplicateMaxAlerts {
+			break
+		}
+		count++
+
+		result.Alerts = append(result.Alerts, Alert{
+			Type:       AlertPotentialDuplicate,
+			Severity:   SeverityInfo,
+			Message:    fmt.Sprintf("%s may duplicate %s (%.0f%% similar)", sug.TargetBead, sug.RelatedBead, sug.Confidence*100),
+			CurrentVal: sug.Confidence,
+			IssueID:    sug.TargetBead,
+			DetectedAt: time.Now().UTC(),
+			Details: []string{
+				fmt.Sprintf("duplicate_of=%s", sug.RelatedBead),
+				sug.Reason,
+			},
+		})
+	}
+}
+
 // cycleKey creates a normalized key for a cycle for comparison.
 // It rotates the cycle so the lexicographically smallest element is first,
 // preserving the order (direction) of elements.
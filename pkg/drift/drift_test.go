@@ -1482,4 +1482,173 @@ func TestLabelOverridesValidation(t *testing.T) {
 	if err := cfg.Validate(); err == nil {
 		t.Error("negative days should fail validation")
 	}
-}
\ No newline at end of file
+}
+
+func alertsOfType(result *Result, typ AlertType) []Alert {
+	var out []Alert
+	for _, a := range result.Alerts {
+		if a.Type == typ {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func TestCheckVelocity(t *testing.T) {
+	now := time.Now().UTC()
+	monday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for monday.Weekday() != time.Monday {
+		monday = monday.AddDate(0, 0, -1)
+	}
+
+	// One closure in each of the last four full weeks, all labelled api
+	var issues []model.Issue
+	for k := 1; k <= 4; k++ {
+		closed := monday.AddDate(0, 0, -7*k+1)
+		issues = append(issues, model.Issue{
+			ID: "C" + string(rune('0'+k)), Status: model.StatusClosed, Labels: []string{"api"},
+			CreatedAt: closed.AddDate(0, 0, -2), UpdatedAt: closed, ClosedAt: &closed,
+		})
+	}
+
+	stats := ComputeVelocityStats(issues, now, 4)
+	if stats.WeeklyCloseRate != 1 || stats.LabelCloseRates["api"] != 1 {
+		t.Fatalf("velocity stats = %+v, want 1/week overall and for api", stats)
+	}
+
+	bl := &baseline.Baseline{Velocity: &baseline.VelocityStats{
+		WindowWeeks:     4,
+		WeeklyCloseRate: 6,
+		LabelCloseRates: map[string]float64{"api": 4, "docs": 0.5},
+	}}
+	calc := NewCalculator(bl, &baseline.Baseline{}, DefaultConfig())
+	calc.SetIssues(issues)
+	alerts := alertsOfType(calc.Calculate(), AlertVelocityDrop)
+	if len(alerts) != 2 || alerts[0].Label != "" || alerts[1].Label != "api" {
+		t.Fatalf("velocity alerts = %+v, want project and api (docs is below the minimum rate)", alerts)
+	}
+	if alerts[0].BaselineVal != 6 || alerts[0].CurrentVal != 1 {
+		t.Errorf("project alert values = %v -> %v", alerts[0].BaselineVal, alerts[0].CurrentVal)
+	}
+
+	cfg := DefaultConfig()
+	cfg.LabelOverrides = map[string]*LabelConfig{"api": {VelocityDropWarningPct: 90}}
+	calc = NewCalculator(bl, &baseline.Baseline{}, cfg)
+	calc.SetIssues(issues)
+	if alerts := alertsOfType(calc.Calculate(), AlertVelocityDrop); len(alerts) != 1 {
+		t.Errorf("api override should suppress the 75%% label drop, got %+v", alerts)
+	}
+
+	// Baselines saved before velocity was recorded are skipped
+	calc = NewCalculator(&baseline.Baseline{}, &baseline.Baseline{}, DefaultConfig())
+	calc.SetIssues(issues)
+	if alerts := alertsOfType(calc.Calculate(), AlertVelocityDrop); len(alerts) != 0 {
+		t.Errorf("no baseline velocity should mean no alerts, got %+v", alerts)
+	}
+}
+
+func TestCheckAbandonedClaims(t *testing.T) {
+	old := time.Now().Add(-10 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusInProgress, Assignee: "alice", UpdatedAt: old},
+		{ID: "B", Status: model.StatusInProgress, UpdatedAt: old},                                                  // unassigned
+		{ID: "C", Status: model.StatusInProgress, Assignee: "bob", UpdatedAt: time.Now()},                          // recent
+		{ID: "D", Status: model.StatusInProgress, Assignee: "carol", UpdatedAt: old, Labels: []string{"research"}}, // looser override
+	}
+	cfg := DefaultConfig()
+	cfg.LabelOverrides = map[string]*LabelConfig{"research": {AbandonedClaimDays: 30}}
+
+	calc := NewCalculator(&baseline.Baseline{}, &baseline.Baseline{}, cfg)
+	calc.SetIssues(issues)
+	alerts := alertsOfType(calc.Calculate(), AlertAbandonedClaim)
+	if len(alerts) != 1 || alerts[0].IssueID != "A" {
+		t.Fatalf("abandoned claim alerts = %+v, want only A", alerts)
+	}
+	if !strings.Contains(alerts[0].Message, "alice") {
+		t.Errorf("message should name the assignee: %q", alerts[0].Message)
+	}
+}
+
+func TestCheckHighImpactUnblocks(t *testing.T) {
+	blocks := func(from, to string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: from, DependsOnID: to, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Priority: 2},
+		{ID: "B", Status: model.StatusOpen, Priority: 1, Dependencies: blocks("B", "A")},
+		{ID: "C", Status: model.StatusOpen, Priority: 3, Dependencies: blocks("C", "A")},
+		{ID: "D", Status: model.StatusOpen, Priority: 2},
+		{ID: "E", Status: model.StatusOpen, Priority: 3, Dependencies: blocks("E", "D")},
+	}
+
+	calc := NewCalculator(&baseline.Baseline{}, &baseline.Baseline{}, DefaultConfig())
+	calc.SetIssues(issues)
+	alerts := alertsOfType(calc.Calculate(), AlertHighImpactUnblock)
+	if len(alerts) != 1 || alerts[0].IssueID != "A" {
+		t.Fatalf("high impact alerts = %+v, want only A", alerts)
+	}
+	if len(alerts[0].Details) != 1 || alerts[0].Details[0] != "B" || alerts[0].UnblocksCount != 1 {
+		t.Errorf("alert should list only the P1 dependent: %+v", alerts[0])
+	}
+
+	cfg := DefaultConfig()
+	cfg.LabelOverrides = map[string]*LabelConfig{"big": {HighImpactMinUnblocks: 2}}
+	issues[0].Labels = []string{"big"}
+	calc = NewCalculator(&baseline.Baseline{}, &baseline.Baseline{}, cfg)
+	calc.SetIssues(issues)
+	if alerts := alertsOfType(calc.Calculate(), AlertHighImpactUnblock); len(alerts) != 0 {
+		t.Errorf("override requiring 2 high-priority dependents should suppress A, got %+v", alerts)
+	}
+}
+
+func TestCheckPotentialDuplicates(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Title: "Login page crashes on submit", Description: "Submitting the login form crashes"},
+		{ID: "B", Status: model.StatusOpen, Title: "Login page crashes on submit", Description: "Submitting the login form crashes"},
+		{ID: "C", Status: model.StatusClosed, Title: "Login page crashes on submit", Description: "Submitting the login form crashes"},
+		{ID: "D", Status: model.StatusOpen, Title: "Add dark mode theme", Description: "Support a dark color scheme"},
+	}
+
+	calc := NewCalculator(&baseline.Baseline{}, &baseline.Baseline{}, DefaultConfig())
+	calc.SetIssues(issues)
+	alerts := alertsOfType(calc.Calculate(), AlertPotentialDuplicate)
+	if len(alerts) != 1 {
+		t.Fatalf("duplicate alerts = %+v, want one (A/B; closed C is ignored)", alerts)
+	}
+	if ids := alerts[0].IssueID + alerts[0].Details[0]; !strings.Contains(ids, "A") || !strings.Contains(ids, "B") {
+		t.Errorf("alert should pair A and B: %+v", alerts[0])
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledAlerts = []string{string(AlertPotentialDuplicate)}
+	calc = NewCalculator(&baseline.Baseline{}, &baseline.Baseline{}, cfg)
+	calc.SetIssues(issues)
+	if alerts := alertsOfType(calc.Calculate(), AlertPotentialDuplicate); len(alerts) != 0 {
+		t.Errorf("disabled alert type should not fire, got %+v", alerts)
+	}
+}
+
+func TestNewThresholdOverrides(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LabelOverrides = map[string]*LabelConfig{
+		"urgent": {AbandonedClaimDays: 2, DuplicateSimilarity: 0.6},
+		"slow":   {AbandonedClaimDays: 20},
+	}
+	if got := cfg.GetAbandonedClaimDays([]string{"slow", "urgent"}); got != 2 {
+		t.Errorf("GetAbandonedClaimDays = %d, want tightest 2", got)
+	}
+	if got := cfg.GetAbandonedClaimDays(nil); got != cfg.AbandonedClaimDays {
+		t.Errorf("GetAbandonedClaimDays without labels = %d", got)
+	}
+	if got := cfg.GetDuplicateSimilarity([]string{"urgent"}); got != 0.6 {
+		t.Errorf("GetDuplicateSimilarity = %v, want 0.6", got)
+	}
+	if got := cfg.GetVelocityDropPct("other"); got != cfg.VelocityDropWarningPct {
+		t.Errorf("GetVelocityDropPct = %v", got)
+	}
+
+	cfg.LabelOverrides["bad"] = &LabelConfig{DuplicateSimilarity: 1.5}
+	if err := cfg.Validate(); err == nil {
+		t.Error("duplicate_similarity > 1 should fail validation")
+	}
+}
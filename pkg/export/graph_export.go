@@ -333,7 +333,7 @@ func generateDOT(issues []model.Issue, issueIDs map[string]bool, stats *analysis
 
 // dotStatusColor returns a DOT-compatible color for a status.
 func dotStatusColor(status model.Status) string {
-	switch status {
+	switch status.Base() {
 	case model.StatusOpen:
 		return "#C8E6C9" // Light green
 	case model.StatusInProgress:
@@ -412,7 +412,7 @@ func generateMermaid(issues []model.Issue, issueIDs map[string]bool) string {
 
 		// Apply class based on status
 		var class string
-		switch i.Status {
+		switch i.Status.Base() {
 		case model.StatusOpen:
 			class = "open"
 		case model.StatusInProgress:
@@ -1,13 +1,48 @@
 package export
 
 import (
+	"encoding/json"
 	"fmt"
+	"html"
+	"strings"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
+// graphTypeLegend builds the type filter options, the type legend and the
+// TYPE_COLORS map for the interactive graph from the type registry. Custom
+// types are drawn as circles and listed with their icon.
+func graphTypeLegend() (options, legend, colorsJSON string) {
+	colors := map[string]string{"feature": "#a855f7", "bug": "#ef4444", "task": "#22d3ee", "epic": "#fbbf24"}
+	var opts, items strings.Builder
+	for _, builtin := range []struct{ name, label, shape string }{
+		{"feature", "Feature", "●"}, {"bug", "Bug", "▲"}, {"task", "Task", "■"}, {"epic", "Epic", "◆"},
+	} {
+		fmt.Fprintf(&opts, "\n                    <option value=\"%s\">%s</option>", builtin.name, builtin.label)
+		fmt.Fprintf(&items, "\n                    <div class=\"legend-item\"><span style=\"font-size:1rem\">%s</span> %s</div>", builtin.shape, builtin.label)
+		if hex := model.IssueType(builtin.name).Color(); hex != "" {
+			colors[builtin.name] = hex
+		}
+	}
+	for _, def := range model.CurrentTypeRegistry().CustomTypes() {
+		name := html.EscapeString(string(def.Name))
+		style := "font-size:1rem"
+		if def.Color != "" {
+			style += ";color:" + def.Color
+			colors[string(def.Name)] = def.Color
+		}
+		fmt.Fprintf(&opts, "\n                    <option value=\"%s\">%s</option>", name, name)
+		fmt.Fprintf(&items, "\n                    <div class=\"legend-item\"><span style=\"%s\">●</span> %s %s</div>", style, html.EscapeString(def.Icon), name)
+	}
+	data, _ := json.Marshal(colors)
+	return opts.String(), items.String(), string(data)
+}
+
 // generateUltimateHTML creates the enhanced HTML visualization with all features
 func generateUltimateHTML(title, dataHash, graphDataJSON string, nodeCount, edgeCount int, projectName, forceGraphLib, markedLib string) string {
 	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	typeOptions, typeLegend, typeColors := graphTypeLegend()
 	return fmt.Sprintf(`<!DOCTYPE html>
 <html lang="en">
 <head>
@@ -620,11 +655,7 @@ func generateUltimateHTML(title, dataHash, graphDataJSON string, nodeCount, edge
                     <option value="closed">Closed</option>
                 </select>
                 <select id="filter-type" title="Filter nodes by type. Shows only beads matching the selected type.">
-                    <option value="">All Types</option>
-                    <option value="feature">Feature</option>
-                    <option value="bug">Bug</option>
-                    <option value="task">Task</option>
-                    <option value="epic">Epic</option>
+                    <option value="">All Types</option>%s
                 </select>
             </div>
             <div class="toolbar-group">
@@ -811,11 +842,7 @@ func generateUltimateHTML(title, dataHash, graphDataJSON string, nodeCount, edge
             </div>
             <div class="panel">
                 <div class="panel-title">Type Shapes</div>
-                <div class="legend">
-                    <div class="legend-item"><span style="font-size:1rem">●</span> Feature</div>
-                    <div class="legend-item"><span style="font-size:1rem">▲</span> Bug</div>
-                    <div class="legend-item"><span style="font-size:1rem">■</span> Task</div>
-                    <div class="legend-item"><span style="font-size:1rem">◆</span> Epic</div>
+                <div class="legend">%s
                 </div>
             </div>
             <div class="panel">
@@ -922,7 +949,7 @@ func generateUltimateHTML(title, dataHash, graphDataJSON string, nodeCount, edge
 const DATA = %s;
 const STATUS_COLORS = { open: '#22c55e', in_progress: '#f97316', blocked: '#ef4444', closed: '#555577' };
 const PRIORITY_COLORS = ['#ef4444', '#f97316', '#eab308', '#22c55e', '#555577'];
-const TYPE_COLORS = { feature: '#a855f7', bug: '#ef4444', task: '#22d3ee', epic: '#fbbf24' };
+const TYPE_COLORS = %s;
 
 // Configure marked for safe HTML rendering
 marked.setOptions({ breaks: true, gfm: true });
@@ -1848,5 +1875,5 @@ loadPreferences();
 setTimeout(() => { Graph.zoomToFit(400, 50); updateVisibleCount(); updateMinimap(); }, 800);
     </script>
 </body>
-</html>`, title, title, nodeCount, edgeCount, nodeCount, nodeCount, edgeCount, timestamp, dataHash, projectName, forceGraphLib, markedLib, graphDataJSON)
+</html>`, title, title, typeOptions, nodeCount, edgeCount, nodeCount, nodeCount, edgeCount, typeLegend, timestamp, dataHash, projectName, forceGraphLib, markedLib, graphDataJSON, typeColors)
 }
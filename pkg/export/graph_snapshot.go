@@ -287,7 +287,7 @@ var (
 )
 
 func statusColor(s model.Status) color.RGBA {
-	switch s {
+	switch s.Base() {
 	case model.StatusOpen:
 		return colorOpen
 	case model.StatusBlocked:
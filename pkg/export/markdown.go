@@ -72,6 +72,11 @@ func sanitizeMermaidText(text string) string {
 
 // GenerateMarkdown creates a comprehensive markdown report of all issues
 func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
+	return generateMarkdown(issues, title, true)
+}
+
+// generateMarkdown renders the report, optionally omitting the Mermaid dependency graph.
+func generateMarkdown(issues []model.Issue, title string, includeGraph bool) (string, error) {
 	var sb strings.Builder
 
 	// Header
@@ -83,7 +88,7 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 
 	open, inProgress, blocked, closed := 0, 0, 0, 0
 	for _, i := range issues {
-		switch i.Status {
+		switch i.Status.Base() {
 		case model.StatusOpen:
 			open++
 		case model.StatusInProgress:
@@ -116,19 +121,21 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 	sb.WriteString("\n---\n\n")
 
 	// Dependency Graph (Mermaid)
-	sb.WriteString("## Dependency Graph\n\n")
-	sb.WriteString("```mermaid\n")
+	if includeGraph {
+		sb.WriteString("## Dependency Graph\n\n")
+		sb.WriteString("```mermaid\n")
 
-	issueIDs := make(map[string]bool)
-	for _, i := range issues {
-		issueIDs[i.ID] = true
-	}
+		issueIDs := make(map[string]bool)
+		for _, i := range issues {
+			issueIDs[i.ID] = true
+		}
 
-	graph := GenerateMermaidGraph(issues, issueIDs, MermaidConfig{ShowNoDependenciesNode: true})
-	sb.WriteString(graph)
+		graph := GenerateMermaidGraph(issues, issueIDs, MermaidConfig{ShowNoDependenciesNode: true})
+		sb.WriteString(graph)
 
-	sb.WriteString("```\n\n")
-	sb.WriteString("---\n\n")
+		sb.WriteString("```\n\n")
+		sb.WriteString("---\n\n")
+	}
 
 	// Individual Issues
 	for _, i := range issues {
@@ -230,7 +237,7 @@ func createSlug(id string) string {
 }
 
 func getStatusEmoji(status string) string {
-	switch status {
+	switch model.Status(status).Base() {
 	case "open":
 		return "🟢"
 	case "in_progress":
@@ -245,20 +252,7 @@ func getStatusEmoji(status string) string {
 }
 
 func getTypeEmoji(issueType string) string {
-	switch issueType {
-	case "bug":
-		return "🐛"
-	case "feature":
-		return "✨"
-	case "task":
-		return "📋"
-	case "epic":
-		return "🚀" // Use rocket instead of mountain - VS-16 variation selector causes width issues
-	case "chore":
-		return "🧹"
-	default:
-		return "•"
-	}
+	return model.IssueType(issueType).Icon()
 }
 
 func getPriorityLabel(priority int) string {
@@ -286,8 +280,8 @@ func SaveMarkdownToFile(issues []model.Issue, filename string) error {
 
 	// Sort issues for the report: Open first, then priority, then date
 	sort.Slice(issuesCopy, func(i, j int) bool {
-		iClosed := issuesCopy[i].Status == model.StatusClosed
-		jClosed := issuesCopy[j].Status == model.StatusClosed
+		iClosed := issuesCopy[i].Status.IsClosed()
+		jClosed := issuesCopy[j].Status.IsClosed()
 		if iClosed != jClosed {
 			return !iClosed
 		}
@@ -314,7 +308,7 @@ func generateQuickActions(issues []model.Issue) string {
 
 	for _, i := range issues {
 		escapedID := shellEscape(i.ID)
-		switch i.Status {
+		switch i.Status.Base() {
 		case model.StatusOpen:
 			openIDs = append(openIDs, escapedID)
 		case model.StatusInProgress:
@@ -322,7 +316,7 @@ func generateQuickActions(issues []model.Issue) string {
 		case model.StatusBlocked:
 			blockedIDs = append(blockedIDs, escapedID)
 		}
-		if i.Status != model.StatusClosed && i.Priority <= 1 {
+		if !i.Status.IsClosed() && i.Priority <= 1 {
 			highPriorityIDs = append(highPriorityIDs, escapedID)
 		}
 	}
@@ -373,7 +367,7 @@ func generateIssueCommands(issue model.Issue) string {
 	var sb strings.Builder
 
 	// Skip command snippets for closed issues
-	if issue.Status == model.StatusClosed {
+	if issue.Status.IsClosed() {
 		return ""
 	}
 
@@ -383,7 +377,7 @@ func generateIssueCommands(issue model.Issue) string {
 	sb.WriteString("```bash\n")
 
 	// Status transitions based on current state
-	switch issue.Status {
+	switch issue.Status.Base() {
 	case model.StatusOpen:
 		sb.WriteString("# Start working on this issue\n")
 		sb.WriteString(fmt.Sprintf("bd update %s -s in_progress\n\n", escapedID))
@@ -569,6 +563,7 @@ func GeneratePriorityBriefFromTriageJSON(triageJSON []byte, config PriorityBrief
 			Title         string `json:"title"`
 			UnblocksCount int    `json:"unblocks_count"`
 			Actionable    bool   `json:"actionable"`
+			Repo          string `json:"repo"`
 		} `json:"blockers_to_clear"`
 	}
 
@@ -679,8 +674,12 @@ func GeneratePriorityBriefFromTriageJSON(triageJSON []byte, config PriorityBrief
 			if b.Actionable {
 				ready = "✅"
 			}
-			sb.WriteString(fmt.Sprintf("| **%s** %s | %d | %s |\n",
-				b.ID,
+			id := "**" + b.ID + "**"
+			if b.Repo != "" {
+				id += " `" + b.Repo + "`" // Owning repo in workspace mode
+			}
+			sb.WriteString(fmt.Sprintf("| %s %s | %d | %s |\n",
+				id,
 				truncateString(b.Title, 30),
 				b.UnblocksCount,
 				ready,
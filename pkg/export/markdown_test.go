@@ -232,6 +232,32 @@ func TestGetTypeEmoji(t *testing.T) {
 	}
 }
 
+func TestCustomTypeRendering(t *testing.T) {
+	registry := &model.TypeRegistry{Types: []model.TypeDefinition{
+		{Name: "incident", Icon: "🚨", Color: "#FF5555"},
+	}}
+	if err := registry.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	model.SetTypeRegistry(registry)
+	t.Cleanup(func() { model.SetTypeRegistry(nil) })
+
+	if got := getTypeEmoji("incident"); got != "🚨" {
+		t.Errorf("getTypeEmoji(incident) = %q; want 🚨", got)
+	}
+
+	options, legend, colors := graphTypeLegend()
+	if !strings.Contains(options, `<option value="incident">incident</option>`) {
+		t.Errorf("type filter missing custom type: %s", options)
+	}
+	if !strings.Contains(legend, "color:#FF5555") || !strings.Contains(legend, "🚨 incident") {
+		t.Errorf("legend missing custom type: %s", legend)
+	}
+	if !strings.Contains(colors, `"incident":"#FF5555"`) {
+		t.Errorf("TYPE_COLORS missing custom type: %s", colors)
+	}
+}
+
 // ============================================================================
 // truncateString (UTF-8 safe) tests
 // ============================================================================
@@ -75,7 +75,7 @@ func GenerateMermaidGraph(issues []model.Issue, issueIDs map[string]bool, config
 
 		// Apply class based on status
 		var class string
-		switch i.Status {
+		switch i.Status.Base() {
 		case model.StatusOpen:
 			class = "open"
 		case model.StatusInProgress:
@@ -0,0 +1,255 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Recipe export formats (recipe export.format).
+const (
+	RecipeFormatMarkdown = "markdown"
+	RecipeFormatJSON     = "json"
+	RecipeFormatCSV      = "csv"
+	RecipeFormatMermaid  = "mermaid"
+)
+
+// RecipeExportOptions controls a recipe-driven export.
+type RecipeExportOptions struct {
+	Format       string // markdown, json, csv, mermaid (ignored when TemplatePath is set)
+	IncludeGraph bool   // Include a Mermaid dependency graph (markdown/json)
+	TemplatePath string // Custom text/template file; overrides Format
+}
+
+// IssueMetrics are the per-issue graph metrics exposed to exports and templates.
+type IssueMetrics struct {
+	PageRank     float64  `json:"pagerank"`
+	Betweenness  float64  `json:"betweenness"`
+	Eigenvector  float64  `json:"eigenvector"`
+	CriticalPath float64  `json:"critical_path"`
+	BlockedBy    []string `json:"blocked_by,omitempty"` // Open issues blocking this one
+	Unblocks     []string `json:"unblocks,omitempty"`   // Issues waiting on this one
+}
+
+// RecipeExportData is the payload for recipe exports. Custom templates receive it as dot,
+// e.g. {{range .Issues}}{{.ID}} {{(index $.Metrics .ID).PageRank}}{{end}}.
+type RecipeExportData struct {
+	Title       string                  `json:"title"`
+	Recipe      string                  `json:"recipe"`
+	GeneratedAt time.Time               `json:"generated_at"`
+	Issues      []model.Issue           `json:"issues"`
+	Metrics     map[string]IssueMetrics `json:"metrics"`
+	Triage      *analysis.TriageResult  `json:"triage,omitempty"`
+	Graph       string                  `json:"graph,omitempty"` // Mermaid source when IncludeGraph
+}
+
+// RecipeFormatForPath infers an export format from a file extension.
+// Returns "" when the extension is not recognized.
+func RecipeFormatForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return RecipeFormatMarkdown
+	case ".json":
+		return RecipeFormatJSON
+	case ".csv":
+		return RecipeFormatCSV
+	case ".mmd", ".mermaid":
+		return RecipeFormatMermaid
+	default:
+		return ""
+	}
+}
+
+// BuildRecipeExportData computes metrics and triage over allIssues (so scores reflect
+// the whole graph) and attaches them to the recipe's selected issues.
+func BuildRecipeExportData(title, recipeName string, selected, allIssues []model.Issue, includeGraph bool) RecipeExportData {
+	analyzer := analysis.NewAnalyzer(allIssues)
+	stats := analyzer.Analyze()
+	triage := analysis.ComputeTriageFromAnalyzer(analyzer, &stats, allIssues, analysis.TriageOptions{}, time.Now())
+
+	byID := make(map[string]model.Issue, len(allIssues))
+	for _, issue := range allIssues {
+		byID[issue.ID] = issue
+	}
+	unblocks := make(map[string][]string)
+	for _, issue := range allIssues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				unblocks[dep.DependsOnID] = append(unblocks[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+
+	metrics := make(map[string]IssueMetrics, len(selected))
+	for _, issue := range selected {
+		m := IssueMetrics{
+			PageRank:     stats.GetPageRankScore(issue.ID),
+			Betweenness:  stats.GetBetweennessScore(issue.ID),
+			Eigenvector:  stats.GetEigenvectorScore(issue.ID),
+			CriticalPath: stats.GetCriticalPathScore(issue.ID),
+			Unblocks:     unblocks[issue.ID],
+		}
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if blocker, ok := byID[dep.DependsOnID]; ok && !blocker.Status.IsClosed() {
+				m.BlockedBy = append(m.BlockedBy, blocker.ID)
+			}
+		}
+		metrics[issue.ID] = m
+	}
+
+	data := RecipeExportData{
+		Title:       title,
+		Recipe:      recipeName,
+		GeneratedAt: time.Now().UTC(),
+		Issues:      selected,
+		Metrics:     metrics,
+		Triage:      &triage,
+	}
+	if includeGraph {
+		ids := make(map[string]bool, len(selected))
+		for _, issue := range selected {
+			ids[issue.ID] = true
+		}
+		data.Graph = GenerateMermaidGraph(selected, ids, MermaidConfig{ShowNoDependenciesNode: true})
+	}
+	return data
+}
+
+// WriteRecipeExport renders data to w using opts.
+func WriteRecipeExport(w io.Writer, data RecipeExportData, opts RecipeExportOptions) error {
+	if opts.TemplatePath != "" {
+		return writeRecipeTemplate(w, data, opts.TemplatePath)
+	}
+
+	switch strings.ToLower(opts.Format) {
+	case "", RecipeFormatMarkdown, "md":
+		out, err := generateMarkdown(data.Issues, data.Title, opts.IncludeGraph)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, out)
+		return err
+	case RecipeFormatJSON:
+		if !opts.IncludeGraph {
+			data.Graph = ""
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	case RecipeFormatCSV:
+		return writeRecipeCSV(w, data)
+	case RecipeFormatMermaid:
+		graph := data.Graph
+		if graph == "" {
+			ids := make(map[string]bool, len(data.Issues))
+			for _, issue := range data.Issues {
+				ids[issue.ID] = true
+			}
+			graph = GenerateMermaidGraph(data.Issues, ids, MermaidConfig{ShowNoDependenciesNode: true})
+		}
+		_, err := io.WriteString(w, graph)
+		return err
+	default:
+		return fmt.Errorf("unknown export format %q (expected markdown, json, csv, or mermaid)", opts.Format)
+	}
+}
+
+// SaveRecipeExport writes a recipe export to filename.
+func SaveRecipeExport(filename string, data RecipeExportData, opts RecipeExportOptions) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := WriteRecipeExport(f, data, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeRecipeCSV(w io.Writer, data RecipeExportData) error {
+	cw := csv.NewWriter(w)
+	header := []string{"id", "title", "status", "priority", "type", "assignee", "labels",
+		"created_at", "updated_at", "closed_at", "pagerank", "betweenness", "critical_path", "blocked_by"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	formatFloat := func(v float64) string { return strconv.FormatFloat(v, 'f', 6, 64) }
+	for _, issue := range data.Issues {
+		m := data.Metrics[issue.ID]
+		closedAt := ""
+		if issue.ClosedAt != nil {
+			closedAt = issue.ClosedAt.Format(time.RFC3339)
+		}
+		row := []string{
+			issue.ID,
+			issue.Title,
+			string(issue.Status),
+			strconv.Itoa(issue.Priority),
+			string(issue.IssueType),
+			issue.Assignee,
+			strings.Join(issue.Labels, ";"),
+			issue.CreatedAt.Format(time.RFC3339),
+			issue.UpdatedAt.Format(time.RFC3339),
+			closedAt,
+			formatFloat(m.PageRank),
+			formatFloat(m.Betweenness),
+			formatFloat(m.CriticalPath),
+			strings.Join(m.BlockedBy, ";"),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// recipeTemplateFuncs are the helpers available to custom export templates.
+var recipeTemplateFuncs = template.FuncMap{
+	"join":          strings.Join,
+	"upper":         strings.ToUpper,
+	"lower":         strings.ToLower,
+	"truncate":      truncateString,
+	"statusEmoji":   func(s model.Status) string { return getStatusEmoji(string(s)) },
+	"typeEmoji":     func(t model.IssueType) string { return getTypeEmoji(string(t)) },
+	"priorityLabel": getPriorityLabel,
+	"date": func(layout string, t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(layout)
+	},
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func writeRecipeTemplate(w io.Writer, data RecipeExportData, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading export template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(recipeTemplateFuncs).Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("parsing export template %s: %w", path, err)
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("executing export template %s: %w", path, err)
+	}
+	return nil
+}
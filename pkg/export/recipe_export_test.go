@@ -0,0 +1,174 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func recipeExportFixture() []model.Issue {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []model.Issue{
+		{ID: "A", Title: "Root task", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 1, CreatedAt: now, UpdatedAt: now},
+		{ID: "B", Title: "Waits on A", Status: model.StatusOpen, IssueType: model.TypeBug, Priority: 2, Labels: []string{"api", "ui"}, CreatedAt: now, UpdatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Done", Status: model.StatusClosed, IssueType: model.TypeTask, Priority: 3, CreatedAt: now, UpdatedAt: now},
+	}
+}
+
+func TestRecipeFormatForPath(t *testing.T) {
+	tests := map[string]string{
+		"out.md":       RecipeFormatMarkdown,
+		"out.JSON":     RecipeFormatJSON,
+		"out.csv":      RecipeFormatCSV,
+		"graph.mmd":    RecipeFormatMermaid,
+		"report.txt":   "",
+		"no-extension": "",
+	}
+	for path, want := range tests {
+		if got := RecipeFormatForPath(path); got != want {
+			t.Errorf("RecipeFormatForPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestBuildRecipeExportData_MetricsUseFullGraph(t *testing.T) {
+	all := recipeExportFixture()
+	data := BuildRecipeExportData("T", "blocked", all[1:2], all, false)
+
+	if len(data.Issues) != 1 || data.Issues[0].ID != "B" {
+		t.Fatalf("unexpected selected issues: %+v", data.Issues)
+	}
+	m, ok := data.Metrics["B"]
+	if !ok {
+		t.Fatal("missing metrics for B")
+	}
+	if len(m.BlockedBy) != 1 || m.BlockedBy[0] != "A" {
+		t.Errorf("BlockedBy = %v, want [A]", m.BlockedBy)
+	}
+	if _, ok := data.Metrics["A"]; ok {
+		t.Errorf("metrics should only cover selected issues")
+	}
+	if data.Triage == nil {
+		t.Error("expected triage data")
+	}
+	if data.Graph != "" {
+		t.Error("graph should be empty when not requested")
+	}
+}
+
+func TestWriteRecipeExport_Formats(t *testing.T) {
+	all := recipeExportFixture()
+	data := BuildRecipeExportData("Report", "r", all, all, true)
+
+	t.Run("markdown without graph", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteRecipeExport(&buf, data, RecipeExportOptions{Format: "markdown"}); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		if !strings.Contains(out, "# Report") || strings.Contains(out, "```mermaid") {
+			t.Errorf("unexpected markdown output:\n%s", out)
+		}
+	})
+
+	t.Run("markdown with graph", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteRecipeExport(&buf, data, RecipeExportOptions{Format: "markdown", IncludeGraph: true}); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "```mermaid") {
+			t.Error("expected mermaid graph in markdown")
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteRecipeExport(&buf, data, RecipeExportOptions{Format: "json"}); err != nil {
+			t.Fatal(err)
+		}
+		var decoded RecipeExportData
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if len(decoded.Issues) != 3 || decoded.Recipe != "r" || decoded.Graph != "" {
+			t.Errorf("unexpected JSON payload: recipe=%q issues=%d graph=%q", decoded.Recipe, len(decoded.Issues), decoded.Graph)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteRecipeExport(&buf, data, RecipeExportOptions{Format: "csv"}); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("invalid CSV: %v", err)
+		}
+		if len(rows) != 4 || rows[0][0] != "id" {
+			t.Fatalf("unexpected CSV rows: %v", rows)
+		}
+		if rows[2][6] != "api;ui" || rows[2][13] != "A" {
+			t.Errorf("unexpected row for B: %v", rows[2])
+		}
+	})
+
+	t.Run("mermaid", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteRecipeExport(&buf, data, RecipeExportOptions{Format: "mermaid"}); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(buf.String(), "graph TD") {
+			t.Errorf("unexpected mermaid output: %q", buf.String())
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		if err := WriteRecipeExport(&bytes.Buffer{}, data, RecipeExportOptions{Format: "xlsx"}); err == nil {
+			t.Error("expected error for unknown format")
+		}
+	})
+}
+
+func TestWriteRecipeExport_Template(t *testing.T) {
+	all := recipeExportFixture()
+	data := BuildRecipeExportData("Report", "blocked", all[:2], all, false)
+
+	dir := t.TempDir()
+	tmplPath := filepath.Join(dir, "status.tmpl")
+	tmpl := `{{.Recipe}}:{{range .Issues}} {{.ID}}={{upper (printf "%s" .Status)}}{{with index $.Metrics .ID}}[{{join .BlockedBy ","}}]{{end}}{{end}} top={{(index .Triage.Recommendations 0).ID}}`
+	if err := os.WriteFile(tmplPath, []byte(tmpl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRecipeExport(&buf, data, RecipeExportOptions{Format: "json", TemplatePath: tmplPath}); err != nil {
+		t.Fatalf("template export: %v", err)
+	}
+	got := buf.String()
+	if !strings.HasPrefix(got, "blocked: A=OPEN[] B=OPEN[A] top=") {
+		t.Errorf("unexpected template output: %q", got)
+	}
+}
+
+func TestWriteRecipeExport_TemplateErrors(t *testing.T) {
+	data := RecipeExportData{}
+	if err := WriteRecipeExport(&bytes.Buffer{}, data, RecipeExportOptions{TemplatePath: filepath.Join(t.TempDir(), "missing.tmpl")}); err == nil {
+		t.Error("expected error for missing template")
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.tmpl")
+	if err := os.WriteFile(bad, []byte("{{.Nope"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteRecipeExport(&bytes.Buffer{}, data, RecipeExportOptions{TemplatePath: bad}); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// GenerateSprintRetroMarkdown renders a sprint retrospective as a Markdown
+// report suitable for pasting into a team retro doc.
+func GenerateSprintRetroMarkdown(retro analysis.SprintRetro) string {
+	var sb strings.Builder
+
+	name := retro.Name
+	if name == "" {
+		name = retro.SprintID
+	}
+	sb.WriteString(fmt.Sprintf("# 🔁 Sprint Retrospective: %s\n\n", name))
+	sb.WriteString(fmt.Sprintf("*%s → %s", retro.StartDate.Format("2006-01-02"), retro.EndDate.Format("2006-01-02")))
+	if !retro.Ended {
+		sb.WriteString(" (in progress)")
+	}
+	sb.WriteString("*\n\n")
+
+	// Summary
+	v := retro.Velocity
+	sb.WriteString("## 📈 Summary\n\n")
+	sb.WriteString("| Planned | Added | Removed | Completed | Carry-over | Per Week |\n")
+	sb.WriteString("|:-------:|:-----:|:-------:|:---------:|:----------:|:--------:|\n")
+	sb.WriteString(fmt.Sprintf("| %d | %d | %d | %d | %d | %.1f |\n\n",
+		len(retro.Planned), len(retro.Added), len(retro.Removed),
+		len(retro.Completed), len(retro.CarryOver), v.PerWeek))
+
+	if v.Target > 0 {
+		verdict := "❌ missed"
+		if v.MetTarget != nil && *v.MetTarget {
+			verdict = "✅ met"
+		}
+		sb.WriteString(fmt.Sprintf("**Velocity:** %d of %g beads (%.0f%% of goal, %s)  \n", v.Completed, v.Target, v.PercentOfGoal, verdict))
+	} else {
+		sb.WriteString(fmt.Sprintf("**Velocity:** %d beads (no target set)  \n", v.Completed))
+	}
+	sb.WriteString(fmt.Sprintf("**Completion rate:** %.0f%% (%d planned, %d added mid-sprint)\n\n", v.CompletionRate*100, v.PlannedDone, v.AddedDone))
+	if v.Note != "" {
+		sb.WriteString(fmt.Sprintf("> %s\n\n", v.Note))
+	}
+	if retro.ScopeSource != "git" {
+		sb.WriteString("> Scope at sprint start could not be read from git history; planned vs added is approximate.\n\n")
+	}
+
+	// Planned vs completed
+	sb.WriteString("## 🎯 Planned vs Completed\n\n")
+	writeRetroBeads(&sb, retro.Planned, "*Nothing was planned at sprint start.*")
+
+	sb.WriteString("## ➕ Scope Changes\n\n")
+	if len(retro.Added) == 0 && len(retro.Removed) == 0 {
+		sb.WriteString("*No mid-sprint scope changes.*\n\n")
+	} else {
+		sb.WriteString("| Change | Issue | Date |\n")
+		sb.WriteString("|:------:|-------|------|\n")
+		for _, b := range retro.Added {
+			sb.WriteString(fmt.Sprintf("| added | **%s** %s | %s |\n", b.ID, truncateString(b.Title, 40), retroDate(b.AddedAt)))
+		}
+		for _, b := range retro.Removed {
+			sb.WriteString(fmt.Sprintf("| removed | **%s** %s | %s |\n", b.ID, truncateString(b.Title, 40), retroDate(b.RemovedAt)))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## ⏭️ Carry-over\n\n")
+	if len(retro.CarryOver) == 0 {
+		sb.WriteString("*Everything was finished.*\n\n")
+	} else {
+		sb.WriteString("| Issue | Status | Next Sprint |\n")
+		sb.WriteString("|-------|:------:|-------------|\n")
+		for _, b := range retro.CarryOver {
+			next := b.CarriedTo
+			if next == "" {
+				next = "-"
+			}
+			sb.WriteString(fmt.Sprintf("| **%s** %s | %s %s | %s |\n", b.ID, truncateString(b.Title, 40), getStatusEmoji(b.Status), b.Status, next))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Cycle time
+	ct := retro.CycleTime
+	sb.WriteString("## ⏱️ Cycle Time\n\n")
+	if ct.Samples == 0 {
+		sb.WriteString("*No beads were completed.*\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("%d completed beads: median **%.1fd**, p85 %.1fd, avg %.1fd (min %.1fd, max %.1fd)\n\n",
+			ct.Samples, ct.P50Days, ct.P85Days, ct.AvgDays, ct.MinDays, ct.MaxDays))
+		sb.WriteString("| Range | Beads | |\n")
+		sb.WriteString("|-------|:-----:|---|\n")
+		for _, bucket := range ct.Buckets {
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s |\n", bucket.Label, bucket.Count, barChart(float64(bucket.Count)/float64(ct.Samples))))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Blockers
+	sb.WriteString("## 🚧 Blockers That Stalled Work\n\n")
+	if len(retro.Blockers) == 0 {
+		sb.WriteString("*No blocked periods found in history.*\n\n")
+	} else {
+		sb.WriteString("| Blocker | Stalled | Blocked Time |\n")
+		sb.WriteString("|---------|---------|:------------:|\n")
+		for _, b := range retro.Blockers {
+			sb.WriteString(fmt.Sprintf("| **%s** %s | %s | %.1fd |\n", b.BlockerID, truncateString(b.Title, 30), strings.Join(b.StalledBeads, ", "), b.BlockedHours/24))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func writeRetroBeads(sb *strings.Builder, beads []analysis.RetroBead, empty string) {
+	if len(beads) == 0 {
+		sb.WriteString(empty + "\n\n")
+		return
+	}
+	sb.WriteString("| Issue | Status | Closed |\n")
+	sb.WriteString("|-------|:------:|--------|\n")
+	for _, b := range beads {
+		sb.WriteString(fmt.Sprintf("| **%s** %s | %s %s | %s |\n", b.ID, truncateString(b.Title, 40), getStatusEmoji(b.Status), b.Status, retroDate(b.ClosedAt)))
+	}
+	sb.WriteString("\n")
+}
+
+func retroDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,47 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestGenerateSprintRetroMarkdown(t *testing.T) {
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	closed := start.AddDate(0, 0, 2)
+	sprint := model.Sprint{ID: "s1", Name: "Sprint 1", StartDate: start, EndDate: start.AddDate(0, 0, 14), VelocityTarget: 2, BeadIDs: []string{"A", "B"}}
+	issues := []model.Issue{
+		{ID: "A", Title: "Ship login", Status: model.StatusClosed, CreatedAt: start, ClosedAt: &closed},
+		{ID: "B", Title: "Fix logout", Status: model.StatusOpen, CreatedAt: start},
+		{ID: "X", Title: "Auth service", Status: model.StatusClosed, CreatedAt: start},
+	}
+	retro := analysis.SprintRetrospective(analysis.SprintRetroInput{
+		Sprint:         sprint,
+		Sprints:        []model.Sprint{sprint, {ID: "s2", Name: "Sprint 2", StartDate: sprint.EndDate, BeadIDs: []string{"B"}}},
+		Issues:         issues,
+		AtStart:        issues,
+		BlockedPeriods: []analysis.RetroBlockedPeriod{{BeadID: "B", BlockerID: "X", Start: start, End: start.AddDate(0, 0, 3)}},
+	}, start.AddDate(0, 0, 20))
+
+	md := GenerateSprintRetroMarkdown(retro)
+	for _, want := range []string{
+		"# 🔁 Sprint Retrospective: Sprint 1",
+		"2025-01-06 → 2025-01-20",
+		"**Velocity:** 1 of 2 beads (50% of goal, ❌ missed)",
+		"| **A** Ship login | ⚫ closed | 2025-01-08 |",
+		"*No mid-sprint scope changes.*",
+		"| **B** Fix logout | 🟢 open | s2 |",
+		"median **2.0d**",
+		"| **X** Auth service | B | 3.0d |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "approximate") {
+		t.Errorf("scope from git should not be flagged approximate:\n%s", md)
+	}
+}
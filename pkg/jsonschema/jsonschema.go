@@ -0,0 +1,267 @@
+// Package jsonschema derives JSON Schema (draft 2020-12) documents from Go
+// types by reflection, so that published schemas always match what
+// encoding/json actually emits for bv's robot output structs.
+//
+// Field names, omission and embedding follow encoding/json rules. Fields
+// without omitempty or omitzero are required; slices, maps and pointers
+// among them may also be null, since that is how nil values encode. Two
+// optional struct tags refine a field:
+//
+//	description:"Human readable text"
+//	enum:"a,b,c"
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect emitted by Reflect.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema. Only the keywords bv needs
+// are modelled.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 any                `json:"type,omitempty"` // string or []string
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	rawType       = reflect.TypeOf(json.RawMessage{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	unsafeDefChar = regexp.MustCompile(`[^A-Za-z0-9_.]`)
+)
+
+// Reflect returns the schema for the type of v. Named struct types other
+// than the root are emitted once under $defs and referenced, which also
+// makes recursive types terminate.
+func Reflect(v any) *Schema {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return &Schema{Schema: Draft}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	r := &reflector{root: t, names: make(map[reflect.Type]string), taken: make(map[string]bool), defs: make(map[string]*Schema)}
+	s := r.schemaFor(t, true)
+	s.Schema = Draft
+	if len(r.defs) > 0 {
+		s.Defs = r.defs
+	}
+	return s
+}
+
+// ReflectAnyOf returns a schema accepting any of the types of vs, for
+// outputs that take one of several shapes. The alternatives share $defs.
+func ReflectAnyOf(vs ...any) *Schema {
+	r := &reflector{names: make(map[reflect.Type]string), taken: make(map[string]bool), defs: make(map[string]*Schema)}
+	s := &Schema{Schema: Draft}
+	for _, v := range vs {
+		t := reflect.TypeOf(v)
+		if t == nil {
+			continue
+		}
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		s.AnyOf = append(s.AnyOf, r.schemaFor(t, true))
+	}
+	if len(r.defs) > 0 {
+		s.Defs = r.defs
+	}
+	return s
+}
+
+type reflector struct {
+	root  reflect.Type
+	names map[reflect.Type]string
+	taken map[string]bool
+	defs  map[string]*Schema
+}
+
+func (r *reflector) schemaFor(t reflect.Type, atRoot bool) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawType:
+		return &Schema{}
+	case t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface &&
+		(t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType)):
+		// Custom encodings cannot be inferred; accept anything.
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Pointer:
+		return r.schemaFor(t.Elem(), false)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &Schema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &Schema{Type: "array", Items: r.schemaFor(t.Elem(), false)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaFor(t.Elem(), false)}
+	case reflect.Struct:
+		if atRoot || t.Name() == "" {
+			return r.structSchema(t)
+		}
+		if t == r.root {
+			return &Schema{Ref: "#"}
+		}
+		return &Schema{Ref: "#/$defs/" + r.define(t)}
+	default:
+		// Interfaces and anything encoding/json treats dynamically
+		return &Schema{}
+	}
+}
+
+// define registers a named struct under $defs and returns its name.
+func (r *reflector) define(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	name := unsafeDefChar.ReplaceAllString(t.Name(), "_")
+	if r.taken[name] {
+		pkg := t.PkgPath()
+		if i := strings.LastIndex(pkg, "/"); i >= 0 {
+			pkg = pkg[i+1:]
+		}
+		name = unsafeDefChar.ReplaceAllString(pkg, "_") + "." + name
+	}
+	r.names[t] = name
+	r.taken[name] = true
+	r.defs[name] = nil // reserve before recursing
+	r.defs[name] = r.structSchema(t)
+	return name
+}
+
+func (r *reflector) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(s, t, make(map[string]bool), false)
+	return s
+}
+
+// addFields adds t's JSON-visible fields to s, flattening embedded structs
+// the way encoding/json does. Shallower fields win name conflicts. Fields
+// promoted through an embedded pointer are never required, since a nil
+// pointer omits them.
+func (r *reflector) addFields(s *Schema, t reflect.Type, seen map[string]bool, optional bool) {
+	type embed struct {
+		t        reflect.Type
+		optional bool
+	}
+	var embedded []embed
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			viaPointer := ft.Kind() == reflect.Pointer
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, embed{ft, optional || viaPointer})
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		omitEmpty := strings.Contains(","+opts+",", ",omitempty,") || strings.Contains(","+opts+",", ",omitzero,")
+		fs := r.schemaFor(f.Type, false)
+		if strings.Contains(","+opts+",", ",string,") {
+			fs = &Schema{Type: "string"}
+		}
+		if desc := f.Tag.Get("description"); desc != "" {
+			fs.Description = desc
+		}
+		if enum := f.Tag.Get("enum"); enum != "" {
+			for _, v := range strings.Split(enum, ",") {
+				fs.Enum = append(fs.Enum, v)
+			}
+		}
+		if !omitEmpty {
+			if !optional {
+				s.Required = append(s.Required, name)
+			}
+			if nullable(f.Type) {
+				fs = withNull(fs)
+			}
+		}
+		s.Properties[name] = fs
+	}
+	for _, e := range embedded {
+		r.addFields(s, e.t, seen, e.optional)
+	}
+}
+
+// nullable reports whether the zero value of t encodes as JSON null.
+func nullable(t reflect.Type) bool {
+	if t == rawType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// withNull widens s to also accept null. Empty schemas already do.
+func withNull(s *Schema) *Schema {
+	if typ, ok := s.Type.(string); ok {
+		s.Type = []string{typ, "null"}
+		return s
+	}
+	if s.Ref != "" {
+		return &Schema{
+			Description: s.Description,
+			AnyOf:       []*Schema{{Ref: s.Ref}, {Type: "null"}},
+		}
+	}
+	return s
+}
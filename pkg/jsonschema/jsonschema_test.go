@@ -0,0 +1,128 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type embeddedMeta struct {
+	Hash string `json:"hash"`
+}
+
+type node struct {
+	Name     string  `json:"name"`
+	Children []*node `json:"children,omitempty"`
+}
+
+type sample struct {
+	embeddedMeta
+	ID       string          `json:"id" description:"Identifier"`
+	Kind     string          `json:"kind,omitempty" enum:"a,b"`
+	Count    uint            `json:"count"`
+	Score    float64         `json:"score,omitempty"`
+	When     time.Time       `json:"when"`
+	Tags     []string        `json:"tags"`
+	Attrs    map[string]int  `json:"attrs,omitempty"`
+	Root     *node           `json:"root,omitempty"`
+	Extra    json.RawMessage `json:"extra,omitempty"`
+	Skipped  string          `json:"-"`
+	internal string
+	Any      any               `json:"any,omitempty"`
+	Nested   struct{ X bool }  `json:"nested"`
+	Labels   map[string]string `json:"labels"`
+}
+
+func TestReflect(t *testing.T) {
+	s := Reflect(sample{})
+	if s.Schema != Draft || s.Type != "object" {
+		t.Fatalf("root = %+v", s)
+	}
+
+	wantRequired := []string{"id", "count", "when", "tags", "nested", "labels", "hash"}
+	if !reflect.DeepEqual(s.Required, wantRequired) {
+		t.Errorf("required = %v, want %v", s.Required, wantRequired)
+	}
+	for _, name := range []string{"Skipped", "internal", "-"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("property %q should be skipped", name)
+		}
+	}
+
+	props := s.Properties
+	if props["id"].Description != "Identifier" {
+		t.Errorf("id description = %q", props["id"].Description)
+	}
+	if !reflect.DeepEqual(props["kind"].Enum, []any{"a", "b"}) {
+		t.Errorf("kind enum = %v", props["kind"].Enum)
+	}
+	if props["count"].Type != "integer" || props["count"].Minimum == nil || *props["count"].Minimum != 0 {
+		t.Errorf("count = %+v", props["count"])
+	}
+	if props["when"].Format != "date-time" {
+		t.Errorf("when = %+v", props["when"])
+	}
+	if !reflect.DeepEqual(props["tags"].Type, []string{"array", "null"}) {
+		t.Errorf("tags type = %v (nil slices encode as null)", props["tags"].Type)
+	}
+	if props["attrs"].AdditionalProperties.Type != "integer" {
+		t.Errorf("attrs = %+v", props["attrs"])
+	}
+	if props["hash"] == nil {
+		t.Error("embedded struct fields should be flattened")
+	}
+	if props["nested"].Properties["X"] == nil {
+		t.Error("anonymous structs should be inlined")
+	}
+
+	if props["root"].Ref != "#/$defs/node" {
+		t.Fatalf("root ref = %q", props["root"].Ref)
+	}
+	def := s.Defs["node"]
+	if def == nil || def.Properties["children"].Items.Ref != "#/$defs/node" {
+		t.Errorf("recursive def = %+v", def)
+	}
+}
+
+func TestReflect_IsValidJSON(t *testing.T) {
+	data, err := json.Marshal(Reflect(&sample{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out["$schema"] != Draft {
+		t.Errorf("$schema = %v", out["$schema"])
+	}
+}
+
+func TestReflectAnyOf(t *testing.T) {
+	s := ReflectAnyOf(node{}, &sample{})
+	if len(s.AnyOf) != 2 || s.Schema != Draft {
+		t.Fatalf("anyOf = %+v", s.AnyOf)
+	}
+	if s.AnyOf[0].Properties["name"] == nil {
+		t.Errorf("first alternative should inline node: %+v", s.AnyOf[0])
+	}
+	if s.AnyOf[1].Properties["root"].Ref != "#/$defs/node" || s.Defs["node"] == nil {
+		t.Errorf("alternatives should share $defs: %+v", s.Defs)
+	}
+}
+
+func TestReflect_OptionalFields(t *testing.T) {
+	type withPointer struct {
+		*embeddedMeta
+		When time.Time `json:"when,omitzero"`
+		Note string    `json:"note"`
+	}
+	s := Reflect(withPointer{})
+	if !reflect.DeepEqual(s.Required, []string{"note"}) {
+		t.Errorf("required = %v, want only note (embedded pointer and omitzero fields may be absent)", s.Required)
+	}
+	if s.Properties["hash"] == nil {
+		t.Error("fields promoted through an embedded pointer should still be described")
+	}
+}
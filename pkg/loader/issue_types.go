@@ -0,0 +1,35 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// IssueTypesFileName is the project issue type config under .bv/.
+const IssueTypesFileName = "types.yaml"
+
+// LoadIssueTypes reads custom issue types from .bv/types.yaml under
+// projectDir. A missing file yields the built-in types.
+func LoadIssueTypes(projectDir string) (*model.TypeRegistry, error) {
+	path := filepath.Join(projectDir, ".bv", IssueTypesFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return model.DefaultTypeRegistry(), nil
+		}
+		return nil, fmt.Errorf("reading issue type config: %w", err)
+	}
+
+	var registry model.TypeRegistry
+	if err := yaml.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := registry.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return &registry, nil
+}
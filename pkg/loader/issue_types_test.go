@@ -0,0 +1,82 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writeIssueTypes(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".bv", IssueTypesFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadIssueTypesMissingFileIsDefault(t *testing.T) {
+	r, err := LoadIssueTypes(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(r.Types) != 5 || len(r.CustomTypes()) != 0 {
+		t.Fatalf("expected the built-in types, got %+v", r.Types)
+	}
+}
+
+func TestLoadIssueTypesCustom(t *testing.T) {
+	dir := writeIssueTypes(t, `types:
+  - name: incident
+    icon: "🚨"
+    color: "#FF5555"
+    priority_weight: 1.5
+  - name: spike
+`)
+	r, err := LoadIssueTypes(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	def, ok := r.Lookup("incident")
+	if !ok || def.Icon != "🚨" || def.Color != "#FF5555" || def.PriorityWeight != 1.5 {
+		t.Errorf("incident = %+v, %v", def, ok)
+	}
+	if _, ok := r.Lookup("spike"); !ok {
+		t.Error("expected spike to be registered")
+	}
+}
+
+func TestLoadIssueTypesInvalid(t *testing.T) {
+	_, err := LoadIssueTypes(writeIssueTypes(t, "types:\n  - name: spike\n    color: blue\n"))
+	if err == nil || !strings.Contains(err.Error(), "color") {
+		t.Fatalf("expected color error, got %v", err)
+	}
+}
+
+func TestLoadIssuesKeepsCustomTypes(t *testing.T) {
+	r, err := LoadIssueTypes(writeIssueTypes(t, "types:\n  - name: spike\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	model.SetTypeRegistry(r)
+	t.Cleanup(func() { model.SetTypeRegistry(nil) })
+
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	data := `{"id":"bv-1","title":"A","status":"open","priority":1,"issue_type":"spike"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].IssueType != "spike" {
+		t.Fatalf("expected the spike issue to load, got %+v", issues)
+	}
+}
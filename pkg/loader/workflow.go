@@ -0,0 +1,35 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkflowFileName is the project workflow config under .bv/.
+const WorkflowFileName = "workflow.yaml"
+
+// LoadWorkflow reads custom statuses from .bv/workflow.yaml under projectDir.
+// A missing file yields the default workflow.
+func LoadWorkflow(projectDir string) (*model.Workflow, error) {
+	path := filepath.Join(projectDir, ".bv", WorkflowFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return model.DefaultWorkflow(), nil
+		}
+		return nil, fmt.Errorf("reading workflow config: %w", err)
+	}
+
+	var workflow model.Workflow
+	if err := yaml.Unmarshal(data, &workflow); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := workflow.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return &workflow, nil
+}
@@ -0,0 +1,89 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writeWorkflow(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".bv", WorkflowFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadWorkflowMissingFileIsDefault(t *testing.T) {
+	w, err := LoadWorkflow(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(w.Statuses) != 4 || w.Statuses[0].Name != model.StatusOpen {
+		t.Fatalf("expected default workflow, got %+v", w.Statuses)
+	}
+}
+
+func TestLoadWorkflowCustomStatuses(t *testing.T) {
+	dir := writeWorkflow(t, `statuses:
+  - name: open
+  - name: in_progress
+  - name: review
+    category: active
+    label: In Review
+  - name: closed
+  - name: wontfix
+    category: done
+`)
+	w, err := LoadWorkflow(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(w.Statuses) != 5 {
+		t.Fatalf("expected 5 statuses, got %d", len(w.Statuses))
+	}
+	def, ok := w.Lookup("review")
+	if !ok || def.Category != model.CategoryActive || def.Label != "In Review" {
+		t.Errorf("review = %+v, %v", def, ok)
+	}
+	if def, _ := w.Lookup(model.StatusClosed); def.Category != model.CategoryDone {
+		t.Errorf("built-in closed should keep category done, got %q", def.Category)
+	}
+}
+
+func TestLoadWorkflowInvalid(t *testing.T) {
+	dir := writeWorkflow(t, "statuses:\n  - name: review\n")
+	_, err := LoadWorkflow(dir)
+	if err == nil || !strings.Contains(err.Error(), "category") {
+		t.Fatalf("expected category error, got %v", err)
+	}
+}
+
+func TestLoadIssuesKeepsCustomStatuses(t *testing.T) {
+	w, err := LoadWorkflow(writeWorkflow(t, "statuses:\n  - name: open\n  - name: review\n    category: active\n  - name: closed\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	model.SetWorkflow(w)
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	data := `{"id":"bv-1","title":"A","status":"review","priority":1,"issue_type":"task"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Status != "review" {
+		t.Fatalf("expected the review issue to load, got %+v", issues)
+	}
+}
@@ -0,0 +1,418 @@
+// Package mcp implements `bv mcp`, a Model Context Protocol server on stdio.
+//
+// Agents that speak MCP get bv's robot queries as typed tools (triage, next,
+// plan, blocker-chain, related, forecast, search, suggest) and each issue
+// as a bv://issue/<id> resource. Queries run against a pkg/server.Server, so
+// the snapshot stays warm and follows the beads file exactly as it does for
+// `bv serve`. Tool input and output schemas are generated from the Go
+// structs by pkg/jsonschema.
+//
+// Messages are newline-delimited JSON-RPC 2.0, per the MCP stdio transport.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/server"
+)
+
+// ProtocolVersion is the latest MCP revision this server implements.
+const ProtocolVersion = "2025-06-18"
+
+// supportedVersions are the revisions accepted during initialize, newest
+// first. Structured tool output was added in 2025-06-18.
+var supportedVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// IssueURIPrefix prefixes every issue resource URI.
+const IssueURIPrefix = "bv://issue/"
+
+// maxMessageSize bounds a single JSON-RPC line.
+const maxMessageSize = 16 << 20
+
+// JSON-RPC 2.0 and MCP error codes.
+const (
+	codeParseError       = -32700
+	codeInvalidRequest   = -32600
+	codeMethodNotFound   = -32601
+	codeInvalidParams    = -32602
+	codeInternalError    = -32603
+	codeResourceNotFound = -32002
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithVersion sets the version reported in serverInfo.
+func WithVersion(v string) Option {
+	return func(s *Server) {
+		s.version = v
+	}
+}
+
+// WithLogger sets where protocol errors are logged. Stdout belongs to the
+// protocol, so this is normally stderr.
+func WithLogger(w io.Writer) Option {
+	return func(s *Server) {
+		s.logger = log.New(w, "bv mcp: ", log.LstdFlags)
+	}
+}
+
+// Server answers MCP requests using a loaded pkg/server.Server.
+type Server struct {
+	backend *server.Server
+	version string
+	logger  *log.Logger
+
+	mu       sync.Mutex // guards protocol
+	protocol string     // negotiated revision
+	outMu    sync.Mutex // serializes writes
+}
+
+// New creates an MCP server over backend, which must already be loaded.
+func New(backend *server.Server, opts ...Option) *Server {
+	s := &Server{
+		backend:  backend,
+		version:  "dev",
+		logger:   log.New(io.Discard, "", 0),
+		protocol: ProtocolVersion,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+func invalidParams(format string, args ...any) *rpcError {
+	return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+// Serve reads requests from r and writes responses to w until r is
+// exhausted or ctx is cancelled. Requests are answered in order.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	lines := make(chan []byte)
+	scanErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line, ok := <-lines:
+			if !ok {
+				select {
+				case err := <-scanErr:
+					return err
+				default:
+					return nil
+				}
+			}
+			if len(strings.TrimSpace(string(line))) == 0 {
+				continue
+			}
+			if resp := s.handle(line); resp != nil {
+				if err := s.write(w, resp); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (s *Server) write(w io.Writer, resp *response) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(response{JSONRPC: "2.0", ID: resp.ID, Error: &rpcError{Code: codeInternalError, Message: err.Error()}})
+	}
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// handle answers one JSON-RPC message. It returns nil for notifications,
+// which get no response.
+func (s *Server) handle(data []byte) *response {
+	var req request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}}
+	}
+	isNotification := len(req.ID) == 0
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if isNotification {
+			return nil
+		}
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: codeInvalidRequest, Message: `expected {"jsonrpc":"2.0","method":...}`}}
+	}
+
+	result, err := s.dispatch(req.Method, req.Params)
+	if isNotification {
+		if err != nil {
+			s.logger.Printf("notification %s: %v", req.Method, err)
+		}
+		return nil
+	}
+	resp := &response{JSONRPC: "2.0", ID: req.ID}
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+		resp.Error = rerr
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *Server) dispatch(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		return s.initialize(params)
+	case "ping":
+		return struct{}{}, nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		return s.callTool(params)
+	case "resources/list":
+		return s.listResources()
+	case "resources/templates/list":
+		return s.listResourceTemplates(), nil
+	case "resources/read":
+		return s.readResource(params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", method)}
+	}
+}
+
+type initializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ServerInfo      map[string]any `json:"serverInfo"`
+	Instructions    string         `json:"instructions,omitempty"`
+}
+
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, invalidParams("initialize: %v", err)
+		}
+	}
+	// Echo the client's revision when we support it, else offer ours.
+	version := ProtocolVersion
+	for _, v := range supportedVersions {
+		if v == p.ProtocolVersion {
+			version = v
+			break
+		}
+	}
+	s.mu.Lock()
+	s.protocol = version
+	s.mu.Unlock()
+
+	return initializeResult{
+		ProtocolVersion: version,
+		Capabilities: map[string]any{
+			"tools":     map[string]any{"listChanged": false},
+			"resources": map[string]any{"listChanged": false, "subscribe": false},
+		},
+		ServerInfo: map[string]any{"name": "bv", "title": "Beads Viewer", "version": s.version},
+		Instructions: "Graph-aware triage for the beads issue tracker. Call triage first; " +
+			"use next for a single pick, blocker-chain to see why an issue is blocked, " +
+			"and read bv://issue/<id> for full issue details.",
+	}, nil
+}
+
+// structuredOutput reports whether the negotiated revision supports
+// outputSchema and structuredContent.
+func (s *Server) structuredOutput() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.protocol >= "2025-06-18"
+}
+
+type toolInfo struct {
+	Name         string `json:"name"`
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description"`
+	InputSchema  any    `json:"inputSchema"`
+	OutputSchema any    `json:"outputSchema,omitempty"`
+}
+
+func (s *Server) listTools() any {
+	structured := s.structuredOutput()
+	tools := make([]toolInfo, 0, len(Tools))
+	for _, t := range Tools {
+		info := toolInfo{Name: t.Name, Title: t.Title, Description: t.Description, InputSchema: t.InputSchema()}
+		if structured {
+			info.OutputSchema = t.OutputSchema()
+		}
+		tools = append(tools, info)
+	}
+	return map[string]any{"tools": tools}
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type toolResult struct {
+	Content           []content `json:"content"`
+	StructuredContent any       `json:"structuredContent,omitempty"`
+	IsError           bool      `json:"isError,omitempty"`
+}
+
+func (s *Server) callTool(params json.RawMessage) (any, error) {
+	var p struct {
+		Name      string                     `json:"name"`
+		Arguments map[string]json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams("tools/call: %v", err)
+	}
+	tool, ok := FindTool(p.Name)
+	if !ok {
+		return nil, invalidParams("unknown tool: %s", p.Name)
+	}
+	args, err := server.ParamsFromJSON(p.Arguments)
+	if err != nil {
+		return nil, invalidParams("%s: %v", p.Name, err)
+	}
+	for _, name := range tool.InputSchema().Required {
+		if args[name] == "" {
+			return toolError(fmt.Errorf("%s is required", name)), nil
+		}
+	}
+
+	// Query failures are reported in the result so the model can see them
+	// and correct its arguments.
+	out, err := s.backend.Call(tool.Method, args)
+	if err != nil {
+		return toolError(err), nil
+	}
+	text, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode %s result: %w", p.Name, err)
+	}
+	result := toolResult{Content: []content{{Type: "text", Text: string(text)}}}
+	if s.structuredOutput() {
+		result.StructuredContent = out
+	}
+	return result, nil
+}
+
+func toolError(err error) toolResult {
+	return toolResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}
+}
+
+type resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+	Text        string `json:"text,omitempty"`
+}
+
+func (s *Server) listResources() (any, error) {
+	issues, err := s.backend.Issues()
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]resource, 0, len(issues))
+	for _, issue := range issues {
+		resources = append(resources, resource{
+			URI:         IssueURIPrefix + issue.ID,
+			Name:        issue.ID,
+			Title:       issue.Title,
+			Description: fmt.Sprintf("%s %s, P%d", issue.Status, issue.IssueType, issue.Priority),
+			MimeType:    "application/json",
+		})
+	}
+	return map[string]any{"resources": resources}, nil
+}
+
+func (s *Server) listResourceTemplates() any {
+	return map[string]any{"resourceTemplates": []map[string]string{{
+		"uriTemplate": IssueURIPrefix + "{id}",
+		"name":        "issue",
+		"title":       "Issue",
+		"description": "One issue with its triage recommendation, blocker chain and dependents",
+		"mimeType":    "application/json",
+	}}}
+}
+
+func (s *Server) readResource(params json.RawMessage) (any, error) {
+	var p struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, invalidParams("resources/read: %v", err)
+	}
+	id, ok := strings.CutPrefix(p.URI, IssueURIPrefix)
+	if !ok || id == "" {
+		return nil, &rpcError{Code: codeResourceNotFound, Message: fmt.Sprintf("resource not found: %s", p.URI)}
+	}
+	out, err := s.backend.Call("issue", server.Params{"id": id})
+	if err != nil {
+		var qerr *server.Error
+		if errors.As(err, &qerr) && qerr.Status == http.StatusNotFound {
+			return nil, &rpcError{Code: codeResourceNotFound, Message: fmt.Sprintf("resource not found: %s", p.URI)}
+		}
+		return nil, err
+	}
+	text, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", p.URI, err)
+	}
+	return map[string]any{"contents": []resource{{URI: p.URI, MimeType: "application/json", Text: string(text)}}}, nil
+}
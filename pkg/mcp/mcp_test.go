@@ -0,0 +1,221 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/server"
+)
+
+const testIssues = `{"id":"bv-1","title":"Set up database","status":"open","priority":1,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bv-2","title":"Build login API","status":"open","priority":2,"issue_type":"feature","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+`
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte(testIssues), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	backend := server.New(path)
+	if err := backend.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	t.Cleanup(func() { backend.Close() })
+	return New(backend, WithVersion("test"))
+}
+
+// session runs the requests through Serve and returns the decoded
+// responses in order.
+func session(t *testing.T, s *Server, requests ...string) []map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	in := strings.NewReader(strings.Join(requests, "\n") + "\n")
+	if err := s.Serve(context.Background(), in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var responses []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("invalid response line %q: %v", line, err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func result(t *testing.T, resp map[string]any) map[string]any {
+	t.Helper()
+	if resp["error"] != nil {
+		t.Fatalf("unexpected error: %v", resp["error"])
+	}
+	return resp["result"].(map[string]any)
+}
+
+func TestServe_InitializeAndListTools(t *testing.T) {
+	s := newTestServer(t)
+	responses := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"ping"}`,
+	)
+	if len(responses) != 3 {
+		t.Fatalf("got %d responses, want 3 (notifications are not answered)", len(responses))
+	}
+
+	init := result(t, responses[0])
+	if init["protocolVersion"] != "2025-06-18" {
+		t.Errorf("protocolVersion = %v", init["protocolVersion"])
+	}
+	if info := init["serverInfo"].(map[string]any); info["name"] != "bv" || info["version"] != "test" {
+		t.Errorf("serverInfo = %v", info)
+	}
+
+	tools := result(t, responses[1])["tools"].([]any)
+	var names []string
+	for _, raw := range tools {
+		tool := raw.(map[string]any)
+		names = append(names, tool["name"].(string))
+		if tool["inputSchema"].(map[string]any)["type"] != "object" {
+			t.Errorf("%s inputSchema must be an object schema", tool["name"])
+		}
+		if tool["outputSchema"].(map[string]any)["type"] != "object" {
+			t.Errorf("%s outputSchema must be an object schema", tool["name"])
+		}
+	}
+	want := "triage,next,plan,blocker-chain,related,forecast,search,suggest"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("tools = %s, want %s", got, want)
+	}
+
+	if responses[2]["id"].(float64) != 3 || responses[2]["error"] != nil {
+		t.Errorf("ping = %v", responses[2])
+	}
+}
+
+func TestServe_OlderProtocolOmitsStructuredOutput(t *testing.T) {
+	s := newTestServer(t)
+	responses := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"next"}}`,
+	)
+	if v := result(t, responses[0])["protocolVersion"]; v != "2024-11-05" {
+		t.Errorf("protocolVersion = %v", v)
+	}
+	tool := result(t, responses[1])["tools"].([]any)[0].(map[string]any)
+	if _, ok := tool["outputSchema"]; ok {
+		t.Error("outputSchema is not part of 2024-11-05")
+	}
+	if _, ok := result(t, responses[2])["structuredContent"]; ok {
+		t.Error("structuredContent is not part of 2024-11-05")
+	}
+}
+
+func TestServe_CallTools(t *testing.T) {
+	s := newTestServer(t)
+	responses := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"next","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"blocker-chain","arguments":{"id":"bv-2"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"blocker-chain","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"triage","arguments":{"group":"bogus"}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"nope"}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"suggest","arguments":{"min_confidence":0.5}}}`,
+	)
+
+	next := result(t, responses[0])
+	if next["isError"] == true {
+		t.Fatalf("next failed: %v", next)
+	}
+	if id := next["structuredContent"].(map[string]any)["id"]; id != "bv-1" {
+		t.Errorf("next id = %v, want bv-1", id)
+	}
+	text := next["content"].([]any)[0].(map[string]any)["text"].(string)
+	if !strings.Contains(text, `"id": "bv-1"`) {
+		t.Errorf("text content should carry the JSON result: %s", text)
+	}
+
+	chain := result(t, responses[1])["structuredContent"].(map[string]any)["result"].(map[string]any)
+	if chain["is_blocked"] != true {
+		t.Errorf("bv-2 should be blocked: %v", chain)
+	}
+
+	for i, want := range []string{"id is required", "group must be"} {
+		res := result(t, responses[2+i])
+		if res["isError"] != true {
+			t.Errorf("response %d should be a tool error: %v", 2+i, res)
+			continue
+		}
+		if msg := res["content"].([]any)[0].(map[string]any)["text"].(string); !strings.Contains(msg, want) {
+			t.Errorf("tool error = %q, want %q", msg, want)
+		}
+	}
+
+	if code := responses[4]["error"].(map[string]any)["code"].(float64); code != codeInvalidParams {
+		t.Errorf("unknown tool code = %v", code)
+	}
+	if res := result(t, responses[5]); res["isError"] == true {
+		t.Errorf("suggest failed: %v", res)
+	}
+}
+
+func TestServe_Resources(t *testing.T) {
+	s := newTestServer(t)
+	responses := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"resources/templates/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/read","params":{"uri":"bv://issue/bv-1"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"resources/read","params":{"uri":"bv://issue/missing"}}`,
+	)
+
+	resources := result(t, responses[0])["resources"].([]any)
+	if len(resources) != 2 || resources[0].(map[string]any)["uri"] != "bv://issue/bv-1" {
+		t.Errorf("resources = %v", resources)
+	}
+	tmpl := result(t, responses[1])["resourceTemplates"].([]any)[0].(map[string]any)
+	if tmpl["uriTemplate"] != "bv://issue/{id}" {
+		t.Errorf("template = %v", tmpl)
+	}
+
+	contents := result(t, responses[2])["contents"].([]any)[0].(map[string]any)
+	var issue server.IssueResponse
+	if err := json.Unmarshal([]byte(contents["text"].(string)), &issue); err != nil {
+		t.Fatal(err)
+	}
+	if issue.Issue.ID != "bv-1" || len(issue.Dependents) != 1 || issue.Dependents[0] != "bv-2" {
+		t.Errorf("issue resource = %+v", issue)
+	}
+
+	if code := responses[3]["error"].(map[string]any)["code"].(float64); code != codeResourceNotFound {
+		t.Errorf("missing resource code = %v", code)
+	}
+}
+
+func TestServe_ProtocolErrors(t *testing.T) {
+	s := newTestServer(t)
+	responses := session(t, s,
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"bogus"}`,
+		`{"id":2,"method":"ping"}`,
+	)
+	codes := []float64{codeParseError, codeMethodNotFound, codeInvalidRequest}
+	for i, want := range codes {
+		if code := responses[i]["error"].(map[string]any)["code"].(float64); code != want {
+			t.Errorf("response %d code = %v, want %v", i, code, want)
+		}
+	}
+}
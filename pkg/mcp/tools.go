@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"github.com/Dicklesworthstone/beads_viewer/pkg/jsonschema"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/server"
+)
+
+// Tool maps an MCP tool onto a pkg/server query. Arguments are decoded into
+// Params exactly as JSON-RPC params are for `bv serve`.
+type Tool struct {
+	Name        string
+	Title       string
+	Description string
+	Method      string // server.Methods key
+	Args        any    // Zero value of the arguments struct, for the input schema
+	Output      any    // Zero value of the result struct, for the output schema
+}
+
+// Argument structs exist only to generate input schemas.
+
+type noArgs struct{}
+
+type triageArgs struct {
+	Group string `json:"group,omitempty" enum:"track,label" description:"Also group recommendations by execution track or by label"`
+}
+
+type idArgs struct {
+	ID string `json:"id" description:"Issue ID"`
+}
+
+type relatedArgs struct {
+	ID            string `json:"id" description:"Issue ID"`
+	MinRelevance  int    `json:"min_relevance,omitempty" description:"Minimum relevance score 0-100 (default 20)"`
+	MaxResults    int    `json:"max_results,omitempty" description:"Maximum results per category (default 10)"`
+	IncludeClosed bool   `json:"include_closed,omitempty" description:"Include closed beads"`
+	Limit         int    `json:"limit,omitempty" description:"Maximum commits to scan (default 500)"`
+}
+
+type forecastArgs struct {
+	ID     string `json:"id,omitempty" description:"Issue ID, or \"all\" for every open issue (default)"`
+	Label  string `json:"label,omitempty" description:"Only forecast issues with this label"`
+	Sprint string `json:"sprint,omitempty" description:"Only forecast issues in this sprint"`
+	Agents int    `json:"agents,omitempty" description:"Number of parallel agents (default 1)"`
+}
+
+type searchArgs struct {
+	Q      string `json:"q" description:"Search query"`
+	Limit  int    `json:"limit,omitempty" description:"Maximum results (default 10)"`
+	Mode   string `json:"mode,omitempty" enum:"text,hybrid" description:"Pure semantic ranking or graph-aware hybrid ranking"`
+	Preset string `json:"preset,omitempty" enum:"default,bug-hunting,sprint-planning,impact-first,text-only" description:"Hybrid ranking weight preset"`
+}
+
+type suggestArgs struct {
+	Type          string  `json:"type,omitempty" enum:"duplicate,dependency,label,cycle" description:"Only return suggestions of this kind"`
+	Bead          string  `json:"bead,omitempty" description:"Only return suggestions involving this issue"`
+	MinConfidence float64 `json:"min_confidence,omitempty" description:"Minimum confidence 0.0-1.0"`
+}
+
+// Tools lists the tools in the order tools/list reports them. Each mirrors
+// the --robot-* flag of the same name.
+var Tools = []Tool{
+	{
+		Name:        "triage",
+		Title:       "Triage",
+		Description: "Unified triage: ranked recommendations, quick wins, blockers to clear, project health and alerts. Start here.",
+		Method:      "triage",
+		Args:        triageArgs{},
+		Output:      server.TriageResponse{},
+	},
+	{
+		Name:        "next",
+		Title:       "Next pick",
+		Description: "The single highest-impact actionable issue, with the command to claim it.",
+		Method:      "next",
+		Args:        noArgs{},
+		Output:      server.NextResponse{},
+	},
+	{
+		Name:        "plan",
+		Title:       "Execution plan",
+		Description: "Dependency-respecting execution plan grouped into parallel tracks.",
+		Method:      "plan",
+		Args:        noArgs{},
+		Output:      server.PlanResponse{},
+	},
+	{
+		Name:        "blocker-chain",
+		Title:       "Blocker chain",
+		Description: "Why an issue is blocked: the full chain of open blockers down to the root blockers that must be done first.",
+		Method:      "blocker-chain",
+		Args:        idArgs{},
+		Output:      server.BlockerChainResponse{},
+	},
+	{
+		Name:        "related",
+		Title:       "Related work",
+		Description: "Beads related to an issue through shared files, shared commits, dependencies and concurrent activity in git history.",
+		Method:      "related",
+		Args:        relatedArgs{},
+		Output:      server.RelatedResponse{},
+	},
+	{
+		Name:        "forecast",
+		Title:       "Forecast",
+		Description: "ETA estimates from complexity, dependency depth and historical velocity.",
+		Method:      "forecast",
+		Args:        forecastArgs{},
+		Output:      server.ForecastResponse{},
+	},
+	{
+		Name:        "search",
+		Title:       "Search",
+		Description: "Semantic search over issue titles and descriptions, optionally re-ranked with graph metrics.",
+		Method:      "search",
+		Args:        searchArgs{},
+		Output:      server.SearchResponse{},
+	},
+	{
+		Name:        "suggest",
+		Title:       "Suggestions",
+		Description: "Hygiene suggestions: potential duplicates, missing dependencies, labels and cycle warnings.",
+		Method:      "suggest",
+		Args:        suggestArgs{},
+		Output:      server.SuggestResponse{},
+	},
+}
+
+// FindTool returns the tool with the given name.
+func FindTool(name string) (Tool, bool) {
+	for _, t := range Tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Tool{}, false
+}
+
+// InputSchema returns the JSON Schema for the tool's arguments.
+func (t Tool) InputSchema() *jsonschema.Schema {
+	return jsonschema.Reflect(t.Args)
+}
+
+// OutputSchema returns the JSON Schema for the tool's structured result.
+func (t Tool) OutputSchema() *jsonschema.Schema {
+	return jsonschema.Reflect(t.Output)
+}
@@ -0,0 +1,163 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// TypeDefinition declares how an issue type is shown and weighed
+type TypeDefinition struct {
+	Name           IssueType `yaml:"name" json:"name"`
+	Icon           string    `yaml:"icon,omitempty" json:"icon,omitempty"`
+	Color          string    `yaml:"color,omitempty" json:"color,omitempty"`                     // Hex color; empty uses the theme's color
+	PriorityWeight float64   `yaml:"priority_weight,omitempty" json:"priority_weight,omitempty"` // Multiplies the priority boost in impact scores (default 1.0)
+}
+
+// TypeRegistry is the set of issue types a project uses: the built-in types
+// followed by any custom ones.
+type TypeRegistry struct {
+	Types []TypeDefinition `yaml:"types" json:"types"`
+
+	byName map[IssueType]TypeDefinition
+}
+
+// builtinTypes are the built-in issue types in display order
+var builtinTypes = []TypeDefinition{
+	{Name: TypeBug, Icon: "🐛", PriorityWeight: 1.0},
+	{Name: TypeFeature, Icon: "✨", PriorityWeight: 1.0},
+	{Name: TypeTask, Icon: "📋", PriorityWeight: 1.0},
+	// Use 🚀 instead of 🏔️ - the snow-capped mountain has a variation selector
+	// (U+FE0F) that causes inconsistent width calculations across terminals
+	{Name: TypeEpic, Icon: "🚀", PriorityWeight: 1.0},
+	{Name: TypeChore, Icon: "🧹", PriorityWeight: 1.0},
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+
+// DefaultTypeRegistry returns the registry of the built-in types.
+func DefaultTypeRegistry() *TypeRegistry {
+	r := &TypeRegistry{}
+	_ = r.Validate()
+	return r
+}
+
+// Validate checks the declared types and indexes them. Declarations of
+// built-in types override their icon, color or weight; the result lists the
+// built-in types first, then custom types in declaration order.
+func (r *TypeRegistry) Validate() error {
+	declared := make(map[IssueType]TypeDefinition, len(r.Types))
+	var custom []TypeDefinition
+	for i, def := range r.Types {
+		name := IssueType(strings.TrimSpace(string(def.Name)))
+		if name == "" {
+			return fmt.Errorf("type %d: name cannot be empty", i+1)
+		}
+		if _, dup := declared[name]; dup {
+			return fmt.Errorf("type %q declared twice", name)
+		}
+		if def.Color != "" && !hexColorPattern.MatchString(def.Color) {
+			return fmt.Errorf("type %q: color must be a hex color like #FF5555 (got %q)", name, def.Color)
+		}
+		if def.PriorityWeight < 0 {
+			return fmt.Errorf("type %q: priority_weight cannot be negative", name)
+		}
+		def.Name = name
+		declared[name] = def
+		if !name.isBuiltin() {
+			custom = append(custom, def)
+		}
+	}
+
+	types := make([]TypeDefinition, 0, len(builtinTypes)+len(custom))
+	for _, builtin := range builtinTypes {
+		def := builtin
+		if override, ok := declared[builtin.Name]; ok {
+			if override.Icon != "" {
+				def.Icon = override.Icon
+			}
+			def.Color = override.Color
+			if override.PriorityWeight > 0 {
+				def.PriorityWeight = override.PriorityWeight
+			}
+		}
+		types = append(types, def)
+	}
+	for _, def := range custom {
+		if def.Icon == "" {
+			def.Icon = "•"
+		}
+		if def.PriorityWeight == 0 {
+			def.PriorityWeight = 1.0
+		}
+		types = append(types, def)
+	}
+
+	r.Types = types
+	r.byName = make(map[IssueType]TypeDefinition, len(types))
+	for _, def := range types {
+		r.byName[def.Name] = def
+	}
+	return nil
+}
+
+// Lookup returns the definition of a registered type
+func (r *TypeRegistry) Lookup(t IssueType) (TypeDefinition, bool) {
+	def, ok := r.byName[t]
+	return def, ok
+}
+
+// CustomTypes returns the registered types that are not built in
+func (r *TypeRegistry) CustomTypes() []TypeDefinition {
+	return r.Types[len(builtinTypes):]
+}
+
+var activeTypeRegistry atomic.Pointer[TypeRegistry]
+
+// SetTypeRegistry makes r the registry used by IssueType methods. A nil
+// registry restores the built-in types. r must have been validated.
+func SetTypeRegistry(r *TypeRegistry) {
+	activeTypeRegistry.Store(r)
+}
+
+// CurrentTypeRegistry returns the registry in use
+func CurrentTypeRegistry() *TypeRegistry {
+	if r := activeTypeRegistry.Load(); r != nil {
+		return r
+	}
+	return defaultTypeRegistry
+}
+
+var defaultTypeRegistry = DefaultTypeRegistry()
+
+func (t IssueType) isBuiltin() bool {
+	for _, def := range builtinTypes {
+		if def.Name == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Icon returns the type's icon, or "•" for unregistered types
+func (t IssueType) Icon() string {
+	if def, ok := CurrentTypeRegistry().Lookup(t); ok {
+		return def.Icon
+	}
+	return "•"
+}
+
+// Color returns the type's configured hex color, or "" to use the theme's
+func (t IssueType) Color() string {
+	def, _ := CurrentTypeRegistry().Lookup(t)
+	return def.Color
+}
+
+// PriorityWeight returns the type's priority weight (1.0 unless configured)
+func (t IssueType) PriorityWeight() float64 {
+	if def, ok := CurrentTypeRegistry().Lookup(t); ok && def.PriorityWeight > 0 {
+		return def.PriorityWeight
+	}
+	return 1.0
+}
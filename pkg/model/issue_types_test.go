@@ -0,0 +1,100 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTypeRegistry_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		types   []TypeDefinition
+		wantErr string
+	}{
+		{"Empty", nil, ""},
+		{"BlankName", []TypeDefinition{{Name: " "}}, "name cannot be empty"},
+		{"Duplicate", []TypeDefinition{{Name: "spike"}, {Name: "spike"}}, "declared twice"},
+		{"BadColor", []TypeDefinition{{Name: "spike", Color: "red"}}, "hex color"},
+		{"NegativeWeight", []TypeDefinition{{Name: "spike", PriorityWeight: -1}}, "negative"},
+		{"Valid", []TypeDefinition{{Name: "incident", Icon: "🚨", Color: "#FF5555", PriorityWeight: 1.5}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&TypeRegistry{Types: tt.types}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIssueType_CustomRegistry(t *testing.T) {
+	r := &TypeRegistry{Types: []TypeDefinition{
+		{Name: "incident", Icon: "🚨", Color: "#FF5555", PriorityWeight: 1.5},
+		{Name: "spike"},
+		{Name: TypeBug, Color: "#AA0000"},
+	}}
+	if err := r.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	SetTypeRegistry(r)
+	t.Cleanup(func() { SetTypeRegistry(nil) })
+
+	// Built-ins first, then custom types in declaration order
+	var names []string
+	for _, def := range r.Types {
+		names = append(names, string(def.Name))
+	}
+	if got := strings.Join(names, ","); got != "bug,feature,task,epic,chore,incident,spike" {
+		t.Errorf("Types = %s", got)
+	}
+	if len(r.CustomTypes()) != 2 {
+		t.Errorf("CustomTypes() = %d, want 2", len(r.CustomTypes()))
+	}
+
+	tests := []struct {
+		typ    IssueType
+		valid  bool
+		icon   string
+		color  string
+		weight float64
+	}{
+		{"incident", true, "🚨", "#FF5555", 1.5},
+		{"spike", true, "•", "", 1.0},
+		{TypeBug, true, "🐛", "#AA0000", 1.0},
+		{TypeEpic, true, "🚀", "", 1.0},
+		{"unknown", false, "•", "", 1.0},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.typ), func(t *testing.T) {
+			if got := tt.typ.IsValid(); got != tt.valid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.valid)
+			}
+			if got := tt.typ.Icon(); got != tt.icon {
+				t.Errorf("Icon() = %q, want %q", got, tt.icon)
+			}
+			if got := tt.typ.Color(); got != tt.color {
+				t.Errorf("Color() = %q, want %q", got, tt.color)
+			}
+			if got := tt.typ.PriorityWeight(); got != tt.weight {
+				t.Errorf("PriorityWeight() = %v, want %v", got, tt.weight)
+			}
+		})
+	}
+}
+
+func TestIssueType_DefaultRegistryRejectsCustom(t *testing.T) {
+	if IssueType("spike").IsValid() {
+		t.Error("unregistered type should be invalid with the built-in registry")
+	}
+	issue := Issue{ID: "bv-1", Title: "Spike", Status: StatusOpen, IssueType: "spike"}
+	if err := issue.Validate(); err == nil {
+		t.Error("expected Validate() to reject an unregistered type")
+	}
+}
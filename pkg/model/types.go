@@ -122,23 +122,27 @@ const (
 	StatusTombstone  Status = "tombstone"
 )
 
-// IsValid returns true if the status is a recognized value
+// IsValid returns true if the status is a built-in value or is declared in
+// the current workflow (.bv/workflow.yaml)
 func (s Status) IsValid() bool {
 	switch s {
 	case StatusOpen, StatusInProgress, StatusBlocked, StatusClosed, StatusTombstone:
 		return true
 	}
-	return false
+	_, ok := CurrentWorkflow().Lookup(s)
+	return ok
 }
 
-// IsClosed returns true if the status represents a closed state
+// IsClosed returns true if the status represents a closed state (closed or a custom done status)
 func (s Status) IsClosed() bool {
-	return s == StatusClosed
+	return s.Base() == StatusClosed
 }
 
-// IsOpen returns true if the status represents an active (open or in_progress) state
+// IsOpen returns true if the status represents an active (open, in_progress or a
+// custom open/active) state
 func (s Status) IsOpen() bool {
-	return s == StatusOpen || s == StatusInProgress
+	base := s.Base()
+	return base == StatusOpen || base == StatusInProgress
 }
 
 // IsTombstone returns true if the status represents a permanently deleted/archived state
@@ -157,13 +161,11 @@ const (
 	TypeChore   IssueType = "chore"
 )
 
-// IsValid returns true if the issue type is a recognized value
+// IsValid returns true if the issue type is a built-in type or one
+// registered in the current type registry
 func (t IssueType) IsValid() bool {
-	switch t {
-	case TypeBug, TypeFeature, TypeTask, TypeEpic, TypeChore:
-		return true
-	}
-	return false
+	_, ok := CurrentTypeRegistry().Lookup(t)
+	return ok
 }
 
 // Dependency represents a relationship between issues
@@ -255,6 +257,61 @@ func (s *Sprint) IsActive() bool {
 		(now.Equal(s.EndDate) || now.Before(s.EndDate))
 }
 
+// IsEnded returns true if the sprint's end date has passed
+func (s *Sprint) IsEnded(now time.Time) bool {
+	return !s.EndDate.IsZero() && s.EndDate.Before(now)
+}
+
+// HasBead returns true if the bead is planned in the sprint
+func (s *Sprint) HasBead(id string) bool {
+	for _, existing := range s.BeadIDs {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+// AddBeads appends beads not already in the sprint and returns how many were added
+func (s *Sprint) AddBeads(ids ...string) int {
+	added := 0
+	for _, id := range ids {
+		if id != "" && !s.HasBead(id) {
+			s.BeadIDs = append(s.BeadIDs, id)
+			added++
+		}
+	}
+	return added
+}
+
+// RemoveBeads removes beads from the sprint and returns how many were removed
+func (s *Sprint) RemoveBeads(ids ...string) int {
+	remove := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+	kept := s.BeadIDs[:0]
+	for _, id := range s.BeadIDs {
+		if !remove[id] {
+			kept = append(kept, id)
+		}
+	}
+	removed := len(s.BeadIDs) - len(kept)
+	s.BeadIDs = kept
+	return removed
+}
+
+// Close ends the sprint at now unless it has already ended
+func (s *Sprint) Close(now time.Time) {
+	if !s.IsEnded(now) {
+		s.EndDate = now
+	}
+	if !s.StartDate.IsZero() && s.StartDate.After(s.EndDate) {
+		s.StartDate = s.EndDate
+	}
+	s.UpdatedAt = now
+}
+
 // Forecast represents an ETA prediction for a specific bead
 type Forecast struct {
 	BeadID     string    `json:"bead_id"`
@@ -669,3 +669,43 @@ func TestIssue_Clone_NilFields(t *testing.T) {
 		t.Errorf("Comments should be nil")
 	}
 }
+
+func TestSprint_Beads(t *testing.T) {
+	sprint := Sprint{ID: "s1", Name: "S1", BeadIDs: []string{"a"}}
+
+	if added := sprint.AddBeads("a", "b", "", "c", "b"); added != 2 {
+		t.Errorf("AddBeads added %d, want 2", added)
+	}
+	if !sprint.HasBead("c") || sprint.HasBead("d") {
+		t.Errorf("HasBead mismatch for %v", sprint.BeadIDs)
+	}
+	if removed := sprint.RemoveBeads("a", "d"); removed != 1 {
+		t.Errorf("RemoveBeads removed %d, want 1", removed)
+	}
+	if got := strings.Join(sprint.BeadIDs, ","); got != "b,c" {
+		t.Errorf("BeadIDs = %s, want b,c", got)
+	}
+}
+
+func TestSprint_Close(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	running := Sprint{ID: "s1", Name: "S1", StartDate: now.AddDate(0, 0, -3), EndDate: now.AddDate(0, 0, 4)}
+	running.Close(now)
+	if !running.EndDate.Equal(now) || !running.UpdatedAt.Equal(now) || !running.IsEnded(now.Add(time.Second)) {
+		t.Errorf("running sprint not closed at now: %+v", running)
+	}
+
+	past := now.AddDate(0, 0, -1)
+	ended := Sprint{ID: "s0", Name: "S0", EndDate: past}
+	ended.Close(now)
+	if !ended.EndDate.Equal(past) {
+		t.Errorf("ended sprint end date moved to %v", ended.EndDate)
+	}
+
+	future := Sprint{ID: "s2", Name: "S2", StartDate: now.AddDate(0, 0, 7), EndDate: now.AddDate(0, 0, 21)}
+	future.Close(now)
+	if err := future.Validate(); err != nil {
+		t.Errorf("closing a future sprint made it invalid: %v", err)
+	}
+}
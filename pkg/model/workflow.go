@@ -0,0 +1,171 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// StatusCategory says what a workflow status means for scheduling
+type StatusCategory string
+
+const (
+	CategoryOpen   StatusCategory = "open"   // Not started yet
+	CategoryActive StatusCategory = "active" // Being worked on (in progress, review, qa, ...)
+	CategoryDone   StatusCategory = "done"   // Finished
+)
+
+// IsValid returns true if the category is a recognized value
+func (c StatusCategory) IsValid() bool {
+	switch c {
+	case CategoryOpen, CategoryActive, CategoryDone:
+		return true
+	}
+	return false
+}
+
+// StatusDefinition declares one status of a workflow
+type StatusDefinition struct {
+	Name     Status         `yaml:"name" json:"name"`
+	Category StatusCategory `yaml:"category,omitempty" json:"category,omitempty"` // Required for custom statuses
+	Label    string         `yaml:"label,omitempty" json:"label,omitempty"`       // Board column title (default: the name)
+}
+
+// Workflow is the set of statuses a project uses. The order of Statuses is
+// the board column order.
+type Workflow struct {
+	Statuses []StatusDefinition `yaml:"statuses" json:"statuses"`
+
+	byName map[Status]StatusDefinition
+}
+
+// builtinCategories are the fixed categories of the built-in statuses.
+// Blocked and tombstone have no category: they keep their own meaning.
+var builtinCategories = map[Status]StatusCategory{
+	StatusOpen:       CategoryOpen,
+	StatusInProgress: CategoryActive,
+	StatusBlocked:    "",
+	StatusClosed:     CategoryDone,
+	StatusTombstone:  "",
+}
+
+// categoryBase is the built-in status a custom status behaves as
+var categoryBase = map[StatusCategory]Status{
+	CategoryOpen:   StatusOpen,
+	CategoryActive: StatusInProgress,
+	CategoryDone:   StatusClosed,
+}
+
+// DefaultWorkflow returns the built-in workflow: open, in_progress, blocked
+// and closed, in that board order.
+func DefaultWorkflow() *Workflow {
+	w := &Workflow{Statuses: []StatusDefinition{
+		{Name: StatusOpen, Category: CategoryOpen},
+		{Name: StatusInProgress, Category: CategoryActive},
+		{Name: StatusBlocked},
+		{Name: StatusClosed, Category: CategoryDone},
+	}}
+	_ = w.Validate()
+	return w
+}
+
+// Validate checks the workflow and indexes it. Custom statuses need a
+// category; built-in statuses keep theirs and may only be reordered or
+// relabeled.
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("workflow must declare at least one status")
+	}
+	byName := make(map[Status]StatusDefinition, len(w.Statuses))
+	for i, def := range w.Statuses {
+		name := Status(strings.TrimSpace(string(def.Name)))
+		if name == "" {
+			return fmt.Errorf("status %d: name cannot be empty", i+1)
+		}
+		if _, dup := byName[name]; dup {
+			return fmt.Errorf("status %q declared twice", name)
+		}
+		if builtin, ok := builtinCategories[name]; ok {
+			if def.Category != "" && def.Category != builtin {
+				return fmt.Errorf("status %q is built in; its category cannot be changed", name)
+			}
+			def.Category = builtin
+		} else if !def.Category.IsValid() {
+			return fmt.Errorf("status %q: category must be open, active or done (got %q)", name, def.Category)
+		}
+		def.Name = name
+		w.Statuses[i] = def
+		byName[name] = def
+	}
+	w.byName = byName
+	return nil
+}
+
+// Lookup returns the definition of a declared status
+func (w *Workflow) Lookup(s Status) (StatusDefinition, bool) {
+	def, ok := w.byName[s]
+	return def, ok
+}
+
+// ColumnTitle returns the board column title for a declared status
+func (def StatusDefinition) ColumnTitle() string {
+	if def.Label != "" {
+		return strings.ToUpper(def.Label)
+	}
+	return strings.ToUpper(strings.ReplaceAll(string(def.Name), "_", " "))
+}
+
+var activeWorkflow atomic.Pointer[Workflow]
+
+// SetWorkflow makes w the workflow used by Status methods. A nil workflow
+// restores the default. w must have been validated.
+func SetWorkflow(w *Workflow) {
+	activeWorkflow.Store(w)
+}
+
+// CurrentWorkflow returns the workflow in use
+func CurrentWorkflow() *Workflow {
+	if w := activeWorkflow.Load(); w != nil {
+		return w
+	}
+	return defaultWorkflow
+}
+
+var defaultWorkflow = DefaultWorkflow()
+
+// Category returns the status' category in the current workflow, or "" for
+// blocked, tombstone and undeclared statuses.
+func (s Status) Category() StatusCategory {
+	if category, ok := builtinCategories[s]; ok {
+		return category
+	}
+	if def, ok := CurrentWorkflow().Lookup(s); ok {
+		return def.Category
+	}
+	return ""
+}
+
+// Base returns the built-in status s behaves as. Built-in and undeclared
+// statuses are their own base; custom statuses map by category: open to
+// open, active to in_progress and done to closed.
+func (s Status) Base() Status {
+	if _, ok := builtinCategories[s]; ok {
+		return s
+	}
+	if def, ok := CurrentWorkflow().Lookup(s); ok {
+		return categoryBase[def.Category]
+	}
+	return s
+}
+
+// IsActive returns true if the status means work is underway (in_progress or a custom active status)
+func (s Status) IsActive() bool {
+	return s.Base() == StatusInProgress
+}
+
+// MatchesFilter reports whether s satisfies a status filter value such as a
+// recipe's status list. Custom statuses also match their base status, so
+// filters written for the built-in workflow keep working.
+func (s Status) MatchesFilter(value string) bool {
+	return strings.EqualFold(string(s), value) || strings.EqualFold(string(s.Base()), value)
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func useWorkflow(t *testing.T, statuses ...StatusDefinition) {
+	t.Helper()
+	w := &Workflow{Statuses: statuses}
+	if err := w.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	SetWorkflow(w)
+	t.Cleanup(func() { SetWorkflow(nil) })
+}
+
+func TestWorkflow_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []StatusDefinition
+		wantErr  string
+	}{
+		{"Empty", nil, "at least one status"},
+		{"BlankName", []StatusDefinition{{Name: " "}}, "name cannot be empty"},
+		{"Duplicate", []StatusDefinition{{Name: "open"}, {Name: "open"}}, "declared twice"},
+		{"BuiltinRecategorized", []StatusDefinition{{Name: "closed", Category: CategoryActive}}, "cannot be changed"},
+		{"CustomWithoutCategory", []StatusDefinition{{Name: "review"}}, "category must be"},
+		{"CustomBadCategory", []StatusDefinition{{Name: "review", Category: "doing"}}, "category must be"},
+		{"Valid", []StatusDefinition{{Name: "open"}, {Name: "review", Category: CategoryActive}, {Name: "closed", Category: CategoryDone}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Workflow{Statuses: tt.statuses}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatus_CustomWorkflow(t *testing.T) {
+	useWorkflow(t,
+		StatusDefinition{Name: "triage", Category: CategoryOpen},
+		StatusDefinition{Name: "open"},
+		StatusDefinition{Name: "in_progress"},
+		StatusDefinition{Name: "review", Category: CategoryActive, Label: "In Review"},
+		StatusDefinition{Name: "closed"},
+		StatusDefinition{Name: "wontfix", Category: CategoryDone},
+	)
+
+	tests := []struct {
+		status   Status
+		base     Status
+		valid    bool
+		isOpen   bool
+		isClosed bool
+		isActive bool
+	}{
+		{"triage", StatusOpen, true, true, false, false},
+		{"review", StatusInProgress, true, true, false, true},
+		{"wontfix", StatusClosed, true, false, true, false},
+		{StatusBlocked, StatusBlocked, true, false, false, false},
+		{"unknown", "unknown", false, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.Base(); got != tt.base {
+				t.Errorf("Base() = %q, want %q", got, tt.base)
+			}
+			if got := tt.status.IsValid(); got != tt.valid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.valid)
+			}
+			if got := tt.status.IsOpen(); got != tt.isOpen {
+				t.Errorf("IsOpen() = %v, want %v", got, tt.isOpen)
+			}
+			if got := tt.status.IsClosed(); got != tt.isClosed {
+				t.Errorf("IsClosed() = %v, want %v", got, tt.isClosed)
+			}
+			if got := tt.status.IsActive(); got != tt.isActive {
+				t.Errorf("IsActive() = %v, want %v", got, tt.isActive)
+			}
+		})
+	}
+
+	if !Status("review").MatchesFilter("in_progress") || !Status("review").MatchesFilter("REVIEW") {
+		t.Error("custom status should match its own name and its base status")
+	}
+	if Status("in_progress").MatchesFilter("review") {
+		t.Error("built-in status should not match a custom status filter")
+	}
+
+	def, _ := CurrentWorkflow().Lookup("review")
+	if got := def.ColumnTitle(); got != "IN REVIEW" {
+		t.Errorf("ColumnTitle() = %q, want %q", got, "IN REVIEW")
+	}
+}
+
+func TestStatus_DefaultWorkflowRejectsCustom(t *testing.T) {
+	if Status("review").IsValid() {
+		t.Error("undeclared status should be invalid in the default workflow")
+	}
+	if got := Status("review").Base(); got != "review" {
+		t.Errorf("Base() = %q, want the status itself", got)
+	}
+}
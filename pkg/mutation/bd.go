@@ -0,0 +1,125 @@
+package mutation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// BDMutator applies edits by running the bd CLI in the project root, so bd's
+// database, JSONL export, and hooks all see the change.
+type BDMutator struct {
+	bin string
+	dir string
+}
+
+// BDMutatorOption configures a BDMutator.
+type BDMutatorOption func(*BDMutator)
+
+// WithBDBinary sets the bd executable (name on PATH or absolute path).
+func WithBDBinary(bin string) BDMutatorOption {
+	return func(m *BDMutator) {
+		if bin != "" {
+			m.bin = bin
+		}
+	}
+}
+
+// NewBDMutator creates a BDMutator for the project owning beadsPath
+// (e.g. /repo/.beads/issues.jsonl runs bd in /repo).
+func NewBDMutator(beadsPath string, opts ...BDMutatorOption) *BDMutator {
+	m := &BDMutator{
+		bin: "bd",
+		dir: filepath.Dir(filepath.Dir(beadsPath)),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+func (*BDMutator) Backend() string { return BackendBD }
+
+func (m *BDMutator) SetStatus(ctx context.Context, id string, status model.Status) error {
+	if err := validateStatus(status); err != nil {
+		return err
+	}
+	if status.IsClosed() {
+		return m.run(ctx, "close", id)
+	}
+	return m.run(ctx, "update", id, "--status", string(status))
+}
+
+func (m *BDMutator) SetPriority(ctx context.Context, id string, priority int) error {
+	if err := validatePriority(priority); err != nil {
+		return err
+	}
+	return m.run(ctx, "update", id, "--priority", strconv.Itoa(priority))
+}
+
+func (m *BDMutator) SetAssignee(ctx context.Context, id, assignee string) error {
+	return m.run(ctx, "update", id, "--assignee", assignee)
+}
+
+func (m *BDMutator) AddLabels(ctx context.Context, id string, labels ...string) error {
+	for _, label := range labels {
+		if err := m.run(ctx, "label", "add", id, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *BDMutator) RemoveLabels(ctx context.Context, id string, labels ...string) error {
+	for _, label := range labels {
+		if err := m.run(ctx, "label", "remove", id, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *BDMutator) AddComment(ctx context.Context, id, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("comment text is empty")
+	}
+	return m.run(ctx, "comments", "add", id, text)
+}
+
+func (m *BDMutator) AddDependency(ctx context.Context, id, dependsOn string, depType model.DependencyType) error {
+	if err := validateDependency(id, dependsOn, depType); err != nil {
+		return err
+	}
+	return m.run(ctx, "dep", "add", id, dependsOn, "--type", string(depType))
+}
+
+func (m *BDMutator) RemoveDependency(ctx context.Context, id, dependsOn string, depType model.DependencyType) error {
+	if err := validateDependency(id, dependsOn, depType); err != nil {
+		return err
+	}
+	return m.run(ctx, "dep", "remove", id, dependsOn)
+}
+
+// run executes bd with args, folding stderr into the returned error.
+func (m *BDMutator) run(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, m.bin, args...)
+	cmd.Dir = m.dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("bd %s: %w", args[0], ctx.Err())
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("bd %s: %s: %w", args[0], msg, err)
+		}
+		return fmt.Errorf("bd %s: %w", args[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package mutation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// fakeBD writes a bd stand-in that logs its working directory and arguments
+// (one invocation per line) and fails when the first argument is "fail".
+func fakeBD(t *testing.T) (bin, logPath, repo string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bd script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	logPath = filepath.Join(dir, "bd.log")
+	bin = filepath.Join(dir, "bd")
+	script := "#!/bin/sh\n" +
+		"if [ \"$2\" = \"fail\" ]; then echo \"Error: issue fail not found\" >&2; exit 1; fi\n" +
+		"printf '%s|%s\\n' \"$(pwd)\" \"$*\" >> " + logPath + "\n"
+	if err := os.WriteFile(bin, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo = filepath.Join(dir, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return bin, logPath, repo
+}
+
+func readLog(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestBDMutator_Commands(t *testing.T) {
+	bin, logPath, repo := fakeBD(t)
+	m := NewBDMutator(filepath.Join(repo, ".beads", "issues.jsonl"), WithBDBinary(bin))
+	ctx := context.Background()
+
+	steps := []func() error{
+		func() error { return m.SetStatus(ctx, "bv-1", model.StatusInProgress) },
+		func() error { return m.SetStatus(ctx, "bv-1", model.StatusClosed) },
+		func() error { return m.SetPriority(ctx, "bv-1", 0) },
+		func() error { return m.SetAssignee(ctx, "bv-1", "alice") },
+		func() error { return m.AddLabels(ctx, "bv-1", "ui", "api") },
+		func() error { return m.RemoveLabels(ctx, "bv-1", "old") },
+		func() error { return m.AddComment(ctx, "bv-1", "looks good") },
+		func() error { return m.AddDependency(ctx, "bv-1", "bv-2", model.DepParentChild) },
+		func() error { return m.RemoveDependency(ctx, "bv-1", "bv-3", model.DepBlocks) },
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+
+	want := []string{
+		"update bv-1 --status in_progress",
+		"close bv-1",
+		"update bv-1 --priority 0",
+		"update bv-1 --assignee alice",
+		"label add bv-1 ui",
+		"label add bv-1 api",
+		"label remove bv-1 old",
+		"comments add bv-1 looks good",
+		"dep add bv-1 bv-2 --type parent-child",
+		"dep remove bv-1 bv-3",
+	}
+	got := readLog(t, logPath)
+	if len(got) != len(want) {
+		t.Fatalf("got %d invocations, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		dir, args, _ := strings.Cut(got[i], "|")
+		if args != want[i] {
+			t.Errorf("invocation %d = %q, want %q", i, args, want[i])
+		}
+		if resolved, _ := filepath.EvalSymlinks(repo); dir != repo && dir != resolved {
+			t.Errorf("invocation %d ran in %q, want %q", i, dir, repo)
+		}
+	}
+}
+
+func TestBDMutator_SurfacesStderr(t *testing.T) {
+	bin, _, repo := fakeBD(t)
+	m := NewBDMutator(filepath.Join(repo, ".beads", "issues.jsonl"), WithBDBinary(bin))
+
+	err := m.SetPriority(context.Background(), "fail", 1)
+	if err == nil || !strings.Contains(err.Error(), "issue fail not found") {
+		t.Fatalf("expected bd stderr in error, got %v", err)
+	}
+}
+
+func TestBDMutator_ValidatesBeforeRunning(t *testing.T) {
+	bin, logPath, repo := fakeBD(t)
+	m := NewBDMutator(filepath.Join(repo, ".beads", "issues.jsonl"), WithBDBinary(bin))
+
+	if err := m.SetPriority(context.Background(), "bv-1", 9); err == nil {
+		t.Error("expected invalid priority error")
+	}
+	if err := m.SetStatus(context.Background(), "bv-1", "done"); err == nil {
+		t.Error("expected invalid status error")
+	}
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Error("bd should not run for invalid input")
+	}
+}
+
+func TestNew_SelectsBackend(t *testing.T) {
+	bin, _, repo := fakeBD(t)
+	path := filepath.Join(repo, ".beads", "issues.jsonl")
+
+	if _, err := New(""); err != ErrReadOnly {
+		t.Errorf("New(\"\") = %v, want ErrReadOnly", err)
+	}
+
+	t.Setenv(EnvBDBinary, bin)
+	t.Setenv(EnvMutator, "")
+	if m, err := New(path); err != nil || m.Backend() != BackendBD {
+		t.Errorf("auto with bd available: %v %v", m, err)
+	}
+
+	t.Setenv(EnvMutator, "jsonl")
+	if m, err := New(path); err != nil || m.Backend() != BackendJSONL {
+		t.Errorf("forced jsonl: %v %v", m, err)
+	}
+
+	t.Setenv(EnvBDBinary, filepath.Join(repo, "missing-bd"))
+	t.Setenv(EnvMutator, "auto")
+	if m, err := New(path); err != nil || m.Backend() != BackendJSONL {
+		t.Errorf("auto without bd should fall back to jsonl: %v %v", m, err)
+	}
+	t.Setenv(EnvMutator, "bd")
+	if _, err := New(path); err == nil {
+		t.Error("forced bd without binary should fail")
+	}
+}
@@ -0,0 +1,308 @@
+package mutation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// JSONLMutator edits the issues JSONL file in place. Only the target line is
+// re-encoded; other lines are written back byte-for-byte and fields bv does not
+// model are preserved. The file is replaced atomically via rename.
+//
+// If bd is also in use, its database will not see these edits until the next
+// `bd import`, so BDMutator is preferred whenever bd is installed.
+type JSONLMutator struct {
+	path string
+	now  func() time.Time
+	mu   sync.Mutex
+}
+
+// NewJSONLMutator creates a JSONLMutator for the file at path.
+func NewJSONLMutator(path string) *JSONLMutator {
+	return &JSONLMutator{path: path, now: time.Now}
+}
+
+func (*JSONLMutator) Backend() string { return BackendJSONL }
+
+func (m *JSONLMutator) SetStatus(ctx context.Context, id string, status model.Status) error {
+	if err := validateStatus(status); err != nil {
+		return err
+	}
+	return m.edit(ctx, id, func(rec map[string]json.RawMessage, now time.Time) error {
+		if err := setField(rec, "status", status); err != nil {
+			return err
+		}
+		if !status.IsClosed() {
+			delete(rec, "closed_at")
+			return nil
+		}
+		if _, ok := rec["closed_at"]; ok && string(rec["closed_at"]) != "null" {
+			return nil
+		}
+		return setField(rec, "closed_at", now)
+	})
+}
+
+func (m *JSONLMutator) SetPriority(ctx context.Context, id string, priority int) error {
+	if err := validatePriority(priority); err != nil {
+		return err
+	}
+	return m.edit(ctx, id, func(rec map[string]json.RawMessage, _ time.Time) error {
+		return setField(rec, "priority", priority)
+	})
+}
+
+func (m *JSONLMutator) SetAssignee(ctx context.Context, id, assignee string) error {
+	return m.edit(ctx, id, func(rec map[string]json.RawMessage, _ time.Time) error {
+		if assignee == "" {
+			delete(rec, "assignee")
+			return nil
+		}
+		return setField(rec, "assignee", assignee)
+	})
+}
+
+func (m *JSONLMutator) AddLabels(ctx context.Context, id string, labels ...string) error {
+	return m.editLabels(ctx, id, labels, nil)
+}
+
+func (m *JSONLMutator) RemoveLabels(ctx context.Context, id string, labels ...string) error {
+	return m.editLabels(ctx, id, nil, labels)
+}
+
+func (m *JSONLMutator) editLabels(ctx context.Context, id string, add, remove []string) error {
+	return m.edit(ctx, id, func(rec map[string]json.RawMessage, _ time.Time) error {
+		var labels []string
+		if raw, ok := rec["labels"]; ok {
+			if err := json.Unmarshal(raw, &labels); err != nil {
+				return fmt.Errorf("decode labels: %w", err)
+			}
+		}
+		labels = editLabels(labels, add, remove)
+		if len(labels) == 0 {
+			delete(rec, "labels")
+			return nil
+		}
+		return setField(rec, "labels", labels)
+	})
+}
+
+func (m *JSONLMutator) AddComment(ctx context.Context, id, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("comment text is empty")
+	}
+	return m.edit(ctx, id, func(rec map[string]json.RawMessage, now time.Time) error {
+		var comments []json.RawMessage
+		if raw, ok := rec["comments"]; ok {
+			if err := json.Unmarshal(raw, &comments); err != nil {
+				return fmt.Errorf("decode comments: %w", err)
+			}
+		}
+		var nextID int64 = 1
+		for _, raw := range comments {
+			var c struct {
+				ID int64 `json:"id"`
+			}
+			if json.Unmarshal(raw, &c) == nil && c.ID >= nextID {
+				nextID = c.ID + 1
+			}
+		}
+		comment, err := json.Marshal(model.Comment{
+			ID:        nextID,
+			IssueID:   id,
+			Author:    Actor(),
+			Text:      text,
+			CreatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+		return setField(rec, "comments", append(comments, comment))
+	})
+}
+
+func (m *JSONLMutator) AddDependency(ctx context.Context, id, dependsOn string, depType model.DependencyType) error {
+	if err := validateDependency(id, dependsOn, depType); err != nil {
+		return err
+	}
+	return m.edit(ctx, id, func(rec map[string]json.RawMessage, now time.Time) error {
+		deps, err := decodeDependencies(rec)
+		if err != nil {
+			return err
+		}
+		for _, raw := range deps {
+			if dependencyMatches(raw, dependsOn, depType) {
+				return nil
+			}
+		}
+		dep, err := json.Marshal(model.Dependency{
+			IssueID:     id,
+			DependsOnID: dependsOn,
+			Type:        depType,
+			CreatedAt:   now,
+			CreatedBy:   Actor(),
+		})
+		if err != nil {
+			return err
+		}
+		return setField(rec, "dependencies", append(deps, dep))
+	})
+}
+
+func (m *JSONLMutator) RemoveDependency(ctx context.Context, id, dependsOn string, depType model.DependencyType) error {
+	if err := validateDependency(id, dependsOn, depType); err != nil {
+		return err
+	}
+	return m.edit(ctx, id, func(rec map[string]json.RawMessage, _ time.Time) error {
+		deps, err := decodeDependencies(rec)
+		if err != nil {
+			return err
+		}
+		kept := make([]json.RawMessage, 0, len(deps))
+		for _, raw := range deps {
+			if !dependencyMatches(raw, dependsOn, depType) {
+				kept = append(kept, raw)
+			}
+		}
+		if len(kept) == len(deps) {
+			return fmt.Errorf("no %s dependency on %s", depType, dependsOn)
+		}
+		if len(kept) == 0 {
+			delete(rec, "dependencies")
+			return nil
+		}
+		return setField(rec, "dependencies", kept)
+	})
+}
+
+func decodeDependencies(rec map[string]json.RawMessage) ([]json.RawMessage, error) {
+	var deps []json.RawMessage
+	if raw, ok := rec["dependencies"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &deps); err != nil {
+			return nil, fmt.Errorf("decode dependencies: %w", err)
+		}
+	}
+	return deps, nil
+}
+
+func dependencyMatches(raw json.RawMessage, dependsOn string, depType model.DependencyType) bool {
+	var dep model.Dependency
+	if err := json.Unmarshal(raw, &dep); err != nil {
+		return false
+	}
+	return dep.DependsOnID == dependsOn && sameDepType(dep.Type, depType)
+}
+
+// edit rewrites the record for id through fn and bumps updated_at.
+func (m *JSONLMutator) edit(ctx context.Context, id string, fn func(rec map[string]json.RawMessage, now time.Time) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(m.path)
+	if err != nil {
+		return fmt.Errorf("read issues file: %w", err)
+	}
+
+	now := m.now().UTC()
+	var out bytes.Buffer
+	out.Grow(len(content) + 256)
+	found := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !found && lineHasID(line, id) {
+			var rec map[string]json.RawMessage
+			if err := json.Unmarshal(bytes.TrimPrefix(line, []byte("\xef\xbb\xbf")), &rec); err != nil {
+				return fmt.Errorf("decode issue %s: %w", id, err)
+			}
+			if err := fn(rec, now); err != nil {
+				return fmt.Errorf("edit issue %s: %w", id, err)
+			}
+			if err := setField(rec, "updated_at", now); err != nil {
+				return err
+			}
+			encoded, err := json.Marshal(rec)
+			if err != nil {
+				return fmt.Errorf("encode issue %s: %w", id, err)
+			}
+			line = encoded
+			found = true
+		}
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scan issues file: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("issue %s not found in %s", id, filepath.Base(m.path))
+	}
+	return writeFileAtomic(m.path, out.Bytes())
+}
+
+// lineHasID reports whether a JSONL line is the record for id.
+func lineHasID(line []byte, id string) bool {
+	if !bytes.Contains(line, []byte(id)) {
+		return false
+	}
+	var rec struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(bytes.TrimPrefix(line, []byte("\xef\xbb\xbf")), &rec); err != nil {
+		return false
+	}
+	return rec.ID == id
+}
+
+func setField(rec map[string]json.RawMessage, key string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+	rec[key] = raw
+	return nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpName, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("replace issues file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package mutation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const testJSONL = `{"id":"bv-1","title":"One","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","labels":["ui"],"x_custom":{"keep":true}}
+{"id":"bv-10","title":"Ten","status":"open","priority":1,"issue_type":"bug","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+`
+
+func newTestJSONL(t *testing.T) (*JSONLMutator, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	if err := os.WriteFile(path, []byte(testJSONL), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewJSONLMutator(path)
+	m.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	return m, path
+}
+
+func loadByID(t *testing.T, path string) map[string]model.Issue {
+	t.Helper()
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		out[issue.ID] = issue
+	}
+	return out
+}
+
+func TestJSONLMutator_EditsOnlyTarget(t *testing.T) {
+	m, path := newTestJSONL(t)
+	ctx := context.Background()
+
+	if err := m.SetStatus(ctx, "bv-1", model.StatusClosed); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetPriority(ctx, "bv-1", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetAssignee(ctx, "bv-1", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddLabels(ctx, "bv-1", "api", "ui"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RemoveLabels(ctx, "bv-1", "ui"); err != nil {
+		t.Fatal(err)
+	}
+
+	issues := loadByID(t, path)
+	one := issues["bv-1"]
+	if one.Status != model.StatusClosed || one.ClosedAt == nil {
+		t.Errorf("status not closed with closed_at: %+v", one)
+	}
+	if one.Priority != 0 || one.Assignee != "alice" {
+		t.Errorf("priority/assignee not updated: %+v", one)
+	}
+	if strings.Join(one.Labels, ",") != "api" {
+		t.Errorf("labels = %v, want [api]", one.Labels)
+	}
+	if !one.UpdatedAt.Equal(m.now()) {
+		t.Errorf("updated_at = %v", one.UpdatedAt)
+	}
+
+	content, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if !strings.Contains(lines[0], `"x_custom":{"keep":true}`) {
+		t.Errorf("unknown fields dropped: %s", lines[0])
+	}
+	if want := strings.Split(testJSONL, "\n")[1]; lines[1] != want {
+		t.Errorf("untouched line rewritten:\n got %s\nwant %s", lines[1], want)
+	}
+
+	// Reopening clears closed_at.
+	if err := m.SetStatus(ctx, "bv-1", model.StatusOpen); err != nil {
+		t.Fatal(err)
+	}
+	if reopened := loadByID(t, path)["bv-1"]; reopened.ClosedAt != nil {
+		t.Errorf("closed_at not cleared on reopen")
+	}
+}
+
+func TestJSONLMutator_AddComment(t *testing.T) {
+	m, path := newTestJSONL(t)
+	t.Setenv("BD_ACTOR", "bob")
+
+	for _, text := range []string{"first", "second"} {
+		if err := m.AddComment(context.Background(), "bv-10", text); err != nil {
+			t.Fatal(err)
+		}
+	}
+	comments := loadByID(t, path)["bv-10"].Comments
+	if len(comments) != 2 || comments[1].ID != 2 || comments[1].Text != "second" || comments[1].Author != "bob" {
+		t.Fatalf("unexpected comments: %+v %+v", comments[0], comments[len(comments)-1])
+	}
+	if err := m.AddComment(context.Background(), "bv-10", "  "); err == nil {
+		t.Error("expected empty comment error")
+	}
+}
+
+func TestJSONLMutator_MissingIssue(t *testing.T) {
+	m, path := newTestJSONL(t)
+	before, _ := os.ReadFile(path)
+
+	if err := m.SetPriority(context.Background(), "bv-2", 1); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Error("file changed on failed edit")
+	}
+}
+
+func TestJSONLMutator_Dependencies(t *testing.T) {
+	m, path := newTestJSONL(t)
+	ctx := context.Background()
+
+	if err := m.AddDependency(ctx, "bv-10", "bv-1", model.DepBlocks); err != nil {
+		t.Fatal(err)
+	}
+	// Adding the same edge again is a no-op.
+	if err := m.AddDependency(ctx, "bv-10", "bv-1", model.DepBlocks); err != nil {
+		t.Fatal(err)
+	}
+	deps := loadByID(t, path)["bv-10"].Dependencies
+	if len(deps) != 1 || deps[0].DependsOnID != "bv-1" || deps[0].Type != model.DepBlocks {
+		t.Fatalf("unexpected dependencies: %+v", deps)
+	}
+
+	if err := m.RemoveDependency(ctx, "bv-10", "bv-1", model.DepParentChild); err == nil {
+		t.Fatal("removing an edge of the wrong type should fail")
+	}
+	if err := m.RemoveDependency(ctx, "bv-10", "bv-1", model.DepBlocks); err != nil {
+		t.Fatal(err)
+	}
+	if deps := loadByID(t, path)["bv-10"].Dependencies; len(deps) != 0 {
+		t.Fatalf("dependency not removed: %+v", deps)
+	}
+	if err := m.AddDependency(ctx, "bv-10", "bv-10", model.DepBlocks); err == nil {
+		t.Fatal("self dependency should be rejected")
+	}
+}
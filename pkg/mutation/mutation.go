@@ -0,0 +1,325 @@
+// Package mutation writes issue edits back to a beads project.
+//
+// bv is a viewer first; edits go through a Mutator so the storage details stay
+// out of the UI. Two backends exist: BDMutator shells out to the bd CLI (the
+// preferred path, since bd keeps its database and JSONL export in sync) and
+// JSONLMutator rewrites the issues JSONL file directly for projects without bd.
+package mutation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const (
+	// EnvMutator selects the backend: "bd", "jsonl", or "auto" (default).
+	EnvMutator = "BV_MUTATOR"
+	// EnvBDBinary overrides the bd executable used by BDMutator.
+	EnvBDBinary = "BV_BD_BIN"
+	// envActor is the author recorded on comments (shared with bd).
+	envActor = "BD_ACTOR"
+)
+
+// Backend names reported by Mutator.Backend.
+const (
+	BackendBD    = "bd"
+	BackendJSONL = "jsonl"
+)
+
+// ErrReadOnly is returned when no writable backend is available.
+var ErrReadOnly = errors.New("issues are read-only in this mode")
+
+// Mutator applies edits to issues. Implementations must be safe to call from
+// a goroutine other than the UI loop.
+type Mutator interface {
+	Backend() string
+	SetStatus(ctx context.Context, id string, status model.Status) error
+	SetPriority(ctx context.Context, id string, priority int) error
+	SetAssignee(ctx context.Context, id, assignee string) error
+	AddLabels(ctx context.Context, id string, labels ...string) error
+	RemoveLabels(ctx context.Context, id string, labels ...string) error
+	AddComment(ctx context.Context, id, text string) error
+	// AddDependency records that id depends on dependsOn.
+	AddDependency(ctx context.Context, id, dependsOn string, depType model.DependencyType) error
+	RemoveDependency(ctx context.Context, id, dependsOn string, depType model.DependencyType) error
+}
+
+// New returns the Mutator for the project owning beadsPath, honoring
+// BV_MUTATOR. In auto mode bd is used when it is on PATH, otherwise the JSONL
+// file is edited directly.
+func New(beadsPath string) (Mutator, error) {
+	if beadsPath == "" {
+		return nil, ErrReadOnly
+	}
+	bin := strings.TrimSpace(os.Getenv(EnvBDBinary))
+	if bin == "" {
+		bin = "bd"
+	}
+
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv(EnvMutator))); mode {
+	case "", "auto":
+		if _, err := exec.LookPath(bin); err == nil {
+			return NewBDMutator(beadsPath, WithBDBinary(bin)), nil
+		}
+		return NewJSONLMutator(beadsPath), nil
+	case BackendBD:
+		if _, err := exec.LookPath(bin); err != nil {
+			return nil, fmt.Errorf("%s=bd but %q was not found: %w", EnvMutator, bin, err)
+		}
+		return NewBDMutator(beadsPath, WithBDBinary(bin)), nil
+	case BackendJSONL:
+		return NewJSONLMutator(beadsPath), nil
+	default:
+		return nil, fmt.Errorf("unknown %s %q (expected auto, bd, or jsonl)", EnvMutator, mode)
+	}
+}
+
+// ChangeKind identifies the field a Change edits.
+type ChangeKind int
+
+const (
+	ChangeStatus ChangeKind = iota
+	ChangePriority
+	ChangeAssignee
+	ChangeLabels
+	ChangeComment
+	ChangeAddDependency
+	ChangeRemoveDependency
+)
+
+// Change is a single edit. The UI applies it optimistically with ApplyTo and
+// persists it with Apply.
+type Change struct {
+	Kind         ChangeKind
+	Status       model.Status
+	Priority     int
+	Assignee     string
+	AddLabels    []string
+	RemoveLabels []string
+	Comment      string
+	DependsOn    string
+	DepType      model.DependencyType
+}
+
+// Apply persists the change through m.
+func (c Change) Apply(ctx context.Context, m Mutator, id string) error {
+	switch c.Kind {
+	case ChangeStatus:
+		return m.SetStatus(ctx, id, c.Status)
+	case ChangePriority:
+		return m.SetPriority(ctx, id, c.Priority)
+	case ChangeAssignee:
+		return m.SetAssignee(ctx, id, c.Assignee)
+	case ChangeLabels:
+		if len(c.RemoveLabels) > 0 {
+			if err := m.RemoveLabels(ctx, id, c.RemoveLabels...); err != nil {
+				return err
+			}
+		}
+		if len(c.AddLabels) > 0 {
+			return m.AddLabels(ctx, id, c.AddLabels...)
+		}
+		return nil
+	case ChangeComment:
+		return m.AddComment(ctx, id, c.Comment)
+	case ChangeAddDependency:
+		return m.AddDependency(ctx, id, c.DependsOn, c.depType())
+	case ChangeRemoveDependency:
+		return m.RemoveDependency(ctx, id, c.DependsOn, c.depType())
+	default:
+		return fmt.Errorf("unknown change kind %d", c.Kind)
+	}
+}
+
+// ApplyTo mutates issue in memory the way the backends will on disk.
+func (c Change) ApplyTo(issue *model.Issue, now time.Time) {
+	switch c.Kind {
+	case ChangeStatus:
+		applyStatus(issue, c.Status, now)
+	case ChangePriority:
+		issue.Priority = c.Priority
+	case ChangeAssignee:
+		issue.Assignee = c.Assignee
+	case ChangeLabels:
+		issue.Labels = editLabels(issue.Labels, c.AddLabels, c.RemoveLabels)
+	case ChangeComment:
+		var nextID int64 = 1
+		for _, comment := range issue.Comments {
+			if comment != nil && comment.ID >= nextID {
+				nextID = comment.ID + 1
+			}
+		}
+		issue.Comments = append(issue.Comments, &model.Comment{
+			ID:        nextID,
+			IssueID:   issue.ID,
+			Author:    Actor(),
+			Text:      c.Comment,
+			CreatedAt: now,
+		})
+	case ChangeAddDependency:
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{
+			IssueID:     issue.ID,
+			DependsOnID: c.DependsOn,
+			Type:        c.depType(),
+			CreatedAt:   now,
+			CreatedBy:   Actor(),
+		})
+	case ChangeRemoveDependency:
+		var kept []*model.Dependency
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID == c.DependsOn && sameDepType(dep.Type, c.depType()) {
+				continue
+			}
+			kept = append(kept, dep)
+		}
+		issue.Dependencies = kept
+	}
+	issue.UpdatedAt = now
+}
+
+// Describe returns a short human summary, e.g. "status → in_progress".
+func (c Change) Describe() string {
+	switch c.Kind {
+	case ChangeStatus:
+		return "status → " + string(c.Status)
+	case ChangePriority:
+		return fmt.Sprintf("priority → P%d", c.Priority)
+	case ChangeAssignee:
+		if c.Assignee == "" {
+			return "assignee cleared"
+		}
+		return "assignee → @" + c.Assignee
+	case ChangeLabels:
+		var parts []string
+		for _, l := range c.AddLabels {
+			parts = append(parts, "+"+l)
+		}
+		for _, l := range c.RemoveLabels {
+			parts = append(parts, "-"+l)
+		}
+		return "labels " + strings.Join(parts, " ")
+	case ChangeComment:
+		return "comment added"
+	case ChangeAddDependency:
+		return fmt.Sprintf("now depends on %s (%s)", c.DependsOn, c.depType())
+	case ChangeRemoveDependency:
+		return fmt.Sprintf("no longer depends on %s (%s)", c.DependsOn, c.depType())
+	default:
+		return "edit"
+	}
+}
+
+// ParseLabelEdit parses "+a -b c" into labels to add and remove. Bare words
+// are added.
+func ParseLabelEdit(input string) (add, remove []string) {
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' }) {
+		switch {
+		case strings.HasPrefix(field, "-"):
+			if l := strings.TrimPrefix(field, "-"); l != "" {
+				remove = append(remove, l)
+			}
+		case strings.HasPrefix(field, "+"):
+			if l := strings.TrimPrefix(field, "+"); l != "" {
+				add = append(add, l)
+			}
+		default:
+			add = append(add, field)
+		}
+	}
+	return add, remove
+}
+
+// Actor returns the name recorded as comment author: BD_ACTOR, then USER.
+func Actor() string {
+	if actor := strings.TrimSpace(os.Getenv(envActor)); actor != "" {
+		return actor
+	}
+	if user := strings.TrimSpace(os.Getenv("USER")); user != "" {
+		return user
+	}
+	return "bv"
+}
+
+func applyStatus(issue *model.Issue, status model.Status, now time.Time) {
+	issue.Status = status
+	if status.IsClosed() {
+		if issue.ClosedAt == nil {
+			closedAt := now
+			issue.ClosedAt = &closedAt
+		}
+	} else {
+		issue.ClosedAt = nil
+	}
+}
+
+// editLabels returns labels with add appended (deduplicated) and remove dropped,
+// sorted for stable output.
+func editLabels(labels, add, remove []string) []string {
+	set := make(map[string]bool, len(labels)+len(add))
+	for _, l := range labels {
+		set[l] = true
+	}
+	for _, l := range add {
+		set[l] = true
+	}
+	for _, l := range remove {
+		delete(set, l)
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(set))
+	for l := range set {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// depType defaults an unset dependency type to blocks.
+func (c Change) depType() model.DependencyType {
+	if c.DepType == "" {
+		return model.DepBlocks
+	}
+	return c.DepType
+}
+
+// sameDepType treats the legacy empty type as "blocks".
+func sameDepType(a, b model.DependencyType) bool {
+	if a.IsBlocking() && b.IsBlocking() {
+		return true
+	}
+	return a == b
+}
+
+func validateDependency(id, dependsOn string, depType model.DependencyType) error {
+	if dependsOn == "" || dependsOn == id {
+		return fmt.Errorf("invalid dependency target %q", dependsOn)
+	}
+	if !depType.IsValid() {
+		return fmt.Errorf("invalid dependency type %q", depType)
+	}
+	return nil
+}
+
+func validateStatus(status model.Status) error {
+	if !status.IsValid() {
+		return fmt.Errorf("invalid status %q", status)
+	}
+	return nil
+}
+
+func validatePriority(priority int) error {
+	if priority < 0 || priority > 4 {
+		return fmt.Errorf("invalid priority %d (expected 0-4)", priority)
+	}
+	return nil
+}
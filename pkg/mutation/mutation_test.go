@@ -0,0 +1,57 @@
+package mutation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestChange_ApplyTo(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	issue := model.Issue{ID: "bv-1", Status: model.StatusOpen, Labels: []string{"b", "a"}}
+
+	Change{Kind: ChangeStatus, Status: model.StatusClosed}.ApplyTo(&issue, now)
+	if issue.Status != model.StatusClosed || issue.ClosedAt == nil || !issue.UpdatedAt.Equal(now) {
+		t.Fatalf("status change not applied: %+v", issue)
+	}
+	Change{Kind: ChangeStatus, Status: model.StatusOpen}.ApplyTo(&issue, now)
+	if issue.ClosedAt != nil {
+		t.Fatal("reopen should clear ClosedAt")
+	}
+
+	Change{Kind: ChangeLabels, AddLabels: []string{"c", "a"}, RemoveLabels: []string{"b"}}.ApplyTo(&issue, now)
+	if got := strings.Join(issue.Labels, ","); got != "a,c" {
+		t.Fatalf("labels = %q, want a,c", got)
+	}
+
+	Change{Kind: ChangeComment, Comment: "hi"}.ApplyTo(&issue, now)
+	Change{Kind: ChangeComment, Comment: "again"}.ApplyTo(&issue, now)
+	if len(issue.Comments) != 2 || issue.Comments[1].ID != 2 {
+		t.Fatalf("comments not appended with increasing IDs: %+v", issue.Comments)
+	}
+}
+
+func TestParseLabelEdit(t *testing.T) {
+	add, remove := ParseLabelEdit("+ui -old api, -  ,+")
+	if strings.Join(add, ",") != "ui,api" || strings.Join(remove, ",") != "old" {
+		t.Fatalf("ParseLabelEdit = %v / %v", add, remove)
+	}
+}
+
+func TestChange_Describe(t *testing.T) {
+	cases := map[string]Change{
+		"status → blocked":  {Kind: ChangeStatus, Status: model.StatusBlocked},
+		"priority → P1":     {Kind: ChangePriority, Priority: 1},
+		"assignee cleared":  {Kind: ChangeAssignee},
+		"labels +a -b":      {Kind: ChangeLabels, AddLabels: []string{"a"}, RemoveLabels: []string{"b"}},
+		"comment added":     {Kind: ChangeComment, Comment: "x"},
+		"assignee → @alice": {Kind: ChangeAssignee, Assignee: "alice"},
+	}
+	for want, c := range cases {
+		if got := c.Describe(); got != want {
+			t.Errorf("Describe() = %q, want %q", got, want)
+		}
+	}
+}
@@ -29,6 +29,7 @@ type RecipeSummary struct {
 type Loader struct {
 	recipes    map[string]Recipe
 	sources    map[string]string // recipe name -> source
+	paths      map[string]string // recipe name -> file it was loaded from
 	userPath   string
 	projectDir string
 	warnings   []string
@@ -56,6 +57,7 @@ func NewLoader(opts ...LoaderOption) *Loader {
 	l := &Loader{
 		recipes: make(map[string]Recipe),
 		sources: make(map[string]string),
+		paths:   make(map[string]string),
 	}
 
 	for _, opt := range opts {
@@ -126,6 +128,7 @@ func (l *Loader) loadBuiltin() error {
 		recipe.Name = name
 		l.recipes[name] = *recipe
 		l.sources[name] = "builtin"
+		delete(l.paths, name)
 	}
 
 	return nil
@@ -148,11 +151,13 @@ func (l *Loader) loadFromFile(path, source string) error {
 			// Explicit null means "disable this recipe"
 			delete(l.recipes, name)
 			delete(l.sources, name)
+			delete(l.paths, name)
 			continue
 		}
 		recipe.Name = name
 		l.recipes[name] = *recipe
 		l.sources[name] = source
+		l.paths[name] = path
 	}
 
 	return nil
@@ -220,6 +225,11 @@ func (l *Loader) Source(name string) string {
 	return l.sources[name]
 }
 
+// Path returns the file a recipe was loaded from, or "" for builtin recipes
+func (l *Loader) Path(name string) string {
+	return l.paths[name]
+}
+
 // LoadDefault creates a loader and loads with default settings
 func LoadDefault() (*Loader, error) {
 	loader := NewLoader()
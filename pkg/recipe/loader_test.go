@@ -170,6 +170,12 @@ recipes:
 	if loader.Source("project-local") != "project" {
 		t.Errorf("Expected source 'project', got %q", loader.Source("project-local"))
 	}
+	if want := filepath.Join(projectDir, "recipes.yaml"); loader.Path("project-local") != want {
+		t.Errorf("Expected path %q, got %q", want, loader.Path("project-local"))
+	}
+	if loader.Path("default") != "" {
+		t.Errorf("Expected no path for builtin recipe, got %q", loader.Path("default"))
+	}
 }
 
 func TestLoaderDisableRecipe(t *testing.T) {
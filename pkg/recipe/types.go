@@ -55,8 +55,8 @@ type ViewConfig struct {
 // ExportConfig controls output format options
 type ExportConfig struct {
 	Format       string `yaml:"format,omitempty" json:"format,omitempty"`               // markdown, json, csv, mermaid
-	IncludeGraph bool   `yaml:"include_graph,omitempty" json:"include_graph,omitempty"` // Include Mermaid diagram
-	Template     string `yaml:"template,omitempty" json:"template,omitempty"`           // Custom template path
+	IncludeGraph *bool  `yaml:"include_graph,omitempty" json:"include_graph,omitempty"` // Include Mermaid diagram (default: markdown only)
+	Template     string `yaml:"template,omitempty" json:"template,omitempty"`           // Custom template path, relative to the recipe file
 }
 
 // relativeTimePattern matches relative time expressions like "14d", "2w", "1m", "1y"
@@ -14,6 +14,10 @@ import (
 //   - BV_SEMANTIC_EMBEDDER: embedding provider (default: "hash")
 //   - BV_SEMANTIC_MODEL: model identifier (provider-specific, optional)
 //   - BV_SEMANTIC_DIM: embedding dimension (default: DefaultEmbeddingDim)
+//   - BV_SEMANTIC_PYTHON / BV_SEMANTIC_WORKER: interpreter and worker script for
+//     the python-sentence-transformers provider (see NewPythonEmbedder)
+//   - BV_SEMANTIC_BASE_URL / BV_SEMANTIC_API_KEY: endpoint and key for the
+//     OpenAI-compatible provider (see NewOpenAIEmbedder)
 func EmbeddingConfigFromEnv() EmbeddingConfig {
 	provider := strings.ToLower(strings.TrimSpace(os.Getenv(EnvSemanticEmbedder)))
 	cfg := EmbeddingConfig{
@@ -38,9 +42,9 @@ func NewEmbedderFromConfig(cfg EmbeddingConfig) (Embedder, error) {
 	case "", ProviderHash:
 		return NewHashEmbedder(cfg.Dim), nil
 	case ProviderPythonSentenceTransformers:
-		return nil, fmt.Errorf("semantic embedder %q not implemented (mvp placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
+		return NewPythonEmbedder(cfg), nil
 	case ProviderOpenAI:
-		return nil, fmt.Errorf("semantic embedder %q not implemented (placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
+		return NewOpenAIEmbedder(cfg), nil
 	default:
 		return nil, fmt.Errorf("unknown semantic embedder %q; expected %q", cfg.Provider, ProviderHash)
 	}
@@ -336,16 +336,30 @@ func TestNewEmbedderFromConfig(t *testing.T) {
 			},
 		},
 		{
-			name:        "python-sentence-transformers not implemented",
-			cfg:         EmbeddingConfig{Provider: ProviderPythonSentenceTransformers, Dim: 384},
-			wantErr:     true,
-			errContains: "not implemented",
+			name:    "python-sentence-transformers provider",
+			cfg:     EmbeddingConfig{Provider: ProviderPythonSentenceTransformers, Dim: 384},
+			wantErr: false,
+			checkEmbed: func(t *testing.T, e Embedder) {
+				if e.Provider() != ProviderPythonSentenceTransformers {
+					t.Errorf("Provider() = %q, want %q", e.Provider(), ProviderPythonSentenceTransformers)
+				}
+				if e.Dim() != 384 {
+					t.Errorf("Dim() = %d, want 384", e.Dim())
+				}
+			},
 		},
 		{
-			name:        "openai not implemented",
-			cfg:         EmbeddingConfig{Provider: ProviderOpenAI, Dim: 1536},
-			wantErr:     true,
-			errContains: "not implemented",
+			name:    "openai provider",
+			cfg:     EmbeddingConfig{Provider: ProviderOpenAI, Dim: 1536},
+			wantErr: false,
+			checkEmbed: func(t *testing.T, e Embedder) {
+				if e.Provider() != ProviderOpenAI {
+					t.Errorf("Provider() = %q, want %q", e.Provider(), ProviderOpenAI)
+				}
+				if e.Dim() != 1536 {
+					t.Errorf("Dim() = %d, want 1536", e.Dim())
+				}
+			},
 		},
 		{
 			name:        "unknown provider error",
@@ -355,7 +369,7 @@ func TestNewEmbedderFromConfig(t *testing.T) {
 		},
 		{
 			name:        "error message suggests hash fallback",
-			cfg:         EmbeddingConfig{Provider: ProviderOpenAI},
+			cfg:         EmbeddingConfig{Provider: "sentencepiece"},
 			wantErr:     true,
 			errContains: ProviderHash.String(),
 		},
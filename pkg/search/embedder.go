@@ -47,3 +47,9 @@ type Embedder interface {
 	Dim() int
 	Embed(ctx context.Context, texts []string) ([][]float32, error)
 }
+
+// BatchLimiter is implemented by embedders that accept a bounded number of texts per
+// Embed call (e.g. subprocess or HTTP backends). SyncVectorIndex never exceeds it.
+type BatchLimiter interface {
+	MaxBatchSize() int
+}
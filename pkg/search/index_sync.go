@@ -22,6 +22,17 @@ func DefaultIndexPath(projectDir string, cfg EmbeddingConfig) string {
 	return filepath.Join(projectDir, ".bv", "semantic", fmt.Sprintf("index-%s-%d.bvvi", safeProvider, cfg.Dim))
 }
 
+// SyncTimeout returns a reasonable deadline for building or updating an index with
+// the given provider. Model-backed providers need time to load weights on first use.
+func SyncTimeout(provider Provider) time.Duration {
+	switch provider {
+	case "", ProviderHash:
+		return 30 * time.Second
+	default:
+		return 5 * time.Minute
+	}
+}
+
 type IndexSyncStats struct {
 	Total    int `json:"total"`
 	Added    int `json:"added"`
@@ -81,6 +92,11 @@ func SyncVectorIndex(ctx context.Context, idx *VectorIndex, embedder Embedder, d
 	if batchSize <= 0 {
 		batchSize = 32
 	}
+	if limiter, ok := embedder.(BatchLimiter); ok {
+		if limit := limiter.MaxBatchSize(); limit > 0 && batchSize > limit {
+			batchSize = limit
+		}
+	}
 
 	stats.Total = len(docs)
 
@@ -0,0 +1,319 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EnvSemanticBaseURL sets the OpenAI-compatible API root (e.g. http://localhost:11434/v1).
+	EnvSemanticBaseURL = "BV_SEMANTIC_BASE_URL"
+	// EnvSemanticAPIKey sets the bearer token; OPENAI_API_KEY is used when unset.
+	EnvSemanticAPIKey = "BV_SEMANTIC_API_KEY"
+	envOpenAIAPIKey   = "OPENAI_API_KEY"
+)
+
+const (
+	DefaultOpenAIBaseURL    = "https://api.openai.com/v1"
+	DefaultOpenAIModel      = "text-embedding-3-small"
+	DefaultOpenAIMaxBatch   = 96
+	DefaultOpenAIMaxRetries = 4
+	DefaultOpenAITimeout    = 30 * time.Second
+	defaultOpenAIBackoff    = 500 * time.Millisecond
+	maxOpenAIBackoff        = 30 * time.Second
+)
+
+// OpenAIEmbedder calls an OpenAI-compatible /embeddings endpoint. Besides the
+// hosted API this works with local servers such as llama.cpp and Ollama.
+type OpenAIEmbedder struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	dim        int
+	maxBatch   int
+	maxRetries int
+	backoff    time.Duration
+	client     *http.Client
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+// OpenAIEmbedderOption configures an OpenAIEmbedder.
+type OpenAIEmbedderOption func(*OpenAIEmbedder)
+
+// WithOpenAIBaseURL sets the API root; "/embeddings" is appended per request.
+func WithOpenAIBaseURL(baseURL string) OpenAIEmbedderOption {
+	return func(e *OpenAIEmbedder) {
+		if baseURL != "" {
+			e.baseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithOpenAIAPIKey sets the bearer token sent with each request.
+func WithOpenAIAPIKey(key string) OpenAIEmbedderOption {
+	return func(e *OpenAIEmbedder) {
+		e.apiKey = key
+	}
+}
+
+// WithOpenAIHTTPClient replaces the HTTP client (and thus its timeout).
+func WithOpenAIHTTPClient(client *http.Client) OpenAIEmbedderOption {
+	return func(e *OpenAIEmbedder) {
+		if client != nil {
+			e.client = client
+		}
+	}
+}
+
+// WithOpenAIMaxBatch caps the number of inputs sent per request.
+func WithOpenAIMaxBatch(n int) OpenAIEmbedderOption {
+	return func(e *OpenAIEmbedder) {
+		if n > 0 {
+			e.maxBatch = n
+		}
+	}
+}
+
+// WithOpenAIRetries sets the retry budget and initial backoff for transient failures.
+func WithOpenAIRetries(maxRetries int, backoff time.Duration) OpenAIEmbedderOption {
+	return func(e *OpenAIEmbedder) {
+		if maxRetries >= 0 {
+			e.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			e.backoff = backoff
+		}
+	}
+}
+
+// NewOpenAIEmbedder creates an OpenAIEmbedder for cfg. BV_SEMANTIC_BASE_URL and
+// BV_SEMANTIC_API_KEY (or OPENAI_API_KEY) are honored before opts are applied.
+func NewOpenAIEmbedder(cfg EmbeddingConfig, opts ...OpenAIEmbedderOption) *OpenAIEmbedder {
+	cfg = cfg.Normalized()
+	e := &OpenAIEmbedder{
+		baseURL:    DefaultOpenAIBaseURL,
+		model:      cfg.Model,
+		dim:        cfg.Dim,
+		maxBatch:   DefaultOpenAIMaxBatch,
+		maxRetries: DefaultOpenAIMaxRetries,
+		backoff:    defaultOpenAIBackoff,
+		client:     &http.Client{Timeout: DefaultOpenAITimeout},
+		sleep:      sleepContext,
+	}
+	if e.model == "" {
+		e.model = DefaultOpenAIModel
+	}
+	if baseURL := strings.TrimSpace(os.Getenv(EnvSemanticBaseURL)); baseURL != "" {
+		e.baseURL = strings.TrimRight(baseURL, "/")
+	}
+	e.apiKey = strings.TrimSpace(os.Getenv(EnvSemanticAPIKey))
+	if e.apiKey == "" {
+		e.apiKey = strings.TrimSpace(os.Getenv(envOpenAIAPIKey))
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+func (*OpenAIEmbedder) Provider() Provider { return ProviderOpenAI }
+func (e *OpenAIEmbedder) Dim() int         { return e.dim }
+
+// Model returns the model name sent to the endpoint.
+func (e *OpenAIEmbedder) Model() string { return e.model }
+
+// MaxBatchSize implements BatchLimiter.
+func (e *OpenAIEmbedder) MaxBatchSize() int { return e.maxBatch }
+
+// Embed posts texts to the endpoint in batches of at most MaxBatchSize.
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += e.maxBatch {
+		end := start + e.maxBatch
+		if end > len(texts) {
+			end = len(texts)
+		}
+		vecs, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vecs...)
+	}
+	return out, nil
+}
+
+type openAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// openAIStatusError is a non-2xx response. Retryable marks 429 and 5xx.
+type openAIStatusError struct {
+	Status     int
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *openAIStatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("embedding endpoint returned %d: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("embedding endpoint returned %d", e.Status)
+}
+
+func (e *openAIStatusError) retryable() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+func (e *OpenAIEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(openAIEmbeddingRequest{Model: e.model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("encode embedding request: %w", err)
+	}
+
+	backoff := e.backoff
+	var lastErr error
+	for attempt := 0; attempt <= e.maxRetries; attempt++ {
+		if attempt > 0 {
+			wait := backoff
+			if statusErr, ok := lastErr.(*openAIStatusError); ok && statusErr.RetryAfter > 0 {
+				wait = statusErr.RetryAfter
+			}
+			if err := e.sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+			backoff *= 2
+			if backoff > maxOpenAIBackoff {
+				backoff = maxOpenAIBackoff
+			}
+		}
+
+		vecs, err := e.post(ctx, body, len(texts))
+		if err == nil {
+			return vecs, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		statusErr, isStatus := err.(*openAIStatusError)
+		if isStatus && !statusErr.retryable() {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("embedding request failed after %d retries: %w", e.maxRetries, lastErr)
+}
+
+// post performs one request. Transport errors and retryable statuses are returned
+// as-is so embedBatch can retry; malformed or mismatched payloads are permanent.
+func (e *OpenAIEmbedder) post(ctx context.Context, body []byte, want int) ([][]float32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, &openAIStatusError{Message: err.Error()}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 256<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var payload openAIEmbeddingResponse
+	decodeErr := json.Unmarshal(raw, &payload)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		statusErr := &openAIStatusError{
+			Status:     resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+		if decodeErr == nil && payload.Error != nil {
+			statusErr.Message = payload.Error.Message
+		}
+		return nil, statusErr
+	}
+	if decodeErr != nil {
+		return nil, &openAIStatusError{Status: resp.StatusCode, Message: "decode response: " + decodeErr.Error()}
+	}
+	if len(payload.Data) != want {
+		return nil, &openAIStatusError{Status: resp.StatusCode, Message: fmt.Sprintf("returned %d embeddings for %d inputs", len(payload.Data), want)}
+	}
+
+	out := make([][]float32, want)
+	for _, item := range payload.Data {
+		if item.Index < 0 || item.Index >= want || out[item.Index] != nil {
+			return nil, &openAIStatusError{Status: resp.StatusCode, Message: fmt.Sprintf("invalid embedding index %d", item.Index)}
+		}
+		if len(item.Embedding) != e.dim {
+			return nil, &openAIStatusError{Status: resp.StatusCode, Message: fmt.Sprintf("model %q returned dim %d but %s=%d; set %s to match", e.model, len(item.Embedding), EnvSemanticDim, e.dim, EnvSemanticDim)}
+		}
+		out[item.Index] = item.Embedding
+	}
+	return out, nil
+}
+
+// parseRetryAfter understands both delta-seconds and HTTP-date forms.
+func parseRetryAfter(v string) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		d := time.Duration(secs) * time.Second
+		if d > maxOpenAIBackoff {
+			d = maxOpenAIBackoff
+		}
+		return d
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			return 0
+		}
+		if d > maxOpenAIBackoff {
+			d = maxOpenAIBackoff
+		}
+		return d
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
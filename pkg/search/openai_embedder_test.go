@@ -0,0 +1,215 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeEmbeddingsHandler serves /embeddings, returning vectors of dim whose first
+// component is the input's position in the request. Responses are emitted in
+// reverse order to exercise index-based reassembly.
+func fakeEmbeddingsHandler(t *testing.T, dim int, batches *[]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		var req openAIEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if batches != nil {
+			*batches = append(*batches, len(req.Input))
+		}
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		data := make([]item, 0, len(req.Input))
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			vec := make([]float32, dim)
+			vec[0] = float32(i)
+			data = append(data, item{Index: i, Embedding: vec})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}
+}
+
+func newTestOpenAIEmbedder(url string, dim int, opts ...OpenAIEmbedderOption) *OpenAIEmbedder {
+	opts = append([]OpenAIEmbedderOption{
+		WithOpenAIBaseURL(url + "/v1"),
+		WithOpenAIRetries(3, time.Millisecond),
+	}, opts...)
+	e := NewOpenAIEmbedder(EmbeddingConfig{Provider: ProviderOpenAI, Model: "test-model", Dim: dim}, opts...)
+	e.sleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
+	return e
+}
+
+func TestOpenAIEmbedder_BatchingAndOrdering(t *testing.T) {
+	var batches []int
+	srv := httptest.NewServer(fakeEmbeddingsHandler(t, 4, &batches))
+	defer srv.Close()
+
+	e := newTestOpenAIEmbedder(srv.URL, 4, WithOpenAIMaxBatch(2))
+	vecs, err := e.Embed(context.Background(), []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(vecs) != 3 {
+		t.Fatalf("expected 3 vectors, got %d", len(vecs))
+	}
+	if vecs[0][0] != 0 || vecs[1][0] != 1 || vecs[2][0] != 0 {
+		t.Fatalf("vectors not reassembled by index: %v %v %v", vecs[0][0], vecs[1][0], vecs[2][0])
+	}
+	if len(batches) != 2 || batches[0] != 2 || batches[1] != 1 {
+		t.Fatalf("unexpected batches: %v", batches)
+	}
+}
+
+func TestOpenAIEmbedder_SendsAuthAndModel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer sk-test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var req openAIEmbeddingRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "test-model" {
+			t.Errorf("model = %q", req.Model)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{{"index": 0, "embedding": []float32{1, 0}}}})
+	}))
+	defer srv.Close()
+
+	e := newTestOpenAIEmbedder(srv.URL, 2, WithOpenAIAPIKey("sk-test"))
+	if _, err := e.Embed(context.Background(), []string{"x"}); err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+}
+
+func TestOpenAIEmbedder_RetriesTransientFailures(t *testing.T) {
+	var calls int32
+	ok := fakeEmbeddingsHandler(t, 4, nil)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"message":"slow down"}}`))
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			ok(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	e := newTestOpenAIEmbedder(srv.URL, 4)
+	e.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	if _, err := e.Embed(context.Background(), []string{"a"}); err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+	if len(waits) != 2 || waits[0] != time.Second {
+		t.Fatalf("expected Retry-After to drive first wait, got %v", waits)
+	}
+	if waits[1] != 2*time.Millisecond {
+		t.Fatalf("expected exponential backoff for second wait, got %v", waits[1])
+	}
+}
+
+func TestOpenAIEmbedder_GivesUpAfterRetries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	e := newTestOpenAIEmbedder(srv.URL, 4)
+	_, err := e.Embed(context.Background(), []string{"a"})
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected 503 error, got %v", err)
+	}
+	if calls != 4 {
+		t.Fatalf("expected 1 attempt + 3 retries, got %d", calls)
+	}
+}
+
+func TestOpenAIEmbedder_ClientErrorNotRetried(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"bad key"}}`))
+	}))
+	defer srv.Close()
+
+	e := newTestOpenAIEmbedder(srv.URL, 4)
+	_, err := e.Embed(context.Background(), []string{"a"})
+	if err == nil || !strings.Contains(err.Error(), "bad key") {
+		t.Fatalf("expected auth error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected no retries for 401, got %d calls", calls)
+	}
+}
+
+func TestOpenAIEmbedder_DimMismatch(t *testing.T) {
+	srv := httptest.NewServer(fakeEmbeddingsHandler(t, 8, nil))
+	defer srv.Close()
+
+	e := newTestOpenAIEmbedder(srv.URL, 4)
+	_, err := e.Embed(context.Background(), []string{"a"})
+	if err == nil || !strings.Contains(err.Error(), EnvSemanticDim) {
+		t.Fatalf("expected dim mismatch error, got %v", err)
+	}
+}
+
+func TestOpenAIEmbedder_SyncVectorIndex(t *testing.T) {
+	var batches []int
+	srv := httptest.NewServer(fakeEmbeddingsHandler(t, 4, &batches))
+	defer srv.Close()
+
+	e := newTestOpenAIEmbedder(srv.URL, 4, WithOpenAIMaxBatch(2))
+	if _, err := SyncVectorIndex(context.Background(), NewVectorIndex(8), e, map[string]string{"A": "a"}, 0); err == nil {
+		t.Fatal("expected index/embedder dim mismatch")
+	}
+
+	idx := NewVectorIndex(4)
+	stats, err := SyncVectorIndex(context.Background(), idx, e, map[string]string{"A": "a", "B": "b", "C": "c"}, 64)
+	if err != nil {
+		t.Fatalf("SyncVectorIndex: %v", err)
+	}
+	if stats.Embedded != 3 || idx.Size() != 3 {
+		t.Fatalf("unexpected stats %+v size %d", stats, idx.Size())
+	}
+	if len(batches) != 2 {
+		t.Fatalf("expected SyncVectorIndex to honor MaxBatchSize, got batches %v", batches)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("3"); got != 3*time.Second {
+		t.Errorf("parseRetryAfter(3) = %v", got)
+	}
+	if got := parseRetryAfter("9999"); got != maxOpenAIBackoff {
+		t.Errorf("parseRetryAfter(9999) = %v, want cap", got)
+	}
+	if got := parseRetryAfter("garbage"); got != 0 {
+		t.Errorf("parseRetryAfter(garbage) = %v", got)
+	}
+}
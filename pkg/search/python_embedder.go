@@ -0,0 +1,366 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed python_worker.py
+var pythonWorkerSource string
+
+const (
+	// EnvSemanticPython overrides the Python interpreter used for the worker (default: python3).
+	EnvSemanticPython = "BV_SEMANTIC_PYTHON"
+	// EnvSemanticWorker points at a custom worker script speaking the same protocol
+	// as the embedded one (useful for alternative runtimes and tests).
+	EnvSemanticWorker = "BV_SEMANTIC_WORKER"
+)
+
+const (
+	DefaultPythonModel         = "sentence-transformers/all-MiniLM-L6-v2"
+	DefaultPythonStartTimeout  = 2 * time.Minute
+	DefaultPythonEmbedTimeout  = 30 * time.Second
+	DefaultPythonMaxBatch      = 64
+	defaultPythonWorkerRetries = 1
+)
+
+// errWorkerGone marks failures caused by the worker process dying or closing its pipes.
+// These are retried on a fresh worker; protocol-level errors are not.
+var errWorkerGone = errors.New("python embedding worker exited")
+
+// PythonEmbedder embeds text via a long-lived Python subprocess running
+// sentence-transformers. The worker is started lazily on the first Embed call
+// and restarted transparently if it dies.
+type PythonEmbedder struct {
+	model        string
+	dim          int
+	python       string
+	scriptPath   string
+	startTimeout time.Duration
+	embedTimeout time.Duration
+	maxBatch     int
+	retries      int
+
+	mu     sync.Mutex
+	worker *pythonWorker
+	nextID int64
+	closed bool
+}
+
+// PythonEmbedderOption configures a PythonEmbedder.
+type PythonEmbedderOption func(*PythonEmbedder)
+
+// WithPythonInterpreter sets the Python interpreter used to run the worker.
+func WithPythonInterpreter(python string) PythonEmbedderOption {
+	return func(e *PythonEmbedder) {
+		if python != "" {
+			e.python = python
+		}
+	}
+}
+
+// WithPythonWorkerScript runs the given script instead of the embedded worker.
+func WithPythonWorkerScript(path string) PythonEmbedderOption {
+	return func(e *PythonEmbedder) {
+		e.scriptPath = path
+	}
+}
+
+// WithPythonTimeouts sets the worker startup (model load) and per-request timeouts.
+func WithPythonTimeouts(start, embed time.Duration) PythonEmbedderOption {
+	return func(e *PythonEmbedder) {
+		if start > 0 {
+			e.startTimeout = start
+		}
+		if embed > 0 {
+			e.embedTimeout = embed
+		}
+	}
+}
+
+// WithPythonMaxBatch caps the number of texts sent to the worker per request.
+func WithPythonMaxBatch(n int) PythonEmbedderOption {
+	return func(e *PythonEmbedder) {
+		if n > 0 {
+			e.maxBatch = n
+		}
+	}
+}
+
+// NewPythonEmbedder creates a PythonEmbedder for cfg. BV_SEMANTIC_PYTHON and
+// BV_SEMANTIC_WORKER are honored before opts are applied.
+func NewPythonEmbedder(cfg EmbeddingConfig, opts ...PythonEmbedderOption) *PythonEmbedder {
+	cfg = cfg.Normalized()
+	e := &PythonEmbedder{
+		model:        cfg.Model,
+		dim:          cfg.Dim,
+		python:       "python3",
+		startTimeout: DefaultPythonStartTimeout,
+		embedTimeout: DefaultPythonEmbedTimeout,
+		maxBatch:     DefaultPythonMaxBatch,
+		retries:      defaultPythonWorkerRetries,
+	}
+	if e.model == "" {
+		e.model = DefaultPythonModel
+	}
+	if python := strings.TrimSpace(os.Getenv(EnvSemanticPython)); python != "" {
+		e.python = python
+	}
+	if script := strings.TrimSpace(os.Getenv(EnvSemanticWorker)); script != "" {
+		e.scriptPath = script
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+func (*PythonEmbedder) Provider() Provider { return ProviderPythonSentenceTransformers }
+func (e *PythonEmbedder) Dim() int         { return e.dim }
+
+// Model returns the sentence-transformers model name passed to the worker.
+func (e *PythonEmbedder) Model() string { return e.model }
+
+// MaxBatchSize implements BatchLimiter.
+func (e *PythonEmbedder) MaxBatchSize() int { return e.maxBatch }
+
+// Embed sends texts to the worker in batches of at most MaxBatchSize.
+func (e *PythonEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += e.maxBatch {
+		end := start + e.maxBatch
+		if end > len(texts) {
+			end = len(texts)
+		}
+		vecs, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vecs...)
+	}
+	return out, nil
+}
+
+// Close stops the worker process. Subsequent Embed calls fail.
+func (e *PythonEmbedder) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	if e.worker != nil {
+		e.worker.stop()
+		e.worker = nil
+	}
+	return nil
+}
+
+func (e *PythonEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var lastErr error
+	for attempt := 0; attempt <= e.retries; attempt++ {
+		if e.closed {
+			return nil, fmt.Errorf("python embedder is closed")
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if e.worker == nil {
+			w, err := e.startWorker(ctx)
+			if err != nil {
+				return nil, err
+			}
+			e.worker = w
+		}
+
+		e.nextID++
+		vecs, err := e.worker.embed(ctx, e.nextID, texts, e.embedTimeout)
+		if err == nil {
+			if len(vecs) != len(texts) {
+				return nil, fmt.Errorf("python worker returned %d vectors for %d texts", len(vecs), len(texts))
+			}
+			for i, vec := range vecs {
+				if len(vec) != e.dim {
+					return nil, fmt.Errorf("python worker vector %d has dim %d, expected %d", i, len(vec), e.dim)
+				}
+			}
+			return vecs, nil
+		}
+
+		// Any transport failure leaves the worker in an unknown state; discard it.
+		var protoErr *pythonWorkerError
+		if errors.As(err, &protoErr) {
+			return nil, err
+		}
+		e.worker.stop()
+		e.worker = nil
+		if !errors.Is(err, errWorkerGone) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("python embedding worker failed after %d restarts: %w", e.retries, lastErr)
+}
+
+func (e *PythonEmbedder) startWorker(ctx context.Context) (*pythonWorker, error) {
+	args := []string{"-u"}
+	if e.scriptPath != "" {
+		args = append(args, e.scriptPath)
+	} else {
+		args = append(args, "-c", pythonWorkerSource)
+	}
+
+	cmd := exec.Command(e.python, args...)
+	cmd.Env = append(os.Environ(), EnvSemanticModel+"="+e.model)
+	cmd.Stderr = io.Discard
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("python worker stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("python worker stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start python embedding worker (%s): %w", e.python, err)
+	}
+
+	w := &pythonWorker{
+		cmd:   cmd,
+		stdin: stdin,
+		lines: make(chan []byte, 1),
+		done:  make(chan struct{}),
+	}
+	go w.readLoop(stdout)
+
+	var ready struct {
+		Ready bool   `json:"ready"`
+		Model string `json:"model"`
+		Dim   int    `json:"dim"`
+		Error string `json:"error"`
+	}
+	line, err := w.next(ctx, e.startTimeout)
+	if err == nil {
+		err = json.Unmarshal(line, &ready)
+	}
+	switch {
+	case err != nil:
+		w.stop()
+		return nil, fmt.Errorf("python embedding worker startup: %w", err)
+	case !ready.Ready:
+		w.stop()
+		if ready.Error == "" {
+			ready.Error = "worker did not report ready"
+		}
+		return nil, fmt.Errorf("python embedding worker startup: %s", ready.Error)
+	case ready.Dim != e.dim:
+		w.stop()
+		return nil, fmt.Errorf("model %q produces dim %d but %s=%d; set %s to match", e.model, ready.Dim, EnvSemanticDim, e.dim, EnvSemanticDim)
+	}
+	return w, nil
+}
+
+// pythonWorkerError is an error reported by the worker for a single request.
+// The worker is still healthy, so these are not retried.
+type pythonWorkerError struct {
+	msg string
+}
+
+func (e *pythonWorkerError) Error() string { return "python embedding worker: " + e.msg }
+
+type pythonWorker struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan []byte
+	done  chan struct{}
+	once  sync.Once
+}
+
+func (w *pythonWorker) readLoop(r io.Reader) {
+	defer close(w.lines)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		select {
+		case w.lines <- line:
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// next waits for the next stdout line, honoring ctx and timeout.
+func (w *pythonWorker) next(ctx context.Context, timeout time.Duration) ([]byte, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case line, ok := <-w.lines:
+		if !ok {
+			return nil, errWorkerGone
+		}
+		return line, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("python embedding worker timed out after %s", timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (w *pythonWorker) embed(ctx context.Context, id int64, texts []string, timeout time.Duration) ([][]float32, error) {
+	req, err := json.Marshal(struct {
+		ID    int64    `json:"id"`
+		Texts []string `json:"texts"`
+	}{ID: id, Texts: texts})
+	if err != nil {
+		return nil, fmt.Errorf("encode embed request: %w", err)
+	}
+	if _, err := w.stdin.Write(append(req, '\n')); err != nil {
+		return nil, fmt.Errorf("%w: %v", errWorkerGone, err)
+	}
+
+	for {
+		line, err := w.next(ctx, timeout)
+		if err != nil {
+			return nil, err
+		}
+		var resp struct {
+			ID      int64       `json:"id"`
+			Vectors [][]float32 `json:"vectors"`
+			Error   string      `json:"error"`
+		}
+		if err := json.Unmarshal(line, &resp); err != nil {
+			return nil, fmt.Errorf("decode python worker response: %w", err)
+		}
+		if resp.ID != id {
+			// Stale response from an earlier, abandoned request.
+			continue
+		}
+		if resp.Error != "" {
+			return nil, &pythonWorkerError{msg: resp.Error}
+		}
+		return resp.Vectors, nil
+	}
+}
+
+func (w *pythonWorker) stop() {
+	w.once.Do(func() {
+		close(w.done)
+		_ = w.stdin.Close()
+		if w.cmd.Process != nil {
+			_ = w.cmd.Process.Kill()
+		}
+		_ = w.cmd.Wait()
+	})
+}
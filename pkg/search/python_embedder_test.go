@@ -0,0 +1,153 @@
+package search
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeWorkerScript mimics python_worker.py without sentence-transformers.
+// Each vector encodes [batch size, index in batch, 0...]. Special texts:
+//   - "crash": exits once (tracked via a marker file) to exercise restarts
+//   - "hang": sleeps to exercise timeouts
+//   - "fail": returns a per-request error
+const fakeWorkerScript = `
+import json, os, sys, time
+dim = int(os.environ.get("FAKE_DIM", "8"))
+marker = os.environ.get("FAKE_MARKER", "")
+print(json.dumps({"ready": True, "model": os.environ.get("BV_SEMANTIC_MODEL"), "dim": dim}), flush=True)
+for line in sys.stdin:
+    req = json.loads(line)
+    texts = req["texts"]
+    if "crash" in texts and marker and not os.path.exists(marker):
+        open(marker, "w").close()
+        sys.exit(1)
+    if "hang" in texts:
+        time.sleep(10)
+    if "fail" in texts:
+        print(json.dumps({"id": req["id"], "error": "boom"}), flush=True)
+        continue
+    vecs = []
+    for i, _ in enumerate(texts):
+        v = [0.0] * dim
+        v[0] = float(len(texts))
+        v[1] = float(i)
+        vecs.append(v)
+    print(json.dumps({"id": req["id"], "vectors": vecs}), flush=True)
+`
+
+func newFakePythonEmbedder(t *testing.T, dim int, opts ...PythonEmbedderOption) *PythonEmbedder {
+	t.Helper()
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not available")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "fake_worker.py")
+	if err := os.WriteFile(script, []byte(fakeWorkerScript), 0o644); err != nil {
+		t.Fatalf("write fake worker: %v", err)
+	}
+	t.Setenv("FAKE_DIM", "8")
+	t.Setenv("FAKE_MARKER", filepath.Join(dir, "crashed"))
+
+	opts = append([]PythonEmbedderOption{WithPythonInterpreter(python), WithPythonWorkerScript(script)}, opts...)
+	e := NewPythonEmbedder(EmbeddingConfig{Provider: ProviderPythonSentenceTransformers, Model: "fake-model", Dim: dim}, opts...)
+	t.Cleanup(func() { _ = e.Close() })
+	return e
+}
+
+func TestPythonEmbedder_EmbedAndBatching(t *testing.T) {
+	e := newFakePythonEmbedder(t, 8, WithPythonMaxBatch(2))
+
+	vecs, err := e.Embed(context.Background(), []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(vecs) != 3 {
+		t.Fatalf("expected 3 vectors, got %d", len(vecs))
+	}
+	// Batches of [a b] and [c].
+	if vecs[0][0] != 2 || vecs[1][0] != 2 || vecs[2][0] != 1 {
+		t.Fatalf("unexpected batch sizes: %v %v %v", vecs[0][0], vecs[1][0], vecs[2][0])
+	}
+	if vecs[1][1] != 1 || vecs[2][1] != 0 {
+		t.Fatalf("unexpected batch positions: %v %v", vecs[1][1], vecs[2][1])
+	}
+}
+
+func TestPythonEmbedder_DimMismatch(t *testing.T) {
+	e := newFakePythonEmbedder(t, 16)
+	_, err := e.Embed(context.Background(), []string{"a"})
+	if err == nil || !strings.Contains(err.Error(), EnvSemanticDim) {
+		t.Fatalf("expected dim mismatch error mentioning %s, got %v", EnvSemanticDim, err)
+	}
+}
+
+func TestPythonEmbedder_RestartsAfterCrash(t *testing.T) {
+	e := newFakePythonEmbedder(t, 8)
+	vecs, err := e.Embed(context.Background(), []string{"crash"})
+	if err != nil {
+		t.Fatalf("expected restart to recover, got %v", err)
+	}
+	if len(vecs) != 1 {
+		t.Fatalf("expected 1 vector, got %d", len(vecs))
+	}
+}
+
+func TestPythonEmbedder_WorkerErrorNotRetried(t *testing.T) {
+	e := newFakePythonEmbedder(t, 8)
+	_, err := e.Embed(context.Background(), []string{"fail"})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected worker error, got %v", err)
+	}
+	// Worker must still be usable.
+	if _, err := e.Embed(context.Background(), []string{"ok"}); err != nil {
+		t.Fatalf("Embed after worker error: %v", err)
+	}
+}
+
+func TestPythonEmbedder_Timeout(t *testing.T) {
+	e := newFakePythonEmbedder(t, 8, WithPythonTimeouts(0, 200*time.Millisecond))
+	_, err := e.Embed(context.Background(), []string{"hang"})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	// The hung worker is discarded and a fresh one serves the next request.
+	if _, err := e.Embed(context.Background(), []string{"ok"}); err != nil {
+		t.Fatalf("Embed after timeout: %v", err)
+	}
+}
+
+func TestPythonEmbedder_ClosedRejectsEmbed(t *testing.T) {
+	e := newFakePythonEmbedder(t, 8)
+	_ = e.Close()
+	if _, err := e.Embed(context.Background(), []string{"a"}); err == nil {
+		t.Fatal("expected error after Close")
+	}
+}
+
+func TestSyncVectorIndex_RespectsBatchLimiter(t *testing.T) {
+	e := newFakePythonEmbedder(t, 8, WithPythonMaxBatch(2))
+	idx := NewVectorIndex(8)
+	docs := map[string]string{"A": "a", "B": "b", "C": "c"}
+
+	stats, err := SyncVectorIndex(context.Background(), idx, e, docs, 64)
+	if err != nil {
+		t.Fatalf("SyncVectorIndex: %v", err)
+	}
+	if stats.Embedded != 3 {
+		t.Fatalf("expected 3 embedded, got %d", stats.Embedded)
+	}
+	entry, ok := idx.Get("C")
+	if !ok {
+		t.Fatal("missing entry C")
+	}
+	// C lands alone in the second batch when the limiter is honored.
+	if entry.Vector[0] != 1 {
+		t.Fatalf("expected C to be embedded in a batch of 1, got %v", entry.Vector[0])
+	}
+}
@@ -0,0 +1,52 @@
+"""bv semantic embedding worker.
+
+Speaks a line-delimited JSON protocol over stdin/stdout:
+
+  startup:  -> {"ready": true, "model": "...", "dim": 384}
+  request:  <- {"id": 1, "texts": ["...", "..."]}
+  response: -> {"id": 1, "vectors": [[...], [...]]}
+  failure:  -> {"id": 1, "error": "message"}
+
+The worker stays alive until stdin is closed so the model is loaded once.
+"""
+
+import json
+import os
+import sys
+
+
+def emit(payload):
+    sys.stdout.write(json.dumps(payload) + "\n")
+    sys.stdout.flush()
+
+
+def main():
+    model_name = os.environ.get("BV_SEMANTIC_MODEL") or "sentence-transformers/all-MiniLM-L6-v2"
+    try:
+        from sentence_transformers import SentenceTransformer
+
+        model = SentenceTransformer(model_name)
+    except Exception as exc:  # noqa: BLE001 - report any import/load failure to bv
+        emit({"ready": False, "error": "load %s: %s" % (model_name, exc)})
+        return 1
+
+    emit({"ready": True, "model": model_name, "dim": int(model.get_sentence_embedding_dimension())})
+
+    for line in sys.stdin:
+        line = line.strip()
+        if not line:
+            continue
+        req_id = None
+        try:
+            req = json.loads(line)
+            req_id = req.get("id")
+            texts = req.get("texts") or []
+            vectors = model.encode(texts, normalize_embeddings=True, show_progress_bar=False)
+            emit({"id": req_id, "vectors": [[float(x) for x in v] for v in vectors]})
+        except Exception as exc:  # noqa: BLE001 - keep serving after a bad request
+            emit({"id": req_id, "error": str(exc)})
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
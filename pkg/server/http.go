@@ -0,0 +1,255 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Method answers one query against the warm snapshot.
+type Method func(s *Server, p Params) (any, error)
+
+// Methods maps endpoint names to their handlers. Each is served at
+// GET /<name> and as JSON-RPC method <name> on POST /rpc.
+var Methods = map[string]Method{
+	"health":        (*Server).Health,
+	"triage":        (*Server).Triage,
+	"next":          (*Server).Next,
+	"plan":          (*Server).Plan,
+	"insights":      (*Server).Insights,
+	"graph":         (*Server).Graph,
+	"search":        (*Server).Search,
+	"forecast":      (*Server).Forecast,
+	"history":       (*Server).History,
+	"blocker-chain": (*Server).BlockerChain,
+	"related":       (*Server).Related,
+	"suggest":       (*Server).Suggest,
+	"issue":         (*Server).Issue,
+}
+
+// MethodNames returns the endpoint names in sorted order.
+func MethodNames() []string {
+	names := make([]string, 0, len(Methods))
+	for name := range Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Call runs the named method.
+func (s *Server) Call(name string, p Params) (any, error) {
+	method, ok := Methods[name]
+	if !ok {
+		return nil, notFound("unknown method %q", name)
+	}
+	return method(s, p)
+}
+
+// Handler returns the HTTP handler for the server. Requests must name a
+// loopback Host, which stops DNS-rebinding pages from reading issues.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, name := range MethodNames() {
+		name := name
+		mux.HandleFunc("/"+name, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				writeError(w, &Error{Status: http.StatusMethodNotAllowed, Message: "use GET"})
+				return
+			}
+			params := make(Params)
+			for key, values := range r.URL.Query() {
+				if len(values) > 0 {
+					params[key] = values[len(values)-1]
+				}
+			}
+			start := time.Now()
+			result, err := s.Call(name, params)
+			s.logger.Printf("GET %s (%s)", r.URL.RequestURI(), time.Since(start).Round(time.Millisecond))
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, result)
+		})
+	}
+	mux.HandleFunc("/rpc", s.handleRPC)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			writeError(w, notFound("unknown endpoint %s (try one of: %s)", r.URL.Path, strings.Join(MethodNames(), ", ")))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"endpoints": MethodNames(), "rpc": "/rpc"})
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := checkHost(r); err != nil {
+			writeError(w, err)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// checkHost rejects requests whose Host is not a loopback name on the port
+// the connection was accepted on. Unix socket connections are not reachable
+// from a browser and are let through.
+func checkHost(r *http.Request) error {
+	local, _ := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	if _, ok := local.(*net.UnixAddr); ok {
+		return nil
+	}
+
+	host, port, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host, port = r.Host, "80"
+	}
+	switch strings.ToLower(host) {
+	case "127.0.0.1", "localhost", "::1":
+	default:
+		return forbidden("host %q is not a loopback address", r.Host)
+	}
+	if tcp, ok := local.(*net.TCPAddr); ok && port != strconv.Itoa(tcp.Port) {
+		return forbidden("host %q does not match port %d", r.Host, tcp.Port)
+	}
+	return nil
+}
+
+// checkOrigin rejects browser requests sent from another origin. Requests
+// without an Origin header (curl, agents) are allowed.
+func checkOrigin(r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme != "http" || !strings.EqualFold(u.Host, r.Host) {
+		return forbidden("cross-origin request from %q", origin)
+	}
+	return nil
+}
+
+// JSON-RPC 2.0 error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcServerError    = -32000
+)
+
+type rpcRequest struct {
+	JSONRPC string                     `json:"jsonrpc"`
+	Method  string                     `json:"method"`
+	Params  map[string]json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage            `json:"id,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// handleRPC serves a single JSON-RPC 2.0 request. Params must be an object;
+// values may be strings, numbers or booleans.
+func (s *Server) handleRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, &Error{Status: http.StatusMethodNotAllowed, Message: "use POST"})
+		return
+	}
+	if err := checkOrigin(r); err != nil {
+		writeError(w, err)
+		return
+	}
+	var req rpcRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusOK, rpcResponse{JSONRPC: "2.0", Error: &rpcError{Code: rpcParseError, Message: err.Error()}, ID: json.RawMessage("null")})
+		return
+	}
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	if resp.ID == nil {
+		resp.ID = json.RawMessage("null")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{Code: rpcInvalidRequest, Message: `expected {"jsonrpc":"2.0","method":...}`}
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+	if _, ok := Methods[req.Method]; !ok {
+		resp.Error = &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("unknown method %q", req.Method)}
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+	params, err := ParamsFromJSON(req.Params)
+	if err != nil {
+		resp.Error = &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	start := time.Now()
+	result, err := s.Call(req.Method, params)
+	s.logger.Printf("RPC %s (%s)", req.Method, time.Since(start).Round(time.Millisecond))
+	if err != nil {
+		code := rpcServerError
+		var qerr *Error
+		if errors.As(err, &qerr) && qerr.Status == http.StatusBadRequest {
+			code = rpcInvalidParams
+		}
+		resp.Error = &rpcError{Code: code, Message: err.Error()}
+	} else {
+		resp.Result = result
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ParamsFromJSON flattens a JSON params object into Params.
+func ParamsFromJSON(raw map[string]json.RawMessage) (Params, error) {
+	params := make(Params, len(raw))
+	for key, value := range raw {
+		var v any
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, fmt.Errorf("param %s: %w", key, err)
+		}
+		switch v := v.(type) {
+		case nil:
+		case string:
+			params[key] = v
+		case float64, bool:
+			params[key] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("param %s must be a string, number or boolean", key)
+		}
+	}
+	return params, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var qerr *Error
+	if errors.As(err, &qerr) {
+		status = qerr.Status
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
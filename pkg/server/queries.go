@@ -0,0 +1,674 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+)
+
+// Params are query parameters, taken from the URL query string or from
+// JSON-RPC params.
+type Params map[string]string
+
+// Get returns the value for key, or def if it is unset.
+func (p Params) Get(key, def string) string {
+	if v, ok := p[key]; ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// Int returns key parsed as an integer, or def if it is unset.
+func (p Params) Int(key string, def int) (int, error) {
+	v, ok := p[key]
+	if !ok || v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, badRequest("%s must be an integer, got %q", key, v)
+	}
+	return n, nil
+}
+
+// Float returns key parsed as a float, or def if it is unset.
+func (p Params) Float(key string, def float64) (float64, error) {
+	v, ok := p[key]
+	if !ok || v == "" {
+		return def, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, badRequest("%s must be a number, got %q", key, v)
+	}
+	return f, nil
+}
+
+// Error is a query failure carrying the HTTP status to report.
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+func badRequest(format string, args ...any) *Error {
+	return &Error{Status: http.StatusBadRequest, Message: fmt.Sprintf(format, args...)}
+}
+
+func forbidden(format string, args ...any) *Error {
+	return &Error{Status: http.StatusForbidden, Message: fmt.Sprintf(format, args...)}
+}
+
+func notFound(format string, args ...any) *Error {
+	return &Error{Status: http.StatusNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// Meta is included in every response so clients can tell which data
+// version answered.
+type Meta struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	LoadedAt    string `json:"loaded_at"`
+}
+
+func (s *Server) meta(snap *snapshot) Meta {
+	return Meta{
+		GeneratedAt: s.now().UTC().Format(time.RFC3339),
+		DataHash:    snap.dataHash,
+		LoadedAt:    snap.loadedAt.Format(time.RFC3339),
+	}
+}
+
+// HealthResponse reports server state.
+type HealthResponse struct {
+	Status      string `json:"status"`
+	BeadsPath   string `json:"beads_path"`
+	Issues      int    `json:"issues"`
+	DataHash    string `json:"data_hash"`
+	LoadedAt    string `json:"loaded_at"`
+	Loads       int    `json:"loads"` // Successful loads, including the first
+	Phase2Ready bool   `json:"phase2_ready"`
+	Watching    bool   `json:"watching"`
+	LastError   string `json:"last_error,omitempty"` // Most recent failed reload
+}
+
+// Health reports whether data is loaded and Phase 2 metrics are warm.
+func (s *Server) Health(Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	resp := HealthResponse{
+		Status:      "ok",
+		BeadsPath:   s.beadsPath,
+		Issues:      len(snap.issues),
+		DataHash:    snap.dataHash,
+		LoadedAt:    snap.loadedAt.Format(time.RFC3339),
+		Loads:       s.loads,
+		Phase2Ready: snap.stats.IsPhase2Ready(),
+		Watching:    s.watcher != nil,
+	}
+	if s.loadErr != nil {
+		resp.LastError = s.loadErr.Error()
+	}
+	s.mu.RUnlock()
+	return resp, nil
+}
+
+// TriageResponse mirrors --robot-triage.
+type TriageResponse struct {
+	Meta
+	Triage   analysis.TriageResult  `json:"triage"`
+	Feedback *analysis.FeedbackJSON `json:"feedback,omitempty"`
+}
+
+// Triage returns the unified triage. Params: group=track|label.
+func (s *Server) Triage(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	triage, err := s.triage(snap, p.Get("group", ""))
+	if err != nil {
+		return nil, err
+	}
+	resp := TriageResponse{Meta: s.meta(snap), Triage: triage}
+	if feedback, err := analysis.LoadFeedback(filepath.Dir(s.beadsPath)); err == nil && len(feedback.Events) > 0 {
+		info := feedback.ToJSON()
+		resp.Feedback = &info
+	}
+	return resp, nil
+}
+
+func (s *Server) triage(snap *snapshot, group string) (analysis.TriageResult, error) {
+	opts := analysis.TriageOptions{WaitForPhase2: true}
+	switch group {
+	case "":
+	case "track":
+		opts.GroupByTrack = true
+	case "label":
+		opts.GroupByLabel = true
+	default:
+		return analysis.TriageResult{}, badRequest("group must be track or label, got %q", group)
+	}
+	v, err := snap.memoize("triage|"+group, func() (any, error) {
+		return analysis.ComputeTriageFromAnalyzer(snap.analyzer.Analyzer, snap.phase2(), snap.issues, opts, s.now()), nil
+	})
+	if err != nil {
+		return analysis.TriageResult{}, err
+	}
+	return v.(analysis.TriageResult), nil
+}
+
+// NextResponse mirrors --robot-next.
+type NextResponse struct {
+	Meta
+	ID       string   `json:"id,omitempty"`
+	Title    string   `json:"title,omitempty"`
+	Score    float64  `json:"score,omitempty"`
+	Reasons  []string `json:"reasons,omitempty"`
+	Unblocks int      `json:"unblocks,omitempty"`
+	ClaimCmd string   `json:"claim_command,omitempty"`
+	ShowCmd  string   `json:"show_command,omitempty"`
+	Message  string   `json:"message,omitempty"`
+}
+
+// Next returns only the top triage pick.
+func (s *Server) Next(Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	triage, err := s.triage(snap, "")
+	if err != nil {
+		return nil, err
+	}
+	resp := NextResponse{Meta: s.meta(snap)}
+	if len(triage.QuickRef.TopPicks) == 0 {
+		resp.Message = "No actionable items available"
+		return resp, nil
+	}
+	top := triage.QuickRef.TopPicks[0]
+	resp.ID = top.ID
+	resp.Title = top.Title
+	resp.Score = top.Score
+	resp.Reasons = top.Reasons
+	resp.Unblocks = top.Unblocks
+	resp.ClaimCmd = fmt.Sprintf("bd update %s --status=in_progress", top.ID)
+	resp.ShowCmd = fmt.Sprintf("bd show %s", top.ID)
+	return resp, nil
+}
+
+// PlanResponse mirrors --robot-plan.
+type PlanResponse struct {
+	Meta
+	Status analysis.MetricStatus  `json:"status"`
+	Plan   analysis.ExecutionPlan `json:"plan"`
+}
+
+// Plan returns the dependency-respecting execution plan.
+func (s *Server) Plan(Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	v, err := snap.memoize("plan", func() (any, error) {
+		return snap.analyzer.GetExecutionPlan(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return PlanResponse{Meta: s.meta(snap), Status: snap.phase2().Status(), Plan: v.(analysis.ExecutionPlan)}, nil
+}
+
+// InsightsResponse mirrors the core of --robot-insights.
+type InsightsResponse struct {
+	Meta
+	AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
+	Status         analysis.MetricStatus   `json:"status"`
+	analysis.Insights
+}
+
+// Insights returns graph insights. Params: limit (default 50).
+func (s *Server) Insights(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	limit, err := p.Int("limit", 50)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, badRequest("limit must be positive")
+	}
+	stats := snap.phase2()
+	v, err := snap.memoize("insights|"+strconv.Itoa(limit), func() (any, error) {
+		return stats.GenerateInsights(limit), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return InsightsResponse{
+		Meta:           s.meta(snap),
+		AnalysisConfig: stats.Config,
+		Status:         stats.Status(),
+		Insights:       v.(analysis.Insights),
+	}, nil
+}
+
+// GraphResponse wraps the --robot-graph export.
+type GraphResponse struct {
+	Meta
+	Graph *export.GraphExportResult `json:"graph"`
+}
+
+// Graph exports the dependency graph. Params: format=json|dot|mermaid,
+// root, depth, label.
+func (s *Server) Graph(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	var format export.GraphExportFormat
+	switch strings.ToLower(p.Get("format", "json")) {
+	case "json":
+		format = export.GraphFormatJSON
+	case "dot":
+		format = export.GraphFormatDOT
+	case "mermaid":
+		format = export.GraphFormatMermaid
+	default:
+		return nil, badRequest("format must be json, dot, or mermaid")
+	}
+	depth, err := p.Int("depth", 0)
+	if err != nil {
+		return nil, err
+	}
+	result, err := export.ExportGraph(snap.issues, snap.phase2(), export.GraphExportConfig{
+		Format:   format,
+		Label:    p.Get("label", ""),
+		Root:     p.Get("root", ""),
+		Depth:    depth,
+		DataHash: snap.dataHash,
+	})
+	if err != nil {
+		return nil, badRequest("%v", err)
+	}
+	return GraphResponse{Meta: s.meta(snap), Graph: result}, nil
+}
+
+// ForecastSummary aggregates a multi-issue forecast.
+type ForecastSummary struct {
+	TotalMinutes  int       `json:"total_minutes"`
+	TotalDays     float64   `json:"total_days"`
+	AvgConfidence float64   `json:"avg_confidence"`
+	EarliestETA   time.Time `json:"earliest_eta"`
+	LatestETA     time.Time `json:"latest_eta"`
+}
+
+// ForecastResponse mirrors --robot-forecast.
+type ForecastResponse struct {
+	Meta
+	Agents        int                    `json:"agents"`
+	Filters       map[string]string      `json:"filters,omitempty"`
+	ForecastCount int                    `json:"forecast_count"`
+	Forecasts     []analysis.ETAEstimate `json:"forecasts"`
+	Summary       *ForecastSummary       `json:"summary,omitempty"`
+}
+
+// Forecast estimates ETAs. Params: id (issue ID or "all", default "all"),
+// label, sprint, agents.
+func (s *Server) Forecast(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	agents, err := p.Int("agents", 1)
+	if err != nil {
+		return nil, err
+	}
+	if agents <= 0 {
+		agents = 1
+	}
+	target := p.Get("id", "all")
+	label := p.Get("label", "")
+	sprintID := p.Get("sprint", "")
+
+	var sprintBeads map[string]bool
+	if sprintID != "" {
+		sprints, err := loader.LoadSprints(s.projectDir)
+		if err != nil {
+			return nil, fmt.Errorf("load sprints: %w", err)
+		}
+		for _, sprint := range sprints {
+			if sprint.ID == sprintID {
+				sprintBeads = make(map[string]bool, len(sprint.BeadIDs))
+				for _, id := range sprint.BeadIDs {
+					sprintBeads[id] = true
+				}
+				break
+			}
+		}
+		if sprintBeads == nil {
+			return nil, notFound("sprint not found: %s", sprintID)
+		}
+	}
+
+	stats := snap.phase2()
+	now := s.now()
+	var forecasts []analysis.ETAEstimate
+	if target == "all" {
+		for _, issue := range snap.issues {
+			if issue.Status.IsClosed() {
+				continue
+			}
+			if label != "" && !hasLabel(issue, label) {
+				continue
+			}
+			if sprintBeads != nil && !sprintBeads[issue.ID] {
+				continue
+			}
+			eta, err := analysis.EstimateETAForIssue(snap.issues, stats, issue.ID, agents, now)
+			if err != nil {
+				continue
+			}
+			forecasts = append(forecasts, eta)
+		}
+	} else {
+		eta, err := analysis.EstimateETAForIssue(snap.issues, stats, target, agents, now)
+		if err != nil {
+			return nil, notFound("%v", err)
+		}
+		forecasts = append(forecasts, eta)
+	}
+
+	resp := ForecastResponse{
+		Meta:          s.meta(snap),
+		Agents:        agents,
+		ForecastCount: len(forecasts),
+		Forecasts:     forecasts,
+	}
+	if label != "" || sprintID != "" {
+		resp.Filters = make(map[string]string)
+		if label != "" {
+			resp.Filters["label"] = label
+		}
+		if sprintID != "" {
+			resp.Filters["sprint"] = sprintID
+		}
+	}
+	if len(forecasts) > 1 {
+		summary := &ForecastSummary{EarliestETA: forecasts[0].ETADate, LatestETA: forecasts[0].ETADate}
+		totalConf := 0.0
+		for _, f := range forecasts {
+			summary.TotalMinutes += f.EstimatedMinutes
+			totalConf += f.Confidence
+			if f.ETADate.Before(summary.EarliestETA) {
+				summary.EarliestETA = f.ETADate
+			}
+			if f.ETADate.After(summary.LatestETA) {
+				summary.LatestETA = f.ETADate
+			}
+		}
+		summary.TotalDays = float64(summary.TotalMinutes) / (60.0 * 8.0) // 8hr workday
+		summary.AvgConfidence = totalConf / float64(len(forecasts))
+		resp.Summary = summary
+	}
+	return resp, nil
+}
+
+// HistoryResponse wraps the --robot-history report.
+type HistoryResponse struct {
+	Meta
+	History *correlation.HistoryReport `json:"history"`
+}
+
+// History correlates beads with git commits. Params: bead, limit, since
+// (e.g. "30d" or a date), min_confidence. Git history is read on every
+// call since commits can land without touching the beads file.
+func (s *Server) History(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	if err := correlation.ValidateRepository(s.projectDir); err != nil {
+		return nil, badRequest("%v", err)
+	}
+	limit, err := p.Int("limit", 0)
+	if err != nil {
+		return nil, err
+	}
+	minConfidence, err := p.Float("min_confidence", 0)
+	if err != nil {
+		return nil, err
+	}
+	opts := correlation.CorrelatorOptions{BeadID: p.Get("bead", ""), Limit: limit}
+	if raw := p.Get("since", ""); raw != "" {
+		since, err := recipe.ParseRelativeTime(raw, s.now())
+		if err != nil {
+			return nil, badRequest("invalid since: %v", err)
+		}
+		if !since.IsZero() {
+			opts.Since = &since
+		}
+	}
+
+	beads := make([]correlation.BeadInfo, len(snap.issues))
+	for i, issue := range snap.issues {
+		beads[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+	}
+	report, err := correlation.NewCorrelator(s.projectDir, s.beadsPath).GenerateReport(beads, opts)
+	if err != nil {
+		return nil, fmt.Errorf("generate history report: %w", err)
+	}
+
+	if minConfidence > 0 {
+		report.Histories = correlation.NewScorer().FilterHistoriesByConfidence(report.Histories, minConfidence)
+		report.CommitIndex = make(correlation.CommitIndex)
+		report.Stats.BeadsWithCommits = 0
+		for beadID, history := range report.Histories {
+			for _, commit := range history.Commits {
+				report.CommitIndex[commit.SHA] = append(report.CommitIndex[commit.SHA], beadID)
+			}
+			if len(history.Commits) > 0 {
+				report.Stats.BeadsWithCommits++
+			}
+		}
+	}
+	return HistoryResponse{Meta: s.meta(snap), History: report}, nil
+}
+
+// BlockerChainResponse mirrors --robot-blocker-chain.
+type BlockerChainResponse struct {
+	Meta
+	Result *analysis.BlockerChainResult `json:"result"`
+}
+
+// BlockerChain explains why an issue is blocked. Params: id (required).
+func (s *Server) BlockerChain(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	id := p.Get("id", "")
+	if id == "" {
+		return nil, badRequest("id is required")
+	}
+	result := snap.analyzer.GetBlockerChain(id)
+	if result == nil {
+		return nil, notFound("issue not found: %s", id)
+	}
+	return BlockerChainResponse{Meta: s.meta(snap), Result: result}, nil
+}
+
+// RelatedResponse mirrors --robot-related.
+type RelatedResponse struct {
+	Meta
+	Related *correlation.RelatedWorkResult `json:"related"`
+}
+
+// Related finds beads related to an issue through shared files, commits,
+// dependencies and concurrent activity. Params: id (required),
+// min_relevance (default 20), max_results (default 10), include_closed,
+// limit (commits to scan, default 500).
+func (s *Server) Related(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	id := p.Get("id", "")
+	if id == "" {
+		return nil, badRequest("id is required")
+	}
+	opts := correlation.DefaultRelatedWorkOptions()
+	if opts.MinRelevance, err = p.Int("min_relevance", opts.MinRelevance); err != nil {
+		return nil, err
+	}
+	if opts.MaxResults, err = p.Int("max_results", opts.MaxResults); err != nil {
+		return nil, err
+	}
+	opts.IncludeClosed = p.Get("include_closed", "false") == "true"
+	limit, err := p.Int("limit", 500)
+	if err != nil {
+		return nil, err
+	}
+	if err := correlation.ValidateRepository(s.projectDir); err != nil {
+		return nil, badRequest("%v", err)
+	}
+
+	beads := make([]correlation.BeadInfo, len(snap.issues))
+	opts.DependencyGraph = make(map[string][]string)
+	for i, issue := range snap.issues {
+		beads[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+		for _, dep := range issue.Dependencies {
+			if dep != nil {
+				opts.DependencyGraph[issue.ID] = append(opts.DependencyGraph[issue.ID], dep.DependsOnID)
+			}
+		}
+	}
+	report, err := correlation.NewCorrelator(s.projectDir, s.beadsPath).GenerateReport(beads, correlation.CorrelatorOptions{Limit: limit})
+	if err != nil {
+		return nil, fmt.Errorf("generate history report: %w", err)
+	}
+	result := report.FindRelatedWork(id, opts)
+	if result == nil {
+		return nil, notFound("bead not found in history: %s", id)
+	}
+	return RelatedResponse{Meta: s.meta(snap), Related: result}, nil
+}
+
+// SuggestResponse mirrors --robot-suggest.
+type SuggestResponse struct {
+	Meta
+	Suggest analysis.RobotSuggestOutput `json:"suggest"`
+}
+
+// Suggest returns hygiene suggestions. Params: type=duplicate|dependency|
+// label|cycle, bead, min_confidence.
+func (s *Server) Suggest(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	config := analysis.DefaultSuggestAllConfig()
+	if config.MinConfidence, err = p.Float("min_confidence", 0); err != nil {
+		return nil, err
+	}
+	config.FilterBead = p.Get("bead", "")
+	switch kind := p.Get("type", ""); kind {
+	case "":
+	case "duplicate", "duplicates":
+		config.FilterType = analysis.SuggestionPotentialDuplicate
+	case "dependency", "dependencies":
+		config.FilterType = analysis.SuggestionMissingDependency
+	case "label", "labels":
+		config.FilterType = analysis.SuggestionLabelSuggestion
+	case "cycle", "cycles":
+		config.FilterType = analysis.SuggestionCycleWarning
+	default:
+		return nil, badRequest("type must be duplicate, dependency, label or cycle, got %q", kind)
+	}
+	key := fmt.Sprintf("suggest|%s|%s|%g", config.FilterType, config.FilterBead, config.MinConfidence)
+	v, err := snap.memoize(key, func() (any, error) {
+		return analysis.GenerateRobotSuggestOutput(snap.issues, config, snap.dataHash), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return SuggestResponse{Meta: s.meta(snap), Suggest: v.(analysis.RobotSuggestOutput)}, nil
+}
+
+// IssueResponse is one issue with its triage context.
+type IssueResponse struct {
+	Meta
+	Issue          model.Issue                  `json:"issue"`
+	Recommendation *analysis.Recommendation     `json:"recommendation,omitempty"` // Set when triage recommends the issue
+	BlockerChain   *analysis.BlockerChainResult `json:"blocker_chain,omitempty"`  // Set when the issue is blocked
+	Dependents     []string                     `json:"dependents,omitempty"`     // Issues that depend on this one
+}
+
+// Issue returns a single issue. Params: id (required).
+func (s *Server) Issue(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	id := p.Get("id", "")
+	if id == "" {
+		return nil, badRequest("id is required")
+	}
+	resp := IssueResponse{Meta: s.meta(snap)}
+	found := false
+	for _, issue := range snap.issues {
+		if issue.ID == id {
+			resp.Issue = issue
+			found = true
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID == id {
+				resp.Dependents = append(resp.Dependents, issue.ID)
+			}
+		}
+	}
+	if !found {
+		return nil, notFound("issue not found: %s", id)
+	}
+
+	triage, err := s.triage(snap, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range triage.Recommendations {
+		if triage.Recommendations[i].ID == id {
+			rec := triage.Recommendations[i]
+			resp.Recommendation = &rec
+			break
+		}
+	}
+	if chain := snap.analyzer.GetBlockerChain(id); chain != nil && chain.IsBlocked {
+		resp.BlockerChain = chain
+	}
+	return resp, nil
+}
+
+func hasLabel(issue model.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+)
+
+// searchState keeps the embedder and vector index open between requests.
+// The index is re-synced only when the data hash changes.
+type searchState struct {
+	mu         sync.Mutex
+	cfg        search.EmbeddingConfig
+	embedder   search.Embedder
+	idx        *search.VectorIndex
+	indexPath  string
+	syncedHash string
+	lastSync   search.IndexSyncStats
+}
+
+func (st *searchState) close() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if closer, ok := st.embedder.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// SearchResult is one hit.
+type SearchResult struct {
+	IssueID         string             `json:"issue_id"`
+	Score           float64            `json:"score"`
+	TextScore       float64            `json:"text_score,omitempty"`
+	Title           string             `json:"title,omitempty"`
+	ComponentScores map[string]float64 `json:"component_scores,omitempty"`
+}
+
+// SearchResponse mirrors --robot-search.
+type SearchResponse struct {
+	Meta
+	Query    string                `json:"query"`
+	Provider search.Provider       `json:"provider"`
+	Model    string                `json:"model,omitempty"`
+	Dim      int                   `json:"dim"`
+	Index    search.IndexSyncStats `json:"index"`
+	Limit    int                   `json:"limit"`
+	Mode     search.SearchMode     `json:"mode"`
+	Preset   search.PresetName     `json:"preset,omitempty"`
+	Results  []SearchResult        `json:"results"`
+}
+
+// Search runs semantic search. Params: q (required), limit (default 10),
+// mode=text|hybrid and preset (defaults from BV_SEARCH_* env vars).
+func (s *Server) Search(p Params) (any, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	query := strings.TrimSpace(p.Get("q", ""))
+	if query == "" {
+		return nil, badRequest("q is required")
+	}
+	limit, err := p.Int("limit", 10)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	cfg, err := search.SearchConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if mode := p.Get("mode", ""); mode != "" {
+		switch search.SearchMode(strings.ToLower(mode)) {
+		case search.SearchModeText, search.SearchModeHybrid:
+			cfg.Mode = search.SearchMode(strings.ToLower(mode))
+		default:
+			return nil, badRequest("mode must be text or hybrid, got %q", mode)
+		}
+	}
+	if preset := p.Get("preset", ""); preset != "" {
+		cfg.Preset = search.PresetName(strings.ToLower(preset))
+		cfg.HasWeights = false
+	}
+
+	st := &s.search
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	docs := search.DocumentsFromIssues(snap.issues)
+	if err := s.syncSearchIndex(st, snap.dataHash, docs); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), search.SyncTimeout(st.embedder.Provider()))
+	defer cancel()
+	qvecs, err := st.embedder.Embed(ctx, []string{query})
+	if err != nil || len(qvecs) != 1 {
+		if err == nil {
+			err = fmt.Errorf("embedder returned %d vectors for query", len(qvecs))
+		}
+		return nil, fmt.Errorf("embed query: %w", err)
+	}
+
+	fetch := limit
+	if cfg.Mode == search.SearchModeHybrid {
+		fetch = search.HybridCandidateLimit(limit, len(snap.issues), query)
+	}
+	hits, err := st.idx.SearchTopK(qvecs[0], fetch)
+	if err != nil {
+		return nil, fmt.Errorf("search index: %w", err)
+	}
+	hits = search.ApplyShortQueryLexicalBoost(hits, query, docs)
+
+	titles := make(map[string]string, len(snap.issues))
+	for _, issue := range snap.issues {
+		titles[issue.ID] = issue.Title
+	}
+
+	resp := SearchResponse{
+		Meta:     s.meta(snap),
+		Query:    query,
+		Provider: st.cfg.Provider,
+		Model:    st.cfg.Model,
+		Dim:      st.embedder.Dim(),
+		Index:    st.lastSync,
+		Limit:    limit,
+		Mode:     cfg.Mode,
+		Results:  make([]SearchResult, 0, limit),
+	}
+
+	if cfg.Mode != search.SearchModeHybrid {
+		for _, hit := range hits {
+			resp.Results = append(resp.Results, SearchResult{IssueID: hit.IssueID, Score: hit.Score, Title: titles[hit.IssueID]})
+		}
+		return resp, nil
+	}
+
+	weights := cfg.Weights
+	if !cfg.HasWeights {
+		if weights, err = search.GetPreset(cfg.Preset); err != nil {
+			return nil, badRequest("%v", err)
+		}
+		resp.Preset = cfg.Preset
+	}
+	weights = search.AdjustWeightsForQuery(weights.Normalize(), query)
+
+	metrics, err := snap.memoize("search-metrics", func() (any, error) {
+		cache := search.NewMetricsCache(search.NewAnalyzerMetricsLoader(snap.issues))
+		if err := cache.Refresh(); err != nil {
+			return nil, fmt.Errorf("compute hybrid metrics: %w", err)
+		}
+		return cache, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	scorer := search.NewHybridScorer(weights, metrics.(search.MetricsCache))
+	scored := make([]search.HybridScore, 0, len(hits))
+	for _, hit := range hits {
+		score, err := scorer.Score(hit.IssueID, hit.Score)
+		if err != nil {
+			return nil, fmt.Errorf("score %s: %w", hit.IssueID, err)
+		}
+		scored = append(scored, score)
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].FinalScore == scored[j].FinalScore {
+			return scored[i].IssueID < scored[j].IssueID
+		}
+		return scored[i].FinalScore > scored[j].FinalScore
+	})
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+	for _, r := range scored {
+		resp.Results = append(resp.Results, SearchResult{
+			IssueID:         r.IssueID,
+			Score:           r.FinalScore,
+			TextScore:       r.TextScore,
+			Title:           titles[r.IssueID],
+			ComponentScores: r.ComponentScores,
+		})
+	}
+	return resp, nil
+}
+
+// syncSearchIndex opens the embedder and index on first use and re-embeds
+// changed documents when the data hash moves. Callers hold st.mu.
+func (s *Server) syncSearchIndex(st *searchState, dataHash string, docs map[string]string) error {
+	if st.embedder == nil {
+		st.cfg = search.EmbeddingConfigFromEnv()
+		embedder, err := search.NewEmbedderFromConfig(st.cfg)
+		if err != nil {
+			return err
+		}
+		st.indexPath = search.DefaultIndexPath(s.projectDir, st.cfg)
+		idx, _, err := search.LoadOrNewVectorIndex(st.indexPath, embedder.Dim())
+		if err != nil {
+			if closer, ok := embedder.(io.Closer); ok {
+				closer.Close()
+			}
+			return err
+		}
+		st.embedder = embedder
+		st.idx = idx
+	}
+	if st.syncedHash == dataHash {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), search.SyncTimeout(st.embedder.Provider()))
+	defer cancel()
+	stats, err := search.SyncVectorIndex(ctx, st.idx, st.embedder, docs, 64)
+	if err != nil {
+		return fmt.Errorf("sync search index: %w", err)
+	}
+	if stats.Changed() {
+		if err := st.idx.Save(st.indexPath); err != nil {
+			return fmt.Errorf("save search index: %w", err)
+		}
+	}
+	st.syncedHash = dataHash
+	st.lastSync = stats
+	s.logger.Printf("search index synced: +%d ~%d -%d", stats.Added, stats.Updated, stats.Removed)
+	return nil
+}
@@ -0,0 +1,322 @@
+// Package server implements `bv serve`, a long-running process that answers
+// robot queries (triage, plan, insights, graph, search, forecast, history,
+// blocker chains, related work, suggestions) over HTTP on a Unix socket or a
+// loopback TCP address. pkg/mcp serves the same queries over MCP stdio.
+//
+// The one-shot --robot-* flags reload the JSONL, rebuild the graph and
+// recompute Phase 2 metrics on every call. The server does that once, keeps
+// the result in an analysis.Cache, and only recomputes after pkg/watcher
+// reports that the beads file changed.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+)
+
+// DefaultAddr is the loopback address used when neither an address nor a
+// socket is given.
+const DefaultAddr = "127.0.0.1:7331"
+
+// cacheTTL keeps analysis results for as long as the data is unchanged;
+// the watcher, not the clock, decides when they go stale.
+const cacheTTL = 24 * time.Hour
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithCache sets the analysis cache (defaults to a private cache).
+func WithCache(c *analysis.Cache) Option {
+	return func(s *Server) {
+		if c != nil {
+			s.cache = c
+		}
+	}
+}
+
+// WithLogger sets where request and reload logs are written.
+func WithLogger(w io.Writer) Option {
+	return func(s *Server) {
+		s.logger = log.New(w, "bv serve: ", log.LstdFlags)
+	}
+}
+
+// WithDebounce sets the watcher debounce duration.
+func WithDebounce(d time.Duration) Option {
+	return func(s *Server) {
+		s.debounce = d
+	}
+}
+
+// WithProjectDir sets the project root used for git history, sprints and the
+// search index (defaults to the parent of the beads directory).
+func WithProjectDir(dir string) Option {
+	return func(s *Server) {
+		if dir != "" {
+			s.projectDir = dir
+		}
+	}
+}
+
+// Server holds the warm issue snapshot and serves queries against it.
+type Server struct {
+	beadsPath  string
+	projectDir string
+	cache      *analysis.Cache
+	logger     *log.Logger
+	debounce   time.Duration
+	now        func() time.Time
+
+	mu      sync.RWMutex
+	snap    *snapshot
+	loadErr error
+	loads   int
+
+	watcher *watcher.Watcher
+	search  searchState
+}
+
+// snapshot is one loaded version of the beads file and everything derived
+// from it. It is replaced wholesale on reload, never mutated, so queries
+// holding an old snapshot keep a consistent view.
+type snapshot struct {
+	issues   []model.Issue
+	dataHash string
+	loadedAt time.Time
+	analyzer *analysis.CachedAnalyzer
+	stats    *analysis.GraphStats
+
+	// memo caches query results that depend only on the snapshot.
+	memoMu sync.Mutex
+	memo   map[string]any
+}
+
+// New creates a Server for the beads file at beadsPath. Call Load before
+// serving and Watch to follow changes.
+func New(beadsPath string, opts ...Option) *Server {
+	s := &Server{
+		beadsPath:  beadsPath,
+		projectDir: filepath.Dir(filepath.Dir(beadsPath)),
+		cache:      analysis.NewCache(cacheTTL),
+		logger:     log.New(io.Discard, "", 0),
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Load reads the beads file and starts analysis. Phase 2 metrics are
+// computed in the background and stored in the cache when ready.
+func (s *Server) Load() error {
+	start := time.Now()
+	issues, err := loader.LoadIssuesFromFile(s.beadsPath)
+	if err != nil {
+		s.mu.Lock()
+		s.loadErr = err
+		s.mu.Unlock()
+		return fmt.Errorf("load %s: %w", s.beadsPath, err)
+	}
+
+	analyzer := analysis.NewCachedAnalyzer(issues, s.cache)
+	cfg := analysis.ConfigForSize(len(issues), countEdges(issues))
+	analyzer.SetConfig(&cfg)
+	snap := &snapshot{
+		issues:   issues,
+		dataHash: analyzer.DataHash(),
+		loadedAt: s.now().UTC(),
+		analyzer: analyzer,
+		stats:    analyzer.AnalyzeAsync(context.Background()),
+		memo:     make(map[string]any),
+	}
+
+	s.mu.Lock()
+	s.snap = snap
+	s.loadErr = nil
+	s.loads++
+	s.mu.Unlock()
+
+	s.logger.Printf("loaded %d issues (hash %s, cache hit %v) in %s",
+		len(issues), snap.dataHash, analyzer.WasCacheHit(), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// Watch starts following the beads file. Each change invalidates the cache
+// and reloads; a failed reload keeps serving the previous snapshot.
+func (s *Server) Watch() error {
+	var opts []watcher.WatcherOption
+	if s.debounce > 0 {
+		opts = append(opts, watcher.WithDebounceDuration(s.debounce))
+	}
+	opts = append(opts,
+		watcher.WithOnChange(func() {
+			s.cache.Invalidate()
+			if err := s.Load(); err != nil {
+				s.logger.Printf("reload failed, serving previous data: %v", err)
+			}
+		}),
+		watcher.WithOnError(func(err error) {
+			s.logger.Printf("watcher: %v", err)
+		}),
+	)
+
+	w, err := watcher.NewWatcher(s.beadsPath, opts...)
+	if err != nil {
+		return fmt.Errorf("watch %s: %w", s.beadsPath, err)
+	}
+	if err := w.Start(); err != nil {
+		return fmt.Errorf("watch %s: %w", s.beadsPath, err)
+	}
+	s.watcher = w
+	return nil
+}
+
+// Close stops the watcher and releases the search embedder.
+func (s *Server) Close() error {
+	if s.watcher != nil {
+		s.watcher.Stop()
+	}
+	return s.search.close()
+}
+
+// Serve answers requests on ln until ctx is cancelled, then shuts down
+// gracefully.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// current returns the active snapshot.
+func (s *Server) current() (*snapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.snap == nil {
+		if s.loadErr != nil {
+			return nil, s.loadErr
+		}
+		return nil, errors.New("issues not loaded yet")
+	}
+	return s.snap, nil
+}
+
+// Issues returns the issues in the active snapshot. Callers must not modify
+// them.
+func (s *Server) Issues() ([]model.Issue, error) {
+	snap, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+	return snap.issues, nil
+}
+
+// phase2 returns the snapshot's stats once Phase 2 metrics are ready.
+func (snap *snapshot) phase2() *analysis.GraphStats {
+	snap.stats.WaitForPhase2()
+	return snap.stats
+}
+
+// memoize returns the cached result for key, computing it with fn on first
+// use. Errors are not cached.
+func (snap *snapshot) memoize(key string, fn func() (any, error)) (any, error) {
+	snap.memoMu.Lock()
+	defer snap.memoMu.Unlock()
+	if v, ok := snap.memo[key]; ok {
+		return v, nil
+	}
+	v, err := fn()
+	if err != nil {
+		return nil, err
+	}
+	snap.memo[key] = v
+	return v, nil
+}
+
+// Listen opens the listener for `bv serve`. A non-empty socket path takes
+// precedence and is created with owner-only permissions (a stale socket
+// file is replaced). TCP addresses must be loopback: the server has no
+// authentication.
+func Listen(addr, socket string) (net.Listener, error) {
+	if socket != "" {
+		if info, err := os.Lstat(socket); err == nil {
+			if info.Mode()&os.ModeSocket == 0 {
+				return nil, fmt.Errorf("%s exists and is not a socket", socket)
+			}
+			if err := os.Remove(socket); err != nil {
+				return nil, fmt.Errorf("remove stale socket: %w", err)
+			}
+		}
+		ln, err := net.Listen("unix", socket)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Chmod(socket, 0o600); err != nil {
+			ln.Close()
+			return nil, fmt.Errorf("chmod socket: %w", err)
+		}
+		return ln, nil
+	}
+
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if !isLoopbackHost(host) {
+		return nil, fmt.Errorf("refusing to listen on non-loopback address %q (use a Unix socket or 127.0.0.1)", addr)
+	}
+	return net.Listen("tcp", addr)
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func countEdges(issues []model.Issue) int {
+	edges := 0
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				edges++
+			}
+		}
+	}
+	return edges
+}
@@ -0,0 +1,314 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testIssues = `{"id":"bv-1","title":"Set up database","status":"open","priority":1,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bv-2","title":"Build login API","status":"open","priority":2,"issue_type":"feature","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+{"id":"bv-3","title":"Write docs","status":"closed","priority":3,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-02T00:00:00Z","closed_at":"2025-01-02T00:00:00Z"}
+`
+
+func newTestServer(t *testing.T, opts ...Option) (*Server, string) {
+	t.Helper()
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte(testIssues), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := New(path, opts...)
+	if err := s.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s, path
+}
+
+// newRequest builds a request addressed to the loopback host the handler
+// accepts.
+func newRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Host = "127.0.0.1:7331"
+	return req
+}
+
+func getJSON(t *testing.T, h http.Handler, target string, wantStatus int) map[string]any {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, newRequest(http.MethodGet, target, nil))
+	if rec.Code != wantStatus {
+		t.Fatalf("GET %s: status %d, want %d: %s", target, rec.Code, wantStatus, rec.Body.String())
+	}
+	var out map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("GET %s: invalid JSON: %v", target, err)
+	}
+	return out
+}
+
+func TestServer_Endpoints(t *testing.T) {
+	s, _ := newTestServer(t)
+	h := s.Handler()
+
+	health := getJSON(t, h, "/health", http.StatusOK)
+	if health["issues"].(float64) != 3 || health["data_hash"] == "" {
+		t.Fatalf("health = %v", health)
+	}
+
+	triage := getJSON(t, h, "/triage", http.StatusOK)
+	if triage["data_hash"] != health["data_hash"] {
+		t.Errorf("triage data_hash = %v, want %v", triage["data_hash"], health["data_hash"])
+	}
+	if _, ok := triage["triage"].(map[string]any)["quick_ref"]; !ok {
+		t.Errorf("triage missing quick_ref: %v", triage)
+	}
+
+	next := getJSON(t, h, "/next", http.StatusOK)
+	if next["id"] != "bv-1" {
+		t.Errorf("next id = %v, want bv-1 (it unblocks bv-2)", next["id"])
+	}
+
+	plan := getJSON(t, h, "/plan", http.StatusOK)
+	if _, ok := plan["plan"]; !ok {
+		t.Errorf("plan missing: %v", plan)
+	}
+
+	getJSON(t, h, "/insights?limit=5", http.StatusOK)
+
+	graph := getJSON(t, h, "/graph?format=dot", http.StatusOK)
+	if g := graph["graph"].(map[string]any); !strings.Contains(g["graph"].(string), "digraph") {
+		t.Errorf("dot graph = %v", g["graph"])
+	}
+
+	forecast := getJSON(t, h, "/forecast?id=bv-2", http.StatusOK)
+	if forecast["forecast_count"].(float64) != 1 {
+		t.Errorf("forecast = %v", forecast)
+	}
+
+	chain := getJSON(t, h, "/blocker-chain?id=bv-2", http.StatusOK)
+	if chain["result"].(map[string]any)["is_blocked"] != true {
+		t.Errorf("blocker chain = %v", chain)
+	}
+
+	issue := getJSON(t, h, "/issue?id=bv-1", http.StatusOK)
+	if deps := issue["dependents"].([]any); len(deps) != 1 || deps[0] != "bv-2" {
+		t.Errorf("issue dependents = %v", issue["dependents"])
+	}
+
+	getJSON(t, h, "/suggest?type=cycle", http.StatusOK)
+
+	getJSON(t, h, "/blocker-chain", http.StatusBadRequest)
+	getJSON(t, h, "/issue?id=nope", http.StatusNotFound)
+	getJSON(t, h, "/suggest?type=bogus", http.StatusBadRequest)
+	getJSON(t, h, "/graph?format=png", http.StatusBadRequest)
+	getJSON(t, h, "/insights?limit=x", http.StatusBadRequest)
+	getJSON(t, h, "/forecast?id=nope", http.StatusNotFound)
+	getJSON(t, h, "/nope", http.StatusNotFound)
+}
+
+func TestServer_TriageIsMemoized(t *testing.T) {
+	s, _ := newTestServer(t)
+	snap, _ := s.current()
+	if _, err := s.Triage(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snap.memo["triage|"]; !ok {
+		t.Fatal("triage result should be cached on the snapshot")
+	}
+}
+
+func TestServer_Search(t *testing.T) {
+	t.Setenv("BV_SEMANTIC_EMBEDDER", "hash")
+	s, _ := newTestServer(t)
+	out := getJSON(t, s.Handler(), "/search?q=login&limit=2", http.StatusOK)
+	results := out["results"].([]any)
+	if len(results) == 0 {
+		t.Fatal("expected search results")
+	}
+	if id := results[0].(map[string]any)["issue_id"]; id != "bv-2" {
+		t.Errorf("top result = %v, want bv-2", id)
+	}
+	getJSON(t, s.Handler(), "/search", http.StatusBadRequest)
+}
+
+func TestServer_RPC(t *testing.T) {
+	s, _ := newTestServer(t)
+	h := s.Handler()
+
+	call := func(body string) map[string]any {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newRequest(http.MethodPost, "/rpc", bytes.NewBufferString(body)))
+		var out map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+			t.Fatalf("invalid JSON: %v: %s", err, rec.Body.String())
+		}
+		return out
+	}
+
+	out := call(`{"jsonrpc":"2.0","id":7,"method":"insights","params":{"limit":3}}`)
+	if out["error"] != nil || out["id"].(float64) != 7 || out["result"] == nil {
+		t.Fatalf("insights rpc = %v", out)
+	}
+
+	out = call(`{"jsonrpc":"2.0","id":1,"method":"bogus"}`)
+	if code := out["error"].(map[string]any)["code"].(float64); code != rpcMethodNotFound {
+		t.Errorf("unknown method code = %v", code)
+	}
+
+	out = call(`{"jsonrpc":"2.0","id":2,"method":"graph","params":{"format":"png"}}`)
+	if code := out["error"].(map[string]any)["code"].(float64); code != rpcInvalidParams {
+		t.Errorf("bad params code = %v", code)
+	}
+
+	out = call(`not json`)
+	if code := out["error"].(map[string]any)["code"].(float64); code != rpcParseError {
+		t.Errorf("parse error code = %v", code)
+	}
+}
+
+func TestServer_RejectsRebinding(t *testing.T) {
+	s, _ := newTestServer(t)
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+	port := ts.Listener.Addr().(*net.TCPAddr).Port
+
+	do := func(method, path, host, origin, body string) int {
+		t.Helper()
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = host
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	local := fmt.Sprintf("localhost:%d", port)
+	rpc := `{"jsonrpc":"2.0","id":1,"method":"health"}`
+	tests := []struct {
+		name, method, path, host, origin string
+		want                             int
+	}{
+		{"loopback host", http.MethodGet, "/health", fmt.Sprintf("127.0.0.1:%d", port), "", http.StatusOK},
+		{"localhost", http.MethodGet, "/health", local, "", http.StatusOK},
+		{"ipv6 loopback", http.MethodGet, "/health", fmt.Sprintf("[::1]:%d", port), "", http.StatusOK},
+		{"rebound name", http.MethodGet, "/health", fmt.Sprintf("evil.example:%d", port), "", http.StatusForbidden},
+		{"wrong port", http.MethodGet, "/health", "localhost:1", "", http.StatusForbidden},
+		{"missing port", http.MethodGet, "/triage", "localhost", "", http.StatusForbidden},
+		{"rpc same origin", http.MethodPost, "/rpc", local, "http://" + local, http.StatusOK},
+		{"rpc no origin", http.MethodPost, "/rpc", local, "", http.StatusOK},
+		{"rpc cross origin", http.MethodPost, "/rpc", local, "http://evil.example", http.StatusForbidden},
+		{"rpc null origin", http.MethodPost, "/rpc", local, "null", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		if got := do(tt.method, tt.path, tt.host, tt.origin, rpc); got != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestServer_WatchReloads(t *testing.T) {
+	s, path := newTestServer(t, WithDebounce(20*time.Millisecond))
+	if err := s.Watch(); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	before, _ := s.current()
+
+	extra := `{"id":"bv-4","title":"New work","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-03T00:00:00Z","updated_at":"2025-01-03T00:00:00Z"}` + "\n"
+	if err := os.WriteFile(path, []byte(testIssues+extra), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if snap, _ := s.current(); snap != before {
+			if len(snap.issues) != 4 {
+				t.Fatalf("reloaded %d issues, want 4", len(snap.issues))
+			}
+			if snap.dataHash == before.dataHash {
+				t.Fatal("data hash should change after reload")
+			}
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("server did not reload after the beads file changed")
+}
+
+func TestServer_ReloadFailureKeepsSnapshot(t *testing.T) {
+	s, path := newTestServer(t)
+	before, _ := s.current()
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Load(); err == nil {
+		t.Fatal("expected load error for a missing file")
+	}
+	after, err := s.current()
+	if err != nil || after != before {
+		t.Fatalf("previous snapshot should stay active (err=%v)", err)
+	}
+	health, _ := s.Health(nil)
+	if health.(HealthResponse).LastError == "" {
+		t.Error("health should report the failed reload")
+	}
+}
+
+func TestListen(t *testing.T) {
+	if _, err := Listen("0.0.0.0:0", ""); err == nil {
+		t.Error("non-loopback address should be refused")
+	}
+	ln, err := Listen("127.0.0.1:0", "")
+	if err != nil {
+		t.Fatalf("loopback listen: %v", err)
+	}
+	ln.Close()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets not tested on windows")
+	}
+	sock := filepath.Join(t.TempDir(), "bv.sock")
+	ln, err = Listen("", sock)
+	if err != nil {
+		t.Fatalf("socket listen: %v", err)
+	}
+	info, err := os.Stat(sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket permissions = %o, want 600", perm)
+	}
+	ln.Close()
+
+	// A stale socket file is replaced
+	ln, err = Listen("", sock)
+	if err != nil {
+		t.Fatalf("relisten on stale socket: %v", err)
+	}
+	ln.Close()
+}
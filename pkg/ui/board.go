@@ -15,10 +15,10 @@ import (
 
 // BoardModel represents the Kanban board view with adaptive columns
 type BoardModel struct {
-	columns      [4][]model.Issue
-	activeColIdx []int  // Indices of non-empty columns (for navigation)
-	focusedCol   int    // Index into activeColIdx
-	selectedRow  [4]int // Store selection for each column
+	columns      [][]model.Issue
+	activeColIdx []int // Indices of non-empty columns (for navigation)
+	focusedCol   int   // Index into activeColIdx
+	selectedRow  []int // Store selection for each column
 	theme        Theme
 
 	// Swimlane grouping mode (bv-wjs0)
@@ -60,7 +60,7 @@ type BoardModel struct {
 
 // searchMatch holds info about a matching card (bv-yg39)
 type searchMatch struct {
-	col int // Column index (0-3)
+	col int // Column index
 	row int // Row index within column
 }
 
@@ -76,7 +76,7 @@ const (
 type SwimLaneMode int
 
 const (
-	SwimByStatus   SwimLaneMode = iota // Default: Open | In Progress | Blocked | Closed
+	SwimByStatus   SwimLaneMode = iota // Default: one column per workflow status
 	SwimByPriority                     // P0 Critical | P1 High | P2 Medium | P3+ Other
 	SwimByType                         // Bug | Feature | Task | Epic
 )
@@ -158,21 +158,23 @@ func sortIssuesByPriorityAndDate(issues []model.Issue) {
 
 // updateActiveColumns rebuilds the list of non-empty column indices (bv-tf6j)
 // Behavior depends on swimlane mode unless explicitly overridden:
-// - Status mode: shows all 4 columns (even empty) for workflow visibility
+// - Status mode: shows all columns (even empty) for workflow visibility
 // - Priority/Type modes: hides empty columns to save space
 func (b *BoardModel) updateActiveColumns() {
 	// Determine whether to show empty columns
 	showEmpty := b.shouldShowEmptyColumns()
 
 	b.activeColIdx = nil
-	for i := 0; i < 4; i++ {
+	for i := range b.columns {
 		if len(b.columns[i]) > 0 || showEmpty {
 			b.activeColIdx = append(b.activeColIdx, i)
 		}
 	}
 	// If all columns are empty (and we're hiding empty), include all columns anyway
 	if len(b.activeColIdx) == 0 {
-		b.activeColIdx = []int{ColOpen, ColInProgress, ColBlocked, ColClosed}
+		for i := range b.columns {
+			b.activeColIdx = append(b.activeColIdx, i)
+		}
 	}
 	// Ensure focused column is within valid range
 	if b.focusedCol >= len(b.activeColIdx) {
@@ -222,7 +224,7 @@ func (b *BoardModel) GetEmptyColumnVisibilityMode() string {
 // HiddenColumnCount returns the number of empty columns currently hidden (bv-tf6j)
 func (b *BoardModel) HiddenColumnCount() int {
 	hidden := 0
-	for i := 0; i < 4; i++ {
+	for i := range b.columns {
 		if len(b.columns[i]) == 0 {
 			// Check if this column is in activeColIdx
 			found := false
@@ -255,26 +257,58 @@ func buildBlocksIndex(issues []model.Issue) map[string][]string {
 	return index
 }
 
-// groupIssuesByMode distributes issues into 4 columns based on swimlane mode (bv-wjs0)
-func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [4][]model.Issue {
-	var cols [4][]model.Issue
+// boardCategory is the category whose columns take a status the workflow does
+// not declare. Blocked work has been started and tombstones are finished.
+func boardCategory(s model.Status) model.StatusCategory {
+	switch s.Base() {
+	case model.StatusBlocked:
+		return model.CategoryActive
+	case model.StatusTombstone:
+		return model.CategoryDone
+	}
+	return s.Category()
+}
+
+// groupIssuesByMode distributes issues into columns based on swimlane mode (bv-wjs0).
+// Status mode has one column per workflow status, type mode adds a column per
+// custom issue type, and priority mode has 4.
+func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [][]model.Issue {
+	statuses := model.CurrentWorkflow().Statuses
+	customTypes := model.CurrentTypeRegistry().CustomTypes()
+	numCols := 4
+	statusCol := make(map[model.Status]int, len(statuses))
+	categoryCol := make(map[model.StatusCategory]int, 3)
+	typeCol := make(map[model.IssueType]int, len(customTypes))
+	switch mode {
+	case SwimByStatus:
+		numCols = len(statuses)
+		for i := len(statuses) - 1; i >= 0; i-- {
+			def := statuses[i]
+			statusCol[def.Name] = i
+			if def.Category != "" {
+				categoryCol[def.Category] = i
+			}
+		}
+	case SwimByType:
+		for i, def := range customTypes {
+			typeCol[def.Name] = 4 + i
+		}
+		numCols += len(customTypes)
+	}
+	cols := make([][]model.Issue, numCols)
 
 	for _, issue := range issues {
 		var colIdx int
 		switch mode {
 		case SwimByStatus:
-			// Default: Open | In Progress | Blocked | Closed
-			switch issue.Status {
-			case model.StatusOpen:
-				colIdx = 0
-			case model.StatusInProgress:
-				colIdx = 1
-			case model.StatusBlocked:
-				colIdx = 2
-			case model.StatusClosed:
-				colIdx = 3
-			default:
-				colIdx = 0
+			// Exact status column, else the column of the status it behaves
+			// as, else the first column of its category, else the first column
+			if idx, ok := statusCol[issue.Status]; ok {
+				colIdx = idx
+			} else if idx, ok := statusCol[issue.Status.Base()]; ok {
+				colIdx = idx
+			} else if idx, ok := categoryCol[boardCategory(issue.Status)]; ok {
+				colIdx = idx
 			}
 		case SwimByPriority:
 			// P0 Critical | P1 High | P2 Medium | P3+ Other
@@ -289,7 +323,11 @@ func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [4][]model.Issue
 				colIdx = 3 // P3+ Other
 			}
 		case SwimByType:
-			// Bug | Feature | Task | Epic
+			// Bug | Feature | Task | Epic | custom types...
+			if idx, ok := typeCol[issue.IssueType]; ok {
+				colIdx = idx
+				break
+			}
 			switch issue.IssueType {
 			case model.TypeBug:
 				colIdx = 0
@@ -307,7 +345,7 @@ func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [4][]model.Issue
 	}
 
 	// Sort each column
-	for i := 0; i < 4; i++ {
+	for i := range cols {
 		sortIssuesByPriorityAndDate(cols[i])
 	}
 
@@ -344,7 +382,21 @@ func (b *BoardModel) regroupIssues() {
 	b.columns = groupIssuesByMode(b.allIssues, b.swimLaneMode)
 
 	// Reset selection to avoid out-of-bounds
-	for i := 0; i < 4; i++ {
+	b.clampSelection()
+
+	b.updateActiveColumns()
+	b.CancelSearch() // Clear stale search matches
+	b.lastDetailID = "" // Force detail panel refresh
+}
+
+// clampSelection keeps one selected row per column, each within its column
+func (b *BoardModel) clampSelection() {
+	if len(b.selectedRow) != len(b.columns) {
+		rows := make([]int, len(b.columns))
+		copy(rows, b.selectedRow)
+		b.selectedRow = rows
+	}
+	for i := range b.columns {
 		if b.selectedRow[i] >= len(b.columns[i]) {
 			if len(b.columns[i]) > 0 {
 				b.selectedRow[i] = len(b.columns[i]) - 1
@@ -353,10 +405,12 @@ func (b *BoardModel) regroupIssues() {
 			}
 		}
 	}
+}
 
-	b.updateActiveColumns()
-	b.CancelSearch() // Clear stale search matches
-	b.lastDetailID = "" // Force detail panel refresh
+// isActiveStatusColumn reports whether a status-mode column holds work underway
+func (b *BoardModel) isActiveStatusColumn(colIdx int) bool {
+	statuses := model.CurrentWorkflow().Statuses
+	return colIdx < len(statuses) && statuses[colIdx].Name.IsActive()
 }
 
 // getColumnHeaders returns the column header titles based on swimlane mode (bv-wjs0)
@@ -366,11 +420,31 @@ func (b *BoardModel) getColumnHeaders() ([]string, []string) {
 		return []string{"P0 CRITICAL", "P1 HIGH", "P2 MEDIUM", "P3+ OTHER"},
 			[]string{"🔥", "⚡", "🔹", "💤"}
 	case SwimByType:
-		return []string{"BUG", "FEATURE", "TASK", "EPIC"},
-			[]string{"🐛", "✨", "📋", "🎯"}
+		titles := []string{"BUG", "FEATURE", "TASK", "EPIC"}
+		emoji := []string{model.TypeBug.Icon(), model.TypeFeature.Icon(), model.TypeTask.Icon(), model.TypeEpic.Icon()}
+		for _, def := range model.CurrentTypeRegistry().CustomTypes() {
+			titles = append(titles, strings.ToUpper(string(def.Name)))
+			emoji = append(emoji, def.Icon)
+		}
+		return titles, emoji
 	default: // SwimByStatus
-		return []string{"OPEN", "IN PROGRESS", "BLOCKED", "CLOSED"},
-			[]string{"📋", "🔄", "🚫", "✅"}
+		statuses := model.CurrentWorkflow().Statuses
+		titles := make([]string, len(statuses))
+		emoji := make([]string, len(statuses))
+		for i, def := range statuses {
+			titles[i] = def.ColumnTitle()
+			switch def.Name.Base() {
+			case model.StatusInProgress:
+				emoji[i] = "🔄"
+			case model.StatusBlocked:
+				emoji[i] = "🚫"
+			case model.StatusClosed:
+				emoji[i] = "✅"
+			default:
+				emoji[i] = "📋"
+			}
+		}
+		return titles, emoji
 	}
 }
 
@@ -394,6 +468,7 @@ func NewBoardModel(issues []model.Issue, theme Theme) BoardModel {
 
 	b := BoardModel{
 		columns:      cols,
+		selectedRow:  make([]int, len(cols)),
 		focusedCol:   0,
 		theme:        theme,
 		swimLaneMode: SwimByStatus, // Default mode (bv-wjs0)
@@ -430,15 +505,7 @@ func (b *BoardModel) SetIssues(issues []model.Issue) {
 	b.lastDetailID = ""
 
 	// Sanitize selection to prevent out-of-bounds
-	for i := 0; i < 4; i++ {
-		if b.selectedRow[i] >= len(b.columns[i]) {
-			if len(b.columns[i]) > 0 {
-				b.selectedRow[i] = len(b.columns[i]) - 1
-			} else {
-				b.selectedRow[i] = 0
-			}
-		}
-	}
+	b.clampSelection()
 
 	b.updateActiveColumns()
 }
@@ -527,7 +594,7 @@ func (b *BoardModel) PageUp(visibleRows int) {
 
 // JumpToColumn jumps directly to a specific column (1-4 maps to 0-3)
 func (b *BoardModel) JumpToColumn(colIdx int) {
-	if colIdx < 0 || colIdx > 3 {
+	if colIdx < 0 || colIdx >= len(b.columns) {
 		return
 	}
 	for i, activeCol := range b.activeColIdx {
@@ -757,7 +824,7 @@ func (b *BoardModel) SelectedIssue() *model.Issue {
 
 // ColumnCount returns the number of issues in a column
 func (b *BoardModel) ColumnCount(col int) int {
-	if col >= 0 && col < 4 {
+	if col >= 0 && col < len(b.columns) {
 		return len(b.columns[col])
 	}
 	return 0
@@ -766,7 +833,7 @@ func (b *BoardModel) ColumnCount(col int) int {
 // TotalCount returns the total number of issues across all columns
 func (b *BoardModel) TotalCount() int {
 	total := 0
-	for i := 0; i < 4; i++ {
+	for i := range b.columns {
 		total += len(b.columns[i])
 	}
 	return total
@@ -884,8 +951,14 @@ func (b BoardModel) View(width, height int) string {
 			{Light: "#1565c0", Dark: "#64b5f6"}, // Task - blue
 			{Light: "#7b1fa2", Dark: "#ce93d8"}, // Epic - purple
 		}
+		for _, def := range model.CurrentTypeRegistry().CustomTypes() {
+			_, color := t.GetTypeIcon(string(def.Name))
+			columnColors = append(columnColors, color)
+		}
 	default: // SwimByStatus
-		columnColors = []lipgloss.AdaptiveColor{t.Open, t.InProgress, t.Blocked, t.Closed}
+		for _, def := range model.CurrentWorkflow().Statuses {
+			columnColors = append(columnColors, t.GetStatusColor(string(def.Name)))
+		}
 	}
 
 	var renderedCols []string
@@ -931,8 +1004,8 @@ func (b BoardModel) View(width, height int) string {
 			if stats.P1Count > 0 {
 				indicators = append(indicators, fmt.Sprintf("%d🟡", stats.P1Count))
 			}
-			// Show blocked count in In Progress column (colIdx == ColInProgress when in status mode)
-			if b.swimLaneMode == SwimByStatus && colIdx == ColInProgress && stats.BlockedCount > 0 {
+			// Show blocked count in active columns (In Progress and custom active statuses)
+			if b.swimLaneMode == SwimByStatus && b.isActiveStatusColumn(colIdx) && stats.BlockedCount > 0 {
 				indicators = append(indicators, fmt.Sprintf("⚠️%d", stats.BlockedCount))
 			}
 			// Show oldest age with color indicator
@@ -1167,7 +1240,7 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 		borderColor = lipgloss.AdaptiveColor{Light: "#c62828", Dark: "#ef5350"} // Red - blocked
 	} else if blocksOthers {
 		borderColor = lipgloss.AdaptiveColor{Light: "#f57c00", Dark: "#ffb74d"} // Yellow/orange - high impact
-	} else if issue.Status == model.StatusOpen {
+	} else if issue.Status.Base() == model.StatusOpen {
 		borderColor = lipgloss.AdaptiveColor{Light: "#2e7d32", Dark: "#81c784"} // Green - ready
 	} else {
 		borderColor = t.Border // Default border
@@ -1328,7 +1401,7 @@ func (b BoardModel) renderExpandedCard(issue model.Issue, width int, _, _ int) s
 		borderColor = lipgloss.AdaptiveColor{Light: "#c62828", Dark: "#ef5350"} // Red - blocked
 	} else if blocksOthers {
 		borderColor = lipgloss.AdaptiveColor{Light: "#f57c00", Dark: "#ffb74d"} // Yellow - high impact
-	} else if issue.Status == model.StatusOpen {
+	} else if issue.Status.Base() == model.StatusOpen {
 		borderColor = lipgloss.AdaptiveColor{Light: "#2e7d32", Dark: "#81c784"} // Green - ready
 	} else {
 		borderColor = t.Primary // Selected uses primary
@@ -249,6 +249,78 @@ func TestColumnCounts(t *testing.T) {
 	}
 }
 
+// TestCustomWorkflowColumns verifies the board has one column per workflow status, in order
+func TestCustomWorkflowColumns(t *testing.T) {
+	workflow := &model.Workflow{Statuses: []model.StatusDefinition{
+		{Name: model.StatusOpen},
+		{Name: model.StatusInProgress},
+		{Name: "review", Category: model.CategoryActive, Label: "In Review"},
+		{Name: model.StatusClosed},
+		{Name: "wontfix", Category: model.CategoryDone},
+	}}
+	if err := workflow.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	model.SetWorkflow(workflow)
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	issues := []model.Issue{
+		{ID: "1", Status: model.StatusOpen},
+		{ID: "2", Status: "review"},
+		{ID: "3", Status: "review"},
+		{ID: "4", Status: "wontfix"},
+		{ID: "5", Status: model.StatusBlocked}, // Not declared: first active column
+	}
+	b := ui.NewBoardModel(issues, createTheme())
+
+	want := []int{1, 1, 2, 0, 1}
+	for col, n := range want {
+		if got := b.ColumnCount(col); got != n {
+			t.Errorf("column %d: expected %d issues, got %d", col, n, got)
+		}
+	}
+	if b.ColumnCount(len(want)) != 0 {
+		t.Error("expected no column past the workflow statuses")
+	}
+
+	view := b.View(200, 30)
+	if !strings.Contains(view, "IN REVIEW") || !strings.Contains(view, "WONTFIX") {
+		t.Error("expected custom status column headers in the view")
+	}
+}
+
+// TestWorkflowWithoutBuiltinStatuses verifies that built-in statuses the
+// workflow leaves out go to the first column of their category
+func TestWorkflowWithoutBuiltinStatuses(t *testing.T) {
+	workflow := &model.Workflow{Statuses: []model.StatusDefinition{
+		{Name: model.StatusOpen},
+		{Name: "review", Category: model.CategoryActive},
+		{Name: "done", Category: model.CategoryDone},
+	}}
+	if err := workflow.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	model.SetWorkflow(workflow)
+	t.Cleanup(func() { model.SetWorkflow(nil) })
+
+	issues := []model.Issue{
+		{ID: "1", Status: model.StatusOpen},
+		{ID: "2", Status: model.StatusInProgress},
+		{ID: "3", Status: model.StatusBlocked},
+		{ID: "4", Status: model.StatusClosed},
+		{ID: "5", Status: "done"},
+		{ID: "6", Status: "unknown"}, // No category: first column
+	}
+	b := ui.NewBoardModel(issues, createTheme())
+
+	want := []int{2, 2, 2}
+	for col, n := range want {
+		if got := b.ColumnCount(col); got != n {
+			t.Errorf("column %d: expected %d issues, got %d", col, n, got)
+		}
+	}
+}
+
 // TestSetIssuesSanitizesSelection verifies selection is sanitized after SetIssues
 func TestSetIssuesSanitizesSelection(t *testing.T) {
 	theme := createTheme()
@@ -594,6 +666,44 @@ func TestSwimLaneModeByType(t *testing.T) {
 	}
 }
 
+// TestSwimLaneTypeModeCustomTypes verifies custom issue types get their own Type columns
+func TestSwimLaneTypeModeCustomTypes(t *testing.T) {
+	registry := &model.TypeRegistry{Types: []model.TypeDefinition{
+		{Name: model.TypeBug, Icon: "🪲"},
+		{Name: "incident", Icon: "🚨", Color: "#FF5555"},
+		{Name: "spike"},
+	}}
+	if err := registry.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	model.SetTypeRegistry(registry)
+	t.Cleanup(func() { model.SetTypeRegistry(nil) })
+
+	issues := []model.Issue{
+		{ID: "bug1", Status: model.StatusOpen, IssueType: model.TypeBug},
+		{ID: "inc1", Status: model.StatusOpen, IssueType: "incident"},
+		{ID: "inc2", Status: model.StatusOpen, IssueType: "incident"},
+		{ID: "spike1", Status: model.StatusOpen, IssueType: "spike"},
+	}
+	b := ui.NewBoardModel(issues, createTheme())
+	b.CycleSwimLaneMode()
+	b.CycleSwimLaneMode()
+
+	if b.ColumnCount(4) != 2 {
+		t.Errorf("Expected 2 in incident column, got %d", b.ColumnCount(4))
+	}
+	if b.ColumnCount(5) != 1 {
+		t.Errorf("Expected 1 in spike column, got %d", b.ColumnCount(5))
+	}
+	view := b.View(200, 30)
+	if !strings.Contains(view, "INCIDENT") {
+		t.Error("Expected an INCIDENT column header")
+	}
+	if !strings.Contains(view, "🪲 BUG") {
+		t.Error("Expected the BUG header to use the registry icon")
+	}
+}
+
 // TestSwimLaneModeCycles verifies mode cycles back to Status after Type
 func TestSwimLaneModeCycles(t *testing.T) {
 	theme := createTheme()
@@ -112,6 +112,7 @@ const contextHelpList = `## List View
   h         History view
 
 **Actions**
+  e         Edit status, priority, labels, etc.
   U         Self-update bv
   V         Preview cass sessions`
 
@@ -124,6 +125,10 @@ const contextHelpGraph = `## Graph View
   f         Focus on subgraph
   Esc       Exit to list
 
+**Editing**
+  +         Add dependency (with cycle check)
+  -         Remove dependency
+
 **Understanding the Graph**
 • Arrows point TO what's blocked
   (A → B means A blocks B)
@@ -140,10 +145,8 @@ const contextHelpBoard = `## Board View
   H/L       Jump to first/last column
   gg/G      Go to top/bottom of column
 
-**Filtering**
+**Filtering & Search**
   o/c/r     Filter: open/closed/ready
-
-**Search**
   /         Start search
   n/N       Next/prev match
 
@@ -160,6 +163,7 @@ const contextHelpBoard = `## Board View
   Ctrl+j/k  Scroll detail panel
   V         Preview cass sessions
   y         Copy issue ID
+  +/-       Add/remove dependency
   Enter     View issue details
   Esc       Return to List view`
 
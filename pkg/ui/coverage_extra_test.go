@@ -881,6 +881,28 @@ func TestGraphIconsAndTruncation(t *testing.T) {
 	}
 }
 
+func TestGraphTypeIconHonorsRegistry(t *testing.T) {
+	registry := &model.TypeRegistry{Types: []model.TypeDefinition{
+		{Name: model.TypeBug, Icon: "🪲"},
+		{Name: "incident", Icon: "🚨"},
+	}}
+	if err := registry.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	model.SetTypeRegistry(registry)
+	t.Cleanup(func() { model.SetTypeRegistry(nil) })
+
+	if got := getTypeIcon(model.TypeBug); got != "🪲" {
+		t.Errorf("bug icon = %q, want the configured 🪲", got)
+	}
+	if got := getTypeIcon("incident"); got != "🚨" {
+		t.Errorf("incident icon = %q, want 🚨", got)
+	}
+	if got := getTypeIcon("unknown"); got != "📄" {
+		t.Errorf("unknown icon = %q, want 📄", got)
+	}
+}
+
 func TestHelpOverlayScroll(t *testing.T) {
 	issues := []model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}
 	m := NewModel(issues, nil, "")
@@ -19,6 +19,10 @@ type IssueDelegate struct {
 	PriorityHints     map[string]*analysis.PriorityRecommendation
 	WorkspaceMode     bool // When true, shows repo prefix badges
 	ShowSearchScores  bool // Show semantic/hybrid score badge when search is active
+
+	// Recipe view config: when Columns is set, rows render only those columns
+	Columns       []string
+	TruncateTitle int // Max title width in columns mode (0 = fill available space)
 }
 
 func (d IssueDelegate) Height() int {
@@ -34,10 +38,18 @@ func (d IssueDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 }
 
 func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	if header, ok := listItem.(GroupHeaderItem); ok {
+		d.renderGroupHeader(w, m, index, header)
+		return
+	}
 	i, ok := listItem.(IssueItem)
 	if !ok {
 		return
 	}
+	if len(d.Columns) > 0 {
+		d.renderColumns(w, m, index, i)
+		return
+	}
 
 	t := d.Theme
 	width := m.Width()
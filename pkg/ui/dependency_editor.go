@@ -0,0 +1,345 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/mutation"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// depEditorMaxRows caps the number of target candidates shown at once.
+const depEditorMaxRows = 8
+
+// depEditorTarget is a candidate for the other end of the edge.
+type depEditorTarget struct {
+	issue   model.Issue
+	depType model.DependencyType // Existing edge type (remove mode only)
+}
+
+// DependencyEditor adds or removes a blocks/parent-child edge from the
+// selected issue to a picked target. Enter on a target computes an
+// analysis.DependencyEditPreview (cycle path, critical path and unblocks
+// change); a second Enter confirms. Edits that would close a cycle cannot be
+// confirmed.
+type DependencyEditor struct {
+	from      model.Issue
+	issues    []model.Issue // Full graph, used for previews
+	remove    bool
+	depType   model.DependencyType
+	input     textinput.Model
+	targets   []depEditorTarget
+	filtered  []depEditorTarget
+	cursor    int
+	preview   *analysis.DependencyEditPreview
+	result    *mutation.Change
+	cancelled bool
+	theme     Theme
+	width     int
+}
+
+// NewDependencyEditor creates an editor for edges leaving from. In remove
+// mode only from's existing blocks/parent-child dependencies are offered.
+func NewDependencyEditor(from model.Issue, issues []model.Issue, remove bool, theme Theme) DependencyEditor {
+	ti := textinput.New()
+	ti.Placeholder = "filter by ID or title"
+	ti.Prompt = "🔎 "
+	ti.CharLimit = 100
+	ti.Width = 40
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(theme.Base.GetForeground())
+	ti.Focus()
+
+	e := DependencyEditor{
+		from:    from,
+		issues:  issues,
+		remove:  remove,
+		depType: model.DepBlocks,
+		input:   ti,
+		theme:   theme,
+		width:   64,
+	}
+
+	if remove {
+		byID := make(map[string]model.Issue, len(issues))
+		for _, issue := range issues {
+			byID[issue.ID] = issue
+		}
+		for _, dep := range from.Dependencies {
+			if dep == nil {
+				continue
+			}
+			depType := dep.Type
+			if depType == "" {
+				depType = model.DepBlocks
+			}
+			if depType != model.DepBlocks && depType != model.DepParentChild {
+				continue
+			}
+			target, ok := byID[dep.DependsOnID]
+			if !ok {
+				target = model.Issue{ID: dep.DependsOnID, Title: "(not loaded)"}
+			}
+			e.targets = append(e.targets, depEditorTarget{issue: target, depType: depType})
+		}
+	} else {
+		for _, issue := range issues {
+			if issue.ID != from.ID && issue.Status != model.StatusTombstone {
+				e.targets = append(e.targets, depEditorTarget{issue: issue})
+			}
+		}
+	}
+	e.applyFilter()
+	return e
+}
+
+// IssueID returns the ID of the issue the edge leaves from.
+func (e DependencyEditor) IssueID() string { return e.from.ID }
+
+// Result returns the confirmed change, if any.
+func (e DependencyEditor) Result() (mutation.Change, bool) {
+	if e.result == nil {
+		return mutation.Change{}, false
+	}
+	return *e.result, true
+}
+
+// Cancelled reports whether the editor was closed without a change.
+func (e DependencyEditor) Cancelled() bool { return e.cancelled }
+
+// Preview returns the preview for the picked target, if one was computed.
+func (e DependencyEditor) Preview() *analysis.DependencyEditPreview { return e.preview }
+
+// Update handles input for the editor.
+func (e DependencyEditor) Update(msg tea.Msg) (DependencyEditor, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return e, nil
+	}
+
+	if e.preview != nil {
+		switch keyMsg.String() {
+		case "enter", "y", "Y":
+			if e.preview.Allowed {
+				kind := mutation.ChangeAddDependency
+				if e.remove {
+					kind = mutation.ChangeRemoveDependency
+				}
+				e.result = &mutation.Change{Kind: kind, DependsOn: e.preview.Edit.To, DepType: e.preview.Edit.Type}
+			}
+		case "esc", "n", "N", "backspace":
+			e.preview = nil
+		}
+		return e, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		e.cancelled = true
+		return e, nil
+	case "up", "ctrl+p", "ctrl+k":
+		if e.cursor > 0 {
+			e.cursor--
+		}
+		return e, nil
+	case "down", "ctrl+n", "ctrl+j":
+		if e.cursor < len(e.filtered)-1 {
+			e.cursor++
+		}
+		return e, nil
+	case "tab":
+		if !e.remove {
+			if e.depType == model.DepBlocks {
+				e.depType = model.DepParentChild
+			} else {
+				e.depType = model.DepBlocks
+			}
+		}
+		return e, nil
+	case "enter":
+		if e.cursor < len(e.filtered) {
+			target := e.filtered[e.cursor]
+			edit := analysis.DependencyEdit{From: e.from.ID, To: target.issue.ID, Type: e.depType, Remove: e.remove}
+			if e.remove {
+				edit.Type = target.depType
+			}
+			preview := analysis.PreviewDependencyEdit(e.issues, edit)
+			e.preview = &preview
+		}
+		return e, nil
+	}
+
+	var cmd tea.Cmd
+	before := e.input.Value()
+	e.input, cmd = e.input.Update(msg)
+	if e.input.Value() != before {
+		e.applyFilter()
+	}
+	return e, cmd
+}
+
+func (e *DependencyEditor) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(e.input.Value()))
+	e.filtered = e.filtered[:0]
+	for _, t := range e.targets {
+		if query == "" ||
+			strings.Contains(strings.ToLower(t.issue.ID), query) ||
+			strings.Contains(strings.ToLower(t.issue.Title), query) {
+			e.filtered = append(e.filtered, t)
+		}
+	}
+	if e.cursor >= len(e.filtered) {
+		e.cursor = 0
+	}
+}
+
+// View renders the editor.
+func (e DependencyEditor) View() string {
+	t := e.theme
+	r := t.Renderer
+
+	modalStyle := r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(e.width)
+	titleStyle := r.NewStyle().Foreground(t.Primary).Bold(true)
+	subtleStyle := r.NewStyle().Foreground(t.Subtext)
+	selectedStyle := r.NewStyle().Foreground(t.Primary).Bold(true)
+	idStyle := r.NewStyle().Foreground(t.Secondary)
+
+	verb := "Add dependency"
+	if e.remove {
+		verb = "Remove dependency"
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("🔗 " + verb + " from " + e.from.ID))
+	b.WriteString("\n")
+	b.WriteString(subtleStyle.Render(truncateRunesHelper(e.from.Title, e.width-4, "…")))
+	b.WriteString("\n\n")
+
+	if e.preview != nil {
+		b.WriteString(e.renderPreview())
+		return modalStyle.Render(b.String())
+	}
+
+	if !e.remove {
+		b.WriteString("Type: " + selectedStyle.Render(string(e.depType)) + subtleStyle.Render("  (tab to switch)") + "\n")
+		b.WriteString(subtleStyle.Render(e.from.ID+" will depend on the picked issue") + "\n\n")
+	}
+	b.WriteString(e.input.View())
+	b.WriteString("\n\n")
+
+	if len(e.filtered) == 0 {
+		if e.remove && len(e.targets) == 0 {
+			b.WriteString(subtleStyle.Render("No blocks or parent-child dependencies to remove"))
+		} else {
+			b.WriteString(subtleStyle.Render("No matching issues"))
+		}
+	} else {
+		start := 0
+		if e.cursor >= depEditorMaxRows {
+			start = e.cursor - depEditorMaxRows + 1
+		}
+		end := start + depEditorMaxRows
+		if end > len(e.filtered) {
+			end = len(e.filtered)
+		}
+		for i := start; i < end; i++ {
+			target := e.filtered[i]
+			label := target.issue.ID
+			if e.remove {
+				label += " [" + string(target.depType) + "]"
+			}
+			title := truncateRunesHelper(target.issue.Title, e.width-lipgloss.Width(label)-8, "…")
+			if i == e.cursor {
+				b.WriteString(selectedStyle.Render("▸ "+label+" "+title) + "\n")
+			} else {
+				b.WriteString("  " + idStyle.Render(label) + " " + title + "\n")
+			}
+		}
+		if len(e.filtered) > end {
+			b.WriteString(subtleStyle.Render(fmt.Sprintf("  … %d more", len(e.filtered)-end)) + "\n")
+		}
+	}
+	b.WriteString("\n" + subtleStyle.Render("↑/↓ select • Enter preview • Esc cancel"))
+	return modalStyle.Render(b.String())
+}
+
+func (e DependencyEditor) renderPreview() string {
+	t := e.theme
+	r := t.Renderer
+	p := e.preview
+	subtleStyle := r.NewStyle().Foreground(t.Subtext)
+	errStyle := r.NewStyle().Foreground(t.Blocked).Bold(true)
+	okStyle := r.NewStyle().Foreground(t.Open).Bold(true)
+
+	var b strings.Builder
+	action := "+"
+	if p.Edit.Remove {
+		action = "−"
+	}
+	b.WriteString(fmt.Sprintf("%s %s → %s (%s)\n\n", action, p.Edit.From, p.Edit.To, p.Edit.Type))
+
+	if !p.Allowed {
+		if len(p.CyclePath) > 0 {
+			b.WriteString(errStyle.Render("⛔ Would create a cycle:") + "\n")
+			b.WriteString(errStyle.Render("   "+strings.Join(p.CyclePath, " → ")) + "\n")
+		} else {
+			b.WriteString(errStyle.Render("⛔ "+p.Reason) + "\n")
+		}
+		b.WriteString("\n" + subtleStyle.Render("Esc to pick another target"))
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("Critical path:  %d → %d%s\n",
+		p.CriticalPathBefore, p.CriticalPathAfter, signedDelta(p.CriticalPathAfter-p.CriticalPathBefore)))
+	b.WriteString(fmt.Sprintf("Closing %s unblocks: %s → %s\n",
+		p.Edit.To, formatIDList(p.UnblocksBefore), formatIDList(p.UnblocksAfter)))
+	b.WriteString(fmt.Sprintf("%s actionable:  %s → %s\n",
+		p.Edit.From, yesNo(p.FromActionableBefore), yesNo(p.FromActionableAfter)))
+	if p.Edit.Type == model.DepParentChild {
+		b.WriteString(subtleStyle.Render("parent-child edges do not block work") + "\n")
+	}
+	b.WriteString("\n" + okStyle.Render("Enter/y to save") + subtleStyle.Render(" • Esc back"))
+	return b.String()
+}
+
+// CenterModal returns the editor view centered in the given dimensions.
+func (e DependencyEditor) CenterModal(termWidth, termHeight int) string {
+	return lipgloss.Place(termWidth, termHeight, lipgloss.Center, lipgloss.Center, e.View())
+}
+
+func signedDelta(d int) string {
+	switch {
+	case d > 0:
+		return fmt.Sprintf(" (+%d)", d)
+	case d < 0:
+		return fmt.Sprintf(" (%d)", d)
+	default:
+		return " (unchanged)"
+	}
+}
+
+func formatIDList(ids []string) string {
+	if len(ids) == 0 {
+		return "none"
+	}
+	if len(ids) > 4 {
+		return strings.Join(ids[:4], ", ") + fmt.Sprintf(" +%d", len(ids)-4)
+	}
+	return strings.Join(ids, ", ")
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
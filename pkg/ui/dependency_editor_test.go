@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/mutation"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func depEditorIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "bv-1", Title: "One", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Two", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks},
+		}},
+		{ID: "bv-3", Title: "Three", Status: model.StatusOpen},
+	}
+}
+
+func updateDepEditor(e DependencyEditor, msgs ...tea.KeyMsg) DependencyEditor {
+	for _, msg := range msgs {
+		e, _ = e.Update(msg)
+	}
+	return e
+}
+
+func TestDependencyEditor_RefusesCycle(t *testing.T) {
+	issues := depEditorIssues()
+	e := NewDependencyEditor(issues[0], issues, false, DefaultTheme(nil))
+
+	e = updateDepEditor(e, keys("bv-2")...)
+	e = updateDepEditor(e, tea.KeyMsg{Type: tea.KeyEnter})
+	preview := e.Preview()
+	if preview == nil {
+		t.Fatal("expected a preview after picking a target")
+	}
+	if preview.Allowed || len(preview.CyclePath) == 0 {
+		t.Fatalf("bv-1 → bv-2 should be refused as a cycle: %+v", preview)
+	}
+	if !strings.Contains(e.View(), "cycle") {
+		t.Error("preview should mention the cycle")
+	}
+
+	e = updateDepEditor(e, tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := e.Result(); ok {
+		t.Fatal("a cyclic edit must not be confirmable")
+	}
+}
+
+func TestDependencyEditor_RemoveOffersExistingDeps(t *testing.T) {
+	issues := depEditorIssues()
+	e := NewDependencyEditor(issues[1], issues, true, DefaultTheme(nil))
+	if len(e.filtered) != 1 || e.filtered[0].issue.ID != "bv-1" {
+		t.Fatalf("remove targets = %+v, want only bv-1", e.filtered)
+	}
+
+	e = updateDepEditor(e, tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	change, ok := e.Result()
+	if !ok {
+		t.Fatal("expected a confirmed change")
+	}
+	if change.Kind != mutation.ChangeRemoveDependency || change.DependsOn != "bv-1" || change.DepType != model.DepBlocks {
+		t.Fatalf("change = %+v", change)
+	}
+}
+
+func TestDependencyEditor_GraphViewAddsEdge(t *testing.T) {
+	mut := &recordingMutator{}
+	m := NewModel(depEditorIssues(), nil, "")
+	m.mutator = mut
+
+	m, _ = pressKeys(t, m, keys("g")...)
+	if !m.isGraphView {
+		t.Fatal("expected graph view")
+	}
+	selected := m.graphView.SelectedIssue()
+	if selected == nil {
+		t.Fatal("expected a selected graph node")
+	}
+	target := "bv-3"
+	if selected.ID == target {
+		target = "bv-1"
+	}
+
+	m, _ = pressKeys(t, m, keys("+")...)
+	if !m.showDependencyEditor {
+		t.Fatal("+ should open the dependency editor")
+	}
+	m, _ = pressKeys(t, m, keys(target)...)
+	m, _ = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if p := m.dependencyEditor.Preview(); p == nil || !p.Allowed {
+		t.Fatalf("expected an allowed preview, got %+v", p)
+	}
+	m, cmd := pressKeys(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showDependencyEditor {
+		t.Fatal("editor should close after confirming")
+	}
+	if cmd == nil {
+		t.Fatal("expected a write-back command")
+	}
+	if !hasDependency(m.issueMap[selected.ID], target) {
+		t.Fatalf("optimistic edge %s → %s missing", selected.ID, target)
+	}
+
+	next, _ := m.Update(cmd())
+	m = next.(Model)
+	want := "dep+ " + selected.ID + " " + target + " blocks"
+	if len(mut.calls) != 1 || mut.calls[0] != want {
+		t.Fatalf("mutator calls = %v, want [%s]", mut.calls, want)
+	}
+	if !m.isGraphView {
+		t.Error("should stay in graph view")
+	}
+}
+
+func hasDependency(issue *model.Issue, dependsOn string) bool {
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.DependsOnID == dependsOn {
+			return true
+		}
+	}
+	return false
+}
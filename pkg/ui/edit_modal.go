@@ -0,0 +1,408 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/mutation"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// editStage is the current step of the edit modal.
+type editStage int
+
+const (
+	editStageMenu editStage = iota
+	editStageStatus
+	editStagePriority
+	editStageText
+)
+
+// editStatusOptions returns the statuses offered by the edit modal: the
+// workflow's statuses, in board order.
+func editStatusOptions() []model.Status {
+	statuses := model.CurrentWorkflow().Statuses
+	options := make([]model.Status, len(statuses))
+	for i, def := range statuses {
+		options[i] = def.Name
+	}
+	return options
+}
+
+// EditModal collects a single edit (status, priority, assignee, labels, or a
+// comment) for the selected issue. The caller applies the resulting
+// mutation.Change through a mutation.Mutator.
+type EditModal struct {
+	issue     model.Issue
+	stage     editStage
+	textKind  mutation.ChangeKind
+	cursor    int
+	input     textinput.Model
+	result    *mutation.Change
+	cancelled bool
+	theme     Theme
+	width     int
+}
+
+// NewEditModal creates an edit modal for issue.
+func NewEditModal(issue model.Issue, theme Theme) EditModal {
+	ti := textinput.New()
+	ti.CharLimit = 500
+	ti.Width = 44
+	ti.PromptStyle = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	ti.TextStyle = lipgloss.NewStyle().Foreground(theme.Base.GetForeground())
+
+	return EditModal{
+		issue: issue,
+		stage: editStageMenu,
+		input: ti,
+		theme: theme,
+		width: 56,
+	}
+}
+
+// IssueID returns the ID of the issue being edited.
+func (m EditModal) IssueID() string { return m.issue.ID }
+
+// Result returns the submitted change, if any.
+func (m EditModal) Result() (mutation.Change, bool) {
+	if m.result == nil {
+		return mutation.Change{}, false
+	}
+	return *m.result, true
+}
+
+// Cancelled reports whether the user closed the modal without an edit.
+func (m EditModal) Cancelled() bool { return m.cancelled }
+
+// Update handles input for the modal.
+func (m EditModal) Update(msg tea.Msg) (EditModal, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	key := keyMsg.String()
+
+	switch m.stage {
+	case editStageMenu:
+		switch key {
+		case "s":
+			m.stage = editStageStatus
+			m.cursor = 0
+			for i, s := range editStatusOptions() {
+				if s == m.issue.Status {
+					m.cursor = i
+				}
+			}
+		case "p":
+			m.stage = editStagePriority
+			m.cursor = m.issue.Priority
+			if m.cursor < 0 || m.cursor > 4 {
+				m.cursor = 2
+			}
+		case "a":
+			return m.startText(mutation.ChangeAssignee, "@ ", "assignee (empty to clear)", m.issue.Assignee)
+		case "l":
+			return m.startText(mutation.ChangeLabels, "🏷  ", "+add -remove", "")
+		case "c":
+			return m.startText(mutation.ChangeComment, "💬 ", "comment text", "")
+		case "esc", "q", "e":
+			m.cancelled = true
+		}
+
+	case editStageStatus:
+		options := editStatusOptions()
+		switch key {
+		case "j", "down":
+			if m.cursor < len(options)-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			if n := int(key[0] - '1'); n < len(options) {
+				m.cursor = n
+				m.result = &mutation.Change{Kind: mutation.ChangeStatus, Status: options[m.cursor]}
+			}
+		case "enter":
+			m.result = &mutation.Change{Kind: mutation.ChangeStatus, Status: options[m.cursor]}
+		case "esc":
+			m.stage = editStageMenu
+		}
+
+	case editStagePriority:
+		switch key {
+		case "j", "down":
+			if m.cursor < 4 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "0", "1", "2", "3", "4":
+			m.cursor = int(key[0] - '0')
+			fallthrough
+		case "enter":
+			m.result = &mutation.Change{Kind: mutation.ChangePriority, Priority: m.cursor}
+		case "esc":
+			m.stage = editStageMenu
+		}
+
+	case editStageText:
+		switch key {
+		case "esc":
+			m.input.Blur()
+			m.stage = editStageMenu
+			return m, nil
+		case "enter":
+			m.submitText()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m EditModal) startText(kind mutation.ChangeKind, prompt, placeholder, value string) (EditModal, tea.Cmd) {
+	m.stage = editStageText
+	m.textKind = kind
+	m.input.Prompt = prompt
+	m.input.Placeholder = placeholder
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+func (m *EditModal) submitText() {
+	value := strings.TrimSpace(m.input.Value())
+	switch m.textKind {
+	case mutation.ChangeAssignee:
+		value = strings.TrimPrefix(value, "@")
+		if value == m.issue.Assignee {
+			m.cancelled = true
+			return
+		}
+		m.result = &mutation.Change{Kind: mutation.ChangeAssignee, Assignee: value}
+	case mutation.ChangeLabels:
+		add, remove := mutation.ParseLabelEdit(value)
+		if len(add) == 0 && len(remove) == 0 {
+			return
+		}
+		m.result = &mutation.Change{Kind: mutation.ChangeLabels, AddLabels: add, RemoveLabels: remove}
+	case mutation.ChangeComment:
+		if value == "" {
+			return
+		}
+		m.result = &mutation.Change{Kind: mutation.ChangeComment, Comment: value}
+	}
+}
+
+// View renders the modal.
+func (m EditModal) View() string {
+	t := m.theme
+	r := t.Renderer
+
+	modalStyle := r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(m.width)
+	titleStyle := r.NewStyle().Foreground(t.Primary).Bold(true)
+	subtleStyle := r.NewStyle().Foreground(t.Subtext)
+	keyStyle := r.NewStyle().Foreground(t.Primary).Bold(true)
+	selectedStyle := r.NewStyle().Foreground(t.Primary).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("✏️  Edit " + m.issue.ID))
+	b.WriteString("\n")
+	b.WriteString(subtleStyle.Render(truncateRunesHelper(m.issue.Title, m.width-4, "…")))
+	b.WriteString("\n\n")
+
+	switch m.stage {
+	case editStageMenu:
+		rows := []struct{ key, label, current string }{
+			{"s", "Status", string(m.issue.Status)},
+			{"p", "Priority", fmt.Sprintf("P%d", m.issue.Priority)},
+			{"a", "Assignee", m.issue.Assignee},
+			{"l", "Labels", strings.Join(m.issue.Labels, ", ")},
+			{"c", "Add comment", fmt.Sprintf("%d existing", len(m.issue.Comments))},
+		}
+		for _, row := range rows {
+			b.WriteString(keyStyle.Render(row.key) + "  " + fmt.Sprintf("%-12s", row.label) + subtleStyle.Render(row.current) + "\n")
+		}
+		b.WriteString("\n" + subtleStyle.Render("Esc to cancel"))
+
+	case editStageStatus:
+		b.WriteString("Status:\n")
+		for i, s := range editStatusOptions() {
+			line := fmt.Sprintf("%d %s", i+1, s)
+			if i == m.cursor {
+				b.WriteString(selectedStyle.Render("▸ "+line) + "\n")
+			} else {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+		b.WriteString("\n" + subtleStyle.Render("j/k select • Enter apply • Esc back"))
+
+	case editStagePriority:
+		b.WriteString("Priority:\n")
+		for p := 0; p <= 4; p++ {
+			line := fmt.Sprintf("P%d", p)
+			if p == m.cursor {
+				b.WriteString(selectedStyle.Render("▸ "+line) + "\n")
+			} else {
+				b.WriteString("  " + line + "\n")
+			}
+		}
+		b.WriteString("\n" + subtleStyle.Render("0-4 or j/k • Enter apply • Esc back"))
+
+	case editStageText:
+		b.WriteString(m.input.View())
+		b.WriteString("\n\n" + subtleStyle.Render("Enter apply • Esc back"))
+	}
+
+	return modalStyle.Render(b.String())
+}
+
+// CenterModal returns the modal view centered in the given dimensions.
+func (m EditModal) CenterModal(termWidth, termHeight int) string {
+	return lipgloss.Place(termWidth, termHeight, lipgloss.Center, lipgloss.Center, m.View())
+}
+
+// mutationTimeout bounds a single write-back (bd can be slow on first run).
+const mutationTimeout = 30 * time.Second
+
+// MutationResultMsg reports the outcome of a write-back started by submitEdit.
+// Previous is the issue as it was before the optimistic update, restored on error.
+type MutationResultMsg struct {
+	IssueID  string
+	Change   mutation.Change
+	Previous model.Issue
+	Backend  string
+	Err      error
+}
+
+// openEditModal opens the edit modal for the selected issue, creating the
+// mutator on first use.
+func (m *Model) openEditModal() {
+	issueItem, ok := m.list.SelectedItem().(IssueItem)
+	if !ok || !m.ensureMutator() {
+		return
+	}
+
+	issue := issueItem.Issue
+	if current, ok := m.issueMap[issue.ID]; ok {
+		issue = *current
+	}
+	m.editModal = NewEditModal(issue, m.theme)
+	m.showEditModal = true
+}
+
+// ensureMutator reports whether edits can be written back, creating the
+// mutator on first use. On failure the reason is shown in the status bar.
+func (m *Model) ensureMutator() bool {
+	if m.timeTravelMode {
+		m.statusMsg = "⚠️ Editing is disabled in time-travel mode (press t to exit)"
+		m.statusIsError = true
+		return false
+	}
+	if m.workspaceMode {
+		m.statusMsg = "⚠️ Editing is not available in workspace mode"
+		m.statusIsError = true
+		return false
+	}
+	if m.mutator == nil {
+		mut, err := mutation.New(m.beadsPath)
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("⚠️ Editing unavailable: %v", err)
+			m.statusIsError = true
+			return false
+		}
+		m.mutator = mut
+	}
+	return true
+}
+
+// openDependencyEditor opens the dependency editor for edges leaving issue.
+// With remove set only its existing dependencies are offered as targets.
+func (m *Model) openDependencyEditor(issue *model.Issue, remove bool) {
+	if issue == nil || !m.ensureMutator() {
+		return
+	}
+	from := *issue
+	if current, ok := m.issueMap[issue.ID]; ok {
+		from = *current
+	}
+	m.dependencyEditor = NewDependencyEditor(from, m.issues, remove, m.theme)
+	m.showDependencyEditor = true
+}
+
+// submitEdit applies change to the in-memory issue immediately and returns a
+// command that persists it. The file watcher reload reconciles the result.
+func (m *Model) submitEdit(id string, change mutation.Change) tea.Cmd {
+	current, ok := m.issueMap[id]
+	if !ok || m.mutator == nil {
+		return nil
+	}
+	previous := current.Clone()
+	updated := current.Clone()
+	change.ApplyTo(&updated, time.Now().UTC())
+	m.replaceIssue(updated)
+	m.statusMsg = fmt.Sprintf("Saving %s: %s…", id, change.Describe())
+	m.statusIsError = false
+
+	mut := m.mutator
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), mutationTimeout)
+		defer cancel()
+		err := change.Apply(ctx, mut, id)
+		return MutationResultMsg{IssueID: id, Change: change, Previous: previous, Backend: mut.Backend(), Err: err}
+	}
+}
+
+// handleMutationResult reports a finished write-back, rolling back the
+// optimistic update on failure.
+func (m *Model) handleMutationResult(msg MutationResultMsg) {
+	if msg.Err != nil {
+		m.replaceIssue(msg.Previous)
+		m.statusMsg = fmt.Sprintf("❌ %s: %s failed: %v", msg.IssueID, msg.Change.Describe(), msg.Err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("✓ %s: %s (via %s)", msg.IssueID, msg.Change.Describe(), msg.Backend)
+	m.statusIsError = false
+}
+
+// replaceIssue swaps in an updated copy of an issue everywhere the list and
+// detail pane read it from, keeping the current filter and selection.
+func (m *Model) replaceIssue(updated model.Issue) {
+	if current, ok := m.issueMap[updated.ID]; ok {
+		*current = updated
+	}
+	for i, it := range m.list.Items() {
+		if item, ok := it.(IssueItem); ok && item.Issue.ID == updated.ID {
+			item.Issue = updated
+			m.list.SetItem(i, item)
+			break
+		}
+	}
+	// The board and graph hold their own copies of the visible issues
+	visible := m.FilteredIssues()
+	m.board.SetIssues(visible)
+	if m.analysis != nil {
+		ins := m.analysis.GenerateInsights(len(visible))
+		m.graphView.SetIssues(visible, &ins)
+	}
+	m.updateViewportContent()
+}
@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/mutation"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordingMutator records calls and optionally fails them.
+type recordingMutator struct {
+	calls []string
+	err   error
+}
+
+func (r *recordingMutator) record(call string) error {
+	r.calls = append(r.calls, call)
+	return r.err
+}
+
+func (r *recordingMutator) Backend() string { return "fake" }
+func (r *recordingMutator) SetStatus(_ context.Context, id string, s model.Status) error {
+	return r.record("status " + id + " " + string(s))
+}
+func (r *recordingMutator) SetPriority(_ context.Context, id string, p int) error {
+	return r.record("priority " + id)
+}
+func (r *recordingMutator) SetAssignee(_ context.Context, id, a string) error {
+	return r.record("assignee " + id + " " + a)
+}
+func (r *recordingMutator) AddLabels(_ context.Context, id string, l ...string) error {
+	return r.record("label+ " + id)
+}
+func (r *recordingMutator) RemoveLabels(_ context.Context, id string, l ...string) error {
+	return r.record("label- " + id)
+}
+func (r *recordingMutator) AddComment(_ context.Context, id, text string) error {
+	return r.record("comment " + id + " " + text)
+}
+func (r *recordingMutator) AddDependency(_ context.Context, id, dependsOn string, t model.DependencyType) error {
+	return r.record("dep+ " + id + " " + dependsOn + " " + string(t))
+}
+func (r *recordingMutator) RemoveDependency(_ context.Context, id, dependsOn string, t model.DependencyType) error {
+	return r.record("dep- " + id + " " + dependsOn + " " + string(t))
+}
+
+func keys(s string) []tea.KeyMsg {
+	var out []tea.KeyMsg
+	for _, r := range s {
+		out = append(out, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return out
+}
+
+func pressKeys(t *testing.T, m Model, msgs ...tea.KeyMsg) (Model, tea.Cmd) {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, msg := range msgs {
+		var next tea.Model
+		next, cmd = m.Update(msg)
+		m = next.(Model)
+	}
+	return m, cmd
+}
+
+func newEditTestModel(mut mutation.Mutator) Model {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "One", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask},
+		{ID: "bv-2", Title: "Two", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeBug},
+	}
+	m := NewModel(issues, nil, "")
+	m.mutator = mut
+	return m
+}
+
+func selectIssue(m *Model, id string) {
+	for i, it := range m.list.Items() {
+		if item, ok := it.(IssueItem); ok && item.Issue.ID == id {
+			m.list.Select(i)
+			return
+		}
+	}
+}
+
+func TestEditModal_StatusChangeIsOptimistic(t *testing.T) {
+	mut := &recordingMutator{}
+	m := newEditTestModel(mut)
+	selectIssue(&m, "bv-1")
+
+	m, cmd := pressKeys(t, m, keys("es2")...)
+	if m.showEditModal {
+		t.Fatal("modal should close after choosing a status")
+	}
+	if got := m.issueMap["bv-1"].Status; got != model.StatusInProgress {
+		t.Fatalf("optimistic status = %q, want in_progress", got)
+	}
+	if item := m.list.SelectedItem().(IssueItem); item.Issue.Status != model.StatusInProgress {
+		t.Fatalf("list item not refreshed: %q", item.Issue.Status)
+	}
+	if cmd == nil {
+		t.Fatal("expected a write-back command")
+	}
+
+	next, _ := m.Update(cmd())
+	m = next.(Model)
+	if len(mut.calls) != 1 || mut.calls[0] != "status bv-1 in_progress" {
+		t.Fatalf("mutator calls = %v", mut.calls)
+	}
+	if m.statusIsError {
+		t.Fatalf("unexpected error status: %s", m.statusMsg)
+	}
+}
+
+func TestEditModal_FailureRollsBack(t *testing.T) {
+	mut := &recordingMutator{err: errors.New("bd exploded")}
+	m := newEditTestModel(mut)
+	selectIssue(&m, "bv-2")
+
+	m, cmd := pressKeys(t, m, keys("ep0")...)
+	if got := m.issueMap["bv-2"].Priority; got != 0 {
+		t.Fatalf("optimistic priority = %d, want 0", got)
+	}
+	next, _ := m.Update(cmd())
+	m = next.(Model)
+	if got := m.issueMap["bv-2"].Priority; got != 1 {
+		t.Fatalf("priority after failure = %d, want rollback to 1", got)
+	}
+	if !m.statusIsError {
+		t.Fatal("expected error status after failed write-back")
+	}
+}
+
+func TestEditModal_CommentAndCancel(t *testing.T) {
+	mut := &recordingMutator{}
+	m := newEditTestModel(mut)
+	selectIssue(&m, "bv-1")
+
+	// Esc from the menu closes without writing.
+	m, cmd := pressKeys(t, m, append(keys("e"), tea.KeyMsg{Type: tea.KeyEsc})...)
+	if m.showEditModal || cmd != nil {
+		t.Fatal("esc should cancel the edit")
+	}
+
+	msgs := append(keys("ec"), keys("ship it")...)
+	msgs = append(msgs, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = pressKeys(t, m, msgs...)
+	if len(m.issueMap["bv-1"].Comments) != 1 {
+		t.Fatal("comment not applied optimistically")
+	}
+	m.Update(cmd())
+	if len(mut.calls) != 1 || mut.calls[0] != "comment bv-1 ship it" {
+		t.Fatalf("mutator calls = %v", mut.calls)
+	}
+}
+
+func TestEditModal_ReadOnlyWithoutBeadsPath(t *testing.T) {
+	m := newEditTestModel(nil)
+	selectIssue(&m, "bv-1")
+
+	m, _ = pressKeys(t, m, keys("e")...)
+	if m.showEditModal || !m.statusIsError {
+		t.Fatal("editing without a beads file should be refused")
+	}
+}
+
+func TestEditModal_JSONLBackendEndToEnd(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	content := `{"id":"bv-1","title":"One","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(issues, nil, "")
+	m.mutator = mutation.NewJSONLMutator(path)
+
+	msgs := append(keys("el"), keys("+ui")...)
+	msgs = append(msgs, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd := pressKeys(t, m, msgs...)
+	m.Update(cmd())
+
+	reloaded, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded[0].Labels) != 1 || reloaded[0].Labels[0] != "ui" {
+		t.Fatalf("labels on disk = %v", reloaded[0].Labels)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -26,6 +27,9 @@ type GraphModel struct {
 	blockers   map[string][]string // What each issue depends on (blocks this issue)
 	dependents map[string][]string // What depends on each issue (this issue blocks)
 
+	// Workspace mode: dependency targets outside the loaded repos (id -> label)
+	dangling map[string]string
+
 	// Flat list for navigation
 	sortedIDs []string
 
@@ -85,6 +89,29 @@ func (g *GraphModel) SetIssues(issues []model.Issue, insights *analysis.Insights
 	}
 }
 
+// SetDanglingRefs marks dependency targets that are not in any loaded
+// workspace repo, so they render as missing rather than filtered out.
+func (g *GraphModel) SetDanglingRefs(refs []workspace.DanglingRef) {
+	g.dangling = make(map[string]string, len(refs))
+	for _, ref := range refs {
+		switch {
+		case ref.Reason == workspace.DanglingRepoNotLoaded:
+			g.dangling[ref.DependsOnID] = "(" + ref.TargetRepo + " not loaded)"
+		case ref.TargetRepo != "":
+			g.dangling[ref.DependsOnID] = "(missing in " + ref.TargetRepo + ")"
+		default:
+			g.dangling[ref.DependsOnID] = "(missing)"
+		}
+	}
+}
+
+// crossRepo reports whether two issues are owned by different workspace repos
+func (g *GraphModel) crossRepo(a, b string) bool {
+	repoA := analysis.IssueRepo(g.issueMap[a])
+	repoB := analysis.IssueRepo(g.issueMap[b])
+	return repoA != "" && repoB != "" && repoA != repoB
+}
+
 func (g *GraphModel) rebuildGraph() {
 	size := len(g.issues)
 	g.issueMap = make(map[string]*model.Issue, size)
@@ -554,6 +581,11 @@ func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool)
 		if issue.Title != "" {
 			title = truncateRunesHelper(issue.Title, boxWidth-4, "…")
 		}
+	} else if label, ok := g.dangling[id]; ok {
+		statusIcon = "⛓"
+		statusColor = t.Blocked
+		displayID = smartTruncateID(id, boxWidth-4)
+		title = truncateRunesHelper(label, boxWidth-4, "…")
 	} else {
 		statusIcon = "❓"
 		statusColor = t.Secondary
@@ -564,6 +596,12 @@ func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool)
 	// Build box content
 	line1 := fmt.Sprintf("%s %s", statusIcon, displayID)
 
+	// Cross-repo neighbours show the repo that owns them
+	var repoLine string
+	if sel := g.SelectedIssue(); !isEgo && sel != nil && g.crossRepo(sel.ID, id) {
+		repoLine = truncateRunesHelper("⇄ "+analysis.IssueRepo(issue), boxWidth-4, "…")
+	}
+
 	var boxStyle lipgloss.Style
 	if isEgo {
 		// Ego node gets double-line border and highlight
@@ -589,6 +627,9 @@ func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool)
 	if title != "" && boxWidth > 14 {
 		content = line1 + "\n" + title
 	}
+	if repoLine != "" {
+		content += "\n" + repoLine
+	}
 
 	return boxStyle.Render(content)
 }
@@ -630,6 +671,15 @@ func (g *GraphModel) renderEgoNode(id string, issue *model.Issue, width int, t T
 	blockerCount := len(g.blockers[id])
 	dependentCount := len(g.dependents[id])
 	content += fmt.Sprintf("\n⬆%d  ⬇%d", blockerCount, dependentCount)
+	crossRepoCount := 0
+	for _, bid := range g.blockers[id] {
+		if g.crossRepo(id, bid) {
+			crossRepoCount++
+		}
+	}
+	if crossRepoCount > 0 {
+		content += fmt.Sprintf("  ⇄%d", crossRepoCount)
+	}
 
 	egoStyle := t.Renderer.NewStyle().
 		Border(lipgloss.DoubleBorder()).
@@ -860,7 +910,7 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 // Helper functions
 
 func getStatusIcon(status model.Status) string {
-	switch status {
+	switch status.Base() {
 	case model.StatusOpen:
 		return "🔵"
 	case model.StatusInProgress:
@@ -875,7 +925,7 @@ func getStatusIcon(status model.Status) string {
 }
 
 func getStatusColor(status model.Status, t Theme) lipgloss.AdaptiveColor {
-	switch status {
+	switch status.Base() {
 	case model.StatusOpen:
 		return t.Open
 	case model.StatusInProgress:
@@ -905,20 +955,10 @@ func getPriorityIcon(priority int) string {
 }
 
 func getTypeIcon(itype model.IssueType) string {
-	switch itype {
-	case model.TypeBug:
-		return "🐛"
-	case model.TypeFeature:
-		return "✨"
-	case model.TypeTask:
-		return "📝"
-	case model.TypeEpic:
-		return "🎯"
-	case model.TypeChore:
-		return "🔧"
-	default:
-		return "📄"
+	if _, ok := model.CurrentTypeRegistry().Lookup(itype); ok {
+		return itype.Icon()
 	}
+	return "📄"
 }
 
 func smartTruncateID(id string, maxLen int) string {
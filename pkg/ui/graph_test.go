@@ -2,11 +2,13 @@ package ui_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 )
 
 // TestGraphModelEmpty verifies behavior with no issues
@@ -308,6 +310,36 @@ func TestGraphModelViewRendering(t *testing.T) {
 	}
 }
 
+// TestGraphModelCrossRepoBlockers verifies cross-repo and dangling blockers are labelled
+func TestGraphModelCrossRepoBlockers(t *testing.T) {
+	theme := createTheme()
+	issues := []model.Issue{
+		{ID: "web-1", Title: "Login", SourceRepo: "web", Dependencies: []*model.Dependency{
+			{DependsOnID: "api-1", Type: model.DepBlocks},
+			{DependsOnID: "api-9", Type: model.DepBlocks},
+		}},
+		{ID: "api-1", Title: "Token", SourceRepo: "api"},
+	}
+
+	g := ui.NewGraphModel(issues, nil, theme)
+	g.SetDanglingRefs([]workspace.DanglingRef{
+		{IssueID: "web-1", Repo: "web", DependsOnID: "api-9", TargetRepo: "api", Reason: workspace.DanglingMissingIssue},
+	})
+	if !g.SelectByID("web-1") {
+		t.Fatal("web-1 not found")
+	}
+
+	view := g.View(160, 40)
+	for _, want := range []string{"⇄ api", "⇄1", "(missing in api)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("graph view missing %q", want)
+		}
+	}
+	if strings.Contains(view, "(not in filter)") {
+		t.Error("dangling blocker should not render as filtered out")
+	}
+}
+
 // TestGraphModelIgnoresNonBlockingDeps verifies only blocking deps create edges
 func TestGraphModelIgnoresNonBlockingDeps(t *testing.T) {
 	theme := createTheme()
@@ -221,7 +221,7 @@ func getDepTypeIcon(depType string) string {
 
 // GetStatusIcon returns a colored icon for a status
 func GetStatusIcon(s string) string {
-	switch s {
+	switch model.Status(s).Base() {
 	case "open":
 		return "🟢"
 	case "in_progress":
@@ -10,6 +10,7 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/cass"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -54,6 +55,13 @@ type CommitListEntry struct {
 	Timestamp string
 	FileCount int
 	BeadIDs   []string // Beads related to this commit
+
+	// Pull-request grouping: a group header stands for a merged branch or
+	// pull request and lists its correlated commits as members
+	IsGroup  bool                     // Entry is a group header; SHA is the merge commit
+	Group    *correlation.CommitGroup // For group headers: the group
+	Members  []CommitListEntry        // For group headers: correlated commits, most recent first
+	GroupSHA string                   // For members: merge commit of their group
 }
 
 // historySearchMode tracks what type of search is active (bv-nkrj)
@@ -123,6 +131,7 @@ type HistoryModel struct {
 	selectedGitCommit    int               // Index into commitList
 	selectedRelatedBead  int               // Index into selected commit's BeadIDs
 	gitScrollOffset      int               // For scrolling the commit list
+	expandedGroups       map[string]bool   // Merge SHAs of expanded commit groups
 
 	// Three-pane middle panel scroll state (bv-xrfh)
 	middleScrollOffset int // Scroll offset for middle pane content
@@ -161,6 +170,11 @@ type HistoryModel struct {
 	// Cass session integration state (bv-pr1l)
 	sessionCache map[string][]cass.ScoredResult // Cached sessions per bead ID
 
+	// Ownership panel state
+	showOwnership  bool                        // Whether the ownership panel replaces the panes
+	ownership      OwnershipModel              // Directory owners table
+	ownershipBeads []correlation.OwnershipBead // Bead fields the ownership report was built from
+
 	// View mode transition state (bv-kvlx)
 	modeChangedAt time.Time // Timestamp of last mode toggle for transition animation
 }
@@ -174,14 +188,16 @@ func NewHistoryModel(report *correlation.HistoryReport, theme Theme) HistoryMode
 	ti.Width = 40
 
 	h := HistoryModel{
-		report:        report,
-		theme:         theme,
-		focused:       historyFocusList,
-		minConfidence: 0.0, // Show all by default
-		expandedBeads: make(map[string]bool),
-		searchInput:   ti,
-		searchMode:    searchModeOff,
-		sessionCache:  make(map[string][]cass.ScoredResult), // bv-pr1l
+		report:         report,
+		theme:          theme,
+		focused:        historyFocusList,
+		minConfidence:  0.0, // Show all by default
+		expandedBeads:  make(map[string]bool),
+		expandedGroups: make(map[string]bool),
+		searchInput:    ti,
+		searchMode:     searchModeOff,
+		sessionCache:   make(map[string][]cass.ScoredResult), // bv-pr1l
+		ownership:      NewOwnershipModel(theme),
 	}
 	h.rebuildFilteredList()
 	return h
@@ -191,6 +207,40 @@ func NewHistoryModel(report *correlation.HistoryReport, theme Theme) HistoryMode
 func (h *HistoryModel) SetReport(report *correlation.HistoryReport) {
 	h.report = report
 	h.rebuildFilteredList()
+	if h.showOwnership {
+		h.buildOwnership()
+	}
+}
+
+// ToggleOwnership shows or hides the ownership panel, building it from the
+// current report and the given beads when shown
+func (h *HistoryModel) ToggleOwnership(beads []correlation.OwnershipBead) {
+	h.showOwnership = !h.showOwnership
+	if h.showOwnership {
+		h.ownershipBeads = beads
+		h.buildOwnership()
+	}
+}
+
+// buildOwnership recomputes the ownership report for the panel
+func (h *HistoryModel) buildOwnership() {
+	report := correlation.BuildOwnershipReport(h.report, h.ownershipBeads, correlation.DefaultOwnershipOptions())
+	h.ownership.SetData(report)
+}
+
+// IsOwnershipVisible returns whether the ownership panel is visible
+func (h *HistoryModel) IsOwnershipVisible() bool {
+	return h.showOwnership
+}
+
+// UpdateOwnership forwards navigation keys to the ownership panel
+func (h *HistoryModel) UpdateOwnership(msg tea.KeyMsg) {
+	h.ownership.Update(msg)
+}
+
+// SelectedOwnershipArea returns the area selected in the ownership panel
+func (h *HistoryModel) SelectedOwnershipArea() *correlation.OwnershipArea {
+	return h.ownership.SelectedArea()
 }
 
 // SetSessionsForBead stores correlated sessions for a bead in the cache (bv-pr1l)
@@ -800,7 +850,12 @@ func (h *HistoryModel) filterCommitList(query string) {
 	var filtered []CommitListEntry
 
 	for _, commit := range h.commitList {
-		if h.commitMatchesQuery(commit, query) {
+		// A group header matches when any of its commits does
+		matched := h.commitMatchesQuery(commit, query)
+		for _, member := range commit.Members {
+			matched = matched || h.commitMatchesQuery(member, query)
+		}
+		if matched {
 			filtered = append(filtered, commit)
 		}
 	}
@@ -1042,7 +1097,109 @@ func (h *HistoryModel) buildCommitList() {
 		return entries[i].Timestamp > entries[j].Timestamp
 	})
 
-	h.commitList = entries
+	h.commitList = h.groupCommits(entries)
+}
+
+// groupCommits folds commits that belong to a merged branch or pull request
+// under a header for the group. Members are listed after their header only
+// while the group is expanded.
+func (h *HistoryModel) groupCommits(entries []CommitListEntry) []CommitListEntry {
+	groups := h.report.Groups
+	if len(groups) == 0 {
+		return entries
+	}
+
+	groupOf := make(map[string]int)
+	for i, group := range groups {
+		for _, sha := range group.Commits {
+			groupOf[sha] = i
+		}
+	}
+
+	members := make([][]CommitListEntry, len(groups))
+	var rows []CommitListEntry
+	for _, entry := range entries {
+		if i, ok := groupOf[entry.SHA]; ok {
+			entry.GroupSHA = groups[i].MergeSHA
+			members[i] = append(members[i], entry)
+			continue
+		}
+		rows = append(rows, entry)
+	}
+
+	for i := range groups {
+		if len(members[i]) == 0 {
+			continue
+		}
+		group := &groups[i]
+		header := CommitListEntry{
+			SHA:       group.MergeSHA,
+			ShortSHA:  group.ID,
+			Message:   group.Title,
+			Author:    group.Author,
+			Timestamp: group.MergedAt.Format("2006-01-02 15:04"),
+			BeadIDs:   append([]string(nil), group.BeadIDs...),
+			IsGroup:   true,
+			Group:     group,
+			Members:   members[i],
+		}
+		for _, member := range members[i] {
+			header.FileCount += member.FileCount
+		}
+		rows = append(rows, header)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Timestamp > rows[j].Timestamp
+	})
+
+	var list []CommitListEntry
+	for _, row := range rows {
+		list = append(list, row)
+		if row.IsGroup && h.expandedGroups[row.SHA] {
+			list = append(list, row.Members...)
+		}
+	}
+	return list
+}
+
+// ToggleGroup expands or collapses the commit group of the selected entry in
+// git mode and selects its header. It returns false if the entry is not in a
+// group.
+func (h *HistoryModel) ToggleGroup() bool {
+	commit := h.SelectedGitCommit()
+	if commit == nil {
+		return false
+	}
+	mergeSHA := commit.GroupSHA
+	if commit.IsGroup {
+		mergeSHA = commit.SHA
+	}
+	if mergeSHA == "" {
+		return false
+	}
+
+	h.expandedGroups[mergeSHA] = !h.expandedGroups[mergeSHA]
+	h.buildCommitList()
+	if query := strings.TrimSpace(h.searchInput.Value()); query != "" {
+		h.filterCommitList(query)
+	}
+
+	for i, entry := range h.GetFilteredCommitList() {
+		if entry.IsGroup && entry.SHA == mergeSHA {
+			h.selectedGitCommit = i
+			break
+		}
+	}
+	h.selectedRelatedBead = 0
+	h.middleScrollOffset = 0
+	h.ensureGitCommitVisible()
+	return true
+}
+
+// IsGroupExpanded reports whether the commit group merged by mergeSHA is expanded
+func (h *HistoryModel) IsGroupExpanded(mergeSHA string) bool {
+	return h.expandedGroups[mergeSHA]
 }
 
 // MoveUpGit moves selection up in git mode
@@ -1259,6 +1416,12 @@ func (h *HistoryModel) View() string {
 		return h.renderEmpty("No history data loaded")
 	}
 
+	if h.showOwnership {
+		header := h.renderHeader()
+		h.ownership.SetSize(h.width, h.height-lipgloss.Height(header))
+		return lipgloss.JoinVertical(lipgloss.Left, header, h.ownership.View())
+	}
+
 	// In git mode, check commit list; in bead mode, check histories
 	if h.viewMode == historyModeGit {
 		if len(h.commitList) == 0 {
@@ -1800,7 +1963,10 @@ func (h *HistoryModel) renderHeader() string {
 	// Show view mode indicator with icons (bv-tl3n, bv-kvlx)
 	// Icons: ◉ for git-centric (commits), ◈ for bead-centric (beads)
 	var modeIcon, modeLabel string
-	if h.viewMode == historyModeGit {
+	if h.showOwnership {
+		modeIcon = "◎"
+		modeLabel = "Ownership"
+	} else if h.viewMode == historyModeGit {
 		modeIcon = "◉"
 		modeLabel = "Git"
 	} else {
@@ -1854,6 +2020,11 @@ func (h *HistoryModel) renderHeader() string {
 			Render("[Esc] cancel")
 
 		rightContent = searchBox + escHint
+	} else if h.showOwnership {
+		rightContent = t.Renderer.NewStyle().
+			Foreground(t.Muted).
+			Padding(0, 1).
+			Render("[O] back  [H] close")
 	} else {
 		// Show close hint and search hint
 		rightContent = t.Renderer.NewStyle().
@@ -1930,6 +2101,13 @@ func (h *HistoryModel) renderStatsLine() string {
 		badges = append(badges, cycleBadge)
 	}
 
+	// Average first-commit-to-merge time of commit groups (git mode)
+	if h.viewMode == historyModeGit && stats.AvgGroupCycleTimeDays != nil {
+		cycleStr := formatCycleTime(*stats.AvgGroupCycleTimeDays)
+		groupBadge := badgeStyle.Render("⌀ " + valueStyle.Render(cycleStr) + " merge cycle")
+		badges = append(badges, groupBadge)
+	}
+
 	// Commits per bead
 	if stats.AvgCommitsPerBead > 0 {
 		cpdBadge := badgeStyle.Render(valueStyle.Render(fmt.Sprintf("%.1f", stats.AvgCommitsPerBead)) + " commits/bead")
@@ -2620,6 +2798,10 @@ func methodLabel(method correlation.CorrelationMethod) string {
 		return "(explicit ID)"
 	case correlation.MethodTemporalAuthor:
 		return "(temporal)"
+	case correlation.MethodTrailer:
+		return "(trailer)"
+	case correlation.MethodBranchName:
+		return "(branch)"
 	default:
 		return ""
 	}
@@ -3077,11 +3259,25 @@ func (h *HistoryModel) renderGitCommitLine(idx int, commit CommitListEntry, widt
 		indicator = "▸ "
 	}
 
+	// Group headers get an expand icon; their members are indented under them
+	if commit.IsGroup {
+		if h.expandedGroups[commit.SHA] {
+			indicator += "▼ "
+		} else {
+			indicator += "▶ "
+		}
+	} else if commit.GroupSHA != "" {
+		indicator += "    "
+	}
+
 	// Bead count badge
 	beadCount := fmt.Sprintf("[%d]", len(commit.BeadIDs))
+	if commit.IsGroup {
+		beadCount = fmt.Sprintf("(%d commits) %s", len(commit.Members), beadCount)
+	}
 
 	// Truncate message
-	maxMsgLen := width - len(indicator) - len(commit.ShortSHA) - len(beadCount) - 6
+	maxMsgLen := width - lipgloss.Width(indicator) - len(commit.ShortSHA) - len(beadCount) - 6
 	if maxMsgLen < 10 {
 		maxMsgLen = 10
 	}
@@ -3191,10 +3387,87 @@ func (h *HistoryModel) renderGitDetailPanel(width, height int) string {
 	// Add separator before commit details
 	lines = append(lines, "")
 	lines = append(lines, strings.Repeat("─", detailSepWidth))
-	lines = append(lines, headerStyle.Render("COMMIT DETAILS"))
+	if commit.IsGroup {
+		lines = append(lines, headerStyle.Render("GROUP DETAILS"))
+		lines = append(lines, strings.Repeat("─", detailSepWidth))
+		lines = append(lines, h.renderGroupDetails(commit, width)...)
+	} else {
+		lines = append(lines, headerStyle.Render("COMMIT DETAILS"))
+		lines = append(lines, strings.Repeat("─", detailSepWidth))
+		lines = append(lines, h.renderGitCommitDetails(commit, width)...)
+	}
+
+	// Reserve space for footer hint (bv-xf4p)
+	footerHeight := 2
+	contentHeight := height - 2 - footerHeight
+	if contentHeight < 1 {
+		contentHeight = 1 // Minimum content height to avoid negative slicing
+	}
+
+	// Pad with empty lines
+	for len(lines) < contentHeight {
+		lines = append(lines, "")
+	}
+
+	// Truncate if too many lines
+	if len(lines) > contentHeight {
+		lines = lines[:contentHeight]
+	}
+
+	// Add footer hint (bv-xf4p)
 	lines = append(lines, strings.Repeat("─", detailSepWidth))
+	hintStyle := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true)
+	hint := "J/K:bead  y:copy  o:open  g:graph"
+	if commit.IsGroup || commit.GroupSHA != "" {
+		hint += "  space:group"
+	}
+	lines = append(lines, hintStyle.Render(hint))
+
+	content := strings.Join(lines, "\n")
+	return panelStyle.Render(content)
+}
+
+// renderGroupDetails renders the details of a merged branch or pull request
+func (h *HistoryModel) renderGroupDetails(commit *CommitListEntry, width int) []string {
+	t := h.theme
+	group := commit.Group
+
+	var lines []string
+	idLine := fmt.Sprintf("%s (%s)", group.ID, group.Kind)
+	if group.Branch != "" {
+		idLine += " from " + group.Branch
+	}
+	if width > 10 && len(idLine) > width-6 {
+		idLine = idLine[:width-7] + "…"
+	}
+	lines = append(lines, t.Renderer.NewStyle().Foreground(t.Primary).Render(idLine))
+
+	authorLine := fmt.Sprintf("Merged by: %s", group.Author)
+	if width > 10 && len(authorLine) > width-6 {
+		authorLine = authorLine[:width-7] + "…"
+	}
+	lines = append(lines, t.Renderer.NewStyle().Foreground(t.Secondary).Render(authorLine))
 
-	// Commit details
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+	lines = append(lines, mutedStyle.Render(fmt.Sprintf("Merged: %s", commit.Timestamp)))
+	lines = append(lines, mutedStyle.Render(fmt.Sprintf("Commits: %d  Files: %d changed", len(commit.Members), commit.FileCount)))
+	lines = append(lines, mutedStyle.Render(fmt.Sprintf("Cycle time: %s (first commit → merge)", formatDuration(group.CycleTime))))
+
+	// Title
+	lines = append(lines, "")
+	title := group.Title
+	if width > 6 && len(title) > width-6 {
+		title = title[:width-7] + "…"
+	}
+	lines = append(lines, t.Renderer.NewStyle().Foreground(t.Base.GetForeground()).Render(title))
+	return lines
+}
+
+// renderGitCommitDetails renders the details of a single commit in git mode
+func (h *HistoryModel) renderGitCommitDetails(commit *CommitListEntry, width int) []string {
+	t := h.theme
+
+	var lines []string
 	shaLine := fmt.Sprintf("SHA: %s", commit.SHA)
 	if width > 10 && len(shaLine) > width-6 {
 		shaLine = shaLine[:width-7] + "…"
@@ -3223,31 +3496,7 @@ func (h *HistoryModel) renderGitDetailPanel(width, height int) string {
 		}
 		lines = append(lines, msgStyle.Render(ml))
 	}
-
-	// Reserve space for footer hint (bv-xf4p)
-	footerHeight := 2
-	contentHeight := height - 2 - footerHeight
-	if contentHeight < 1 {
-		contentHeight = 1 // Minimum content height to avoid negative slicing
-	}
-
-	// Pad with empty lines
-	for len(lines) < contentHeight {
-		lines = append(lines, "")
-	}
-
-	// Truncate if too many lines
-	if len(lines) > contentHeight {
-		lines = lines[:contentHeight]
-	}
-
-	// Add footer hint (bv-xf4p)
-	lines = append(lines, strings.Repeat("─", detailSepWidth))
-	hintStyle := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true)
-	lines = append(lines, hintStyle.Render("J/K:bead  y:copy  o:open  g:graph"))
-
-	content := strings.Join(lines, "\n")
-	return panelStyle.Render(content)
+	return lines
 }
 
 // renderCommitMiddlePanel renders commits for selected bead in middle pane (bv-xrfh)
@@ -429,6 +429,8 @@ func TestMethodLabel(t *testing.T) {
 		{correlation.MethodCoCommitted, "(co-committed)"},
 		{correlation.MethodExplicitID, "(explicit ID)"},
 		{correlation.MethodTemporalAuthor, "(temporal)"},
+		{correlation.MethodTrailer, "(trailer)"},
+		{correlation.MethodBranchName, "(branch)"},
 		{correlation.CorrelationMethod("unknown"), ""},
 	}
 
@@ -835,6 +837,104 @@ func TestHistoryModel_GetFilteredCommitList(t *testing.T) {
 	}
 }
 
+func createGroupedHistoryReport() *correlation.HistoryReport {
+	report := createTestHistoryReport()
+	now := report.GeneratedAt
+	report.Groups = []correlation.CommitGroup{
+		{
+			ID:            "#7",
+			Kind:          correlation.GroupMerge,
+			PRNumber:      7,
+			Title:         "Fix authentication",
+			Branch:        "acme/fix-auth",
+			MergeSHA:      "merge7000000",
+			Author:        "Dev One",
+			FirstCommitAt: now.Add(-time.Hour),
+			MergedAt:      now.Add(time.Minute),
+			CycleTime:     time.Hour + time.Minute,
+			Commits:       []string{"def456ghi789", "abc123def456", "merge7000000"},
+			BeadIDs:       []string{"bv-1", "bv-2"},
+		},
+	}
+	return report
+}
+
+func TestHistoryModel_GroupCommits(t *testing.T) {
+	h := NewHistoryModel(createGroupedHistoryReport(), testTheme())
+	h.ToggleViewMode() // Git mode
+
+	// Collapsed: the group header plus the two ungrouped commits
+	if len(h.commitList) != 3 {
+		t.Fatalf("collapsed commitList has %d entries, want 3", len(h.commitList))
+	}
+	header := h.SelectedGitCommit()
+	if header == nil || !header.IsGroup || header.ShortSHA != "#7" {
+		t.Fatalf("first entry = %+v, want the #7 group header", header)
+	}
+	if len(header.Members) != 2 || len(header.BeadIDs) != 2 {
+		t.Errorf("header has %d members and beads %v, want 2 members and [bv-1 bv-2]", len(header.Members), header.BeadIDs)
+	}
+
+	// Expanding lists the members under the header
+	if !h.ToggleGroup() {
+		t.Fatal("ToggleGroup() on a group header returned false")
+	}
+	if len(h.commitList) != 5 {
+		t.Fatalf("expanded commitList has %d entries, want 5", len(h.commitList))
+	}
+	for _, member := range h.commitList[1:3] {
+		if member.GroupSHA != "merge7000000" {
+			t.Errorf("entry %s GroupSHA = %q, want the merge commit", member.ShortSHA, member.GroupSHA)
+		}
+	}
+
+	// Toggling from a member collapses its group and selects the header
+	h.MoveDownGit()
+	if !h.ToggleGroup() {
+		t.Fatal("ToggleGroup() on a group member returned false")
+	}
+	if len(h.commitList) != 3 || h.selectedGitCommit != 0 {
+		t.Errorf("after collapse: %d entries, selected %d; want 3 entries, header selected", len(h.commitList), h.selectedGitCommit)
+	}
+
+	// Ungrouped commits cannot be toggled
+	h.MoveDownGit()
+	if h.ToggleGroup() {
+		t.Error("ToggleGroup() on an ungrouped commit returned true")
+	}
+}
+
+func TestHistoryModel_GroupSearch(t *testing.T) {
+	h := NewHistoryModel(createGroupedHistoryReport(), testTheme())
+	h.ToggleViewMode() // Git mode
+
+	// A collapsed group matches through its members
+	h.searchInput.SetValue("auth tests")
+	h.applySearchFilter()
+	filtered := h.GetFilteredCommitList()
+	if len(filtered) != 1 || !filtered[0].IsGroup {
+		t.Errorf("filtered = %+v, want only the group header", filtered)
+	}
+
+	h.searchInput.SetValue("db indexes")
+	h.applySearchFilter()
+	filtered = h.GetFilteredCommitList()
+	if len(filtered) != 1 || filtered[0].IsGroup {
+		t.Errorf("filtered = %+v, want only the ungrouped commit", filtered)
+	}
+}
+
+func TestHistoryModel_ViewGitModeGroups(t *testing.T) {
+	h := NewHistoryModel(createGroupedHistoryReport(), testTheme())
+	h.ToggleViewMode() // Git mode
+	h.SetSize(160, 35)
+
+	view := h.View()
+	if !strings.Contains(view, "#7") || !strings.Contains(view, "GROUP DETAILS") {
+		t.Error("View() in Git mode should show the group header and its details")
+	}
+}
+
 // =============================================================================
 // LAYOUT CALCULATION TESTS (bv-xrfh)
 // =============================================================================
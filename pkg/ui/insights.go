@@ -1649,7 +1649,7 @@ func (m *InsightsModel) renderDrillDownIssue(issueID string, isSelected bool, wi
 
 	// Status indicator (matches model.Status constants)
 	statusColor := t.Secondary
-	switch issue.Status {
+	switch issue.Status.Base() {
 	case "open":
 		statusColor = t.Open
 	case "in_progress":
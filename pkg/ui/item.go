@@ -52,6 +52,10 @@ type IssueItem struct {
 	IsQuickWin    bool     // True if identified as a quick win
 	IsBlocker     bool     // True if this item blocks significant downstream work
 	UnblocksCount int      // Number of items this unblocks
+
+	// Recipe view columns (populated by applyRecipe)
+	Betweenness  float64 // Betweenness centrality score
+	OpenBlockers int     // Number of open issues blocking this one
 }
 
 func (i IssueItem) Title() string {
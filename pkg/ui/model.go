@@ -20,10 +20,12 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/mutation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
@@ -126,6 +128,11 @@ func WaitForPhase2Cmd(stats *analysis.GraphStats) tea.Cmd {
 // FileChangedMsg is sent when the beads file changes on disk
 type FileChangedMsg struct{}
 
+// WorkspaceChangedMsg is sent when beads files of workspace repos change on disk
+type WorkspaceChangedMsg struct {
+	Paths []string // Absolute paths of the changed beads files
+}
+
 // semanticDebounceTickMsg is sent after debounce delay to trigger semantic computation
 type semanticDebounceTickMsg struct{}
 
@@ -150,6 +157,15 @@ func WatchFileCmd(w *watcher.Watcher) tea.Cmd {
 	}
 }
 
+// WatchWorkspaceCmd returns a command that waits for any workspace repo to
+// change and sends WorkspaceChangedMsg with the changed files
+func WatchWorkspaceCmd(w *watcher.MultiWatcher) tea.Cmd {
+	return func() tea.Msg {
+		<-w.Changed()
+		return WorkspaceChangedMsg{Paths: w.Drain()}
+	}
+}
+
 // CheckUpdateCmd returns a command that checks for updates
 func CheckUpdateCmd() tea.Cmd {
 	return func() tea.Msg {
@@ -353,6 +369,8 @@ type Model struct {
 	recipePicker     RecipePickerModel
 	activeRecipe     *recipe.Recipe
 	recipeLoader     *recipe.Loader
+	recipeView       *recipe.ViewConfig // View config of the recipe that built the list (nil = default layout)
+	groupToggled     map[string]bool    // Recipe group keys toggled away from the recipe's collapsed default
 
 	// Label picker (bv-126)
 	showLabelPicker bool
@@ -384,6 +402,12 @@ type Model struct {
 	activeRepos      map[string]bool // Which repos are currently shown (nil = all)
 	workspaceSummary string          // Summary text for footer (e.g., "3 repos")
 
+	// Workspace live reload
+	workspaceLoader  *workspace.AggregateLoader
+	workspaceWatcher *watcher.MultiWatcher
+	workspaceFiles   map[string]string                 // Beads file -> repo name
+	workspaceFilter  func([]model.Issue) []model.Issue // Applied to merged issues after a reload (e.g. --repo)
+
 	// Alerts panel (bv-168)
 	alerts          []drift.Alert
 	alertsCritical  int
@@ -416,6 +440,15 @@ type Model struct {
 	// Self-update modal (bv-182)
 	showUpdateModal bool
 	updateModal     UpdateModal
+
+	// Write-back editing
+	showEditModal bool
+	editModal     EditModal
+	mutator       mutation.Mutator // Created lazily on first edit
+
+	// Dependency editor (graph and board views)
+	showDependencyEditor bool
+	dependencyEditor     DependencyEditor
 }
 
 // labelCount is a simple label->count pair for display
@@ -511,6 +544,7 @@ type WorkspaceInfo struct {
 	FailedCount  int
 	TotalIssues  int
 	RepoPrefixes []string
+	DanglingRefs []workspace.DanglingRef // Dependencies on issues outside the workspace
 }
 
 func (m *Model) updateSemanticIDs(items []list.Item) {
@@ -550,9 +584,62 @@ func (m *Model) updateListDelegate() {
 		PriorityHints:     m.priorityHints,
 		WorkspaceMode:     m.workspaceMode,
 		ShowSearchScores:  m.shouldShowSearchScores(),
+		Columns:           m.recipeColumns(),
+		TruncateTitle:     m.recipeTruncateTitle(),
 	})
 }
 
+// recipeColumns returns the active recipe's list columns, or nil for the default layout.
+func (m *Model) recipeColumns() []string {
+	if m.recipeView == nil {
+		return nil
+	}
+	return m.recipeView.Columns
+}
+
+func (m *Model) recipeTruncateTitle() int {
+	if m.recipeView == nil {
+		return 0
+	}
+	return m.recipeView.TruncateTitle
+}
+
+// isRecipeGroupCollapsed reports whether a recipe group is currently collapsed,
+// starting from the recipe's view.collapsed default.
+func (m *Model) isRecipeGroupCollapsed(key string) bool {
+	collapsed := m.recipeView != nil && m.recipeView.Collapsed
+	if m.groupToggled[key] {
+		collapsed = !collapsed
+	}
+	return collapsed
+}
+
+// selectRecipe makes r the active recipe, resetting group collapse state.
+func (m *Model) selectRecipe(r *recipe.Recipe) {
+	m.activeRecipe = r
+	m.groupToggled = nil
+	m.applyRecipe(r)
+}
+
+// toggleRecipeGroup expands or collapses the group headed by g and keeps the header selected.
+func (m *Model) toggleRecipeGroup(g GroupHeaderItem) {
+	if m.activeRecipe == nil {
+		return
+	}
+	if m.groupToggled == nil {
+		m.groupToggled = make(map[string]bool)
+	}
+	m.groupToggled[g.Key] = !m.groupToggled[g.Key]
+	m.applyRecipe(m.activeRecipe)
+	for idx, it := range m.list.Items() {
+		if header, ok := it.(GroupHeaderItem); ok && header.Key == g.Key {
+			m.list.Select(idx)
+			break
+		}
+	}
+	m.updateViewportContent()
+}
+
 func (m *Model) applySemanticScores(term string) {
 	if m.semanticSearch == nil {
 		return
@@ -645,8 +732,8 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	} else {
 		// Default Sort: Open first, then by Priority (ascending), then by date (newest first)
 		sort.Slice(issues, func(i, j int) bool {
-			iClosed := issues[i].Status == model.StatusClosed
-			jClosed := issues[j].Status == model.StatusClosed
+			iClosed := issues[i].Status.IsClosed()
+			jClosed := issues[j].Status.IsClosed()
 			if iClosed != jClosed {
 				return !iClosed // Open issues first
 			}
@@ -677,7 +764,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	cOpen, cReady, cBlocked, cClosed := 0, 0, 0, 0
 	for i := range issues {
 		issue := &issues[i]
-		if issue.Status == model.StatusClosed {
+		if issue.Status.IsClosed() {
 			cClosed++
 			continue
 		}
@@ -694,7 +781,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 			if dep == nil || !dep.Type.IsBlocking() {
 				continue
 			}
-			if blocker, exists := issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
+			if blocker, exists := issueMap[dep.DependsOnID]; exists && !blocker.Status.IsClosed() {
 				isBlocked = true
 				break
 			}
@@ -946,6 +1033,9 @@ func (m Model) Init() tea.Cmd {
 	if m.watcher != nil {
 		cmds = append(cmds, WatchFileCmd(m.watcher))
 	}
+	if m.workspaceWatcher != nil {
+		cmds = append(cmds, WatchWorkspaceCmd(m.workspaceWatcher))
+	}
 	// Start loading history in background
 	if len(m.issues) > 0 {
 		cmds = append(cmds, LoadHistoryCmd(m.issues, m.beadsPath))
@@ -1007,6 +1097,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if m.semanticSearch != nil {
 			m.semanticSearch.SetIndex(msg.Index, msg.Embedder)
+		} else {
+			closeEmbedder(msg.Embedder)
 		}
 		if !msg.Loaded {
 			m.statusMsg = fmt.Sprintf("Semantic index built (%d embedded)", msg.Stats.Embedded)
@@ -1181,6 +1273,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.focused = focusAgentPrompt
 		}
 
+	case MutationResultMsg:
+		m.handleMutationResult(msg)
+		return m, nil
+
 	case FileChangedMsg:
 		// File changed on disk - reload issues and recompute analysis
 		if m.beadsPath == "" {
@@ -1191,19 +1287,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
-		// Clear ephemeral overlays tied to old data
-		m.clearAttentionOverlay()
-
-		// Exit time-travel mode if active (file changed, show current state)
-		if m.timeTravelMode {
-			m.timeTravelMode = false
-			m.timeTravelDiff = nil
-			m.timeTravelSince = ""
-			m.newIssueIDs = nil
-			m.closedIssueIDs = nil
-			m.modifiedIssueIDs = nil
-		}
-
 		// Reload issues from disk
 		// Use custom warning handler to prevent stderr pollution during TUI render (bv-fix)
 		var reloadWarnings []string
@@ -1222,129 +1305,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
-		// Store selected issue ID to restore position after reload
-		var selectedID string
-		if sel := m.list.SelectedItem(); sel != nil {
-			if item, ok := sel.(IssueItem); ok {
-				selectedID = item.Issue.ID
-			}
-		}
-
-		// Apply default sorting (Open first, Priority, Date)
-		sort.Slice(newIssues, func(i, j int) bool {
-			iClosed := newIssues[i].Status == model.StatusClosed
-			jClosed := newIssues[j].Status == model.StatusClosed
-			if iClosed != jClosed {
-				return !iClosed
-			}
-			if newIssues[i].Priority != newIssues[j].Priority {
-				return newIssues[i].Priority < newIssues[j].Priority
-			}
-			return newIssues[i].CreatedAt.After(newIssues[j].CreatedAt)
-		})
-
-		// Recompute analysis (async Phase 1/Phase 2) with caching
-		m.issues = newIssues
-		cachedAnalyzer := analysis.NewCachedAnalyzer(newIssues, nil)
-		m.analyzer = cachedAnalyzer.Analyzer
-		m.analysis = cachedAnalyzer.AnalyzeAsync(context.Background())
-		cacheHit := cachedAnalyzer.WasCacheHit()
-		m.labelHealthCached = false
-		m.attentionCached = false
-
-		// Rebuild lookup map
-		m.issueMap = make(map[string]*model.Issue, len(newIssues))
-		for i := range m.issues {
-			m.issueMap[m.issues[i].ID] = &m.issues[i]
-		}
-
-		// Clear stale priority hints (will be repopulated after Phase 2)
-		m.priorityHints = make(map[string]*analysis.PriorityRecommendation)
-
-		// Recompute stats
-		m.countOpen, m.countReady, m.countBlocked, m.countClosed = 0, 0, 0, 0
-		for i := range m.issues {
-			issue := &m.issues[i]
-			if issue.Status == model.StatusClosed {
-				m.countClosed++
-				continue
-			}
-			m.countOpen++
-			if issue.Status == model.StatusBlocked {
-				m.countBlocked++
-				continue
-			}
-			isBlocked := false
-			for _, dep := range issue.Dependencies {
-				if dep == nil || !dep.Type.IsBlocking() {
-					continue
-				}
-				if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
-					isBlocked = true
-					break
-				}
-			}
-			if !isBlocked {
-				m.countReady++
-			}
-		}
-
-		// Recompute alerts for refreshed dataset
-		m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
-		m.dismissedAlerts = make(map[string]bool)
-		m.showAlertsPanel = false
-
-		// Rebuild list items
-		items := make([]list.Item, len(m.issues))
-		for i := range m.issues {
-			items[i] = IssueItem{
-				Issue:      m.issues[i],
-				GraphScore: m.analysis.GetPageRankScore(m.issues[i].ID),
-				Impact:     m.analysis.GetCriticalPathScore(m.issues[i].ID),
-				RepoPrefix: ExtractRepoPrefix(m.issues[i].ID),
-			}
-		}
-		m.updateSemanticIDs(items)
-		m.clearSemanticScores()
-		if m.semanticSearch != nil {
-			m.semanticSearch.ResetCache()
-			m.semanticSearch.SetMetricsCache(nil)
-		}
-		m.semanticHybridReady = false
-		m.semanticHybridBuilding = false
-		if m.semanticHybridEnabled {
-			m.semanticHybridBuilding = true
-			cmds = append(cmds, BuildHybridMetricsCmd(m.issues))
-		}
-		m.list.SetItems(items)
-
-		// Restore selection position
-		if selectedID != "" {
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
-					m.list.Select(i)
-					break
-				}
-			}
-		}
-
-		// Regenerate sub-views (with Phase 1 data; Phase 2 will update via Phase2ReadyMsg)
-		ins := m.analysis.GenerateInsights(len(m.issues))
-		m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
-		bodyHeight := m.height - 1
-		if bodyHeight < 5 {
-			bodyHeight = 5
-		}
-		m.insightsPanel.SetSize(m.width, bodyHeight)
-		m.graphView.SetIssues(m.issues, &ins)
-
-		// Generate priority recommendations now that Phase 2 is ready
-		m.board = NewBoardModel(m.issues, m.theme)
-
-		// Re-apply recipe filter if active
-		if m.activeRecipe != nil {
-			m.applyRecipe(m.activeRecipe)
-		}
+		cacheHit, reloadCmds := m.replaceIssues(newIssues)
+		cmds = append(cmds, reloadCmds...)
 
 		// Reload sprints (bv-161)
 		if m.beadsPath != "" {
@@ -1370,12 +1332,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Keep semantic index current when enabled.
-		if m.semanticSearchEnabled && !m.semanticIndexBuilding {
-			m.semanticIndexBuilding = true
-			cmds = append(cmds, BuildSemanticIndexCmd(m.issues))
-		}
-
 		if cacheHit {
 			m.statusMsg = fmt.Sprintf("Reloaded %d issues (cached)", len(newIssues))
 		} else {
@@ -1385,9 +1341,6 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMsg += fmt.Sprintf(" (%d warnings)", len(reloadWarnings))
 		}
 		m.statusIsError = false
-		// Invalidate label-derived caches
-		m.labelHealthCached = false
-		m.labelDrilldownCache = make(map[string][]model.Issue)
 		m.updateViewportContent()
 
 		// Re-start watching for next change + wait for Phase 2
@@ -1397,6 +1350,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, WaitForPhase2Cmd(m.analysis))
 		return m, tea.Batch(cmds...)
 
+	case WorkspaceChangedMsg:
+		// One or more workspace repos changed - reload just those repos
+		cmds = append(cmds, m.reloadWorkspaceRepos(msg.Paths)...)
+		if m.workspaceWatcher != nil {
+			cmds = append(cmds, WatchWorkspaceCmd(m.workspaceWatcher))
+		}
+		return m, tea.Batch(cmds...)
+
 	case tea.KeyMsg:
 		// Clear status message on any keypress
 		m.statusMsg = ""
@@ -1488,6 +1449,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		// Handle edit modal
+		if m.showEditModal {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m.editModal, cmd = m.editModal.Update(msg)
+			if change, ok := m.editModal.Result(); ok {
+				m.showEditModal = false
+				m.focused = focusList
+				return m, m.submitEdit(m.editModal.IssueID(), change)
+			}
+			if m.editModal.Cancelled() {
+				m.showEditModal = false
+				m.focused = focusList
+			}
+			return m, cmd
+		}
+
+		// Handle dependency editor
+		if m.showDependencyEditor {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m.dependencyEditor, cmd = m.dependencyEditor.Update(msg)
+			if change, ok := m.dependencyEditor.Result(); ok {
+				m.showDependencyEditor = false
+				return m, m.submitEdit(m.dependencyEditor.IssueID(), change)
+			}
+			if m.dependencyEditor.Cancelled() {
+				m.showDependencyEditor = false
+			}
+			return m, cmd
+		}
+
 		// Close label health detail modal if open
 		if m.showLabelHealthDetail {
 			s := msg.String()
@@ -1794,7 +1789,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if !m.semanticSearch.Snapshot().Ready && !m.semanticIndexBuilding {
 						m.semanticIndexBuilding = true
 						m.statusMsg = "Semantic search: building index…"
-						cmds = append(cmds, BuildSemanticIndexCmd(m.issues))
+						cmds = append(cmds, BuildSemanticIndexCmd(m.issues, m.semanticSearch.Embedder()))
 					} else if !m.semanticSearch.Snapshot().Ready && m.semanticIndexBuilding {
 						m.statusMsg = "Semantic search: indexing…"
 					} else {
@@ -2205,6 +2200,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 
+			case "P":
+				// Toggle sprint dashboard
+				if m.isSprintView {
+					m.isSprintView = false
+					m.focused = focusList
+				} else {
+					m.enterSprintView()
+				}
+				return m, nil
+
 			case "x":
 				// Export to Markdown file
 				m.exportToMarkdown()
@@ -2635,6 +2640,12 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 			m.board.DetailScrollUp(3)
 		}
 
+	// Dependency editing
+	case "+":
+		m.openDependencyEditor(m.board.SelectedIssue(), false)
+	case "-":
+		m.openDependencyEditor(m.board.SelectedIssue(), true)
+
 	// Exit to detail view
 	case "enter":
 		if selected := m.board.SelectedIssue(); selected != nil {
@@ -2678,6 +2689,10 @@ func (m Model) handleGraphKeys(msg tea.KeyMsg) Model {
 		m.graphView.ScrollLeft()
 	case "L":
 		m.graphView.ScrollRight()
+	case "+":
+		m.openDependencyEditor(m.graphView.SelectedIssue(), false)
+	case "-":
+		m.openDependencyEditor(m.graphView.SelectedIssue(), true)
 	case "enter":
 		if selected := m.graphView.SelectedIssue(); selected != nil {
 			// Find and select in list
@@ -2810,6 +2825,23 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 		}
 	}
 
+	// Handle ownership panel navigation while it replaces the panes
+	if m.historyView.IsOwnershipVisible() {
+		switch msg.String() {
+		case "O":
+			m.historyView.ToggleOwnership(nil)
+			m.statusMsg = "👥 Ownership panel hidden"
+			m.statusIsError = false
+		case "j", "down", "k", "up", "home", "G", "end":
+			m.historyView.UpdateOwnership(msg)
+			if area := m.historyView.SelectedOwnershipArea(); area != nil && !area.HasRecentOwner {
+				m.statusMsg = fmt.Sprintf("👥 %s has no recent owner", area.Path)
+				m.statusIsError = false
+			}
+		}
+		return m
+	}
+
 	// Handle file tree navigation when file tree has focus (bv-190l)
 	if m.historyView.FileTreeHasFocus() {
 		switch msg.String() {
@@ -2870,6 +2902,30 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			m.statusMsg = "📦 Bead Mode: beads on left, commits on right"
 		}
 		m.statusIsError = false
+	case "O":
+		// Show directory owners derived from the correlated commits
+		m.historyView.ToggleOwnership(ownershipBeads(m.issues))
+		if area := m.historyView.SelectedOwnershipArea(); area != nil {
+			m.statusMsg = "👥 Ownership: j/k select area, O back"
+		} else {
+			m.statusMsg = "👥 No correlated commits to derive ownership from"
+		}
+		m.statusIsError = false
+	case " ":
+		// Expand or collapse the selected merged branch or pull request
+		if m.historyView.IsGitMode() {
+			if m.historyView.ToggleGroup() {
+				commit := m.historyView.SelectedGitCommit()
+				if m.historyView.IsGroupExpanded(commit.SHA) {
+					m.statusMsg = fmt.Sprintf("🔀 Expanded %s (%d commits)", commit.ShortSHA, len(commit.Members))
+				} else {
+					m.statusMsg = fmt.Sprintf("🔀 Collapsed %s", commit.ShortSHA)
+				}
+			} else {
+				m.statusMsg = "🔀 Commit is not part of a merged branch or pull request"
+			}
+			m.statusIsError = false
+		}
 	case "j", "down":
 		if m.historyView.IsGitMode() {
 			m.historyView.MoveDownGit()
@@ -3184,8 +3240,7 @@ func (m Model) handleRecipePickerKeys(msg tea.KeyMsg) Model {
 	case "enter":
 		// Apply selected recipe
 		if selected := m.recipePicker.SelectedRecipe(); selected != nil {
-			m.activeRecipe = selected
-			m.applyRecipe(selected)
+			m.selectRecipe(selected)
 		}
 		m.showRecipePicker = false
 		m.focused = focusList
@@ -3315,6 +3370,10 @@ func (m Model) handleInsightsKeys(msg tea.KeyMsg) Model {
 func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
 	case "enter":
+		if header, ok := m.list.SelectedItem().(GroupHeaderItem); ok {
+			m.toggleRecipeGroup(header)
+			return m
+		}
 		if !m.isSplitView {
 			m.showDetails = true
 			m.focused = focusDetail
@@ -3384,6 +3443,9 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	case "O":
 		// Open beads.jsonl in editor
 		m.openInEditor()
+	case "e":
+		// Edit status/priority/assignee/labels/comment of the selected issue
+		m.openEditModal()
 	case "h":
 		// Toggle history view
 		if !m.isHistoryView {
@@ -3392,8 +3454,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	case "S":
 		// Apply triage recipe - sort by triage score (bv-151)
 		if r := m.recipeLoader.Get("triage"); r != nil {
-			m.activeRecipe = r
-			m.applyRecipe(r)
+			m.selectRecipe(r)
 		}
 	case "s":
 		// Cycle sort mode (bv-3ita)
@@ -3404,6 +3465,9 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	case "U":
 		// Show self-update modal (bv-182)
 		m.showSelfUpdateModal()
+	case "+", "-":
+		// Add/remove the selected issue to/from the current sprint
+		m.toggleSelectedInSprint(msg.String() == "-")
 	}
 	return m
 }
@@ -3541,6 +3605,10 @@ func (m Model) View() string {
 	} else if m.showUpdateModal {
 		// Self-update modal (bv-182)
 		body = m.updateModal.CenterModal(m.width, m.height-1)
+	} else if m.showEditModal {
+		body = m.editModal.CenterModal(m.width, m.height-1)
+	} else if m.showDependencyEditor {
+		body = m.dependencyEditor.CenterModal(m.width, m.height-1)
 	} else if m.showLabelHealthDetail && m.labelHealthDetail != nil {
 		body = m.renderLabelHealthDetail(*m.labelHealthDetail)
 	} else if m.showLabelGraphAnalysis && m.labelGraphAnalysisResult != nil {
@@ -3906,6 +3974,7 @@ func (m *Model) renderHelpOverlay() string {
 		{"i", "Insights"},
 		{"h", "History view"},
 		{"a", "Actionable"},
+		{"P", "Sprint dashboard"},
 		{"f", "Flow matrix"},
 		{"[", "Label dashboard"},
 		{"]", "Attention view"},
@@ -3938,6 +4007,7 @@ func (m *Model) renderHelpOverlay() string {
 		{"hjkl", "Navigate nodes"},
 		{"H/L", "Scroll left/right"},
 		{"PgUp/Dn", "Scroll up/down"},
+		{"+/-", "Add/remove dependency"},
 		{"Enter", "Jump to issue"},
 	}
 
@@ -3965,6 +4035,8 @@ func (m *Model) renderHelpOverlay() string {
 		{"x", "Export markdown"},
 		{"C", "Copy to clipboard"},
 		{"O", "Open in editor"},
+		{"e", "Edit issue"},
+		{"+/-", "Add to/remove from sprint"},
 	}
 
 	// Build panels
@@ -4203,7 +4275,7 @@ func (m Model) renderLabelDrilldown() string {
 	total := len(issues)
 	open, blocked, inProgress, closed := 0, 0, 0, 0
 	for _, is := range issues {
-		switch is.Status {
+		switch is.Status.Base() {
 		case model.StatusOpen:
 			open++
 		case model.StatusBlocked:
@@ -4987,16 +5059,16 @@ func (m *Model) applyFilter() {
 		case "all":
 			include = true
 		case "open":
-			include = issue.Status != model.StatusClosed
+			include = !issue.Status.IsClosed()
 		case "closed":
-			include = issue.Status == model.StatusClosed
+			include = issue.Status.IsClosed()
 		case "ready":
 			// Ready = Open/InProgress AND NO Open Blockers
-			if issue.Status != model.StatusClosed && issue.Status != model.StatusBlocked {
+			if !issue.Status.IsClosed() && issue.Status != model.StatusBlocked {
 				isBlocked := false
 				for _, dep := range issue.Dependencies {
 					if dep.Type == model.DepBlocks {
-						if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
+						if blocker, exists := m.issueMap[dep.DependsOnID]; exists && !blocker.Status.IsClosed() {
 							isBlocked = true
 							break
 						}
@@ -5042,6 +5114,12 @@ func (m *Model) applyFilter() {
 	// Apply sort mode (bv-3ita)
 	m.sortFilteredItems(filteredItems, filteredIssues)
 
+	// Plain filters use the default row layout
+	if m.recipeView != nil {
+		m.recipeView = nil
+		m.updateListDelegate()
+	}
+
 	m.list.SetItems(filteredItems)
 	m.updateSemanticIDs(filteredItems)
 	m.board.SetIssues(filteredIssues)
@@ -5093,8 +5171,8 @@ func (m *Model) sortFilteredItems(items []list.Item, issues []model.Issue) {
 			return iItem.Issue.UpdatedAt.After(jItem.Issue.UpdatedAt)
 		default:
 			// Default: Open first, then priority, then newest
-			iClosed := iItem.Issue.Status == model.StatusClosed
-			jClosed := jItem.Issue.Status == model.StatusClosed
+			iClosed := iItem.Issue.Status.IsClosed()
+			jClosed := jItem.Issue.Status.IsClosed()
 			if iClosed != jClosed {
 				return !iClosed
 			}
@@ -5140,7 +5218,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 		if len(r.Filters.Status) > 0 {
 			statusMatch := false
 			for _, s := range r.Filters.Status {
-				if string(issue.Status) == s {
+				if issue.Status.MatchesFilter(s) {
 					statusMatch = true
 					break
 				}
@@ -5180,7 +5258,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			isBlocked := false
 			for _, dep := range issue.Dependencies {
 				if dep.Type == model.DepBlocks {
-					if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
+					if blocker, exists := m.issueMap[dep.DependsOnID]; exists && !blocker.Status.IsClosed() {
 						isBlocked = true
 						break
 					}
@@ -5206,6 +5284,8 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			item.IsQuickWin = m.quickWinSet[issue.ID]
 			item.IsBlocker = m.blockerSet[issue.ID]
 			item.UnblocksCount = len(m.unblocksMap[issue.ID])
+			item.Betweenness = m.analysis.GetBetweennessScore(issue.ID)
+			item.OpenBlockers = m.countOpenBlockers(issue)
 			filteredItems = append(filteredItems, item)
 			filteredIssues = append(filteredIssues, issue)
 		}
@@ -5268,23 +5348,50 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 		})
 	}
 
-	m.list.SetItems(filteredItems)
+	// Apply view.max_items (list, board and graph all show the same subset)
+	if r.View.MaxItems > 0 && len(filteredItems) > r.View.MaxItems {
+		filteredItems = filteredItems[:r.View.MaxItems]
+		filteredIssues = filteredIssues[:0]
+		for _, it := range filteredItems {
+			filteredIssues = append(filteredIssues, it.(IssueItem).Issue)
+		}
+	}
+
+	view := r.View
+	m.recipeView = &view
 	m.updateSemanticIDs(filteredItems)
+	listItems := groupRecipeItems(filteredItems, r.View.GroupBy, m.isRecipeGroupCollapsed)
+	m.list.SetItems(listItems)
 	m.board.SetIssues(filteredIssues)
 	// Generate insights for graph view (for metric rankings and sorting)
 	recipeIns := m.analysis.GenerateInsights(len(filteredIssues))
 	m.graphView.SetIssues(filteredIssues, &recipeIns)
 
-	// Update filter indicator
+	// Update filter indicator and list layout
 	m.currentFilter = "recipe:" + r.Name
+	m.updateListDelegate()
 
 	// Keep selection in bounds
-	if len(filteredItems) > 0 && m.list.Index() >= len(filteredItems) {
+	if len(listItems) > 0 && m.list.Index() >= len(listItems) {
 		m.list.Select(0)
 	}
 	m.updateViewportContent()
 }
 
+// countOpenBlockers returns how many non-closed issues block issue.
+func (m *Model) countOpenBlockers(issue model.Issue) int {
+	count := 0
+	for _, dep := range issue.Dependencies {
+		if dep.Type != model.DepBlocks {
+			continue
+		}
+		if blocker, exists := m.issueMap[dep.DependsOnID]; exists && !blocker.Status.IsClosed() {
+			count++
+		}
+	}
+	return count
+}
+
 func (m *Model) updateViewportContent() {
 	selectedItem := m.list.SelectedItem()
 	if selectedItem == nil {
@@ -5292,6 +5399,15 @@ func (m *Model) updateViewportContent() {
 		return
 	}
 
+	if header, ok := selectedItem.(GroupHeaderItem); ok {
+		state := "expanded"
+		if header.Collapsed {
+			state = "collapsed"
+		}
+		m.viewport.SetContent(fmt.Sprintf("%s\n\n%d issues (%s) • press enter to toggle", header.Label, header.Count, state))
+		return
+	}
+
 	// Safe type assertion
 	issueItem, ok := selectedItem.(IssueItem)
 	if !ok {
@@ -5550,20 +5666,7 @@ func truncateString(s string, maxLen int) string {
 
 // GetTypeIconMD returns the emoji icon for an issue type (for markdown)
 func GetTypeIconMD(t string) string {
-	switch t {
-	case "bug":
-		return "🐛"
-	case "feature":
-		return "✨"
-	case "task":
-		return "📋"
-	case "epic":
-		return "🚀" // Use rocket instead of mountain - VS-16 variation selector causes width issues
-	case "chore":
-		return "🧹"
-	default:
-		return "•"
-	}
+	return model.IssueType(t).Icon()
 }
 
 // SetFilter sets the current filter and applies it (exposed for testing)
@@ -5598,10 +5701,99 @@ func (m *Model) EnableWorkspaceMode(info WorkspaceInfo) {
 		}
 	}
 
+	m.graphView.SetDanglingRefs(info.DanglingRefs)
+
 	// Update delegate to show repo badges
 	m.updateListDelegate()
 }
 
+// EnableWorkspaceWatch starts live reload for workspace mode: every loaded
+// repo's beads file is watched, and when one changes only that repo is
+// reloaded through loader before the analysis is refreshed. filter, if
+// non-nil, is applied to the merged issues after each reload.
+func (m *Model) EnableWorkspaceWatch(loader *workspace.AggregateLoader, filter func([]model.Issue) []model.Issue) error {
+	files := loader.BeadsFiles()
+	if len(files) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	w, err := watcher.NewMultiWatcher(paths,
+		watcher.WithDebounceDuration(200*time.Millisecond),
+	)
+	if err != nil {
+		return err
+	}
+	if err := w.Start(); err != nil {
+		return err
+	}
+
+	// Key by the watcher's absolute paths so changed paths can be looked up
+	m.workspaceFiles = make(map[string]string, len(files))
+	for i, abs := range w.Paths() {
+		m.workspaceFiles[abs] = files[paths[i]]
+	}
+	m.workspaceLoader = loader
+	m.workspaceWatcher = w
+	m.workspaceFilter = filter
+	return nil
+}
+
+// reloadWorkspaceRepos reloads the repos owning the changed beads files and
+// refreshes the view. The status bar names the repos that were reloaded.
+func (m *Model) reloadWorkspaceRepos(paths []string) []tea.Cmd {
+	if m.workspaceLoader == nil {
+		return nil
+	}
+
+	var merged []model.Issue
+	var reloaded, failed []string
+	for _, path := range paths {
+		name, ok := m.workspaceFiles[path]
+		if !ok {
+			continue
+		}
+		issues, result, err := m.workspaceLoader.ReloadRepo(context.Background(), name)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		merged = issues
+		reloaded = append(reloaded, fmt.Sprintf("%s (%d issues)", name, len(result.Issues)))
+	}
+
+	if len(reloaded) == 0 {
+		if len(failed) > 0 {
+			m.statusMsg = "Reload error in " + strings.Join(failed, "; ")
+			m.statusIsError = true
+		}
+		return nil
+	}
+
+	if m.workspaceFilter != nil {
+		merged = m.workspaceFilter(merged)
+	}
+	cacheHit, cmds := m.replaceIssues(merged)
+	m.graphView.SetDanglingRefs(m.workspaceLoader.DanglingRefs())
+
+	m.statusMsg = "Reloaded " + strings.Join(reloaded, ", ")
+	if cacheHit {
+		m.statusMsg += " (cached)"
+	}
+	m.statusIsError = false
+	if len(failed) > 0 {
+		m.statusMsg += "; reload error in " + strings.Join(failed, "; ")
+		m.statusIsError = true
+	}
+	m.updateViewportContent()
+
+	return append(cmds, WaitForPhase2Cmd(m.analysis))
+}
+
 // IsWorkspaceMode returns whether workspace mode is active
 func (m Model) IsWorkspaceMode() bool {
 	return m.workspaceMode
@@ -6179,6 +6371,167 @@ func (m *Model) Stop() {
 	if m.watcher != nil {
 		m.watcher.Stop()
 	}
+	if m.workspaceWatcher != nil {
+		m.workspaceWatcher.Stop()
+	}
+}
+
+// replaceIssues swaps in a freshly loaded issue set and recomputes everything
+// derived from it: analysis (served from the analysis cache when the data is
+// unchanged), counts, alerts, list items and sub-views. The selection is kept
+// when the selected issue still exists. It reports whether the analysis was a
+// cache hit and returns follow-up commands to run.
+func (m *Model) replaceIssues(newIssues []model.Issue) (bool, []tea.Cmd) {
+	var cmds []tea.Cmd
+
+	// Clear ephemeral overlays tied to old data
+	m.clearAttentionOverlay()
+
+	// Exit time-travel mode if active (file changed, show current state)
+	if m.timeTravelMode {
+		m.timeTravelMode = false
+		m.timeTravelDiff = nil
+		m.timeTravelSince = ""
+		m.newIssueIDs = nil
+		m.closedIssueIDs = nil
+		m.modifiedIssueIDs = nil
+	}
+
+	// Store selected issue ID to restore position after reload
+	var selectedID string
+	if sel := m.list.SelectedItem(); sel != nil {
+		if item, ok := sel.(IssueItem); ok {
+			selectedID = item.Issue.ID
+		}
+	}
+
+	// Apply default sorting (Open first, Priority, Date)
+	sort.Slice(newIssues, func(i, j int) bool {
+		iClosed := newIssues[i].Status.IsClosed()
+		jClosed := newIssues[j].Status.IsClosed()
+		if iClosed != jClosed {
+			return !iClosed
+		}
+		if newIssues[i].Priority != newIssues[j].Priority {
+			return newIssues[i].Priority < newIssues[j].Priority
+		}
+		return newIssues[i].CreatedAt.After(newIssues[j].CreatedAt)
+	})
+
+	// Recompute analysis (async Phase 1/Phase 2) with caching
+	m.issues = newIssues
+	cachedAnalyzer := analysis.NewCachedAnalyzer(newIssues, nil)
+	m.analyzer = cachedAnalyzer.Analyzer
+	m.analysis = cachedAnalyzer.AnalyzeAsync(context.Background())
+	cacheHit := cachedAnalyzer.WasCacheHit()
+	m.labelHealthCached = false
+	m.attentionCached = false
+
+	// Rebuild lookup map
+	m.issueMap = make(map[string]*model.Issue, len(newIssues))
+	for i := range m.issues {
+		m.issueMap[m.issues[i].ID] = &m.issues[i]
+	}
+
+	// Clear stale priority hints (will be repopulated after Phase 2)
+	m.priorityHints = make(map[string]*analysis.PriorityRecommendation)
+
+	// Recompute stats
+	m.countOpen, m.countReady, m.countBlocked, m.countClosed = 0, 0, 0, 0
+	for i := range m.issues {
+		issue := &m.issues[i]
+		if issue.Status.IsClosed() {
+			m.countClosed++
+			continue
+		}
+		m.countOpen++
+		if issue.Status == model.StatusBlocked {
+			m.countBlocked++
+			continue
+		}
+		isBlocked := false
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if blocker, exists := m.issueMap[dep.DependsOnID]; exists && !blocker.Status.IsClosed() {
+				isBlocked = true
+				break
+			}
+		}
+		if !isBlocked {
+			m.countReady++
+		}
+	}
+
+	// Recompute alerts for refreshed dataset
+	m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
+	m.dismissedAlerts = make(map[string]bool)
+	m.showAlertsPanel = false
+
+	// Rebuild list items
+	items := make([]list.Item, len(m.issues))
+	for i := range m.issues {
+		items[i] = IssueItem{
+			Issue:      m.issues[i],
+			GraphScore: m.analysis.GetPageRankScore(m.issues[i].ID),
+			Impact:     m.analysis.GetCriticalPathScore(m.issues[i].ID),
+			RepoPrefix: ExtractRepoPrefix(m.issues[i].ID),
+		}
+	}
+	m.updateSemanticIDs(items)
+	m.clearSemanticScores()
+	if m.semanticSearch != nil {
+		m.semanticSearch.ResetCache()
+		m.semanticSearch.SetMetricsCache(nil)
+	}
+	m.semanticHybridReady = false
+	m.semanticHybridBuilding = false
+	if m.semanticHybridEnabled {
+		m.semanticHybridBuilding = true
+		cmds = append(cmds, BuildHybridMetricsCmd(m.issues))
+	}
+	m.list.SetItems(items)
+
+	// Restore selection position
+	if selectedID != "" {
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
+				m.list.Select(i)
+				break
+			}
+		}
+	}
+
+	// Regenerate sub-views (with Phase 1 data; Phase 2 will update via Phase2ReadyMsg)
+	ins := m.analysis.GenerateInsights(len(m.issues))
+	m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
+	bodyHeight := m.height - 1
+	if bodyHeight < 5 {
+		bodyHeight = 5
+	}
+	m.insightsPanel.SetSize(m.width, bodyHeight)
+	m.graphView.SetIssues(m.issues, &ins)
+
+	// Generate priority recommendations now that Phase 2 is ready
+	m.board = NewBoardModel(m.issues, m.theme)
+
+	// Re-apply recipe filter if active
+	if m.activeRecipe != nil {
+		m.applyRecipe(m.activeRecipe)
+	}
+
+	// Keep semantic index current when enabled.
+	if m.semanticSearchEnabled && !m.semanticIndexBuilding && m.semanticSearch != nil {
+		m.semanticIndexBuilding = true
+		cmds = append(cmds, BuildSemanticIndexCmd(m.issues, m.semanticSearch.Embedder()))
+	}
+
+	// Invalidate label-derived caches
+	m.labelHealthCached = false
+	m.labelDrilldownCache = make(map[string][]model.Issue)
+
+	return cacheHit, cmds
 }
 
 // clearAttentionOverlay hides the attention overlay and clears its rendered text.
@@ -6208,7 +6561,7 @@ func computeAlerts(issues []model.Issue, stats *analysis.GraphStats, analyzer *a
 
 	openCount, closedCount, blockedCount := 0, 0, 0
 	for _, issue := range issues {
-		switch issue.Status {
+		switch issue.Status.Base() {
 		case model.StatusClosed:
 			closedCount++
 		case model.StatusBlocked:
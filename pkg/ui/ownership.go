@@ -0,0 +1,253 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ownershipDetailRows is the height reserved below the table for the
+// selected area's suggestions and unowned beads
+const ownershipDetailRows = 7
+
+// OwnershipModel renders a table of directory owners with the open work in
+// the selected area
+type OwnershipModel struct {
+	report       *correlation.OwnershipReport
+	cursor       int
+	scrollOffset int // Index of the first visible row
+	width        int
+	height       int
+	theme        Theme
+}
+
+func NewOwnershipModel(theme Theme) OwnershipModel {
+	return OwnershipModel{theme: theme}
+}
+
+func (m *OwnershipModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *OwnershipModel) SetData(report *correlation.OwnershipReport) {
+	m.report = report
+	if m.cursor >= len(m.areas()) {
+		m.cursor = len(m.areas()) - 1
+		if m.cursor < 0 {
+			m.cursor = 0
+		}
+	}
+	if m.scrollOffset > m.cursor {
+		m.scrollOffset = m.cursor
+	}
+}
+
+// SelectedArea returns the area under the cursor, or nil if there are none
+func (m *OwnershipModel) SelectedArea() *correlation.OwnershipArea {
+	areas := m.areas()
+	if m.cursor < 0 || m.cursor >= len(areas) {
+		return nil
+	}
+	return &areas[m.cursor]
+}
+
+func (m *OwnershipModel) areas() []correlation.OwnershipArea {
+	if m.report == nil {
+		return nil
+	}
+	return m.report.Areas
+}
+
+// visibleRows is the number of table rows that fit above the details
+func (m *OwnershipModel) visibleRows() int {
+	rows := m.height - 2 - ownershipDetailRows
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// Update handles navigation keys
+func (m *OwnershipModel) Update(msg tea.KeyMsg) {
+	areas := m.areas()
+	visibleRows := m.visibleRows()
+
+	switch msg.String() {
+	case "j", "down":
+		if m.cursor < len(areas)-1 {
+			m.cursor++
+			if m.cursor >= m.scrollOffset+visibleRows {
+				m.scrollOffset = m.cursor - visibleRows + 1
+			}
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+			if m.cursor < m.scrollOffset {
+				m.scrollOffset = m.cursor
+			}
+		}
+	case "home":
+		m.cursor = 0
+		m.scrollOffset = 0
+	case "G", "end":
+		if len(areas) > 0 {
+			m.cursor = len(areas) - 1
+			if len(areas) > visibleRows {
+				m.scrollOffset = len(areas) - visibleRows
+			} else {
+				m.scrollOffset = 0
+			}
+		}
+	}
+}
+
+func (m OwnershipModel) View() string {
+	areas := m.areas()
+	if len(areas) == 0 {
+		return "No correlated commits to derive ownership from"
+	}
+
+	widths := m.columnWidths()
+	var lines []string
+	lines = append(lines, m.renderRow([]string{"", "Area", "Owners", "Labels", "Commits", "Last touch"}, widths, true, false))
+
+	visibleRows := m.visibleRows()
+	end := m.scrollOffset + visibleRows
+	if end > len(areas) {
+		end = len(areas)
+	}
+	for i := m.scrollOffset; i < end; i++ {
+		lines = append(lines, m.renderRow(m.rowCells(areas[i]), widths, false, i == m.cursor))
+	}
+	for len(lines) < visibleRows+1 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, m.renderDetails()...)
+	return strings.Join(lines, "\n")
+}
+
+// rowCells returns the cells of an area row; areas without a recent owner
+// are flagged with "!"
+func (m OwnershipModel) rowCells(area correlation.OwnershipArea) []string {
+	flag := " "
+	if !area.HasRecentOwner {
+		flag = "!"
+	}
+
+	var owners []string
+	for _, owner := range area.Owners {
+		owners = append(owners, fmt.Sprintf("%s %.0f%%", owner.Name, owner.Share*100))
+	}
+	var labels []string
+	for _, label := range area.Labels {
+		labels = append(labels, fmt.Sprintf("%s(%d)", label.Label, label.Beads))
+	}
+
+	return []string{
+		flag,
+		area.Path,
+		strings.Join(owners, ", "),
+		strings.Join(labels, ", "),
+		fmt.Sprintf("%d", area.Commits),
+		relativeTime(area.LastTouch),
+	}
+}
+
+// columnWidths gives the flexible Owners and Labels columns what is left of
+// the width after the fixed columns
+func (m OwnershipModel) columnWidths() []int {
+	widths := []int{1, 20, 0, 0, 7, 10}
+	rest := m.width - 1 - 20 - 7 - 10 - 5 // 5 separating spaces
+	if rest < 20 {
+		rest = 20
+	}
+	widths[2] = rest * 3 / 5
+	widths[3] = rest - widths[2]
+	return widths
+}
+
+func (m OwnershipModel) renderRow(cells []string, widths []int, header bool, selected bool) string {
+	var parts []string
+	for i, cell := range cells {
+		parts = append(parts, padRight(truncate(cell, widths[i]), widths[i]))
+	}
+	row := strings.Join(parts, " ")
+	if header {
+		return m.theme.Header.Render(row)
+	}
+	if selected {
+		return m.theme.Selected.Render(row)
+	}
+	if cells[0] == "!" {
+		return m.theme.Base.Foreground(m.theme.Blocked).Render(row)
+	}
+	return m.theme.Base.Render(row)
+}
+
+// renderDetails lists assignee suggestions and unowned open beads for the
+// selected area
+func (m OwnershipModel) renderDetails() []string {
+	area := m.SelectedArea()
+	if area == nil {
+		return nil
+	}
+	t := m.theme
+	headerStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Muted)
+
+	var items []string
+	for _, s := range m.report.Suggestions {
+		if slices.Contains(s.Areas, area.Path) {
+			items = append(items, fmt.Sprintf("→ %s: assign %s (%.0f%%) - %s", s.BeadID, s.Assignee, s.Confidence*100, s.Title))
+		}
+	}
+	for _, u := range m.report.Unowned {
+		if slices.Contains(u.Areas, area.Path) {
+			items = append(items, fmt.Sprintf("! %s: no recent owner - %s", u.BeadID, u.Title))
+		}
+	}
+
+	sepWidth := m.width
+	if sepWidth < 1 {
+		sepWidth = 1
+	}
+	lines := []string{
+		strings.Repeat("─", sepWidth),
+		headerStyle.Render(fmt.Sprintf("OPEN WORK IN %s", area.Path)) +
+			mutedStyle.Render(fmt.Sprintf("  %d areas, %d without a recent owner (%dd)",
+				m.report.Stats.TotalAreas, m.report.Stats.AreasWithoutOwner, m.report.StaleAfterDays)),
+	}
+	if len(items) == 0 {
+		lines = append(lines, mutedStyle.Render("No open beads to assign or flag in this area"))
+	}
+	for _, item := range items {
+		if len(lines) >= ownershipDetailRows {
+			break
+		}
+		lines = append(lines, truncate(item, m.width))
+	}
+	return lines
+}
+
+// ownershipBeads converts issues to the bead fields ownership analysis needs
+func ownershipBeads(issues []model.Issue) []correlation.OwnershipBead {
+	beads := make([]correlation.OwnershipBead, len(issues))
+	for i, issue := range issues {
+		beads[i] = correlation.OwnershipBead{
+			ID:          issue.ID,
+			Title:       issue.Title,
+			Description: issue.Description,
+			Assignee:    issue.Assignee,
+			Labels:      issue.Labels,
+			Closed:      issue.Status.IsClosed(),
+		}
+	}
+	return beads
+}
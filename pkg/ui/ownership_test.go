@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func createTestOwnershipReport() *correlation.OwnershipReport {
+	now := time.Now()
+	return &correlation.OwnershipReport{
+		StaleAfterDays: 90,
+		Areas: []correlation.OwnershipArea{
+			{
+				Path:           "pkg/auth",
+				Commits:        3,
+				Owners:         []correlation.AreaOwner{{Name: "Alice", Commits: 2, Share: 0.67}},
+				Labels:         []correlation.AreaLabel{{Label: "auth", Beads: 2}},
+				LastTouch:      now,
+				HasRecentOwner: true,
+			},
+			{Path: "pkg/legacy", Commits: 1, LastTouch: now.Add(-200 * 24 * time.Hour)},
+			{Path: "docs", Commits: 1, LastTouch: now, HasRecentOwner: true},
+		},
+		Suggestions: []correlation.AssigneeSuggestion{
+			{BeadID: "bv-10", Title: "Expire sessions", Assignee: "Alice", Areas: []string{"pkg/auth"}, Confidence: 0.67},
+		},
+		Unowned: []correlation.UnownedBead{
+			{BeadID: "bv-11", Title: "Delete legacy", Areas: []string{"pkg/legacy"}},
+		},
+		Stats: correlation.OwnershipStats{TotalAreas: 3, AreasWithoutOwner: 1},
+	}
+}
+
+func TestOwnershipModel_NavigationAndDetails(t *testing.T) {
+	m := NewOwnershipModel(testTheme())
+	m.SetSize(120, 20)
+	m.SetData(createTestOwnershipReport())
+
+	view := m.View()
+	if !strings.Contains(view, "Alice 67%") || !strings.Contains(view, "auth(2)") {
+		t.Errorf("View() should list owners and labels, got:\n%s", view)
+	}
+	if !strings.Contains(view, "bv-10: assign Alice") {
+		t.Error("View() should show the suggestion for the selected area")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if area := m.SelectedArea(); area == nil || area.Path != "pkg/legacy" {
+		t.Fatalf("after j: SelectedArea() = %+v, want pkg/legacy", area)
+	}
+	if view := m.View(); !strings.Contains(view, "bv-11: no recent owner") {
+		t.Error("View() should flag the unowned bead in pkg/legacy")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if area := m.SelectedArea(); area == nil || area.Path != "docs" {
+		t.Errorf("after G: SelectedArea() = %+v, want docs", area)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyHome})
+	if m.cursor != 0 {
+		t.Errorf("after home: cursor = %d, want 0", m.cursor)
+	}
+}
+
+func TestOwnershipModel_Empty(t *testing.T) {
+	m := NewOwnershipModel(testTheme())
+	if m.SelectedArea() != nil {
+		t.Error("SelectedArea() without data should be nil")
+	}
+	if view := m.View(); !strings.Contains(view, "No correlated commits") {
+		t.Errorf("View() without data = %q", view)
+	}
+}
+
+func TestHistoryModel_ToggleOwnership(t *testing.T) {
+	h := NewHistoryModel(createTestHistoryReport(), testTheme())
+	h.SetSize(120, 30)
+
+	beads := ownershipBeads([]model.Issue{
+		{ID: "bv-1", Title: "Fix authentication bug", Status: model.StatusClosed, Labels: []string{"auth"}},
+		{ID: "bv-3", Title: "Refactor database", Status: model.StatusInProgress},
+	})
+	if !beads[0].Closed || beads[1].Closed || beads[0].Labels[0] != "auth" {
+		t.Fatalf("ownershipBeads() = %+v", beads)
+	}
+
+	h.ToggleOwnership(beads)
+	if !h.IsOwnershipVisible() {
+		t.Fatal("ToggleOwnership() should show the panel")
+	}
+	if view := h.View(); !strings.Contains(view, "Ownership") {
+		t.Error("View() should show the ownership panel")
+	}
+
+	h.ToggleOwnership(nil)
+	if h.IsOwnershipVisible() {
+		t.Error("second ToggleOwnership() should hide the panel")
+	}
+}
@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Recipe view rendering: configurable list columns and collapsible group
+// headers driven by recipe.ViewConfig (columns, group_by, collapsed,
+// max_items, truncate_title).
+
+// GroupHeaderItem is a non-issue list row heading a recipe group.
+// It has an empty FilterValue so fuzzy filtering only matches issues.
+type GroupHeaderItem struct {
+	Key       string // Stable group key, e.g. "status:open"
+	Label     string // Display label, e.g. "Status: open"
+	Count     int    // Issues in the group (including hidden ones)
+	Collapsed bool
+}
+
+func (g GroupHeaderItem) FilterValue() string { return "" }
+
+// recipeGroupKey returns the group key and label for an issue under groupBy.
+// ok is false when groupBy is not a supported grouping.
+func recipeGroupKey(issue model.Issue, groupBy string) (key, label string, ok bool) {
+	switch strings.ToLower(groupBy) {
+	case "status":
+		s := string(issue.Status)
+		return "status:" + s, "Status: " + s, true
+	case "priority":
+		return fmt.Sprintf("priority:%d", issue.Priority), fmt.Sprintf("Priority: P%d", issue.Priority), true
+	case "tag", "tags", "label", "labels":
+		if len(issue.Labels) == 0 {
+			return "tag:", "Tag: (none)", true
+		}
+		labels := append([]string(nil), issue.Labels...)
+		sort.Strings(labels)
+		return "tag:" + labels[0], "Tag: " + labels[0], true
+	case "type":
+		t := string(issue.IssueType)
+		return "type:" + t, "Type: " + t, true
+	case "assignee":
+		if issue.Assignee == "" {
+			return "assignee:", "Assignee: (unassigned)", true
+		}
+		return "assignee:" + issue.Assignee, "Assignee: @" + issue.Assignee, true
+	default:
+		return "", "", false
+	}
+}
+
+// recipeGroupRank orders groups; lower ranks come first.
+func recipeGroupRank(issue model.Issue, groupBy string) int {
+	switch strings.ToLower(groupBy) {
+	case "status":
+		switch issue.Status.Base() {
+		case model.StatusInProgress:
+			return 0
+		case model.StatusOpen:
+			return 1
+		case model.StatusBlocked:
+			return 2
+		case model.StatusClosed:
+			return 4
+		case model.StatusTombstone:
+			return 5
+		default:
+			return 3
+		}
+	case "priority":
+		return issue.Priority
+	}
+	return 0
+}
+
+// groupRecipeItems inserts GroupHeaderItems into items according to groupBy,
+// preserving the incoming (sorted) order within each group. Items in collapsed
+// groups are omitted. collapsed reports whether a group key is collapsed.
+// Unsupported or empty groupBy values return items unchanged.
+func groupRecipeItems(items []list.Item, groupBy string, collapsed func(key string) bool) []list.Item {
+	if groupBy == "" || strings.EqualFold(groupBy, "none") {
+		return items
+	}
+
+	type group struct {
+		header  GroupHeaderItem
+		rank    int
+		members []list.Item
+	}
+	var groups []*group
+	byKey := make(map[string]*group)
+
+	for _, it := range items {
+		issueItem, ok := it.(IssueItem)
+		if !ok {
+			continue
+		}
+		key, label, ok := recipeGroupKey(issueItem.Issue, groupBy)
+		if !ok {
+			return items
+		}
+		g, exists := byKey[key]
+		if !exists {
+			g = &group{
+				header: GroupHeaderItem{Key: key, Label: label},
+				rank:   recipeGroupRank(issueItem.Issue, groupBy),
+			}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.members = append(g.members, it)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].rank != groups[j].rank {
+			return groups[i].rank < groups[j].rank
+		}
+		return groups[i].header.Label < groups[j].header.Label
+	})
+
+	out := make([]list.Item, 0, len(items)+len(groups))
+	for _, g := range groups {
+		g.header.Count = len(g.members)
+		g.header.Collapsed = collapsed != nil && collapsed(g.header.Key)
+		out = append(out, g.header)
+		if !g.header.Collapsed {
+			out = append(out, g.members...)
+		}
+	}
+	return out
+}
+
+// renderGroupHeader draws a group header row.
+func (d IssueDelegate) renderGroupHeader(w io.Writer, m list.Model, index int, g GroupHeaderItem) {
+	t := d.Theme
+	width := m.Width()
+	if width <= 0 {
+		width = 80
+	}
+	width = width - 1
+
+	arrow := "▾"
+	if g.Collapsed {
+		arrow = "▸"
+	}
+	text := fmt.Sprintf("%s %s (%d)", arrow, g.Label, g.Count)
+	text = truncateRunesHelper(text, width, "…")
+
+	style := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true).Width(width).MaxWidth(width)
+	if index == m.Index() {
+		style = style.Foreground(t.Primary).Background(t.Highlight)
+	}
+	fmt.Fprint(w, style.Render(text))
+}
+
+// recipeColumnWidths are fixed widths for non-title recipe columns so rows align.
+var recipeColumnWidths = map[string]int{
+	"id":            14,
+	"status":        6,
+	"priority":      4,
+	"created":       8,
+	"updated":       8,
+	"tags":          20,
+	"labels":        20,
+	"blockers":      4,
+	"assignee":      13,
+	"type":          8,
+	"comments":      4,
+	"pagerank":      6,
+	"betweenness":   6,
+	"impact":        6,
+	"critical_path": 6,
+	"triage":        5,
+	"unblocks":      4,
+}
+
+// renderColumns draws an issue row using the recipe's configured columns.
+// The title column (wherever it appears) takes the remaining width.
+func (d IssueDelegate) renderColumns(w io.Writer, m list.Model, index int, i IssueItem) {
+	t := d.Theme
+	width := m.Width()
+	if width <= 0 {
+		width = 80
+	}
+	width = width - 1
+	isSelected := index == m.Index()
+
+	icon, iconColor := t.GetTypeIcon(string(i.Issue.IssueType))
+	prefix := "  "
+	if isSelected {
+		prefix = t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render("▸ ")
+	}
+	prefix += t.Renderer.NewStyle().Foreground(iconColor).Render(icon) + " "
+
+	cells := make([]string, len(d.Columns))
+	titleIdx := -1
+	used := lipgloss.Width(prefix)
+	for idx, col := range d.Columns {
+		col = strings.ToLower(col)
+		if col == "title" {
+			titleIdx = idx
+			continue
+		}
+		cell, ok := d.renderColumnCell(col, i, isSelected)
+		if !ok {
+			continue
+		}
+		cells[idx] = cell
+		used += lipgloss.Width(cell) + 1
+	}
+
+	if titleIdx >= 0 {
+		titleWidth := width - used - 1
+		if titleWidth < 5 {
+			titleWidth = 5
+		}
+		if d.TruncateTitle > 0 && d.TruncateTitle < titleWidth {
+			titleWidth = d.TruncateTitle
+		}
+		title := truncateRunesHelper(i.Issue.Title, titleWidth, "…")
+		if pad := titleWidth - lipgloss.Width(title); pad > 0 {
+			title += strings.Repeat(" ", pad)
+		}
+		titleStyle := t.Renderer.NewStyle()
+		if isSelected {
+			titleStyle = titleStyle.Foreground(t.Primary).Bold(true)
+		} else {
+			titleStyle = titleStyle.Foreground(lipgloss.AdaptiveColor{Light: "#333333", Dark: "#E8E8E8"})
+		}
+		cells[titleIdx] = titleStyle.Render(title)
+	}
+
+	parts := make([]string, 0, len(cells))
+	for _, c := range cells {
+		if c != "" {
+			parts = append(parts, c)
+		}
+	}
+	row := prefix + strings.Join(parts, " ")
+
+	rowStyle := t.Renderer.NewStyle().Width(width).MaxWidth(width)
+	if isSelected {
+		row = rowStyle.Background(t.Highlight).Render(row)
+	} else {
+		row = rowStyle.Render(row)
+	}
+	fmt.Fprint(w, row)
+}
+
+// renderColumnCell renders one fixed-width recipe column. ok is false for
+// unknown column names, which are skipped.
+func (d IssueDelegate) renderColumnCell(col string, i IssueItem, isSelected bool) (string, bool) {
+	t := d.Theme
+	colWidth, known := recipeColumnWidths[col]
+	if !known {
+		return "", false
+	}
+	pad := func(s string) string {
+		s = truncateRunesHelper(s, colWidth, "…")
+		if n := colWidth - lipgloss.Width(s); n > 0 {
+			s += strings.Repeat(" ", n)
+		}
+		return s
+	}
+	muted := t.Renderer.NewStyle().Foreground(ColorMuted)
+	metric := func(v float64) string {
+		return t.Renderer.NewStyle().Foreground(GetHeatmapColor(v, t)).Render(pad(fmt.Sprintf("%.3f", v)))
+	}
+
+	switch col {
+	case "id":
+		style := t.Renderer.NewStyle().Foreground(t.Secondary)
+		if isSelected {
+			style = style.Bold(true)
+		}
+		return style.Render(pad(i.Issue.ID)), true
+	case "status":
+		return RenderStatusBadge(string(i.Issue.Status)), true
+	case "priority":
+		return RenderPriorityBadge(i.Issue.Priority), true
+	case "created":
+		return muted.Render(pad(FormatTimeRel(i.Issue.CreatedAt))), true
+	case "updated":
+		return muted.Render(pad(FormatTimeRel(i.Issue.UpdatedAt))), true
+	case "tags", "labels":
+		return t.Renderer.NewStyle().Foreground(ColorPrimary).Render(pad(strings.Join(i.Issue.Labels, ","))), true
+	case "blockers":
+		if i.OpenBlockers == 0 {
+			return pad(""), true
+		}
+		return t.Renderer.NewStyle().Foreground(ColorStatusBlocked).Render(pad(fmt.Sprintf("⛔%d", i.OpenBlockers))), true
+	case "unblocks":
+		if i.UnblocksCount == 0 {
+			return pad(""), true
+		}
+		return t.Renderer.NewStyle().Foreground(ColorInfo).Render(pad(fmt.Sprintf("↪%d", i.UnblocksCount))), true
+	case "assignee":
+		if i.Issue.Assignee == "" {
+			return pad(""), true
+		}
+		return t.Renderer.NewStyle().Foreground(ColorSecondary).Render(pad("@" + i.Issue.Assignee)), true
+	case "type":
+		return muted.Render(pad(string(i.Issue.IssueType))), true
+	case "comments":
+		if len(i.Issue.Comments) == 0 {
+			return pad(""), true
+		}
+		return t.Renderer.NewStyle().Foreground(ColorInfo).Render(pad(fmt.Sprintf("💬%d", len(i.Issue.Comments)))), true
+	case "pagerank":
+		return metric(i.GraphScore), true
+	case "betweenness":
+		return metric(i.Betweenness), true
+	case "impact", "critical_path":
+		return metric(i.Impact), true
+	case "triage":
+		return metric(i.TriageScore), true
+	}
+	return "", false
+}
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestGroupRecipeItems_StatusOrderAndCollapse(t *testing.T) {
+	items := []list.Item{
+		IssueItem{Issue: model.Issue{ID: "a", Status: model.StatusClosed}},
+		IssueItem{Issue: model.Issue{ID: "b", Status: model.StatusOpen}},
+		IssueItem{Issue: model.Issue{ID: "c", Status: model.StatusInProgress}},
+		IssueItem{Issue: model.Issue{ID: "d", Status: model.StatusOpen}},
+	}
+
+	out := groupRecipeItems(items, "status", func(key string) bool { return key == "status:closed" })
+
+	var got []string
+	for _, it := range out {
+		switch v := it.(type) {
+		case GroupHeaderItem:
+			got = append(got, "#"+v.Key)
+		case IssueItem:
+			got = append(got, v.Issue.ID)
+		}
+	}
+	want := "#status:in_progress c #status:open b d #status:closed"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("grouped order = %q, want %q", strings.Join(got, " "), want)
+	}
+
+	last := out[len(out)-1].(GroupHeaderItem)
+	if !last.Collapsed || last.Count != 1 {
+		t.Fatalf("closed header = %+v, want collapsed with count 1", last)
+	}
+}
+
+func TestGroupRecipeItems_NoneAndUnknownPassThrough(t *testing.T) {
+	items := []list.Item{IssueItem{Issue: model.Issue{ID: "a"}}}
+	for _, groupBy := range []string{"", "none", "bogus"} {
+		if out := groupRecipeItems(items, groupBy, nil); len(out) != 1 {
+			t.Errorf("groupBy %q: expected items unchanged, got %d", groupBy, len(out))
+		}
+	}
+}
+
+func TestGroupRecipeItems_TagUsesFirstSortedLabel(t *testing.T) {
+	items := []list.Item{
+		IssueItem{Issue: model.Issue{ID: "a", Labels: []string{"ui", "api"}}},
+		IssueItem{Issue: model.Issue{ID: "b"}},
+	}
+	out := groupRecipeItems(items, "tag", nil)
+	if len(out) != 4 {
+		t.Fatalf("expected 2 headers + 2 issues, got %d", len(out))
+	}
+	if h := out[0].(GroupHeaderItem); h.Key != "tag:" {
+		t.Errorf("first group = %q, want untagged group", h.Key)
+	}
+	if h := out[2].(GroupHeaderItem); h.Key != "tag:api" {
+		t.Errorf("second group = %q, want tag:api", h.Key)
+	}
+}
+
+func TestIssueDelegate_RenderColumns(t *testing.T) {
+	item := newTestIssueItem("bv-42")
+	item.GraphScore = 0.125
+	item.OpenBlockers = 2
+	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	delegate := IssueDelegate{Theme: theme, Columns: []string{"id", "title", "pagerank", "blockers", "nonsense"}}
+
+	l := list.New([]list.Item{item}, delegate, 0, 0)
+	l.SetWidth(100)
+
+	var buf bytes.Buffer
+	delegate.Render(&buf, l, 0, item)
+	out := buf.String()
+
+	for _, want := range []string{"bv-42", "Short title", "0.125", "⛔2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("columns row missing %q: %q", want, out)
+		}
+	}
+	// Default-layout extras must not appear in columns mode.
+	if strings.Contains(out, "@alice") {
+		t.Errorf("columns row should not include assignee: %q", out)
+	}
+}
+
+func TestIssueDelegate_RenderColumnsTruncateTitle(t *testing.T) {
+	item := newTestIssueItem("bv-1")
+	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	delegate := IssueDelegate{Theme: theme, Columns: []string{"title"}, TruncateTitle: 6}
+
+	l := list.New([]list.Item{item}, delegate, 0, 0)
+	l.SetWidth(100)
+
+	var buf bytes.Buffer
+	delegate.Render(&buf, l, 0, item)
+	if out := buf.String(); strings.Contains(out, "Short title") || !strings.Contains(out, "Short…") {
+		t.Errorf("expected title truncated to 6 cells: %q", out)
+	}
+}
+
+func TestIssueDelegate_RenderGroupHeader(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	delegate := IssueDelegate{Theme: theme}
+	header := GroupHeaderItem{Key: "status:open", Label: "Status: open", Count: 3, Collapsed: true}
+
+	l := list.New([]list.Item{header}, delegate, 0, 0)
+	l.SetWidth(80)
+
+	var buf bytes.Buffer
+	delegate.Render(&buf, l, 0, header)
+	if out := buf.String(); !strings.Contains(out, "▸ Status: open (3)") {
+		t.Errorf("unexpected header render: %q", out)
+	}
+}
+
+func TestApplyRecipe_GroupByToggleAndMaxItems(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "p1a", Status: model.StatusOpen, Priority: 1},
+		{ID: "p1b", Status: model.StatusOpen, Priority: 1},
+		{ID: "p2", Status: model.StatusOpen, Priority: 2},
+		{ID: "p3", Status: model.StatusOpen, Priority: 3},
+	}
+	m := NewModel(issues, nil, "")
+
+	r := &recipe.Recipe{
+		Name: "grouped",
+		Sort: recipe.SortConfig{Field: "priority"},
+		View: recipe.ViewConfig{
+			Columns:   []string{"id", "title", "priority"},
+			GroupBy:   "priority",
+			Collapsed: true,
+			MaxItems:  3,
+		},
+	}
+	m.selectRecipe(r)
+
+	// All groups start collapsed: only headers are listed, and max_items dropped p3.
+	items := m.list.Items()
+	if len(items) != 2 {
+		t.Fatalf("expected 2 collapsed headers, got %d items", len(items))
+	}
+	if len(m.FilteredIssues()) != 0 {
+		t.Fatalf("collapsed groups should hide issues")
+	}
+
+	// Expand the P1 group with enter.
+	m.list.Select(0)
+	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := len(m.FilteredIssues()); got != 2 {
+		t.Fatalf("expected 2 issues after expanding P1, got %d", got)
+	}
+	if _, ok := m.list.SelectedItem().(GroupHeaderItem); !ok {
+		t.Fatalf("expected header to stay selected after toggle")
+	}
+
+	if len(m.recipeColumns()) != 3 {
+		t.Fatalf("expected recipe columns on delegate, got %v", m.recipeColumns())
+	}
+
+	// Plain filters restore the default layout.
+	m.SetFilter("all")
+	if m.recipeColumns() != nil {
+		t.Fatalf("expected recipe columns cleared after plain filter")
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"sync/atomic"
@@ -221,14 +222,24 @@ func (s *SemanticSearch) Snapshot() semanticSearchSnapshot {
 	return v.(semanticSearchSnapshot)
 }
 
+// SetIndex installs a new index and embedder, closing the embedder it
+// replaces so its worker process does not outlive it.
 func (s *SemanticSearch) SetIndex(idx *search.VectorIndex, embedder search.Embedder) {
 	snap := s.Snapshot()
+	if snap.Embedder != nil && snap.Embedder != embedder {
+		closeEmbedder(snap.Embedder)
+	}
 	snap.Index = idx
 	snap.Embedder = embedder
 	snap.Ready = idx != nil && embedder != nil
 	s.snapshot.Store(snap)
 }
 
+// Embedder returns the embedder of the current index, or nil before one is built.
+func (s *SemanticSearch) Embedder() search.Embedder {
+	return s.Snapshot().Embedder
+}
+
 func (s *SemanticSearch) SetIDs(ids []string) {
 	snap := s.Snapshot()
 	cp := make([]string, len(ids))
@@ -476,37 +487,49 @@ func BuildHybridMetricsCmd(issues []model.Issue) tea.Cmd {
 	}
 }
 
-// BuildSemanticIndexCmd builds or updates the semantic index for the given issues.
-func BuildSemanticIndexCmd(issues []model.Issue) tea.Cmd {
+// BuildSemanticIndexCmd builds or updates the semantic index for the given
+// issues. It reuses embedder when non-nil; otherwise it creates one from the
+// environment and closes it again if the build fails.
+func BuildSemanticIndexCmd(issues []model.Issue, embedder search.Embedder) tea.Cmd {
 	return func() tea.Msg {
 		cfg := search.EmbeddingConfigFromEnv()
-		embedder, err := search.NewEmbedderFromConfig(cfg)
-		if err != nil {
+		owned := embedder == nil
+		if owned {
+			var err error
+			embedder, err = search.NewEmbedderFromConfig(cfg)
+			if err != nil {
+				return SemanticIndexReadyMsg{Error: err}
+			}
+		}
+		fail := func(err error) tea.Msg {
+			if owned {
+				closeEmbedder(embedder)
+			}
 			return SemanticIndexReadyMsg{Error: err}
 		}
 
 		projectDir, err := os.Getwd()
 		if err != nil {
-			return SemanticIndexReadyMsg{Error: err}
+			return fail(err)
 		}
 
 		indexPath := search.DefaultIndexPath(projectDir, cfg)
 		idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
 		if err != nil {
-			return SemanticIndexReadyMsg{Error: err}
+			return fail(err)
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), search.SyncTimeout(embedder.Provider()))
 		defer cancel()
 
 		docs := search.DocumentsFromIssues(issues)
 		stats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
 		if err != nil {
-			return SemanticIndexReadyMsg{Error: err}
+			return fail(err)
 		}
 		if !loaded || stats.Changed() {
 			if err := idx.Save(indexPath); err != nil {
-				return SemanticIndexReadyMsg{Error: fmt.Errorf("save semantic index: %w", err)}
+				return fail(fmt.Errorf("save semantic index: %w", err))
 			}
 		}
 
@@ -520,6 +543,14 @@ func BuildSemanticIndexCmd(issues []model.Issue) tea.Cmd {
 	}
 }
 
+// closeEmbedder stops embedders that hold resources, such as the python
+// worker process.
+func closeEmbedder(embedder search.Embedder) {
+	if c, ok := embedder.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
+
 func dotFloat32(a, b []float32) float64 {
 	if len(a) != len(b) || len(a) == 0 {
 		return 0
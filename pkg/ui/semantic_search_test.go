@@ -123,6 +123,37 @@ func TestSemanticSearchSetIndexNilEmbedder(t *testing.T) {
 	}
 }
 
+// closingEmbedder records whether Close was called
+type closingEmbedder struct {
+	mockEmbedder
+	closed bool
+}
+
+func (c *closingEmbedder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestSemanticSearchSetIndexClosesReplacedEmbedder(t *testing.T) {
+	ss := NewSemanticSearch()
+	first := &closingEmbedder{mockEmbedder: mockEmbedder{dim: 384}}
+	second := &closingEmbedder{mockEmbedder: mockEmbedder{dim: 384}}
+
+	ss.SetIndex(search.NewVectorIndex(384), first)
+	ss.SetIndex(search.NewVectorIndex(384), first)
+	if first.closed {
+		t.Fatal("re-installing the same embedder should not close it")
+	}
+
+	ss.SetIndex(search.NewVectorIndex(384), second)
+	if !first.closed {
+		t.Error("replaced embedder should be closed")
+	}
+	if second.closed || ss.Embedder() != second {
+		t.Error("new embedder should be installed and open")
+	}
+}
+
 func TestSemanticSearchSetIndexBothNil(t *testing.T) {
 	ss := NewSemanticSearch()
 
@@ -120,6 +120,7 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"hjkl", "Navigate"},
 				{"H/L", "Scroll ←/→"},
 				{"PgUp/Dn", "Scroll ↑/↓"},
+				{"+/-", "Add/remove dep"},
 				{"Enter", "Jump to issue"},
 			},
 		},
@@ -141,6 +142,8 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 			contexts: []string{"history"},
 			items: []shortcutItem{
 				{"v", "Git/Bead mode"},
+				{"Space", "Expand PR group"},
+				{"O", "Ownership"},
 				{"/", "Search"},
 				{"j/k", "Navigate ↓/↑"},
 				{"J/K", "Detail ↓/↑"},
@@ -159,6 +162,7 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"j/k", "Items ↓/↑"},
 				{"Tab", "Toggle detail"},
 				{"^j/^k", "Scroll detail"},
+				{"+/-", "Add/remove dep"},
 				{"Enter", "Full view"},
 			},
 		},
@@ -181,6 +185,7 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"x", "Export .md"},
 				{"C", "Copy"},
 				{"O", "Open in $EDITOR"},
+				{"e", "Edit issue"},
 				{"'", "Recipe picker"},
 				{"U", "Self-update"},
 				{"V", "Cass sessions"},
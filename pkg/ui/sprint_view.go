@@ -2,9 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -73,7 +76,13 @@ func (m Model) renderSprintDashboard() string {
 	}
 	sb.WriteString(labelStyle.Render("Remaining:"))
 	sb.WriteString(daysStyle.Render(fmt.Sprintf(" %d days", daysRemaining)))
-	sb.WriteString("\n\n")
+	sb.WriteString("\n")
+	if sprint.VelocityTarget > 0 {
+		sb.WriteString(labelStyle.Render("Target:   "))
+		sb.WriteString(valStyle.Render(fmt.Sprintf("%.0f beads", sprint.VelocityTarget)))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
 
 	// Compute bead stats
 	var totalBeads, closedBeads, openBeads, blockedBeads, inProgressBeads int
@@ -86,7 +95,7 @@ func (m Model) renderSprintDashboard() string {
 		if beadIDSet[iss.ID] {
 			totalBeads++
 			sprintIssues = append(sprintIssues, iss)
-			switch iss.Status {
+			switch iss.Status.Base() {
 			case model.StatusClosed:
 				closedBeads++
 			case model.StatusBlocked:
@@ -178,7 +187,7 @@ func (m Model) renderSprintDashboard() string {
 	const staleThresholdDays = 3
 	var atRisk []model.Issue
 	for _, iss := range sprintIssues {
-		if iss.Status == model.StatusInProgress {
+		if iss.Status.IsActive() {
 			daysSinceUpdate := int(now.Sub(iss.UpdatedAt).Hours() / 24)
 			if daysSinceUpdate >= staleThresholdDays {
 				atRisk = append(atRisk, iss)
@@ -210,7 +219,7 @@ func (m Model) renderSprintDashboard() string {
 		iss := sprintIssues[i]
 		statusIcon := "○"
 		statusStyle := valStyle
-		switch iss.Status {
+		switch iss.Status.Base() {
 		case model.StatusClosed:
 			statusIcon = "✓"
 			statusStyle = t.Renderer.NewStyle().Foreground(t.Open)
@@ -231,7 +240,8 @@ func (m Model) renderSprintDashboard() string {
 	// Footer
 	sb.WriteString("\n")
 	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Muted).Italic(true).Render(
-		"P: close sprint view • j/k: navigate sprints"))
+		"P: close sprint view • j/k: navigate sprints\n" +
+			"n: new sprint • c: close sprint • s: fill from plan • +/-: velocity target"))
 
 	// Wrap in a box
 	boxStyle := t.Renderer.NewStyle().
@@ -292,6 +302,210 @@ func (m Model) handleSprintKeys(msg tea.KeyMsg) Model {
 				}
 			}
 		}
+	case "n":
+		// New sprint following the latest one
+		next := nextSprint(m.sprints, time.Now())
+		m.saveSprints(next.ID, fmt.Sprintf("Created %s (%s → %s)", next.Name,
+			next.StartDate.Format("Jan 2"), next.EndDate.Format("Jan 2")), func(sprints []model.Sprint) []model.Sprint {
+			return append(sprints, next)
+		})
+	case "c":
+		if m.selectedSprint != nil {
+			id := m.selectedSprint.ID
+			m.saveSprints(id, fmt.Sprintf("Closed sprint %s", id), func(sprints []model.Sprint) []model.Sprint {
+				sprints[sprintIndex(sprints, id)].Close(time.Now())
+				return sprints
+			})
+		}
+	case "+", "=", "-":
+		if m.selectedSprint != nil {
+			id := m.selectedSprint.ID
+			delta := 1.0
+			if msg.String() == "-" {
+				delta = -1
+			}
+			target := max(0, m.selectedSprint.VelocityTarget+delta)
+			m.saveSprints(id, fmt.Sprintf("Velocity target for %s: %.0f beads", id, target), func(sprints []model.Sprint) []model.Sprint {
+				sprints[sprintIndex(sprints, id)].VelocityTarget = target
+				sprints[sprintIndex(sprints, id)].UpdatedAt = time.Now()
+				return sprints
+			})
+		}
+	case "s":
+		// Fill the sprint from the execution plan up to capacity
+		if m.selectedSprint != nil {
+			now := time.Now()
+			opts := analysis.SprintSuggestOptionsFor(m.selectedSprint, m.sprints, 1, now)
+			suggestion := analysis.SuggestSprint(m.issues, m.analysis, opts, now)
+			if len(suggestion.Items) == 0 {
+				m.statusMsg = fmt.Sprintf("Sprint %s is at capacity (%d/%d min planned)", m.selectedSprint.ID, suggestion.PlannedMinutes, suggestion.CapacityMinutes)
+				m.statusIsError = false
+				break
+			}
+			id := m.selectedSprint.ID
+			m.saveSprints(id, fmt.Sprintf("Added %d beads to %s from the plan (%d/%d min planned)", len(suggestion.Items), id, suggestion.PlannedMinutes, suggestion.CapacityMinutes),
+				func(sprints []model.Sprint) []model.Sprint {
+					sprints[sprintIndex(sprints, id)].AddBeads(suggestion.BeadIDs()...)
+					sprints[sprintIndex(sprints, id)].UpdatedAt = now
+					return sprints
+				})
+		}
 	}
 	return m
 }
+
+// enterSprintView opens the sprint dashboard on the active sprint, falling
+// back to the most recent one.
+func (m *Model) enterSprintView() {
+	m.clearAttentionOverlay()
+	m.isSprintView = true
+	m.isGraphView = false
+	m.isBoardView = false
+	m.isActionableView = false
+	m.isHistoryView = false
+	m.focused = focusSprint
+	if m.selectedSprint == nil {
+		m.selectedSprint = m.currentSprint()
+	}
+	m.sprintViewText = m.renderSprintDashboard()
+}
+
+// currentSprint returns the selected sprint, else the active one, else the
+// most recent one that has not ended.
+func (m *Model) currentSprint() *model.Sprint {
+	if m.selectedSprint != nil {
+		return m.selectedSprint
+	}
+	now := time.Now()
+	var latest *model.Sprint
+	for i := range m.sprints {
+		if m.sprints[i].IsActive() {
+			return &m.sprints[i]
+		}
+		if !m.sprints[i].IsEnded(now) {
+			latest = &m.sprints[i]
+		}
+	}
+	return latest
+}
+
+// toggleSelectedInSprint adds the selected issue to the current sprint, or
+// removes it with remove set.
+func (m *Model) toggleSelectedInSprint(remove bool) {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return
+	}
+	sprint := m.currentSprint()
+	if sprint == nil {
+		m.statusMsg = "No open sprint (press P, then n to create one)"
+		m.statusIsError = true
+		return
+	}
+	id, beadID := sprint.ID, item.Issue.ID
+	if remove != sprint.HasBead(beadID) {
+		verb := "already in"
+		if remove {
+			verb = "not in"
+		}
+		m.statusMsg = fmt.Sprintf("%s is %s %s", beadID, verb, id)
+		m.statusIsError = false
+		return
+	}
+	status := fmt.Sprintf("Added %s to %s", beadID, id)
+	if remove {
+		status = fmt.Sprintf("Removed %s from %s", beadID, id)
+	}
+	m.saveSprints(id, status, func(sprints []model.Sprint) []model.Sprint {
+		s := &sprints[sprintIndex(sprints, id)]
+		if remove {
+			s.RemoveBeads(beadID)
+		} else {
+			s.AddBeads(beadID)
+		}
+		s.UpdatedAt = time.Now()
+		return sprints
+	})
+}
+
+// saveSprints applies edit to a copy of the sprints, writes them next to the
+// beads file and selects the sprint with selectID. Failures are reported in
+// the status bar and leave the loaded sprints unchanged.
+func (m *Model) saveSprints(selectID, status string, edit func([]model.Sprint) []model.Sprint) {
+	if m.beadsPath == "" {
+		m.statusMsg = "⚠️ Sprint edits need a single .beads directory (not available in workspace mode)"
+		m.statusIsError = true
+		return
+	}
+
+	sprints := make([]model.Sprint, len(m.sprints))
+	for i, s := range m.sprints {
+		s.BeadIDs = append([]string(nil), s.BeadIDs...)
+		sprints[i] = s
+	}
+	sprints = edit(sprints)
+
+	path := filepath.Join(filepath.Dir(m.beadsPath), loader.SprintsFileName)
+	if err := loader.SaveSprintsToFile(path, sprints); err != nil {
+		m.statusMsg = fmt.Sprintf("⚠️ Saving sprints failed: %v", err)
+		m.statusIsError = true
+		return
+	}
+
+	m.sprints = sprints
+	m.selectedSprint = nil
+	if idx := sprintIndex(m.sprints, selectID); idx >= 0 {
+		m.selectedSprint = &m.sprints[idx]
+	}
+	if m.isSprintView {
+		m.sprintViewText = m.renderSprintDashboard()
+	}
+	m.statusMsg = status
+	m.statusIsError = false
+}
+
+func sprintIndex(sprints []model.Sprint, id string) int {
+	for i := range sprints {
+		if sprints[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// nextSprint proposes the sprint after the latest one: numbered after the
+// existing sprints, starting when the latest ends (or today) and as long as
+// it (or DefaultSprintDays).
+func nextSprint(sprints []model.Sprint, now time.Time) model.Sprint {
+	year, month, day := now.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	length := analysis.DefaultSprintDays
+
+	var latest *model.Sprint
+	for i := range sprints {
+		if latest == nil || sprints[i].EndDate.After(latest.EndDate) {
+			latest = &sprints[i]
+		}
+	}
+	if latest != nil {
+		if latest.EndDate.After(start) {
+			start = latest.EndDate
+		}
+		if !latest.StartDate.IsZero() && latest.EndDate.After(latest.StartDate) {
+			length = int(latest.EndDate.Sub(latest.StartDate).Hours()/24 + 0.5)
+		}
+	}
+
+	n := len(sprints) + 1
+	for sprintIndex(sprints, fmt.Sprintf("sprint-%d", n)) >= 0 {
+		n++
+	}
+	return model.Sprint{
+		ID:        fmt.Sprintf("sprint-%d", n),
+		Name:      fmt.Sprintf("Sprint %d", n),
+		StartDate: start,
+		EndDate:   start.AddDate(0, 0, length),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
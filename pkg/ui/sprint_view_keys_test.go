@@ -1,9 +1,12 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -57,6 +60,93 @@ func TestHandleSprintKeys_NextPrevSprint(t *testing.T) {
 	}
 }
 
+func TestSprintActionsWriteSprintsFile(t *testing.T) {
+	dir := t.TempDir()
+	beads := filepath.Join(dir, "beads.jsonl")
+	data := `{"id":"A","title":"Issue A","status":"open","issue_type":"task","priority":1}` + "\n" +
+		`{"id":"B","title":"Issue B","status":"open","issue_type":"task","priority":2}`
+	if err := os.WriteFile(beads, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(issues, nil, beads)
+	defer m.Stop()
+	m.width, m.height = 100, 40
+	press := func(key string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+	saved := func() []model.Sprint {
+		sprints, err := loader.LoadSprintsFromFile(filepath.Join(dir, loader.SprintsFileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sprints
+	}
+
+	press("P")
+	if !m.isSprintView || m.focused != focusSprint {
+		t.Fatalf("P should open the sprint view")
+	}
+	press("n")
+	if got := saved(); len(got) != 1 || got[0].ID != "sprint-1" || m.selectedSprint == nil {
+		t.Fatalf("n saved %+v", got)
+	}
+	press("+")
+	press("+")
+	press("-")
+	if got := saved(); got[0].VelocityTarget != 1 {
+		t.Errorf("velocity target = %v, want 1", got[0].VelocityTarget)
+	}
+
+	// With a target of one bead, the plan fills in the top priority issue
+	press("s")
+	if got := saved(); len(got[0].BeadIDs) != 1 || got[0].BeadIDs[0] != "A" {
+		t.Errorf("s planned %v, want [A] (%s)", got[0].BeadIDs, m.statusMsg)
+	}
+
+	press("P")
+	if m.isSprintView {
+		t.Fatalf("P should close the sprint view")
+	}
+	for i, item := range m.list.Items() {
+		if it, ok := item.(IssueItem); ok && it.Issue.ID == "B" {
+			m.list.Select(i)
+		}
+	}
+	press("+")
+	if got := saved(); !got[0].HasBead("B") {
+		t.Errorf("+ did not add B to the sprint: %v (%s)", got[0].BeadIDs, m.statusMsg)
+	}
+	press("-")
+	if got := saved(); got[0].HasBead("B") {
+		t.Errorf("- did not remove B from the sprint: %v", got[0].BeadIDs)
+	}
+
+	press("P")
+	press("c")
+	if got := saved(); !got[0].IsEnded(time.Now().Add(time.Second)) {
+		t.Errorf("c did not close the sprint: %+v", got[0])
+	}
+}
+
+func TestNextSprint(t *testing.T) {
+	now := time.Date(2025, 1, 10, 15, 0, 0, 0, time.UTC)
+	first := nextSprint(nil, now)
+	if first.ID != "sprint-1" || !first.StartDate.Equal(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)) || first.EndDate.Sub(first.StartDate) != 14*24*time.Hour {
+		t.Errorf("first sprint = %+v", first)
+	}
+
+	sprints := []model.Sprint{{ID: "sprint-2", Name: "Two", StartDate: now, EndDate: now.AddDate(0, 0, 7)}}
+	next := nextSprint(sprints, now)
+	if next.ID != "sprint-3" || !next.StartDate.Equal(now.AddDate(0, 0, 7)) || !next.EndDate.Equal(now.AddDate(0, 0, 14)) {
+		t.Errorf("next sprint = %+v", next)
+	}
+}
+
 // =============================================================================
 // truncateStrSprint Tests
 // =============================================================================
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -128,7 +129,7 @@ func RenderStatusBadge(status string) string {
 	var fg, bg lipgloss.Color
 	var label string
 
-	switch status {
+	switch model.Status(status).Base() {
 	case "open":
 		fg, bg, label = ColorStatusOpen, ColorStatusOpenBg, "OPEN"
 	case "in_progress":
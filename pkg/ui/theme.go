@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -83,7 +84,7 @@ func DefaultTheme(r *lipgloss.Renderer) Theme {
 }
 
 func (t Theme) GetStatusColor(s string) lipgloss.AdaptiveColor {
-	switch s {
+	switch model.Status(s).Base() {
 	case "open":
 		return t.Open
 	case "in_progress":
@@ -97,22 +98,27 @@ func (t Theme) GetStatusColor(s string) lipgloss.AdaptiveColor {
 	}
 }
 
+// GetTypeIcon returns the icon and color for an issue type from the type
+// registry. Types without a configured color use the theme's.
 func (t Theme) GetTypeIcon(typ string) (string, lipgloss.AdaptiveColor) {
-	switch typ {
-	case "bug":
-		return "🐛", t.Bug
-	case "feature":
-		return "✨", t.Feature
-	case "task":
-		return "📋", t.Task
-	case "epic":
-		// Use 🚀 instead of 🏔️ - the snow-capped mountain has a variation selector
-		// (U+FE0F) that causes inconsistent width calculations across terminals
-		return "🚀", t.Epic
-	case "chore":
-		return "🧹", t.Chore
+	issueType := model.IssueType(typ)
+	icon := issueType.Icon()
+	if hex := issueType.Color(); hex != "" {
+		return icon, lipgloss.AdaptiveColor{Light: hex, Dark: hex}
+	}
+	switch issueType {
+	case model.TypeBug:
+		return icon, t.Bug
+	case model.TypeFeature:
+		return icon, t.Feature
+	case model.TypeTask:
+		return icon, t.Task
+	case model.TypeEpic:
+		return icon, t.Epic
+	case model.TypeChore:
+		return icon, t.Chore
 	default:
-		return "•", t.Subtext
+		return icon, t.Subtext
 	}
 }
 
@@ -9,7 +9,9 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
@@ -176,16 +178,17 @@ type IssueTreeNode struct {
 
 // TreeModel manages the hierarchical tree view state
 type TreeModel struct {
-	roots    []*IssueTreeNode           // Root nodes (issues with no parent)
-	flatList []*IssueTreeNode           // Flattened visible nodes for navigation
-	cursor   int                        // Current selection index in flatList
-	viewport viewport.Model             // For scrolling
-	theme    Theme                      // Visual styling
-	mode     TreeViewMode               // Hierarchy vs blocking
-	issueMap map[string]*IssueTreeNode  // Quick lookup by issue ID
-	width          int                  // Available width
-	height         int                  // Available height
-	viewportOffset int                  // Index of first visible node (bv-r4ng)
+	roots          []*IssueTreeNode               // Root nodes (issues with no parent)
+	flatList       []*IssueTreeNode               // Flattened visible nodes for navigation
+	cursor         int                            // Current selection index in flatList
+	viewport       viewport.Model                 // For scrolling
+	theme          Theme                          // Visual styling
+	mode           TreeViewMode                   // Hierarchy vs blocking
+	issueMap       map[string]*IssueTreeNode      // Quick lookup by issue ID
+	epics          map[string]analysis.EpicRollup // Progress rollup per epic, for the epic column
+	width          int                            // Available width
+	height         int                            // Available height
+	viewportOffset int                            // Index of first visible node (bv-r4ng)
 
 	// Build state
 	built    bool   // Has tree been built?
@@ -219,6 +222,7 @@ func (t *TreeModel) Build(issues []model.Issue) {
 	t.roots = nil
 	t.flatList = nil
 	t.issueMap = make(map[string]*IssueTreeNode)
+	t.epics = nil
 	t.cursor = 0
 
 	if len(issues) == 0 {
@@ -287,6 +291,9 @@ func (t *TreeModel) Build(issues []model.Issue) {
 	// Step 4: Sort roots by priority, type, then created date
 	t.sortNodes(t.roots)
 
+	// Epic progress for the epic column (rolls up nested epics)
+	t.epics = analysis.ComputeEpicRollups(issues, 1, time.Now()).ByID()
+
 	// Step 5: Handle empty tree (no parent-child relationships found)
 	// If all issues are roots (no hierarchy), that's fine - show them all
 	// The View() will handle displaying a helpful message if needed
@@ -531,10 +538,16 @@ func (t *TreeModel) renderNode(node *IssueTreeNode, isSelected bool) string {
 	sb.WriteString(idStyle.Render(issue.ID))
 	sb.WriteString(" ")
 
+	// Epic column: rolled-up progress, blocked children and projected finish
+	epicColumn := ""
+	if rollup, ok := t.epics[issue.ID]; ok && issue.IssueType == model.TypeEpic {
+		epicColumn = t.renderEpicColumn(rollup)
+	}
+
 	// Title (truncated if needed)
 	title := issue.Title
 	// Use lipgloss.Width for proper display width (handles ANSI codes + Unicode)
-	maxTitleLen := t.width - lipgloss.Width(prefix) - 25 // Account for prefix, indicator, icon, priority, ID
+	maxTitleLen := t.width - lipgloss.Width(prefix) - 25 - lipgloss.Width(epicColumn) // Account for prefix, indicator, icon, priority, ID
 	if maxTitleLen < 20 {
 		maxTitleLen = 20
 	}
@@ -548,10 +561,32 @@ func (t *TreeModel) renderNode(node *IssueTreeNode, isSelected bool) string {
 	statusDot := " " + GetStatusIcon(string(issue.Status))
 	statusStyle := r.NewStyle().Foreground(statusColor)
 	sb.WriteString(statusStyle.Render(statusDot))
+	sb.WriteString(epicColumn)
 
 	return sb.String()
 }
 
+// renderEpicColumn renders an epic's rollup, e.g. " ████░░░░  50% 4/8 ⛔2 →Mar 14".
+func (t *TreeModel) renderEpicColumn(rollup analysis.EpicRollup) string {
+	if rollup.Total == 0 {
+		return ""
+	}
+	mutedStyle := t.theme.Renderer.NewStyle().Foreground(t.theme.Muted)
+
+	var sb strings.Builder
+	sb.WriteString(" ")
+	sb.WriteString(RenderMiniBar(rollup.PercentByCount/100, 8, t.theme))
+	sb.WriteString(mutedStyle.Render(fmt.Sprintf(" %3.0f%% %d/%d", rollup.PercentByCount, rollup.Closed, rollup.Total)))
+	if len(rollup.Blocked) > 0 {
+		blockedStyle := t.theme.Renderer.NewStyle().Foreground(t.theme.Blocked)
+		sb.WriteString(blockedStyle.Render(fmt.Sprintf(" ⛔%d", len(rollup.Blocked))))
+	}
+	if rollup.ProjectedFinish != nil {
+		sb.WriteString(mutedStyle.Render(" →" + rollup.ProjectedFinish.Format("Jan 2")))
+	}
+	return sb.String()
+}
+
 // buildTreePrefix builds the indentation and branch characters for a node.
 func (t *TreeModel) buildTreePrefix(node *IssueTreeNode) string {
 	if node.Depth == 0 {
@@ -1586,3 +1586,31 @@ func TestPositionIndicatorAtEnd(t *testing.T) {
 		t.Errorf("position indicator at end not found, got:\n%s", output)
 	}
 }
+
+// TestTreeEpicColumn verifies epics show their rolled-up progress
+func TestTreeEpicColumn(t *testing.T) {
+	parent := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepParentChild}}
+	}
+	issues := []model.Issue{
+		{ID: "epic-1", Title: "Epic", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "epic-2", Title: "Nested", IssueType: model.TypeEpic, Status: model.StatusOpen, Dependencies: parent("epic-1")},
+		{ID: "task-1", Title: "Done", IssueType: model.TypeTask, Status: model.StatusClosed, Dependencies: parent("epic-1")},
+		{ID: "task-2", Title: "Todo", IssueType: model.TypeTask, Status: model.StatusOpen, Dependencies: parent("epic-2")},
+	}
+
+	tree := NewTreeModel(newTreeTestTheme())
+	tree.SetSize(120, 20)
+	tree.Build(issues)
+
+	epicLine := tree.renderNode(tree.issueMap["epic-1"], false)
+	if !strings.Contains(epicLine, " 50% 1/2") || !strings.Contains(epicLine, "→") {
+		t.Errorf("epic row missing rollup column: %q", epicLine)
+	}
+	if nested := tree.renderNode(tree.issueMap["epic-2"], false); !strings.Contains(nested, "  0% 0/1") {
+		t.Errorf("nested epic row missing rollup column: %q", nested)
+	}
+	if taskLine := tree.renderNode(tree.issueMap["task-1"], false); strings.Contains(taskLine, "%") {
+		t.Errorf("task row should not have an epic column: %q", taskLine)
+	}
+}
@@ -525,6 +525,8 @@ func structuredTutorialPages() []StructuredTutorialPage {
 				KeyTable{Bindings: []KeyBinding{
 					{Key: "j / k", Desc: "Navigate timeline"},
 					{Key: "v", Desc: "Toggle Bead/Git mode"},
+					{Key: "Space", Desc: "Expand/collapse PR group (Git mode)"},
+					{Key: "O", Desc: "Directory owners and unowned open beads"},
 					{Key: "f", Desc: "Toggle file tree panel"},
 					{Key: "Tab", Desc: "Cycle focus"},
 				}},
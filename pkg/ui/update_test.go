@@ -1,12 +1,15 @@
 package ui
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 	"github.com/charmbracelet/bubbles/list"
 )
 
@@ -106,3 +109,53 @@ func TestUpdateFileChangedReloadsSelection(t *testing.T) {
 		t.Fatalf("expected successful reload, got error %q", m2.statusMsg)
 	}
 }
+
+func TestUpdateWorkspaceChangedReloadsChangedRepo(t *testing.T) {
+	root := t.TempDir()
+	writeBeads := func(repo, data string) string {
+		dir := filepath.Join(root, repo, ".beads")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "beads.jsonl")
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("write beads: %v", err)
+		}
+		return path
+	}
+	writeBeads("api", `{"id":"AUTH-1","title":"Login","status":"open","issue_type":"task"}`)
+	webFile := writeBeads("web", `{"id":"UI-1","title":"Page","status":"open","issue_type":"task"}`)
+
+	loader := workspace.NewAggregateLoader(&workspace.Config{Repos: []workspace.RepoConfig{
+		{Name: "api", Path: "api", Prefix: "api-"},
+		{Name: "web", Path: "web", Prefix: "web-"},
+	}}, root)
+	issues, _, err := loader.LoadAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, "")
+	m.EnableWorkspaceMode(WorkspaceInfo{Enabled: true, RepoCount: 2, RepoPrefixes: []string{"api-", "web-"}})
+	if err := m.EnableWorkspaceWatch(loader, nil); err != nil {
+		t.Fatalf("EnableWorkspaceWatch: %v", err)
+	}
+	defer m.Stop()
+	if len(m.workspaceFiles) != 2 {
+		t.Fatalf("expected 2 watched files, got %v", m.workspaceFiles)
+	}
+
+	writeBeads("web", `{"id":"UI-1","title":"Page","status":"open","issue_type":"task"}
+{"id":"UI-2","title":"Footer","status":"open","issue_type":"task"}`)
+	updated, cmd := m.Update(WorkspaceChangedMsg{Paths: []string{webFile}})
+	if cmd == nil {
+		t.Fatal("expected follow-up commands after reload")
+	}
+	m2 := updated.(Model)
+	if m2.statusIsError || !strings.Contains(m2.statusMsg, "web (2 issues)") {
+		t.Fatalf("status = %q, want it to name the reloaded repo", m2.statusMsg)
+	}
+	if len(m2.issues) != 3 || m2.issueMap["web-UI-2"] == nil || m2.issueMap["api-AUTH-1"] == nil {
+		t.Fatalf("expected merged issues from both repos, got %d", len(m2.issues))
+	}
+}
@@ -0,0 +1,102 @@
+package watcher
+
+import (
+	"sort"
+	"sync"
+)
+
+// MultiWatcher watches several files at once and remembers which of them
+// changed, so callers can reload only what is stale.
+type MultiWatcher struct {
+	watchers []*Watcher
+
+	mu       sync.Mutex
+	pending  map[string]struct{}
+	changeCh chan struct{}
+}
+
+// NewMultiWatcher creates a watcher per path. Options apply to every
+// underlying watcher; WithOnChange is reserved for change tracking and is
+// overridden.
+func NewMultiWatcher(paths []string, opts ...WatcherOption) (*MultiWatcher, error) {
+	mw := &MultiWatcher{
+		pending:  make(map[string]struct{}),
+		changeCh: make(chan struct{}, 1),
+	}
+
+	for _, path := range paths {
+		var w *Watcher
+		onChange := WithOnChange(func() { mw.markChanged(w.Path()) })
+		w, err := NewWatcher(path, append(opts, onChange)...)
+		if err != nil {
+			return nil, err
+		}
+		mw.watchers = append(mw.watchers, w)
+	}
+
+	return mw, nil
+}
+
+// Start starts every underlying watcher. If one fails to start, the ones
+// already running are stopped and the error is returned.
+func (mw *MultiWatcher) Start() error {
+	for i, w := range mw.watchers {
+		if err := w.Start(); err != nil {
+			for _, started := range mw.watchers[:i] {
+				started.Stop()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop stops every underlying watcher.
+func (mw *MultiWatcher) Stop() {
+	for _, w := range mw.watchers {
+		w.Stop()
+	}
+}
+
+// Changed returns a channel that receives when any watched file changes.
+// Call Drain to find out which.
+func (mw *MultiWatcher) Changed() <-chan struct{} {
+	return mw.changeCh
+}
+
+// Drain returns the absolute paths that changed since the last call,
+// sorted, and clears them.
+func (mw *MultiWatcher) Drain() []string {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	paths := make([]string, 0, len(mw.pending))
+	for path := range mw.pending {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	mw.pending = make(map[string]struct{})
+	return paths
+}
+
+// Paths returns the absolute paths being watched.
+func (mw *MultiWatcher) Paths() []string {
+	paths := make([]string, len(mw.watchers))
+	for i, w := range mw.watchers {
+		paths[i] = w.Path()
+	}
+	return paths
+}
+
+// markChanged records path as changed and signals the change channel.
+func (mw *MultiWatcher) markChanged(path string) {
+	mw.mu.Lock()
+	mw.pending[path] = struct{}{}
+	mw.mu.Unlock()
+
+	// Non-blocking send; pending changes are picked up by the next Drain
+	select {
+	case mw.changeCh <- struct{}{}:
+	default:
+	}
+}
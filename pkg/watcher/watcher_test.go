@@ -310,3 +310,49 @@ func TestWatcher_Path(t *testing.T) {
 		t.Errorf("expected path %s, got %s", absPath, w.Path())
 	}
 }
+
+func TestMultiWatcher_ReportsChangedPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	api := filepath.Join(tmpDir, "api.jsonl")
+	web := filepath.Join(tmpDir, "web.jsonl")
+	for _, f := range []string{api, web} {
+		if err := os.WriteFile(f, []byte("initial"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mw, err := NewMultiWatcher([]string{api, web},
+		WithDebounceDuration(50*time.Millisecond),
+		WithPollInterval(100*time.Millisecond),
+		WithForcePoll(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mw.Paths(); len(got) != 2 || got[0] != api || got[1] != web {
+		t.Errorf("Paths() = %v", got)
+	}
+
+	if err := mw.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer mw.Stop()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		os.WriteFile(web, []byte("new content"), 0644)
+	}()
+
+	select {
+	case <-mw.Changed():
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for change notification")
+	}
+
+	if got := mw.Drain(); len(got) != 1 || got[0] != web {
+		t.Errorf("Drain() = %v, want [%s]", got, web)
+	}
+	if got := mw.Drain(); len(got) != 0 {
+		t.Errorf("second Drain() = %v, want empty", got)
+	}
+}
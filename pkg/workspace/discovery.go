@@ -0,0 +1,141 @@
+package workspace
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// withDefaults fills unset discovery fields with the standard values
+func (d DiscoveryConfig) withDefaults() DiscoveryConfig {
+	if len(d.Patterns) == 0 {
+		d.Patterns = DefaultDiscoveryPatterns()
+	}
+	if len(d.Exclude) == 0 {
+		d.Exclude = DefaultExcludePatterns()
+	}
+	if d.MaxDepth == 0 {
+		d.MaxDepth = 2
+	}
+	return d
+}
+
+// Discover walks root looking for directories that contain a .beads
+// directory and whose path relative to root matches one of the discovery
+// patterns. Hidden and excluded directories are not entered, and the walk
+// stops at MaxDepth levels below root. Paths in the returned repos are
+// relative to root, using forward slashes, sorted.
+func Discover(root string, cfg DiscoveryConfig) ([]RepoConfig, error) {
+	cfg = cfg.withDefaults()
+
+	var repos []RepoConfig
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // Unreadable subdirectory - skip it
+		}
+		if !d.IsDir() || path == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if strings.HasPrefix(d.Name(), ".") || isExcluded(rel, cfg.Exclude) {
+			return filepath.SkipDir
+		}
+		depth := strings.Count(rel, "/") + 1
+		if depth > cfg.MaxDepth {
+			return filepath.SkipDir
+		}
+
+		if matchesAny(rel, cfg.Patterns) && hasBeadsDir(path) {
+			repos = append(repos, RepoConfig{Path: rel})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discovering repos under %s: %w", root, err)
+	}
+
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Path < repos[j].Path })
+	return repos, nil
+}
+
+// isExcluded reports whether rel, or its final element, matches an exclude pattern
+func isExcluded(rel string, exclude []string) bool {
+	base := filepath.Base(rel)
+	for _, pattern := range exclude {
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(filepath.ToSlash(pattern), rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func hasBeadsDir(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, ".beads"))
+	return err == nil && info.IsDir()
+}
+
+// mergeDiscovered appends discovered repos to the explicit ones. A discovered
+// repo at the same location as an explicit repo is dropped, so explicit
+// settings (including enabled: false) always win. Discovered repos whose
+// default prefix is already taken get a prefix derived from their path
+// instead, e.g. "services/api" -> "services-api-".
+func mergeDiscovered(root string, explicit, discovered []RepoConfig) (merged, added []RepoConfig) {
+	merged = append(merged, explicit...)
+
+	locations := make(map[string]bool, len(explicit))
+	prefixes := make(map[string]bool, len(explicit))
+	for _, repo := range explicit {
+		locations[repoLocation(root, repo.Path)] = true
+		prefixes[strings.ToLower(repo.GetPrefix())] = true
+	}
+
+	for _, repo := range discovered {
+		if locations[repoLocation(root, repo.Path)] {
+			continue
+		}
+		if prefixes[strings.ToLower(repo.GetPrefix())] {
+			repo.Name = repo.Path
+			base := strings.ToLower(strings.ReplaceAll(repo.Path, "/", "-"))
+			repo.Prefix = base + "-"
+			for n := 2; prefixes[repo.Prefix]; n++ {
+				repo.Prefix = fmt.Sprintf("%s%d-", base, n)
+			}
+		}
+		locations[repoLocation(root, repo.Path)] = true
+		prefixes[strings.ToLower(repo.GetPrefix())] = true
+		merged = append(merged, repo)
+		added = append(added, repo)
+	}
+	return merged, added
+}
+
+// repoLocation returns a comparable absolute location for a repo path
+func repoLocation(root, path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,94 @@
+package workspace_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
+)
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{
+		"api",
+		"packages/shared",
+		"services/api",
+		"node_modules/dep",     // excluded
+		"apps/web/nested/deep", // deeper than MaxDepth
+		".hidden",              // hidden
+	} {
+		createTestBeadsFile(t, filepath.Join(root, dir), []model.Issue{{ID: "X-1", Title: "x"}})
+	}
+	// A directory without .beads is not a repo
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := workspace.Discover(root, workspace.DiscoveryConfig{})
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	var paths []string
+	for _, r := range repos {
+		paths = append(paths, r.Path)
+	}
+	want := []string{"api", "packages/shared", "services/api"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Discover() paths = %v, want %v", paths, want)
+	}
+
+	repos, err = workspace.Discover(root, workspace.DiscoveryConfig{Patterns: []string{"services/*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 1 || repos[0].Path != "services/api" {
+		t.Errorf("Discover(services/*) = %+v", repos)
+	}
+}
+
+func TestLoadAllWithDiscovery(t *testing.T) {
+	root := t.TempDir()
+	createTestBeadsFile(t, filepath.Join(root, "api"), []model.Issue{{ID: "AUTH-1", Title: "Login"}})
+	createTestBeadsFile(t, filepath.Join(root, "services", "api"), []model.Issue{{ID: "SVC-1", Title: "Gateway"}})
+	createTestBeadsFile(t, filepath.Join(root, "packages", "web"), []model.Issue{{ID: "UI-1", Title: "Page"}})
+	createTestBeadsFile(t, filepath.Join(root, "packages", "old"), []model.Issue{{ID: "OLD-1", Title: "Legacy"}})
+
+	disabled := false
+	config := &workspace.Config{
+		Repos: []workspace.RepoConfig{
+			{Name: "api", Path: "api", Prefix: "api-"},
+			{Path: "./packages/old", Enabled: &disabled}, // explicit settings win over discovery
+		},
+		Discovery: workspace.DiscoveryConfig{Enabled: true},
+	}
+
+	issues, results, err := workspace.NewAggregateLoader(config, root).LoadAll(context.Background())
+	if err != nil {
+		t.Fatalf("LoadAll() error = %v", err)
+	}
+
+	ids := make(map[string]bool)
+	for _, issue := range issues {
+		ids[issue.ID] = true
+	}
+	for _, id := range []string{"api-AUTH-1", "services-api-SVC-1", "web-UI-1"} {
+		if !ids[id] {
+			t.Errorf("missing issue %s in %v", id, ids)
+		}
+	}
+	if len(issues) != 3 {
+		t.Errorf("loaded %d issues, want 3 (packages/old is disabled)", len(issues))
+	}
+
+	summary := workspace.Summarize(results)
+	if !reflect.DeepEqual(summary.DiscoveredRepos, []string{"web", "services/api"}) {
+		t.Errorf("DiscoveredRepos = %v, want [web services/api]", summary.DiscoveredRepos)
+	}
+	if summary.TotalRepos != 3 {
+		t.Errorf("TotalRepos = %d, want 3", summary.TotalRepos)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"path/filepath"
+	"sync"
 
 	"golang.org/x/sync/errgroup"
 
@@ -26,6 +27,17 @@ type LoadResult struct {
 
 	// Error is set if loading failed
 	Error error
+
+	// Discovered is true when the repo was found by auto-discovery
+	// rather than listed in the config
+	Discovered bool
+
+	// BeadsFile is the JSONL file the issues were read from (empty if not found)
+	BeadsFile string
+
+	// DanglingRefs are dependencies of this repo's issues whose targets are
+	// not in any loaded repo
+	DanglingRefs []DanglingRef
 }
 
 // AggregateLoader loads issues from multiple repositories in a workspace
@@ -33,6 +45,12 @@ type AggregateLoader struct {
 	config        *Config
 	workspaceRoot string
 	logger        *log.Logger
+	repos         []RepoConfig    // Explicit repos plus discovered ones, set by LoadAll
+	discovered    map[string]bool // Paths of repos found by auto-discovery
+
+	mu      sync.Mutex
+	enabled []RepoConfig // Repos loaded by the last LoadAll, in order
+	results []LoadResult // Latest result per enabled repo, parallel to enabled
 }
 
 // NewAggregateLoader creates a new aggregate loader for the given workspace config
@@ -72,24 +90,117 @@ func (l *AggregateLoader) LoadAll(ctx context.Context) ([]model.Issue, []LoadRes
 		return nil, results, fmt.Errorf("fatal error during parallel loading: %w", err)
 	}
 
-	// Merge all successfully loaded issues
-	var allIssues []model.Issue
+	resolveCrossRepoRefs(results, l.repos)
+
+	l.mu.Lock()
+	l.enabled = enabledRepos
+	l.results = append([]LoadResult(nil), results...)
+	l.mu.Unlock()
+
 	for _, result := range results {
 		if result.Error != nil {
 			// Log but continue - individual repo failures don't break the whole load
 			l.logRepoError(result.RepoName, result.Error)
-			continue
 		}
-		allIssues = append(allIssues, result.Issues...)
 	}
 
-	return allIssues, results, nil
+	return mergeResults(results), results, nil
 }
 
-// getEnabledRepos returns all enabled repos from the config
+// ReloadRepo reloads one repository by name, keeping every other repo's
+// issues from the previous load, and returns the re-merged issue list.
+// LoadAll must have been called first. If the repo fails to load, its
+// previous issues are kept and the error is returned with the result.
+func (l *AggregateLoader) ReloadRepo(ctx context.Context, name string) ([]model.Issue, LoadResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	idx := -1
+	for i, repo := range l.enabled {
+		if repo.GetName() == name {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, LoadResult{}, fmt.Errorf("unknown workspace repo %q", name)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, LoadResult{}, err
+	}
+
+	repo := l.enabled[idx]
+	issues, beadsFile, err := l.loadSingleRepo(repo)
+	result := LoadResult{
+		RepoName:   repo.GetName(),
+		Prefix:     repo.GetPrefix(),
+		Issues:     issues,
+		Error:      err,
+		Discovered: l.discovered[repo.Path],
+		BeadsFile:  beadsFile,
+	}
+	if err != nil {
+		l.logRepoError(result.RepoName, err)
+		return mergeResults(l.results), result, err
+	}
+	l.results[idx] = result
+	// Other repos' references into this one may resolve (or dangle) now
+	resolveCrossRepoRefs(l.results, l.repos)
+	return mergeResults(l.results), l.results[idx], nil
+}
+
+// BeadsFiles maps each successfully loaded repo's beads file to the repo
+// name, for watching the workspace for changes.
+func (l *AggregateLoader) BeadsFiles() map[string]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	files := make(map[string]string, len(l.results))
+	for _, result := range l.results {
+		if result.BeadsFile != "" {
+			files[result.BeadsFile] = result.RepoName
+		}
+	}
+	return files
+}
+
+// mergeResults concatenates the issues of all successful results, in order
+func mergeResults(results []LoadResult) []model.Issue {
+	var allIssues []model.Issue
+	for _, result := range results {
+		if result.Error == nil {
+			allIssues = append(allIssues, result.Issues...)
+		}
+	}
+	return allIssues
+}
+
+// getEnabledRepos returns all enabled repos: the explicit ones from the
+// config, plus any found under the workspace root when discovery is enabled.
+// A failed discovery walk is logged and leaves just the explicit repos.
 func (l *AggregateLoader) getEnabledRepos() []RepoConfig {
+	l.repos = l.config.Repos
+	l.discovered = nil
+	if l.config.Discovery.Enabled {
+		found, err := Discover(l.workspaceRoot, l.config.Discovery)
+		if err != nil {
+			if l.logger != nil {
+				l.logger.Printf("WARNING: workspace discovery failed: %v", err)
+			}
+		} else {
+			var added []RepoConfig
+			l.repos, added = mergeDiscovered(l.workspaceRoot, l.config.Repos, found)
+			l.discovered = make(map[string]bool, len(added))
+			for _, repo := range added {
+				l.discovered[repo.Path] = true
+			}
+			if len(added) > 0 && l.logger != nil {
+				l.logger.Printf("Discovered %d repos under %s", len(added), l.workspaceRoot)
+			}
+		}
+	}
+
 	var enabled []RepoConfig
-	for _, repo := range l.config.Repos {
+	for _, repo := range l.repos {
 		if repo.IsEnabled() {
 			enabled = append(enabled, repo)
 		}
@@ -112,21 +223,24 @@ func (l *AggregateLoader) loadReposParallel(ctx context.Context, repos []RepoCon
 			select {
 			case <-ctx.Done():
 				results[i] = LoadResult{
-					RepoName: repo.GetName(),
-					Prefix:   repo.GetPrefix(),
-					Error:    ctx.Err(),
+					RepoName:   repo.GetName(),
+					Prefix:     repo.GetPrefix(),
+					Error:      ctx.Err(),
+					Discovered: l.discovered[repo.Path],
 				}
 				return nil // Don't propagate context errors as fatal
 			default:
 			}
 
-			issues, err := l.loadSingleRepo(repo)
+			issues, beadsFile, err := l.loadSingleRepo(repo)
 
 			results[i] = LoadResult{
-				RepoName: repo.GetName(),
-				Prefix:   repo.GetPrefix(),
-				Issues:   issues,
-				Error:    err,
+				RepoName:   repo.GetName(),
+				Prefix:     repo.GetPrefix(),
+				Issues:     issues,
+				Error:      err,
+				Discovered: l.discovered[repo.Path],
+				BeadsFile:  beadsFile,
 			}
 
 			return nil // Individual repo errors are captured in results, not propagated
@@ -145,8 +259,9 @@ func (l *AggregateLoader) loadReposParallel(ctx context.Context, repos []RepoCon
 	return results, nil
 }
 
-// loadSingleRepo loads issues from a single repository and namespaced them
-func (l *AggregateLoader) loadSingleRepo(repo RepoConfig) ([]model.Issue, error) {
+// loadSingleRepo loads issues from a single repository and namespaced them.
+// It also returns the beads file it read, once one was found.
+func (l *AggregateLoader) loadSingleRepo(repo RepoConfig) ([]model.Issue, string, error) {
 	// Resolve the repo path relative to workspace root
 	repoPath := repo.Path
 	if !filepath.IsAbs(repoPath) {
@@ -157,11 +272,11 @@ func (l *AggregateLoader) loadSingleRepo(repo RepoConfig) ([]model.Issue, error)
 	beadsDir := filepath.Join(repoPath, repo.GetBeadsPath())
 	jsonlPath, err := loader.FindJSONLPath(beadsDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load issues from %s: %w", repo.GetName(), err)
+		return nil, "", fmt.Errorf("failed to load issues from %s: %w", repo.GetName(), err)
 	}
 	issues, err := loader.LoadIssuesFromFile(jsonlPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load issues from %s: %w", repo.GetName(), err)
+		return nil, jsonlPath, fmt.Errorf("failed to load issues from %s: %w", repo.GetName(), err)
 	}
 
 	// Build map of local IDs for conflict resolution
@@ -174,7 +289,12 @@ func (l *AggregateLoader) loadSingleRepo(repo RepoConfig) ([]model.Issue, error)
 	prefix := repo.GetPrefix()
 	namespacedIssues := l.namespaceIssues(issues, prefix, localIDs)
 
-	return namespacedIssues, nil
+	// Record the owning repo so views can attribute (cross-repo) blockers
+	for i := range namespacedIssues {
+		namespacedIssues[i].SourceRepo = repo.GetName()
+	}
+
+	return namespacedIssues, jsonlPath, nil
 }
 
 // namespaceIssues adds the prefix to all issue IDs and dependency references
@@ -217,7 +337,11 @@ func (l *AggregateLoader) namespaceIssues(issues []model.Issue, prefix string, l
 
 // hasKnownPrefix checks if an ID already has a known namespace prefix
 func (l *AggregateLoader) hasKnownPrefix(id string) bool {
-	for _, repo := range l.config.Repos {
+	repos := l.repos
+	if repos == nil {
+		repos = l.config.Repos
+	}
+	for _, repo := range repos {
 		prefix := repo.GetPrefix()
 		if len(id) > len(prefix) && id[:len(prefix)] == prefix {
 			return true
@@ -235,15 +359,24 @@ func (l *AggregateLoader) logRepoError(repoName string, err error) {
 
 // LoadAllFromConfig is a convenience function that loads a workspace config and all its repos
 func LoadAllFromConfig(ctx context.Context, configPath string) ([]model.Issue, []LoadResult, error) {
+	loader, err := NewAggregateLoaderFromConfig(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return loader.LoadAll(ctx)
+}
+
+// NewAggregateLoaderFromConfig reads a workspace config file and returns a
+// loader rooted at the workspace root. Keep the loader around to reload
+// individual repos later (see ReloadRepo).
+func NewAggregateLoaderFromConfig(configPath string) (*AggregateLoader, error) {
 	config, err := LoadConfig(configPath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load workspace config: %w", err)
+		return nil, fmt.Errorf("failed to load workspace config: %w", err)
 	}
 
 	workspaceRoot := filepath.Dir(filepath.Dir(configPath)) // .bv/workspace.yaml -> workspace root
-	loader := NewAggregateLoader(config, workspaceRoot)
-
-	return loader.LoadAll(ctx)
+	return NewAggregateLoader(config, workspaceRoot), nil
 }
 
 // Summary returns a summary of load results
@@ -253,7 +386,9 @@ type LoadSummary struct {
 	FailedRepos     int
 	TotalIssues     int
 	FailedRepoNames []string
-	RepoPrefixes    []string // Prefixes of successfully loaded repos
+	RepoPrefixes    []string      // Prefixes of successfully loaded repos
+	DiscoveredRepos []string      // Names of repos found by auto-discovery
+	DanglingRefs    []DanglingRef // Dependencies on issues outside the loaded workspace
 }
 
 // Summarize returns a summary of the load results
@@ -263,6 +398,9 @@ func Summarize(results []LoadResult) LoadSummary {
 	}
 
 	for _, result := range results {
+		if result.Discovered {
+			summary.DiscoveredRepos = append(summary.DiscoveredRepos, result.RepoName)
+		}
 		if result.Error != nil {
 			summary.FailedRepos++
 			summary.FailedRepoNames = append(summary.FailedRepoNames, result.RepoName)
@@ -274,6 +412,7 @@ func Summarize(results []LoadResult) LoadSummary {
 			}
 		}
 	}
+	summary.DanglingRefs = collectDanglingRefs(results)
 
 	return summary
 }
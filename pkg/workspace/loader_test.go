@@ -489,3 +489,59 @@ func TestAggregateLoaderCustomBeadsPath(t *testing.T) {
 		t.Errorf("expected namespaced ID svc-CUST-1, got %s", issues[0].ID)
 	}
 }
+
+func TestReloadRepo(t *testing.T) {
+	root := t.TempDir()
+	createTestBeadsFile(t, filepath.Join(root, "api"), []model.Issue{{ID: "AUTH-1", Title: "Login"}})
+	createTestBeadsFile(t, filepath.Join(root, "web"), []model.Issue{{ID: "UI-1", Title: "Page"}})
+
+	config := &workspace.Config{
+		Repos: []workspace.RepoConfig{
+			{Name: "api", Path: "api", Prefix: "api-"},
+			{Name: "web", Path: "web", Prefix: "web-"},
+		},
+	}
+	loader := workspace.NewAggregateLoader(config, root)
+	if _, _, err := loader.LoadAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	files := loader.BeadsFiles()
+	if len(files) != 2 || files[filepath.Join(root, "web", ".beads", "beads.jsonl")] != "web" {
+		t.Errorf("BeadsFiles() = %v", files)
+	}
+
+	createTestBeadsFile(t, filepath.Join(root, "web"), []model.Issue{
+		{ID: "UI-1", Title: "Page"},
+		{ID: "UI-2", Title: "Footer", Dependencies: []*model.Dependency{{IssueID: "UI-2", DependsOnID: "UI-1", Type: model.DepBlocks}}},
+	})
+	issues, result, err := loader.ReloadRepo(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("ReloadRepo() error = %v", err)
+	}
+	if len(result.Issues) != 2 || len(issues) != 3 {
+		t.Fatalf("ReloadRepo() = %d merged, %d in repo; want 3, 2", len(issues), len(result.Issues))
+	}
+	if issues[0].ID != "api-AUTH-1" || issues[2].ID != "web-UI-2" {
+		t.Errorf("merged order = %s, %s, %s", issues[0].ID, issues[1].ID, issues[2].ID)
+	}
+	if dep := issues[2].Dependencies[0]; dep.DependsOnID != "web-UI-1" {
+		t.Errorf("reloaded dependency not namespaced: %s", dep.DependsOnID)
+	}
+
+	// A broken reload keeps the previous issues for that repo
+	if err := os.Remove(filepath.Join(root, "web", ".beads", "beads.jsonl")); err != nil {
+		t.Fatal(err)
+	}
+	issues, _, err = loader.ReloadRepo(context.Background(), "web")
+	if err == nil {
+		t.Error("ReloadRepo() of a repo without a beads file should error")
+	}
+	if len(issues) != 3 {
+		t.Errorf("failed reload dropped issues: got %d, want 3", len(issues))
+	}
+
+	if _, _, err := loader.ReloadRepo(context.Background(), "nope"); err == nil {
+		t.Error("unknown repo should error")
+	}
+}
@@ -0,0 +1,171 @@
+package workspace
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Reasons a dependency could not be resolved
+const (
+	DanglingMissingIssue  = "missing_issue"   // Target repo is loaded but has no such issue
+	DanglingRepoNotLoaded = "repo_not_loaded" // Target repo is disabled or failed to load
+)
+
+// DanglingRef is a dependency whose target is not among the loaded issues.
+// Without a report these edges silently disappear from the graph.
+type DanglingRef struct {
+	IssueID     string               `json:"issue_id"`
+	Repo        string               `json:"repo"` // Repo owning IssueID
+	DependsOnID string               `json:"depends_on_id"`
+	TargetRepo  string               `json:"target_repo,omitempty"` // Repo the target's prefix points at
+	Type        model.DependencyType `json:"type"`
+	Reason      string               `json:"reason"`
+}
+
+// resolveCrossRepoRefs checks every dependency against the issues of all
+// successfully loaded repos. Targets that do not match exactly but identify
+// exactly one loaded issue are rewritten to it:
+//   - a different letter case ("API-AUTH-1" -> "api-AUTH-1")
+//   - a repo-name reference ("api:AUTH-1", "api/AUTH-1", "api#AUTH-1")
+//   - a bare local ID of another repo that the loader qualified as local
+//
+// Whatever is left is stored in each result's DanglingRefs.
+func resolveCrossRepoRefs(results []LoadResult, repos []RepoConfig) {
+	ids := make(map[string]bool)
+	lowerIDs := make(map[string][]string)
+	localIDs := make(map[string][]string) // Unprefixed ID -> namespaced IDs
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		for _, issue := range result.Issues {
+			ids[issue.ID] = true
+			lower := strings.ToLower(issue.ID)
+			lowerIDs[lower] = append(lowerIDs[lower], issue.ID)
+			local := UnqualifyID(issue.ID, result.Prefix)
+			localIDs[local] = append(localIDs[local], issue.ID)
+		}
+	}
+
+	loaded := make(map[string]bool, len(results))
+	for _, result := range results {
+		if result.Error == nil {
+			loaded[result.RepoName] = true
+		}
+	}
+
+	for i := range results {
+		result := &results[i]
+		result.DanglingRefs = nil
+		if result.Error != nil {
+			continue
+		}
+		for j := range result.Issues {
+			issue := &result.Issues[j]
+			for k, dep := range issue.Dependencies {
+				if dep == nil || ids[dep.DependsOnID] {
+					continue
+				}
+				if target, ok := resolveTarget(dep.DependsOnID, result.Prefix, repos, lowerIDs, localIDs); ok {
+					// Copy on write: earlier merged issue lists share these pointers
+					deps := append([]*model.Dependency(nil), issue.Dependencies...)
+					resolved := *dep
+					resolved.DependsOnID = target
+					deps[k] = &resolved
+					issue.Dependencies = deps
+					continue
+				}
+
+				ref := DanglingRef{
+					IssueID:     issue.ID,
+					Repo:        result.RepoName,
+					DependsOnID: dep.DependsOnID,
+					Type:        dep.Type,
+					Reason:      DanglingMissingIssue,
+				}
+				if repo := repoForID(dep.DependsOnID, repos); repo != nil {
+					ref.TargetRepo = repo.GetName()
+					if !loaded[ref.TargetRepo] {
+						ref.Reason = DanglingRepoNotLoaded
+					}
+				}
+				result.DanglingRefs = append(result.DanglingRefs, ref)
+			}
+		}
+	}
+}
+
+// resolveTarget finds the single loaded issue an unmatched target refers to
+func resolveTarget(target, ownPrefix string, repos []RepoConfig, lowerIDs, localIDs map[string][]string) (string, bool) {
+	unique := func(candidates []string) (string, bool) {
+		if len(candidates) == 1 {
+			return candidates[0], true
+		}
+		return "", false
+	}
+
+	// The loader qualifies unknown targets as local; look at the raw form too
+	raw := UnqualifyID(target, ownPrefix)
+
+	for _, candidate := range []string{target, raw} {
+		if id, ok := unique(lowerIDs[strings.ToLower(candidate)]); ok {
+			return id, true
+		}
+		idx := strings.IndexAny(candidate, ":/#")
+		if idx <= 0 {
+			continue
+		}
+		name, local := candidate[:idx], candidate[idx+1:]
+		for _, repo := range repos {
+			if strings.EqualFold(repo.GetName(), name) {
+				if id, ok := unique(lowerIDs[strings.ToLower(QualifyID(local, repo.GetPrefix()))]); ok {
+					return id, true
+				}
+			}
+		}
+	}
+
+	if raw != target {
+		if id, ok := unique(localIDs[raw]); ok {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// repoForID returns the repo whose prefix the ID carries (longest match)
+func repoForID(id string, repos []RepoConfig) *RepoConfig {
+	var best *RepoConfig
+	lower := strings.ToLower(id)
+	for i := range repos {
+		prefix := strings.ToLower(repos[i].GetPrefix())
+		if strings.HasPrefix(lower, prefix) && (best == nil || len(prefix) > len(best.GetPrefix())) {
+			best = &repos[i]
+		}
+	}
+	return best
+}
+
+// DanglingRefs returns the unresolved dependencies of the latest load,
+// sorted by issue ID.
+func (l *AggregateLoader) DanglingRefs() []DanglingRef {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return collectDanglingRefs(l.results)
+}
+
+func collectDanglingRefs(results []LoadResult) []DanglingRef {
+	var refs []DanglingRef
+	for _, result := range results {
+		refs = append(refs, result.DanglingRefs...)
+	}
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].IssueID != refs[j].IssueID {
+			return refs[i].IssueID < refs[j].IssueID
+		}
+		return refs[i].DependsOnID < refs[j].DependsOnID
+	})
+	return refs
+}
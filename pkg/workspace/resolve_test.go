@@ -0,0 +1,89 @@
+package workspace_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
+)
+
+func blocks(from, to string) []*model.Dependency {
+	return []*model.Dependency{{IssueID: from, DependsOnID: to, Type: model.DepBlocks}}
+}
+
+func TestCrossRepoResolution(t *testing.T) {
+	root := t.TempDir()
+	createTestBeadsFile(t, filepath.Join(root, "api"), []model.Issue{
+		{ID: "AUTH-1", Title: "Token endpoint"},
+		{ID: "AUTH-2", Title: "Refresh"},
+	})
+	createTestBeadsFile(t, filepath.Join(root, "web"), []model.Issue{
+		{ID: "UI-1", Title: "Login page", Dependencies: blocks("UI-1", "api-AUTH-1")}, // exact
+		{ID: "UI-2", Title: "Logout", Dependencies: blocks("UI-2", "API-AUTH-2")},     // case differs
+		{ID: "UI-3", Title: "Profile", Dependencies: blocks("UI-3", "api:AUTH-1")},    // repo name
+		{ID: "UI-4", Title: "Session", Dependencies: blocks("UI-4", "AUTH-2")},        // bare local ID of api
+		{ID: "UI-5", Title: "Audit", Dependencies: blocks("UI-5", "api-AUTH-99")},     // missing
+		{ID: "UI-6", Title: "Billing", Dependencies: blocks("UI-6", "pay-PAY-1")},     // disabled repo
+	})
+
+	disabled := false
+	config := &workspace.Config{Repos: []workspace.RepoConfig{
+		{Name: "api", Path: "api", Prefix: "api-"},
+		{Name: "web", Path: "web", Prefix: "web-"},
+		{Name: "pay", Path: "pay", Prefix: "pay-", Enabled: &disabled},
+	}}
+	loader := workspace.NewAggregateLoader(config, root)
+	issues, results, err := loader.LoadAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deps := make(map[string]string)
+	for _, issue := range issues {
+		if issue.ID == "api-AUTH-1" && issue.SourceRepo != "api" {
+			t.Errorf("SourceRepo = %q, want api", issue.SourceRepo)
+		}
+		for _, dep := range issue.Dependencies {
+			deps[issue.ID] = dep.DependsOnID
+		}
+	}
+	want := map[string]string{
+		"web-UI-1": "api-AUTH-1",
+		"web-UI-2": "api-AUTH-2",
+		"web-UI-3": "api-AUTH-1",
+		"web-UI-4": "api-AUTH-2",
+		"web-UI-5": "api-AUTH-99",
+		"web-UI-6": "pay-PAY-1",
+	}
+	for id, target := range want {
+		if deps[id] != target {
+			t.Errorf("%s depends on %q, want %q", id, deps[id], target)
+		}
+	}
+
+	dangling := workspace.Summarize(results).DanglingRefs
+	if len(dangling) != 2 {
+		t.Fatalf("DanglingRefs = %+v, want 2", dangling)
+	}
+	if d := dangling[0]; d.IssueID != "web-UI-5" || d.Repo != "web" || d.TargetRepo != "api" || d.Reason != workspace.DanglingMissingIssue {
+		t.Errorf("dangling[0] = %+v", d)
+	}
+	if d := dangling[1]; d.IssueID != "web-UI-6" || d.TargetRepo != "pay" || d.Reason != workspace.DanglingRepoNotLoaded {
+		t.Errorf("dangling[1] = %+v", d)
+	}
+
+	// Adding the missing issue resolves the reference on reload
+	createTestBeadsFile(t, filepath.Join(root, "api"), []model.Issue{
+		{ID: "AUTH-1", Title: "Token endpoint"},
+		{ID: "AUTH-2", Title: "Refresh"},
+		{ID: "AUTH-99", Title: "Audit log"},
+	})
+	if _, _, err := loader.ReloadRepo(context.Background(), "api"); err != nil {
+		t.Fatal(err)
+	}
+	if refs := loader.DanglingRefs(); len(refs) != 1 || refs[0].IssueID != "web-UI-6" {
+		t.Errorf("after reload DanglingRefs = %+v", refs)
+	}
+}
@@ -162,15 +162,7 @@ func LoadConfig(path string) (*Config, error) {
 
 	// Apply defaults
 	if config.Discovery.Enabled {
-		if len(config.Discovery.Patterns) == 0 {
-			config.Discovery.Patterns = DefaultDiscoveryPatterns()
-		}
-		if len(config.Discovery.Exclude) == 0 {
-			config.Discovery.Exclude = DefaultExcludePatterns()
-		}
-		if config.Discovery.MaxDepth == 0 {
-			config.Discovery.MaxDepth = 2
-		}
+		config.Discovery = config.Discovery.withDefaults()
 	}
 
 	if err := config.Validate(); err != nil {
@@ -158,6 +158,40 @@ func TestRobotForecast_AllAndLabelFilter(t *testing.T) {
 	}
 }
 
+func TestRobotForecast_MonteCarloSeedZero(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir, _ := createForecastRepo(t)
+
+	run := func() (int64, float64) {
+		t.Helper()
+		cmd := exec.Command(bv, "--robot-forecast", "all", "--forecast-mc", "--forecast-seed", "0")
+		cmd.Dir = repoDir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("--robot-forecast --forecast-mc failed: %v\n%s", err, out)
+		}
+		var payload struct {
+			MonteCarlo struct {
+				Seed    int64   `json:"seed"`
+				P85Days float64 `json:"p85_days"`
+			} `json:"monte_carlo"`
+		}
+		if err := json.Unmarshal(out, &payload); err != nil {
+			t.Fatalf("json decode: %v\nout=%s", err, out)
+		}
+		return payload.MonteCarlo.Seed, payload.MonteCarlo.P85Days
+	}
+
+	// An explicit seed of 0 is used as given, not replaced by a random one
+	seed, first := run()
+	if seed != 0 {
+		t.Fatalf("seed = %d, want 0", seed)
+	}
+	if _, second := run(); second != first {
+		t.Fatalf("p85_days = %v then %v, want the same forecast for seed 0", first, second)
+	}
+}
+
 func mustParseRFC3339(t *testing.T, s string) time.Time {
 	t.Helper()
 	parsed, err := time.Parse(time.RFC3339, s)